@@ -3,32 +3,70 @@ package agent
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/trento-project/trento/agent/checks"
 	"github.com/trento-project/trento/agent/discovery"
 	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/agent/facts"
+	"github.com/trento-project/trento/agent/updater"
 	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/exporters"
+	"github.com/trento-project/trento/version"
 )
 
 const trentoAgentCheckId = "trentoAgent"
 
+// updateCheckInterval is how often the agent compares its version with the one
+// the server advertises
+const updateCheckInterval = 5 * time.Minute
+
+// heartbeatFailuresBeforeRollback is how many heartbeats in a row an updated
+// agent may miss before the update is rolled back
+const heartbeatFailuresBeforeRollback = 3
+
+// checksPollInterval is how often the agent asks the server for pending checks
+// when it runs in the agent-executed checks mode
+const checksPollInterval = 30 * time.Second
+
+// agentChecksExecutionMode is the checks execution mode where the agents run
+// the selected checks themselves instead of the server running them over SSH
+const agentChecksExecutionMode = "agent"
+
+// factsPollInterval is how often the agent asks the server for pending
+// troubleshooting probe requests
+const factsPollInterval = 30 * time.Second
+
 type Agent struct {
 	config          *Config
 	collectorClient collector.Client
 	discoveries     []discovery.Discovery
-	ctx             context.Context
-	ctxCancel       context.CancelFunc
+	checksExecutor  *checks.Executor
+	// checksExecutionMode is the server-driven checks execution mode; the
+	// checks loop only runs when it is set to "agent"
+	checksExecutionMode string
+	ctx                 context.Context
+	ctxCancel           context.CancelFunc
+
+	heartbeatMutex   sync.Mutex
+	lastHeartbeatOK  bool
+	failedHeartbeats int
 }
 
 type Config struct {
 	InstanceName    string
 	SSHAddress      string
 	DiscoveryPeriod time.Duration
+	// DiscoveryPeriods holds per-discovery loop periods, overriding DiscoveryPeriod
+	DiscoveryPeriods map[string]time.Duration
+	ManageExporters  bool
+	// AnsibleFolder is where the embedded ansible files are extracted when the
+	// agent executes the checks itself
+	AnsibleFolder   string
 	CollectorConfig *collector.Config
 }
 
@@ -43,8 +81,12 @@ func NewAgent(config *Config) (*Agent, error) {
 	agent := &Agent{
 		config:          config,
 		collectorClient: collectorClient,
-		ctx:             ctx,
-		ctxCancel:       ctxCancel,
+		checksExecutor: &checks.Executor{
+			AnsibleFolder: config.AnsibleFolder,
+			Hostname:      config.InstanceName,
+		},
+		ctx:       ctx,
+		ctxCancel: ctxCancel,
 		discoveries: []discovery.Discovery{
 			discovery.NewClusterDiscovery(collectorClient),
 			discovery.NewSAPSystemsDiscovery(collectorClient),
@@ -56,26 +98,66 @@ func NewAgent(config *Config) (*Agent, error) {
 	return agent, nil
 }
 
-// Start the Agent. This will start the discovery ticker and the heartbeat ticker
+// Start the Agent. This will start the discovery tickers and the heartbeat ticker
 func (a *Agent) Start() error {
 	var wg sync.WaitGroup
 
+	a.applyServerConfig()
+
+	for _, d := range a.discoveries {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup, d discovery.Discovery) {
+			log.Infof("Starting %s loop...", d.GetId())
+			defer wg.Done()
+			a.startDiscoverTicker(d)
+			log.Infof("%s loop stopped.", d.GetId())
+		}(&wg, d)
+	}
+
 	wg.Add(1)
 	go func(wg *sync.WaitGroup) {
-		log.Info("Starting Discover loop...")
+		log.Info("Starting heartbeat loop...")
 		defer wg.Done()
-		a.startDiscoverTicker()
-		log.Info("Discover loop stopped.")
+		a.startHeartbeatTicker()
+		log.Info("heartbeat loop stopped.")
 	}(&wg)
 
 	wg.Add(1)
 	go func(wg *sync.WaitGroup) {
-		log.Info("Starting heartbeat loop...")
+		log.Info("Starting auto-update loop...")
 		defer wg.Done()
-		a.startHeartbeatTicker()
-		log.Info("heartbeat loop stopped.")
+		a.startAutoUpdateTicker()
+		log.Info("auto-update loop stopped.")
+	}(&wg)
+
+	wg.Add(1)
+	go func(wg *sync.WaitGroup) {
+		log.Info("Starting facts gathering loop...")
+		defer wg.Done()
+		a.startFactsTicker()
+		log.Info("facts gathering loop stopped.")
 	}(&wg)
 
+	if a.checksExecutionMode == agentChecksExecutionMode {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			log.Info("Starting agent-executed checks loop...")
+			defer wg.Done()
+			a.startChecksTicker()
+			log.Info("agent-executed checks loop stopped.")
+		}(&wg)
+	}
+
+	if a.config.ManageExporters {
+		wg.Add(1)
+		go func(wg *sync.WaitGroup) {
+			log.Info("Starting exporters management loop...")
+			defer wg.Done()
+			a.startExportersTicker()
+			log.Info("exporters management loop stopped.")
+		}(&wg)
+	}
+
 	wg.Wait()
 
 	return nil
@@ -85,29 +167,63 @@ func (a *Agent) Stop() {
 	a.ctxCancel()
 }
 
-// Start a Ticker loop that will iterate over the hardcoded list of Discovery backends and execute them.
-func (a *Agent) startDiscoverTicker() {
+// applyServerConfig fetches the server-driven agent configuration and applies
+// the discovery period overrides it carries on top of the local flags
+func (a *Agent) applyServerConfig() {
+	serverConfig, err := a.collectorClient.GetAgentConfig()
+	if err != nil {
+		log.Warnf("Could not fetch the agent configuration from the server, using the local one: %s", err)
+		return
+	}
+
+	for id, seconds := range serverConfig.DiscoveryPeriods {
+		if seconds <= 0 {
+			continue
+		}
+
+		if a.config.DiscoveryPeriods == nil {
+			a.config.DiscoveryPeriods = make(map[string]time.Duration)
+		}
+		a.config.DiscoveryPeriods[id] = time.Duration(seconds) * time.Second
+	}
+
+	a.checksExecutionMode = serverConfig.ChecksExecutionMode
+}
+
+// discoveryPeriod returns the loop period of a discovery, falling back to the
+// global discovery period when no override is configured
+func (a *Agent) discoveryPeriod(discoveryID string) time.Duration {
+	if period, ok := a.config.DiscoveryPeriods[discoveryID]; ok && period > 0 {
+		return period
+	}
+
+	return a.config.DiscoveryPeriod
+}
+
+// Start a Ticker loop that executes a single Discovery backend with its own period
+func (a *Agent) startDiscoverTicker(d discovery.Discovery) {
 	tick := func() {
-		var output []string
-		for _, d := range a.discoveries {
-			result, err := d.Discover()
-			if err != nil {
-				result = fmt.Sprintf("Error while running discovery '%s': %s", d.GetId(), err)
+		result, err := d.Discover()
+		if err != nil {
+			result = fmt.Sprintf("Error while running discovery '%s': %s", d.GetId(), err)
 
-				log.Errorln(result)
-			}
-			output = append(output, result)
+			log.Errorln(result)
 		}
-		log.Infof("Discovery tick output: %s", strings.Join(output, "\n\n"))
+		log.Infof("Discovery tick output: %s", result)
 	}
 
-	interval := a.config.DiscoveryPeriod
-	internal.Repeat("agent.discovery", tick, interval, a.ctx)
+	internal.Repeat(fmt.Sprintf("agent.discovery.%s", d.GetId()), tick, a.discoveryPeriod(d.GetId()), a.ctx)
 }
 
 func (a *Agent) startHeartbeatTicker() {
+	var enabledDiscoveries []string
+	for _, d := range a.discoveries {
+		enabledDiscoveries = append(enabledDiscoveries, d.GetId())
+	}
+
 	tick := func() {
-		err := a.collectorClient.Heartbeat()
+		err := a.collectorClient.Heartbeat(enabledDiscoveries)
+		a.recordHeartbeat(err == nil)
 		if err != nil {
 			log.Errorf("Error while sending the heartbeat to the server: %s", err)
 		}
@@ -115,3 +231,180 @@ func (a *Agent) startHeartbeatTicker() {
 
 	internal.Repeat("agent.heartbeat", tick, internal.HeartbeatInterval, a.ctx)
 }
+
+func (a *Agent) recordHeartbeat(ok bool) {
+	a.heartbeatMutex.Lock()
+	defer a.heartbeatMutex.Unlock()
+
+	a.lastHeartbeatOK = ok
+	if ok {
+		a.failedHeartbeats = 0
+	} else {
+		a.failedHeartbeats++
+	}
+}
+
+// heartbeatStatus returns whether the last heartbeat succeeded and how many
+// failed in a row since the last successful one
+func (a *Agent) heartbeatStatus() (bool, int) {
+	a.heartbeatMutex.Lock()
+	defer a.heartbeatMutex.Unlock()
+
+	return a.lastHeartbeatOK, a.failedHeartbeats
+}
+
+// Start a Ticker loop that keeps the agent package aligned with the version
+// the server wants running
+func (a *Agent) startAutoUpdateTicker() {
+	internal.Repeat("agent.autoupdate", a.checkForUpdates, updateCheckInterval, a.ctx)
+}
+
+// checkForUpdates settles an in-flight update first, then compares the running
+// version with the one the server advertises and installs it when they differ.
+// The server omits the advertisement for hosts that opted out of auto-updates.
+func (a *Agent) checkForUpdates() {
+	pending, err := updater.Pending()
+	if err != nil {
+		log.Errorf("Could not read the pending update state: %s", err)
+		return
+	}
+	if pending != nil {
+		a.settlePendingUpdate(pending)
+		return
+	}
+
+	serverConfig, err := a.collectorClient.GetAgentConfig()
+	if err != nil {
+		log.Warnf("Could not fetch the agent configuration from the server, skipping the update check: %s", err)
+		return
+	}
+
+	if serverConfig.DesiredAgentVersion == "" || serverConfig.DesiredAgentVersion == version.Version {
+		return
+	}
+
+	if serverConfig.UpdateRepository != "" {
+		if err := updater.EnsureRepository(serverConfig.UpdateRepository); err != nil {
+			log.Errorf("Could not register the agent update repository: %s", err)
+			return
+		}
+	}
+
+	log.Infof("Updating the agent from %s to %s...", version.Version, serverConfig.DesiredAgentVersion)
+	if err := updater.Update(version.Version, serverConfig.DesiredAgentVersion); err != nil {
+		log.Errorf("Could not update the agent: %s", err)
+	}
+	// on success the package installation restarts the agent service, replacing
+	// this process with the updated one
+}
+
+// settlePendingUpdate commits an installed update once the updated agent
+// manages to heartbeat, and rolls it back when the heartbeats keep failing
+func (a *Agent) settlePendingUpdate(pending *updater.PendingUpdate) {
+	if pending.DesiredVersion != version.Version {
+		// the installation never completed and nothing was replaced, forget it
+		log.Warnf("Found a stale update to %s, discarding it.", pending.DesiredVersion)
+		if err := updater.Commit(); err != nil {
+			log.Errorf("Could not discard the stale update: %s", err)
+		}
+		return
+	}
+
+	healthy, failures := a.heartbeatStatus()
+	if healthy {
+		log.Infof("The update to %s is heartbeating, committing it.", pending.DesiredVersion)
+		if err := updater.Commit(); err != nil {
+			log.Errorf("Could not commit the update: %s", err)
+		}
+		return
+	}
+
+	if failures >= heartbeatFailuresBeforeRollback {
+		log.Errorf("The agent could not heartbeat since the update to %s, rolling back to %s...",
+			pending.DesiredVersion, pending.PreviousVersion)
+		if err := updater.Rollback(pending); err != nil {
+			log.Errorf("Could not roll back the update: %s", err)
+		}
+	}
+}
+
+// Start a Ticker loop that polls the server for pending checks, executes them
+// locally and reports the results back
+func (a *Agent) startChecksTicker() {
+	tick := func() {
+		pendingChecks, err := a.collectorClient.GetPendingChecks()
+		if err != nil {
+			log.Errorf("Error while fetching the pending checks from the server: %s", err)
+			return
+		}
+
+		if pendingChecks.ExecutionID == 0 || len(pendingChecks.Checks) == 0 {
+			return
+		}
+
+		var checkIDs []string
+		for _, check := range pendingChecks.Checks {
+			checkIDs = append(checkIDs, check.ID)
+		}
+
+		log.Infof("Executing %d checks for execution %d...", len(checkIDs), pendingChecks.ExecutionID)
+		results, err := a.checksExecutor.Execute(checkIDs)
+		if err != nil {
+			log.Errorf("Error while executing the checks: %s", err)
+			return
+		}
+
+		if err := a.collectorClient.PostChecksResults(pendingChecks.ExecutionID, results); err != nil {
+			log.Errorf("Error while posting the checks results to the server: %s", err)
+		}
+	}
+
+	internal.Repeat("agent.checks", tick, checksPollInterval, a.ctx)
+}
+
+// Start a Ticker loop that polls the server for pending troubleshooting probe
+// requests, runs the probes and reports their output back
+func (a *Agent) startFactsTicker() {
+	tick := func() {
+		factsRequests, err := a.collectorClient.GetPendingFactsRequests()
+		if err != nil {
+			log.Errorf("Error while fetching the pending facts requests from the server: %s", err)
+			return
+		}
+
+		for _, request := range factsRequests {
+			log.Infof("Running the %s probe for facts request %d...", request.Probe, request.ID)
+
+			var gatheringError string
+			output, err := facts.Gather(request.Probe)
+			if err != nil {
+				log.Errorf("Error while running the %s probe: %s", request.Probe, err)
+				gatheringError = err.Error()
+			}
+
+			if err := a.collectorClient.PostFactsOutput(request.ID, output, gatheringError); err != nil {
+				log.Errorf("Error while posting the facts output to the server: %s", err)
+			}
+		}
+	}
+
+	internal.Repeat("agent.facts", tick, factsPollInterval, a.ctx)
+}
+
+// Start a Ticker loop that makes sure the managed exporter units are up and running
+func (a *Agent) startExportersTicker() {
+	tick := func() {
+		for unit, status := range exporters.Statuses() {
+			if status == exporters.StatusActive {
+				continue
+			}
+
+			log.Infof("The %s unit is %s, trying to enable it...", unit, status)
+			if err := exporters.Enable(unit); err != nil {
+				log.Errorf("Error while enabling the %s unit: %s", unit, err)
+			}
+		}
+	}
+
+	internal.Repeat("agent.exporters", tick, a.config.DiscoveryPeriod, a.ctx)
+}
@@ -0,0 +1,127 @@
+// Package checks executes the selected checks on the local host, reusing the
+// ansible roles the embedded SSH runner ships: the playbook is run against a
+// localhost-only inventory, so the agent-executed mode needs no SSH access
+// from the server.
+package checks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/runner"
+)
+
+// agentGroup is the inventory group the local host is placed in; the group
+// name only matters for addressing the results the callback writes
+const agentGroup = "agent"
+
+// Executor runs checks on the local host by driving ansible-playbook in
+// check mode against a localhost-only inventory
+type Executor struct {
+	// AnsibleFolder is where the embedded ansible file structure is extracted
+	AnsibleFolder string
+	// Hostname is the inventory name of the local host, matching the name
+	// the server knows this host under
+	Hostname string
+}
+
+// Execute runs the given checks locally and returns the outcome of each one
+func (e *Executor) Execute(checkIDs []string) (map[string]*collector.CheckResult, error) {
+	if err := runner.CreateAnsibleFiles(e.AnsibleFolder); err != nil {
+		return nil, err
+	}
+
+	inventoryFile := path.Join(e.AnsibleFolder, runner.AnsibleHostFile)
+	if err := createLocalInventory(inventoryFile, e.Hostname, checkIDs); err != nil {
+		return nil, err
+	}
+
+	resultsFile, err := ioutil.TempFile("", "trento-agent-checks")
+	if err != nil {
+		return nil, err
+	}
+	resultsFile.Close()
+	defer os.Remove(resultsFile.Name())
+
+	ansibleRunner := runner.DefaultAnsibleRunner()
+	if err := ansibleRunner.SetPlaybook(path.Join(e.AnsibleFolder, runner.AnsibleMain)); err != nil {
+		return nil, err
+	}
+	ansibleRunner.Check = true
+	ansibleRunner.SetConfigFile(path.Join(e.AnsibleFolder, runner.AnsibleConfigFile))
+	ansibleRunner.SetResultsFile(resultsFile.Name())
+	if err := ansibleRunner.SetInventory(inventoryFile); err != nil {
+		return nil, err
+	}
+
+	if err := ansibleRunner.RunPlaybook(); err != nil {
+		return nil, err
+	}
+
+	return parseResults(resultsFile.Name(), e.Hostname)
+}
+
+// createLocalInventory writes an inventory with the local host as its only
+// node, carrying the selected checks the same way the SSH runner does
+func createLocalInventory(destination, hostname string, checkIDs []string) error {
+	jsonSelectedChecks, err := json.Marshal(checkIDs)
+	if err != nil {
+		return err
+	}
+
+	content := &runner.InventoryContent{
+		Groups: []*runner.Group{
+			{
+				Name: agentGroup,
+				Nodes: []*runner.Node{
+					{
+						Name:        hostname,
+						AnsibleHost: "localhost",
+						AnsibleUser: runner.DefaultUser,
+						Variables: map[string]interface{}{
+							"ansible_connection":      "local",
+							"cluster_selected_checks": string(jsonSelectedChecks),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return runner.CreateInventory(destination, content)
+}
+
+// parseResults extracts the outcomes of the local host from the results file
+// the trento ansible callback wrote
+func parseResults(resultsFile, hostname string) (map[string]*collector.CheckResult, error) {
+	data, err := ioutil.ReadFile(resultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload struct {
+		Results map[string]struct {
+			Checks map[string]struct {
+				Hosts map[string]*collector.CheckResult `json:"hosts"`
+			} `json:"checks"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("could not parse the checks results file: %s", err)
+	}
+
+	results := make(map[string]*collector.CheckResult)
+	for _, group := range payload.Results {
+		for checkID, check := range group.Checks {
+			if result, ok := check.Hosts[hostname]; ok {
+				results[checkID] = result
+			}
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,110 @@
+package checks
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/trento-project/trento/agent/discovery/collector"
+)
+
+type ChecksTestSuite struct {
+	suite.Suite
+}
+
+func TestChecksTestSuite(t *testing.T) {
+	suite.Run(t, new(ChecksTestSuite))
+}
+
+func (suite *ChecksTestSuite) Test_CreateLocalInventory() {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	destination := path.Join(tmpDir, "ansible_hosts")
+
+	err := createLocalInventory(destination, "node1", []string{"check1", "check2"})
+
+	suite.NoError(err)
+	suite.FileExists(destination)
+
+	expectedContent := "\n" +
+		"[agent]\n" +
+		"node1 ansible_host=localhost ansible_user=root " +
+		"ansible_connection=local cluster_selected_checks=[\"check1\",\"check2\"] \n"
+
+	data, err := ioutil.ReadFile(destination)
+	if err == nil {
+		suite.Equal(expectedContent, string(data))
+	}
+}
+
+func (suite *ChecksTestSuite) Test_ParseResults() {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	resultsFile := path.Join(tmpDir, "results")
+
+	resultsContent := `{
+		"results": {
+			"agent": {
+				"hosts": {
+					"node1": {
+						"reachable": true,
+						"msg": ""
+					}
+				},
+				"checks": {
+					"check1": {
+						"hosts": {
+							"node1": {
+								"result": "passing",
+								"msg": ""
+							}
+						}
+					},
+					"check2": {
+						"hosts": {
+							"node1": {
+								"result": "critical",
+								"msg": "something went wrong"
+							},
+							"othernode": {
+								"result": "passing",
+								"msg": ""
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+	err := ioutil.WriteFile(resultsFile, []byte(resultsContent), 0644)
+	suite.NoError(err)
+
+	results, err := parseResults(resultsFile, "node1")
+
+	expectedResults := map[string]*collector.CheckResult{
+		"check1": {
+			Result: "passing",
+		},
+		"check2": {
+			Result: "critical",
+			Msg:    "something went wrong",
+		},
+	}
+
+	suite.NoError(err)
+	suite.Equal(expectedResults, results)
+}
+
+func (suite *ChecksTestSuite) Test_ParseResultsInvalidContent() {
+	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
+	resultsFile := path.Join(tmpDir, "results")
+
+	err := ioutil.WriteFile(resultsFile, []byte("not json"), 0644)
+	suite.NoError(err)
+
+	_, err = parseResults(resultsFile, "node1")
+
+	suite.EqualError(err, "could not parse the checks results file: invalid character 'o' in literal null (expecting 'u')")
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/trento-project/trento/agent/discovery/collector"
 	"github.com/trento-project/trento/internal/cloud"
 )
 
@@ -15,10 +14,10 @@ type CloudDiscovery struct {
 	discovery BaseDiscovery
 }
 
-func NewCloudDiscovery(collectorClient collector.Client) CloudDiscovery {
+func NewCloudDiscovery(publisher DiscoveryPublisher) CloudDiscovery {
 	r := CloudDiscovery{}
 	r.id = CloudDiscoveryId
-	r.discovery = NewDiscovery(collectorClient)
+	r.discovery = NewDiscovery(publisher)
 	return r
 }
 
@@ -32,7 +31,7 @@ func (d CloudDiscovery) Discover() (string, error) {
 		return "", err
 	}
 
-	err = d.discovery.collectorClient.Publish(d.id, cloudData)
+	err = d.discovery.publisher.Publish(d.id, cloudData)
 	if err != nil {
 		log.Debugf("Error while sending cloud discovery to data collector: %s", err)
 		return "", err
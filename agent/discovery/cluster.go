@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/trento-project/trento/agent/discovery/collector"
 	"github.com/trento-project/trento/internal/cluster"
 )
 
@@ -16,10 +15,10 @@ type ClusterDiscovery struct {
 	discovery BaseDiscovery
 }
 
-func NewClusterDiscovery(collectorClient collector.Client) ClusterDiscovery {
+func NewClusterDiscovery(publisher DiscoveryPublisher) ClusterDiscovery {
 	d := ClusterDiscovery{}
 	d.id = ClusterDiscoveryId
-	d.discovery = NewDiscovery(collectorClient)
+	d.discovery = NewDiscovery(publisher)
 	return d
 }
 
@@ -34,7 +33,7 @@ func (d ClusterDiscovery) Discover() (string, error) {
 		return "No HA cluster discovered on this host", nil
 	}
 
-	err = d.discovery.collectorClient.Publish(d.id, cluster)
+	err = d.discovery.publisher.Publish(d.id, cluster)
 	if err != nil {
 		log.Debugf("Error while sending cluster discovery to data collector: %s", err)
 		return "", err
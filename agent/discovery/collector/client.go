@@ -2,6 +2,7 @@ package collector
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
@@ -9,25 +10,94 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/contracts"
 	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/hosts"
+	"github.com/trento-project/trento/version"
 
 	"github.com/spf13/afero"
 )
 
 type Client interface {
 	Publish(discoveryType string, payload interface{}) error
-	Heartbeat() error
+	Heartbeat(enabledDiscoveries []string) error
+	GetAgentConfig() (*AgentConfig, error)
+	GetPendingChecks() (*AgentChecks, error)
+	PostChecksResults(executionID int64, results map[string]*CheckResult) error
+	GetPendingFactsRequests() ([]*FactsRequest, error)
+	PostFactsOutput(factID int64, output, gatheringError string) error
+	CheckConnection() error
+}
+
+// AgentConfig is the server-driven configuration served by the agents config endpoint
+type AgentConfig struct {
+	DiscoveryPeriods   map[string]int `json:"discovery_periods"`
+	EnabledDiscoveries []string       `json:"enabled_discoveries"`
+	CollectorHost      string         `json:"collector_host"`
+	CollectorPort      int            `json:"collector_port"`
+	// DesiredAgentVersion and UpdateRepository advertise the agent version the
+	// server wants running; the server omits them for hosts opted out of
+	// auto-updates
+	DesiredAgentVersion string `json:"desired_agent_version,omitempty"`
+	UpdateRepository    string `json:"update_repository,omitempty"`
+	// ChecksExecutionMode tells the agent whether it has to execute the
+	// selected checks itself ("agent") or the server runs them over SSH
+	ChecksExecutionMode string `json:"checks_execution_mode,omitempty"`
+}
+
+// AgentChecks is the payload of the pending checks endpoint: the checks this
+// agent has to execute locally as part of a pending execution. An ExecutionID
+// of 0 means nothing is pending.
+type AgentChecks struct {
+	ExecutionID int64        `json:"execution_id"`
+	Checks      []AgentCheck `json:"checks"`
+}
+
+// AgentCheck identifies a single check to execute; the other catalog fields
+// the server sends along are not needed locally
+type AgentCheck struct {
+	ID string `json:"id"`
+}
+
+// CheckResult is the outcome of a single locally executed check
+type CheckResult struct {
+	Result string `json:"result"`
+	Msg    string `json:"msg,omitempty"`
+}
+
+// FactsRequest asks this agent to run one of its predefined troubleshooting
+// probes. Only the probe name is consumed: the command string the server
+// sends along is deliberately ignored, the probe is resolved against the
+// local allowlist of the facts package.
+type FactsRequest struct {
+	ID    int64  `json:"id"`
+	Probe string `json:"probe"`
 }
 
 type client struct {
 	config     *Config
 	agentID    string
 	httpClient *http.Client
+
+	publishedMutex sync.Mutex
+	published      map[string]publishedDiscovery
+}
+
+// publishedDiscovery tracks the last successful publication of a discovery type
+type publishedDiscovery struct {
+	checksum    string
+	publishedAt time.Time
 }
 
+// forcedRefreshInterval is the period after which an unchanged discovery
+// payload is published again to force a full refresh on the server
+const forcedRefreshInterval = 1 * time.Hour
+
 type Config struct {
 	CollectorHost string
 	CollectorPort int
@@ -72,16 +142,31 @@ func NewCollectorClient(config *Config) (*client, error) {
 		config:     config,
 		httpClient: httpClient,
 		agentID:    agentID.String(),
+		published:  make(map[string]publishedDiscovery),
 	}, nil
 }
 
 func (c *client) Publish(discoveryType string, payload interface{}) error {
 	log.Debugf("Sending %s to data collector", discoveryType)
 
+	payloadJson, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	checksum := fmt.Sprintf("%x", sha256.Sum256(payloadJson))
+	if c.isAlreadyPublished(discoveryType, checksum) {
+		log.Debugf("Discovery %s did not change since the last publication, skipping", discoveryType)
+		return nil
+	}
+
 	requestBody, err := json.Marshal(map[string]interface{}{
 		"agent_id":       c.agentID,
 		"discovery_type": discoveryType,
-		"payload":        payload,
+		"payload":        json.RawMessage(payloadJson),
+		"event_id":       uuid.New().String(),
+		"checksum":       checksum,
+		"schema_version": contracts.Version,
 	})
 	if err != nil {
 		return err
@@ -99,12 +184,46 @@ func (c *client) Publish(discoveryType string, payload interface{}) error {
 			resp.StatusCode, c.agentID, discoveryType)
 	}
 
+	c.markAsPublished(discoveryType, checksum)
+
 	return nil
 }
 
-func (c *client) Heartbeat() error {
+// isAlreadyPublished reports whether an identical payload was already sent
+// recently enough that a forced full refresh is not due yet
+func (c *client) isAlreadyPublished(discoveryType, checksum string) bool {
+	c.publishedMutex.Lock()
+	defer c.publishedMutex.Unlock()
+
+	published, ok := c.published[discoveryType]
+
+	return ok &&
+		published.checksum == checksum &&
+		time.Since(published.publishedAt) < forcedRefreshInterval
+}
+
+func (c *client) markAsPublished(discoveryType, checksum string) {
+	c.publishedMutex.Lock()
+	defer c.publishedMutex.Unlock()
+
+	c.published[discoveryType] = publishedDiscovery{
+		checksum:    checksum,
+		publishedAt: time.Now(),
+	}
+}
+
+func (c *client) Heartbeat(enabledDiscoveries []string) error {
+	requestBody, err := json.Marshal(hosts.Heartbeat{
+		AgentVersion:       version.Version,
+		EnabledDiscoveries: enabledDiscoveries,
+		AgentTime:          time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
 	url := fmt.Sprintf("%s/api/hosts/%s/heartbeat", c.getBaseURL(), c.agentID)
-	resp, err := c.httpClient.Post(url, "application/json", nil)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(requestBody))
 	if err != nil {
 		return err
 	}
@@ -116,6 +235,169 @@ func (c *client) Heartbeat() error {
 	return nil
 }
 
+// AgentID returns the stable identifier of this host, derived from the machine ID
+func (c *client) AgentID() string {
+	return c.agentID
+}
+
+// Enroll registers this host with the server, presenting the enrollment token
+// the server handed out for this installation
+func (c *client) Enroll(token string) error {
+	requestBody, err := json.Marshal(map[string]string{
+		"token": token,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/agents/%s/enroll", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("the server did not accept the enrollment token")
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("server responded with status code %d while enrolling the agent", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (c *client) GetAgentConfig() (*AgentConfig, error) {
+	url := fmt.Sprintf("%s/api/agents/%s/config", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded with status code %d while fetching the agent configuration", resp.StatusCode)
+	}
+
+	var agentConfig AgentConfig
+	if err := json.NewDecoder(resp.Body).Decode(&agentConfig); err != nil {
+		return nil, err
+	}
+
+	return &agentConfig, nil
+}
+
+// GetPendingChecks returns the checks this agent has to execute locally as
+// part of a pending execution, if any
+func (c *client) GetPendingChecks() (*AgentChecks, error) {
+	url := fmt.Sprintf("%s/api/agents/%s/checks", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded with status code %d while fetching the pending checks", resp.StatusCode)
+	}
+
+	var agentChecks AgentChecks
+	if err := json.NewDecoder(resp.Body).Decode(&agentChecks); err != nil {
+		return nil, err
+	}
+
+	return &agentChecks, nil
+}
+
+// PostChecksResults reports the partial results of the locally executed
+// checks back to the server
+func (c *client) PostChecksResults(executionID int64, results map[string]*CheckResult) error {
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"execution_id": executionID,
+		"results":      results,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/agents/%s/checks/results", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("server responded with status code %d while posting the checks results", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// GetPendingFactsRequests returns the troubleshooting probes this agent has
+// been asked to run
+func (c *client) GetPendingFactsRequests() ([]*FactsRequest, error) {
+	url := fmt.Sprintf("%s/api/agents/%s/facts", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server responded with status code %d while fetching the pending facts requests", resp.StatusCode)
+	}
+
+	var factsRequests []*FactsRequest
+	if err := json.NewDecoder(resp.Body).Decode(&factsRequests); err != nil {
+		return nil, err
+	}
+
+	return factsRequests, nil
+}
+
+// PostFactsOutput reports the output of a probe back to the server, or the
+// error that prevented gathering it
+func (c *client) PostFactsOutput(factID int64, output, gatheringError string) error {
+	requestBody, err := json.Marshal(map[string]string{
+		"output": output,
+		"error":  gatheringError,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/agents/%s/facts/%d", c.getBaseURL(), c.agentID, factID)
+	resp, err := c.httpClient.Post(url, "application/json", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("server responded with status code %d while posting the facts output", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// CheckConnection verifies that the collector endpoint is reachable and, when
+// mTLS is enabled, that the handshake succeeds, without publishing any data
+func (c *client) CheckConnection() error {
+	url := fmt.Sprintf("%s/api/agents/%s/config", c.getBaseURL(), c.agentID)
+	resp, err := c.httpClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
 func (c *client) getBaseURL() string {
 	protocol := "http"
 	if c.config.EnablemTLS {
@@ -1,6 +1,7 @@
 package collector
 
 import (
+	"bytes"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/contracts"
+	"github.com/trento-project/trento/internal/hosts"
 	_ "github.com/trento-project/trento/test"
 	"github.com/trento-project/trento/test/helpers"
 )
@@ -92,11 +95,21 @@ func (suite *CollectorClientTestSuite) TestCollectorClient_PublishingSuccess() {
 			"agent_id":       DummyAgentID,
 			"discovery_type": discoveryType,
 			"payload":        discoveredDataPayload,
+			"schema_version": contracts.Version,
 		})
 
 		bodyBytes, _ := ioutil.ReadAll(req.Body)
 
-		suite.EqualValues(requestBody, bodyBytes)
+		var outgoing map[string]json.RawMessage
+		suite.NoError(json.Unmarshal(bodyBytes, &outgoing))
+		suite.NotEmpty(outgoing["event_id"])
+		suite.NotEmpty(outgoing["checksum"])
+
+		delete(outgoing, "event_id")
+		delete(outgoing, "checksum")
+		strippedBody, _ := json.Marshal(outgoing)
+
+		suite.JSONEq(string(requestBody), string(strippedBody))
 
 		suite.Equal(req.URL.String(), "https://localhost:8081/api/collect")
 		return &http.Response{
@@ -144,11 +157,169 @@ func (suite *CollectorClientTestSuite) TestCollectorClient_Heartbeat() {
 
 	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
 		suite.Equal(req.URL.String(), fmt.Sprintf("https://localhost:8081/api/hosts/%s/heartbeat", DummyAgentID))
+
+		bodyBytes, _ := ioutil.ReadAll(req.Body)
+
+		var heartbeat hosts.Heartbeat
+		suite.NoError(json.Unmarshal(bodyBytes, &heartbeat))
+		suite.Equal([]string{"host_discovery", "cluster_discovery"}, heartbeat.EnabledDiscoveries)
+		suite.False(heartbeat.AgentTime.IsZero())
+
 		return &http.Response{
 			StatusCode: 204,
 		}
 	})
-	err = collectorClient.Heartbeat()
+	err = collectorClient.Heartbeat([]string{"host_discovery", "cluster_discovery"})
+
+	suite.NoError(err)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_Enroll() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), fmt.Sprintf("http://localhost:8081/api/agents/%s/enroll", DummyAgentID))
+
+		bodyBytes, _ := ioutil.ReadAll(req.Body)
+		suite.JSONEq(`{"token": "some-enrollment-token"}`, string(bodyBytes))
+
+		return &http.Response{
+			StatusCode: 204,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}
+	})
+
+	err = collectorClient.Enroll("some-enrollment-token")
+
+	suite.NoError(err)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_EnrollRejected() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		return &http.Response{
+			StatusCode: 403,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}
+	})
+
+	err = collectorClient.Enroll("a-wrong-token")
+
+	suite.EqualError(err, "the server did not accept the enrollment token")
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_PublishSkipsUnchangedPayloads() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	requestsCount := 0
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		requestsCount++
+		return &http.Response{
+			StatusCode: 202,
+		}
+	})
+
+	// identical payloads are published only once
+	suite.NoError(collectorClient.Publish("some_discovery_type", "payload"))
+	suite.NoError(collectorClient.Publish("some_discovery_type", "payload"))
+	suite.Equal(1, requestsCount)
+
+	// a changed payload is published again
+	suite.NoError(collectorClient.Publish("some_discovery_type", "changed payload"))
+	suite.Equal(2, requestsCount)
+
+	// other discovery types are tracked independently
+	suite.NoError(collectorClient.Publish("another_discovery_type", "payload"))
+	suite.Equal(3, requestsCount)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_PublishFailuresAreRetried() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	statusCode := 500
+	requestsCount := 0
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		requestsCount++
+		return &http.Response{
+			StatusCode: statusCode,
+		}
+	})
+
+	// a failed publication is not tracked, the payload is sent again
+	suite.Error(collectorClient.Publish("some_discovery_type", "payload"))
+
+	statusCode = 202
+	suite.NoError(collectorClient.Publish("some_discovery_type", "payload"))
+	suite.Equal(2, requestsCount)
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_CheckConnection() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	statusCode := 200
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), fmt.Sprintf("http://localhost:8081/api/agents/%s/config", DummyAgentID))
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		}
+	})
+
+	suite.NoError(collectorClient.CheckConnection())
+
+	statusCode = 500
+	suite.Error(collectorClient.CheckConnection())
+}
+
+func (suite *CollectorClientTestSuite) TestCollectorClient_GetAgentConfig() {
+	collectorClient, err := NewCollectorClient(&Config{
+		CollectorHost: "localhost",
+		CollectorPort: 8081,
+	})
+
+	suite.NoError(err)
+
+	collectorClient.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
+		suite.Equal(req.URL.String(), fmt.Sprintf("http://localhost:8081/api/agents/%s/config", DummyAgentID))
+
+		body := ioutil.NopCloser(bytes.NewBufferString(
+			`{"agent_id": "` + DummyAgentID + `", "discovery_periods": {"ha_cluster_discovery": 30}}`))
+
+		return &http.Response{
+			StatusCode: 200,
+			Body:       body,
+		}
+	})
+
+	agentConfig, err := collectorClient.GetAgentConfig()
 
 	suite.NoError(err)
+	suite.Equal(map[string]int{"ha_cluster_discovery": 30}, agentConfig.DiscoveryPeriods)
 }
@@ -0,0 +1,60 @@
+package collector
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// dumpClient implements the Client interface by writing the discovery
+// payloads to a writer instead of publishing them to the collector endpoint,
+// so they can be inspected or attached to support cases
+type dumpClient struct {
+	writer io.Writer
+}
+
+func NewDumpClient(writer io.Writer) *dumpClient {
+	return &dumpClient{writer: writer}
+}
+
+func (c *dumpClient) Publish(discoveryType string, payload interface{}) error {
+	output, err := json.MarshalIndent(map[string]interface{}{
+		"discovery_type": discoveryType,
+		"payload":        payload,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(c.writer, string(output))
+
+	return err
+}
+
+func (c *dumpClient) Heartbeat(_ []string) error {
+	return nil
+}
+
+func (c *dumpClient) GetAgentConfig() (*AgentConfig, error) {
+	return &AgentConfig{}, nil
+}
+
+func (c *dumpClient) GetPendingChecks() (*AgentChecks, error) {
+	return &AgentChecks{}, nil
+}
+
+func (c *dumpClient) PostChecksResults(_ int64, _ map[string]*CheckResult) error {
+	return nil
+}
+
+func (c *dumpClient) GetPendingFactsRequests() ([]*FactsRequest, error) {
+	return nil, nil
+}
+
+func (c *dumpClient) PostFactsOutput(_ int64, _, _ string) error {
+	return nil
+}
+
+func (c *dumpClient) CheckConnection() error {
+	return nil
+}
@@ -0,0 +1,36 @@
+package collector
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpClientPublish(t *testing.T) {
+	var buffer bytes.Buffer
+	dumpClient := NewDumpClient(&buffer)
+
+	err := dumpClient.Publish("the_discovery_type", struct {
+		FieldA string `json:"field_a"`
+	}{
+		FieldA: "some discovered field",
+	})
+	assert.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"discovery_type": "the_discovery_type",
+		"payload": {"field_a": "some discovered field"}
+	}`, buffer.String())
+}
+
+func TestDumpClientDoesNotTalkToTheServer(t *testing.T) {
+	dumpClient := NewDumpClient(&bytes.Buffer{})
+
+	assert.NoError(t, dumpClient.Heartbeat([]string{"host_discovery"}))
+	assert.NoError(t, dumpClient.CheckConnection())
+
+	agentConfig, err := dumpClient.GetAgentConfig()
+	assert.NoError(t, err)
+	assert.Empty(t, agentConfig.DiscoveryPeriods)
+}
@@ -1,15 +1,18 @@
 package collector
 
 import (
+	"crypto/sha256"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/agent/discovery/mocks"
+	"github.com/trento-project/trento/contracts"
 	_ "github.com/trento-project/trento/test"
 	"github.com/trento-project/trento/test/helpers"
 )
@@ -48,7 +51,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingClusterDiscovery
 	discoveredCluster := mocks.NewDiscoveredClusterMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredCluster, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/cluster/expected_published_cluster_discovery.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("ha_cluster_discovery", requestBodyAgainstCollector)
 	})
 }
 
@@ -57,7 +60,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingCloudDiscovery()
 	discoveredCloudInstance := mocks.NewDiscoveredCloudMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredCloudInstance, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/azure/expected_published_cloud_discovery.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("cloud_discovery", requestBodyAgainstCollector)
 	})
 }
 
@@ -66,7 +69,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingHostDiscovery()
 	discoveredHost := mocks.NewDiscoveredHostMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredHost, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/host/expected_published_host_discovery.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("host_discovery", requestBodyAgainstCollector)
 	})
 }
 
@@ -75,7 +78,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingSubscriptionDisc
 	discoveredSubscriptions := mocks.NewDiscoveredSubscriptionsMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredSubscriptions, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/subscriptions/expected_published_subscriptions_discovery.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("subscription_discovery", requestBodyAgainstCollector)
 	})
 }
 
@@ -84,7 +87,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingSAPSystemDatabas
 	discoveredSAPSystem := mocks.NewDiscoveredSAPSystemDatabaseMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredSAPSystem, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/sap_system/expected_published_sap_system_discovery_database.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("sap_system_discovery_database", requestBodyAgainstCollector)
 	})
 }
 
@@ -93,7 +96,7 @@ func (suite *PublishingTestSuite) TestCollectorClient_PublishingSAPSystemApplica
 	discoveredSAPSystem := mocks.NewDiscoveredSAPSystemApplicationMock()
 
 	suite.runDiscoveryScenario(discoveryType, discoveredSAPSystem, func(requestBodyAgainstCollector string) {
-		suite.assertJsonMatchesJsonFileContent("./test/fixtures/discovery/sap_system/expected_published_sap_system_discovery_application.json", requestBodyAgainstCollector)
+		suite.assertJsonMatchesFixture("sap_system_discovery_application", requestBodyAgainstCollector)
 	})
 }
 
@@ -107,13 +110,31 @@ func (suite *PublishingTestSuite) runDiscoveryScenario(discoveryType string, pay
 			"agent_id":       DummyAgentID,
 			"discovery_type": discoveryType,
 			"payload":        payload,
+			"schema_version": contracts.Version,
 		})
 
 		outgoingRequestBody, _ := ioutil.ReadAll(req.Body)
 
-		suite.EqualValues(requestBody, outgoingRequestBody)
+		var outgoing map[string]json.RawMessage
+		suite.NoError(json.Unmarshal(outgoingRequestBody, &outgoing))
 
-		assertion(string(outgoingRequestBody))
+		// the event ID is randomly generated, the checksum covers the payload
+		var eventID string
+		suite.NoError(json.Unmarshal(outgoing["event_id"], &eventID))
+		_, err := uuid.Parse(eventID)
+		suite.NoError(err)
+
+		var checksum string
+		suite.NoError(json.Unmarshal(outgoing["checksum"], &checksum))
+		suite.Equal(fmt.Sprintf("%x", sha256.Sum256(outgoing["payload"])), checksum)
+
+		delete(outgoing, "event_id")
+		delete(outgoing, "checksum")
+		strippedRequestBody, _ := json.Marshal(outgoing)
+
+		suite.JSONEq(string(requestBody), string(strippedRequestBody))
+
+		assertion(string(strippedRequestBody))
 
 		suite.Equal(req.URL.String(), "https://localhost:8443/api/collect")
 		return &http.Response{
@@ -126,13 +147,13 @@ func (suite *PublishingTestSuite) runDiscoveryScenario(discoveryType string, pay
 	suite.NoError(err)
 }
 
-func (suite *PublishingTestSuite) assertJsonMatchesJsonFileContent(expectedJsonContentPath string, actualJson string) {
-	expectedJsonContent, err := os.Open(expectedJsonContentPath)
+// the expected publications are the golden fixtures shipped with the
+// contracts package, shared with the server-side tests
+func (suite *PublishingTestSuite) assertJsonMatchesFixture(fixtureName string, actualJson string) {
+	expectedJsonContent, err := contracts.Fixture(fixtureName)
 	if err != nil {
 		panic(err)
 	}
 
-	b, _ := ioutil.ReadAll(expectedJsonContent)
-
-	suite.JSONEq(string(b), actualJson)
+	suite.JSONEq(string(expectedJsonContent), actualJson)
 }
@@ -2,8 +2,6 @@ package discovery
 
 import (
 	"os"
-
-	"github.com/trento-project/trento/agent/discovery/collector"
 )
 
 type Discovery interface {
@@ -13,10 +11,16 @@ type Discovery interface {
 	Discover() (string, error)
 }
 
+// DiscoveryPublisher is where the discoveries store their results: the HTTP
+// collector client in production, a file or stdout when dumping payloads
+type DiscoveryPublisher interface {
+	Publish(discoveryType string, payload interface{}) error
+}
+
 type BaseDiscovery struct {
-	id              string
-	collectorClient collector.Client
-	host            string
+	id        string
+	publisher DiscoveryPublisher
+	host      string
 }
 
 func (d BaseDiscovery) GetId() string {
@@ -29,11 +33,11 @@ func (d BaseDiscovery) Discover() (string, error) {
 	return "Basic discovery example", nil
 }
 
-// NewDiscovery Return a new base discovery with the support for data collector endpoint
-func NewDiscovery(collectorClient collector.Client) BaseDiscovery {
+// NewDiscovery Return a new base discovery publishing to the given publisher
+func NewDiscovery(publisher DiscoveryPublisher) BaseDiscovery {
 	d := BaseDiscovery{}
 	d.id = ""
-	d.collectorClient = collectorClient
+	d.publisher = publisher
 	d.host, _ = os.Hostname()
 	return d
 }
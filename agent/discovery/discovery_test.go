@@ -0,0 +1,34 @@
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/internal/hosts"
+)
+
+// capturingPublisher implements DiscoveryPublisher by recording the published
+// payloads, so discoverers can be tested without a running collector
+type capturingPublisher struct {
+	published map[string]interface{}
+}
+
+func (p *capturingPublisher) Publish(discoveryType string, payload interface{}) error {
+	p.published[discoveryType] = payload
+	return nil
+}
+
+func TestHostDiscoveryPublishesToThePublisher(t *testing.T) {
+	publisher := &capturingPublisher{published: make(map[string]interface{})}
+
+	d := NewHostDiscovery("some-ssh-address", publisher)
+	result, err := d.Discover()
+
+	assert.NoError(t, err)
+	assert.Contains(t, result, "successfully discovered")
+
+	payload, ok := publisher.published[HostDiscoveryId].(hosts.DiscoveredHost)
+	assert.True(t, ok)
+	assert.Equal(t, "some-ssh-address", payload.SSHAddress)
+	assert.NotEmpty(t, payload.HostName)
+}
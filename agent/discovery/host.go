@@ -1,15 +1,21 @@
 package discovery
 
 import (
+	"crypto/md5"
 	"fmt"
+	"io/ioutil"
 	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/host"
 	"github.com/shirou/gopsutil/mem"
 	log "github.com/sirupsen/logrus"
-	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/internal/exporters"
 	"github.com/trento-project/trento/internal/hosts"
 	"github.com/trento-project/trento/version"
 )
@@ -22,11 +28,11 @@ type HostDiscovery struct {
 	discovery  BaseDiscovery
 }
 
-func NewHostDiscovery(sshAddress string, collectorClient collector.Client) HostDiscovery {
+func NewHostDiscovery(sshAddress string, publisher DiscoveryPublisher) HostDiscovery {
 	d := HostDiscovery{}
 	d.id = HostDiscoveryId
 	d.sshAddress = sshAddress
-	d.discovery = NewDiscovery(collectorClient)
+	d.discovery = NewDiscovery(publisher)
 	return d
 }
 
@@ -41,18 +47,30 @@ func (h HostDiscovery) Discover() (string, error) {
 		return "", err
 	}
 
+	networkInterfaces, err := getNetworkInterfaces()
+	if err != nil {
+		return "", err
+	}
+
 	host := hosts.DiscoveredHost{
-		SSHAddress:      h.sshAddress,
-		OSVersion:       getOSVersion(),
-		HostIpAddresses: ipAddresses,
-		HostName:        h.discovery.host,
-		CPUCount:        getLogicalCPUs(),
-		SocketCount:     getCPUSocketCount(),
-		TotalMemoryMB:   getTotalMemoryMB(),
-		AgentVersion:    version.Version,
+		SSHAddress:           h.sshAddress,
+		OSVersion:            getOSVersion(),
+		KernelVersion:        getKernelVersion(),
+		HostIpAddresses:      ipAddresses,
+		NetworkInterfaces:    networkInterfaces,
+		DefaultGateways:      getDefaultGateways(),
+		HostName:             h.discovery.host,
+		CPUCount:             getLogicalCPUs(),
+		SocketCount:          getCPUSocketCount(),
+		TotalMemoryMB:        getTotalMemoryMB(),
+		AgentVersion:         version.Version,
+		ExportersStatus:      exporters.Statuses(),
+		ConfigFilesChecksums: getConfigFilesChecksums(),
+		InstalledPackages:    getInstalledPackages(),
+		SystemSettings:       getSystemSettings(),
 	}
 
-	err = h.discovery.collectorClient.Publish(h.id, host)
+	err = h.discovery.publisher.Publish(h.id, host)
 	if err != nil {
 		log.Debugf("Error while sending host discovery to data collector: %s", err)
 		return "", err
@@ -84,6 +102,100 @@ func getHostIpAddresses() ([]string, error) {
 	return ipAddrList, nil
 }
 
+func getNetworkInterfaces() ([]hosts.NetworkInterface, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	networkInterfaces := make([]hosts.NetworkInterface, 0)
+
+	for _, inter := range interfaces {
+		networkInterface := hosts.NetworkInterface{
+			Name:       inter.Name,
+			MACAddress: inter.HardwareAddr.String(),
+			MTU:        inter.MTU,
+			Addresses:  []string{},
+			BondMaster: getBondMaster(inter.Name),
+			VlanID:     getVlanID(inter.Name),
+		}
+
+		addrs, err := inter.Addrs()
+		if err == nil {
+			for _, addr := range addrs {
+				networkInterface.Addresses = append(networkInterface.Addresses, addr.String())
+			}
+		}
+
+		networkInterfaces = append(networkInterfaces, networkInterface)
+	}
+
+	return networkInterfaces, nil
+}
+
+// getBondMaster resolves the bonding interface a device is enslaved to, if any
+func getBondMaster(name string) string {
+	master, err := os.Readlink(fmt.Sprintf("/sys/class/net/%s/master", name))
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Base(master)
+}
+
+// getVlanID extracts the 802.1q tag from the conventional "device.id" VLAN interface name
+func getVlanID(name string) int {
+	dotIndex := strings.LastIndex(name, ".")
+	if dotIndex == -1 {
+		return 0
+	}
+
+	vlanID, err := strconv.Atoi(name[dotIndex+1:])
+	if err != nil {
+		return 0
+	}
+
+	return vlanID
+}
+
+// getDefaultGateways parses the kernel routing table looking for default routes
+func getDefaultGateways() []string {
+	gateways := make([]string, 0)
+
+	routes, err := ioutil.ReadFile("/proc/net/route")
+	if err != nil {
+		log.Errorf("Error while reading the routing table: %s", err)
+		return gateways
+	}
+
+	for _, line := range strings.Split(string(routes), "\n")[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+
+		gateway, err := parseHexIPAddress(fields[2])
+		if err != nil {
+			continue
+		}
+
+		gateways = append(gateways, gateway)
+	}
+
+	return gateways
+}
+
+// parseHexIPAddress converts the little-endian hexadecimal IP address notation
+// used by /proc/net/route into the usual dotted form
+func parseHexIPAddress(hexIP string) (string, error) {
+	ip, err := strconv.ParseUint(hexIP, 16, 32)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d.%d.%d.%d", byte(ip), byte(ip>>8), byte(ip>>16), byte(ip>>24)), nil
+}
+
 func getOSVersion() string {
 	infoStat, err := host.Info()
 	if err != nil {
@@ -92,6 +204,117 @@ func getOSVersion() string {
 	return infoStat.PlatformVersion
 }
 
+func getKernelVersion() string {
+	kernelVersion, err := host.KernelVersion()
+	if err != nil {
+		log.Errorf("Error while getting the kernel version: %s", err)
+	}
+	return kernelVersion
+}
+
+// configFilesToChecksum are the HA configuration files that should not
+// diverge between the nodes of a cluster
+var configFilesToChecksum = []string{
+	"/etc/corosync/corosync.conf",
+	"/etc/sysconfig/sbd",
+}
+
+// packagesToDiscover are the HA/SAP stack packages whose patch level
+// matters for a supported setup
+var packagesToDiscover = []string{
+	"pacemaker",
+	"corosync",
+	"SAPHanaSR",
+	"resource-agents",
+	"sbd",
+}
+
+func getInstalledPackages() map[string]string {
+	packages := make(map[string]string)
+
+	for _, name := range packagesToDiscover {
+		output, err := exec.Command("rpm", "-q", "--qf", "%{VERSION}", name).Output()
+		if err != nil {
+			continue
+		}
+		packages[name] = strings.TrimSpace(string(output))
+	}
+
+	return packages
+}
+
+// sysctlsToDiscover are the kernel parameters the SAP notes give
+// recommendations for
+var sysctlsToDiscover = []string{
+	"vm.swappiness",
+	"vm.max_map_count",
+	"net.ipv4.tcp_slow_start_after_idle",
+}
+
+// servicesToDiscover are the systemd units the SAP notes expect to be running
+var servicesToDiscover = []string{
+	"uuidd.socket",
+	"sysstat.service",
+}
+
+func getSystemSettings() map[string]string {
+	settings := make(map[string]string)
+
+	for _, key := range sysctlsToDiscover {
+		path := "/proc/sys/" + strings.ReplaceAll(key, ".", "/")
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		settings["sysctl:"+key] = strings.Join(strings.Fields(string(content)), " ")
+	}
+
+	if thp := getTransparentHugePagesState(); thp != "" {
+		settings["thp"] = thp
+	}
+
+	for _, unit := range servicesToDiscover {
+		// is-active exits non-zero for inactive units but still prints the state
+		output, _ := exec.Command("systemctl", "is-active", unit).Output()
+		if state := strings.TrimSpace(string(output)); state != "" {
+			settings["service:"+unit] = state
+		}
+	}
+
+	return settings
+}
+
+// getTransparentHugePagesState extracts the active value, which the kernel
+// marks with brackets: "always madvise [never]"
+func getTransparentHugePagesState() string {
+	content, err := ioutil.ReadFile("/sys/kernel/mm/transparent_hugepage/enabled")
+	if err != nil {
+		return ""
+	}
+
+	for _, field := range strings.Fields(string(content)) {
+		if strings.HasPrefix(field, "[") {
+			return strings.Trim(field, "[]")
+		}
+	}
+
+	return ""
+}
+
+func getConfigFilesChecksums() map[string]string {
+	checksums := make(map[string]string)
+
+	for _, path := range configFilesToChecksum {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		checksums[path] = fmt.Sprintf("%x", md5.Sum(content))
+	}
+
+	return checksums
+}
+
 func getTotalMemoryMB() int {
 	v, err := mem.VirtualMemory()
 	if err != nil {
@@ -6,11 +6,51 @@ func NewDiscoveredHostMock() hosts.DiscoveredHost {
 	return hosts.DiscoveredHost{
 		SSHAddress:      "10.2.2.22",
 		OSVersion:       "15-SP2",
+		KernelVersion:   "5.3.18-24.102-default",
 		HostIpAddresses: []string{"10.1.1.4", "10.1.1.5", "10.1.1.6"},
+		NetworkInterfaces: []hosts.NetworkInterface{
+			{
+				Name:       "eth0",
+				MACAddress: "00:16:3e:5f:8a:01",
+				MTU:        1500,
+				Addresses:  []string{"10.1.1.4/24"},
+			},
+			{
+				Name:       "eth0.42",
+				MACAddress: "00:16:3e:5f:8a:01",
+				MTU:        1500,
+				Addresses:  []string{"10.1.1.5/24"},
+				VlanID:     42,
+			},
+			{
+				Name:       "eth1",
+				MACAddress: "00:16:3e:5f:8a:02",
+				MTU:        1500,
+				Addresses:  []string{"10.1.1.6/24"},
+				BondMaster: "bond0",
+			},
+		},
+		DefaultGateways: []string{"10.1.1.1"},
 		HostName:        "thehostnamewherethediscoveryhappened",
 		CPUCount:        2,
 		SocketCount:     1,
 		TotalMemoryMB:   4096,
 		AgentVersion:    "trento-agent-version",
+		ExportersStatus: map[string]string{
+			"prometheus-node_exporter.service":   "active",
+			"prometheus-hanadb_exporter.service": "inactive",
+		},
+		ConfigFilesChecksums: map[string]string{
+			"/etc/corosync/corosync.conf": "da2e01eb9978552b75b198e80850ab2b",
+		},
+		InstalledPackages: map[string]string{
+			"pacemaker": "2.0.4",
+			"corosync":  "2.4.5",
+		},
+		SystemSettings: map[string]string{
+			"sysctl:vm.swappiness": "10",
+			"thp":                  "never",
+			"service:uuidd.socket": "active",
+		},
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/trento-project/trento/agent/discovery/collector"
 	"github.com/trento-project/trento/internal/sapsystem"
 )
 
@@ -15,10 +14,10 @@ type SAPSystemsDiscovery struct {
 	discovery BaseDiscovery
 }
 
-func NewSAPSystemsDiscovery(collectorClient collector.Client) SAPSystemsDiscovery {
+func NewSAPSystemsDiscovery(publisher DiscoveryPublisher) SAPSystemsDiscovery {
 	r := SAPSystemsDiscovery{}
 	r.id = SAPDiscoveryId
-	r.discovery = NewDiscovery(collectorClient)
+	r.discovery = NewDiscovery(publisher)
 	return r
 }
 
@@ -33,7 +32,7 @@ func (d SAPSystemsDiscovery) Discover() (string, error) {
 		return "", err
 	}
 
-	err = d.discovery.collectorClient.Publish(d.id, systems)
+	err = d.discovery.publisher.Publish(d.id, systems)
 	if err != nil {
 		log.Debugf("Error while sending sapsystem discovery to data collector: %s", err)
 		return "", err
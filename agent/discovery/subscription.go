@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	log "github.com/sirupsen/logrus"
-	"github.com/trento-project/trento/agent/discovery/collector"
 	"github.com/trento-project/trento/internal/subscription"
 )
 
@@ -15,10 +14,10 @@ type SubscriptionDiscovery struct {
 	discovery BaseDiscovery
 }
 
-func NewSubscriptionDiscovery(collectorClient collector.Client) SubscriptionDiscovery {
+func NewSubscriptionDiscovery(publisher DiscoveryPublisher) SubscriptionDiscovery {
 	r := SubscriptionDiscovery{}
 	r.id = SubscriptionDiscoveryId
-	r.discovery = NewDiscovery(collectorClient)
+	r.discovery = NewDiscovery(publisher)
 	return r
 }
 
@@ -32,7 +31,7 @@ func (d SubscriptionDiscovery) Discover() (string, error) {
 		return "", err
 	}
 
-	err = d.discovery.collectorClient.Publish(d.id, subsData)
+	err = d.discovery.publisher.Publish(d.id, subsData)
 	if err != nil {
 		log.Debugf("Error while sending subscription discovery to data collector: %s", err)
 		return "", err
@@ -0,0 +1,40 @@
+// Package facts runs the troubleshooting probes the server requests from an
+// agent. The probe names are resolved against the local allowlist below, so
+// a compromised or misbehaving server can never inject command strings: an
+// unknown probe name is rejected without executing anything.
+package facts
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+//go:generate mockery --all
+
+// probes maps the supported probe names to the command each one runs. It has
+// to stay aligned with the catalog the server exposes on the facts endpoints.
+var probes = map[string][]string{
+	"corosync-config": {"cat", "/etc/corosync/corosync.conf"},
+	"crm-status":      {"crm_mon", "-1r"},
+	"saphanasr-state": {"SAPHanaSR-showAttr"},
+	"sbd-config":      {"cat", "/etc/sysconfig/sbd"},
+}
+
+type CustomCommand func(name string, arg ...string) *exec.Cmd
+
+var customExecCommand CustomCommand = exec.Command
+
+// Gather runs the given probe and returns its combined output
+func Gather(probe string) (string, error) {
+	command, ok := probes[probe]
+	if !ok {
+		return "", fmt.Errorf("unknown probe: %s", probe)
+	}
+
+	output, err := customExecCommand(command[0], command[1:]...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("the %s probe failed: %s: %s", probe, err, output)
+	}
+
+	return string(output), nil
+}
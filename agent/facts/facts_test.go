@@ -0,0 +1,49 @@
+package facts
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/agent/facts/mocks"
+)
+
+func TestGather(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "cat", "/etc/sysconfig/sbd").Return(
+		exec.Command("echo", "some sbd configuration"),
+	)
+
+	output, err := Gather("sbd-config")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "some sbd configuration\n", output)
+}
+
+func TestGatherProbeError(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "crm_mon", "-1r").Return(
+		exec.Command("sh", "-c", "echo some error; exit 1"),
+	)
+
+	_, err := Gather("crm-status")
+
+	assert.EqualError(t, err, "the crm-status probe failed: exit status 1: some error\n")
+}
+
+func TestGatherUnknownProbe(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	_, err := Gather("rm -rf /")
+
+	assert.EqualError(t, err, "unknown probe: rm -rf /")
+	mockCommand.AssertNotCalled(t, "Execute")
+}
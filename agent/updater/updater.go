@@ -0,0 +1,144 @@
+// Package updater installs the agent package version advertised by the server,
+// keeping enough state on disk to commit the update once the updated agent
+// proves itself healthy, or to roll it back when it does not.
+package updater
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+//go:generate mockery --all
+
+const (
+	agentPackage = "trento-agent"
+	// repositoryAlias is the zypper alias the update repository is registered under
+	repositoryAlias = "trento-agent-updates"
+	// zypperExitErrZypp is returned by zypper addrepo when the alias is already registered
+	zypperExitErrZypp = 4
+)
+
+// pendingUpdatePath persists the in-flight update across the service restart
+// the package installation triggers
+var pendingUpdatePath = filepath.Join("/var/lib/trento", "pending-update.json")
+
+type CustomCommand func(name string, arg ...string) *exec.Cmd
+
+var customExecCommand CustomCommand = exec.Command
+
+var fileSystem = afero.NewOsFs()
+
+// PendingUpdate tracks an update from its installation until the updated agent
+// is confirmed healthy, so the previous version can be restored if it is not
+type PendingUpdate struct {
+	PreviousVersion string    `json:"previous_version"`
+	DesiredVersion  string    `json:"desired_version"`
+	StartedAt       time.Time `json:"started_at"`
+}
+
+// EnsureRepository registers the repository the agent updates are downloaded
+// from. The repository GPG key and the package signatures are verified by
+// zypper itself, so a tampered package aborts the transaction.
+func EnsureRepository(url string) error {
+	output, err := customExecCommand(
+		"zypper", "--non-interactive", "addrepo", "--refresh", url, repositoryAlias).CombinedOutput()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && exitError.ExitCode() == zypperExitErrZypp {
+			// the alias is already registered
+			return nil
+		}
+		return fmt.Errorf("could not add the update repository: %s: %s", err, output)
+	}
+
+	return nil
+}
+
+// Update records the running version for a possible rollback, then installs
+// the desired one. On success the package installation restarts the agent
+// service, replacing the running process with the updated one.
+func Update(currentVersion, desiredVersion string) error {
+	pending := &PendingUpdate{
+		PreviousVersion: currentVersion,
+		DesiredVersion:  desiredVersion,
+		StartedAt:       time.Now(),
+	}
+	if err := storePendingUpdate(pending); err != nil {
+		return err
+	}
+
+	if err := install(desiredVersion); err != nil {
+		// nothing was installed, there is nothing to roll back
+		_ = Commit()
+		return err
+	}
+
+	return nil
+}
+
+// Pending returns the update the agent service was restarted in the middle of,
+// or nil when no update is in flight
+func Pending() (*PendingUpdate, error) {
+	exists, err := afero.Exists(fileSystem, pendingUpdatePath)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	data, err := afero.ReadFile(fileSystem, pendingUpdatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending PendingUpdate
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, err
+	}
+
+	return &pending, nil
+}
+
+// Commit settles the pending update, keeping the currently installed version
+func Commit() error {
+	return fileSystem.Remove(pendingUpdatePath)
+}
+
+// Rollback reinstalls the version that was running before the pending update
+func Rollback(pending *PendingUpdate) error {
+	if err := install(pending.PreviousVersion); err != nil {
+		return err
+	}
+
+	return Commit()
+}
+
+// install installs an exact version of the agent package, allowing downgrades
+// so that the same path serves both updates and rollbacks
+func install(version string) error {
+	output, err := customExecCommand(
+		"zypper", "--non-interactive", "install", "--oldpackage",
+		fmt.Sprintf("%s=%s", agentPackage, version)).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("could not install %s %s: %s: %s", agentPackage, version, err, output)
+	}
+
+	return nil
+}
+
+func storePendingUpdate(pending *PendingUpdate) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+
+	if err := fileSystem.MkdirAll(filepath.Dir(pendingUpdatePath), 0755); err != nil {
+		return err
+	}
+
+	return afero.WriteFile(fileSystem, pendingUpdatePath, data, 0644)
+}
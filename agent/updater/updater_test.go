@@ -0,0 +1,117 @@
+package updater
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/agent/updater/mocks"
+)
+
+func TestEnsureRepository(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "zypper", "--non-interactive", "addrepo", "--refresh",
+		"https://updates.example.com/trento", repositoryAlias).Return(
+		exec.Command("true"),
+	)
+
+	err := EnsureRepository("https://updates.example.com/trento")
+
+	assert.NoError(t, err)
+}
+
+func TestEnsureRepositoryAlreadyRegistered(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "zypper", "--non-interactive", "addrepo", "--refresh",
+		"https://updates.example.com/trento", repositoryAlias).Return(
+		exec.Command("sh", "-c", "exit 4"),
+	)
+
+	err := EnsureRepository("https://updates.example.com/trento")
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateAndCommit(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+	fileSystem = afero.NewMemMapFs()
+
+	mockCommand.On("Execute", "zypper", "--non-interactive", "install", "--oldpackage",
+		"trento-agent=0.9.0").Return(
+		exec.Command("true"),
+	)
+
+	err := Update("0.8.0", "0.9.0")
+	assert.NoError(t, err)
+
+	pending, err := Pending()
+	assert.NoError(t, err)
+	assert.Equal(t, "0.8.0", pending.PreviousVersion)
+	assert.Equal(t, "0.9.0", pending.DesiredVersion)
+	assert.NotEmpty(t, pending.StartedAt)
+
+	err = Commit()
+	assert.NoError(t, err)
+
+	pending, err = Pending()
+	assert.NoError(t, err)
+	assert.Nil(t, pending)
+}
+
+func TestUpdateInstallError(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+	fileSystem = afero.NewMemMapFs()
+
+	mockCommand.On("Execute", "zypper", "--non-interactive", "install", "--oldpackage",
+		"trento-agent=0.9.0").Return(
+		exec.Command("false"),
+	)
+
+	err := Update("0.8.0", "0.9.0")
+	assert.Error(t, err)
+
+	// a failed installation leaves no pending update behind
+	pending, err := Pending()
+	assert.NoError(t, err)
+	assert.Nil(t, pending)
+}
+
+func TestRollback(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+	fileSystem = afero.NewMemMapFs()
+
+	mockCommand.On("Execute", "zypper", "--non-interactive", "install", "--oldpackage",
+		"trento-agent=0.9.0").Return(
+		exec.Command("true"),
+	)
+	mockCommand.On("Execute", "zypper", "--non-interactive", "install", "--oldpackage",
+		"trento-agent=0.8.0").Return(
+		exec.Command("true"),
+	)
+
+	err := Update("0.8.0", "0.9.0")
+	assert.NoError(t, err)
+
+	pending, err := Pending()
+	assert.NoError(t, err)
+
+	err = Rollback(pending)
+	assert.NoError(t, err)
+
+	pending, err = Pending()
+	assert.NoError(t, err)
+	assert.Nil(t, pending)
+}
@@ -1,6 +1,7 @@
 package agent
 
 import (
+	"io"
 	"os"
 	"os/signal"
 	"syscall"
@@ -12,20 +13,15 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/trento-project/trento/agent"
+	"github.com/trento-project/trento/agent/discovery"
+	"github.com/trento-project/trento/agent/discovery/collector"
 	"github.com/trento-project/trento/internal"
 )
 
 func NewAgentCmd() *cobra.Command {
 	var sshAddress string
 	var discoveryPeriod int
-
-	var collectorHost string
-	var collectorPort int
-
-	var enablemTLS bool
-	var cert string
-	var key string
-	var ca string
+	var manageExporters bool
 
 	agentCmd := &cobra.Command{
 		Use:   "agent",
@@ -49,19 +45,55 @@ func NewAgentCmd() *cobra.Command {
 
 	startCmd.Flags().IntVarP(&discoveryPeriod, "discovery-period", "", 10, "Discovery mechanism loop period in seconds")
 
-	startCmd.Flags().StringVar(&collectorHost, "collector-host", "localhost", "Data Collector host")
-	startCmd.Flags().IntVar(&collectorPort, "collector-port", 8081, "Data Collector port")
+	startCmd.Flags().Int("host-discovery-period", 0, "Host discovery loop period in seconds, 0 uses the global discovery period")
+	startCmd.Flags().Int("cluster-discovery-period", 0, "Cluster discovery loop period in seconds, 0 uses the global discovery period")
+	startCmd.Flags().Int("sapsystem-discovery-period", 0, "SAP system discovery loop period in seconds, 0 uses the global discovery period")
+	startCmd.Flags().Int("cloud-discovery-period", 0, "Cloud discovery loop period in seconds, 0 uses the global discovery period")
+	startCmd.Flags().Int("subscription-discovery-period", 0, "Subscription discovery loop period in seconds, 0 uses the global discovery period")
+
+	startCmd.Flags().BoolVar(&manageExporters, "manage-exporters", false, "Enable and keep running the Prometheus exporter systemd units on the host")
+
+	startCmd.Flags().String("ansible-folder", "/tmp/trento", "Folder where the ansible file structure is created when the agent executes the checks itself")
+
+	addCollectorFlags(startCmd)
+
+	checkConnectionCmd := &cobra.Command{
+		Use:   "check-connection",
+		Short: "Check connectivity and mTLS authentication against the collector endpoint",
+		Run:   checkConnection,
+	}
+
+	addCollectorFlags(checkConnectionCmd)
 
-	startCmd.Flags().BoolVar(&enablemTLS, "enable-mtls", false, "Enable mTLS authentication between server and agent")
-	startCmd.Flags().StringVar(&cert, "cert", "", "mTLS client certificate")
-	startCmd.Flags().StringVar(&key, "key", "", "mTLS client key")
-	startCmd.Flags().StringVar(&ca, "ca", "", "mTLS Certificate Authority")
+	dumpCmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Run the discoveries once and dump the payloads without sending them",
+		Run:   dump,
+	}
+
+	dumpCmd.Flags().String("ssh-address", "", "The address to which the trento-agent should be reachable for ssh connection by the runner for check execution.")
+	dumpCmd.Flags().String("output", "", "File the discovery payloads are written to, defaults to stdout")
 
 	agentCmd.AddCommand(startCmd)
+	agentCmd.AddCommand(checkConnectionCmd)
+	agentCmd.AddCommand(dumpCmd)
+	addServiceCmds(agentCmd)
 
 	return agentCmd
 }
 
+// addCollectorFlags registers the collector endpoint and mTLS flags shared by
+// the subcommands that talk to the server
+func addCollectorFlags(cmd *cobra.Command) {
+	cmd.Flags().String("collector-host", "localhost", "Data Collector host")
+	cmd.Flags().Int("collector-port", 8081, "Data Collector port")
+
+	cmd.Flags().Bool("enable-mtls", false, "Enable mTLS authentication between server and agent")
+	cmd.Flags().String("cert", "", "mTLS client certificate")
+	cmd.Flags().String("key", "", "mTLS client key")
+	cmd.Flags().String("ca", "", "mTLS Certificate Authority")
+}
+
 func start(*cobra.Command, []string) {
 	var err error
 
@@ -92,3 +124,53 @@ func start(*cobra.Command, []string) {
 		log.Fatal("Failed to start the agent: ", err)
 	}
 }
+
+func checkConnection(*cobra.Command, []string) {
+	collectorConfig, err := LoadCollectorConfig()
+	if err != nil {
+		log.Fatal("Failed to create the collector configuration: ", err)
+	}
+
+	collectorClient, err := collector.NewCollectorClient(collectorConfig)
+	if err != nil {
+		log.Fatal("Failed to create the collector client: ", err)
+	}
+
+	log.Printf("Connecting to %s:%d...", collectorConfig.CollectorHost, collectorConfig.CollectorPort)
+	if err := collectorClient.CheckConnection(); err != nil {
+		log.Fatal("Could not connect to the collector endpoint: ", err)
+	}
+
+	log.Println("Successfully connected to the collector endpoint.")
+}
+
+func dump(*cobra.Command, []string) {
+	var writer io.Writer = os.Stdout
+
+	if output := viper.GetString("output"); output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			log.Fatal("Failed to create the output file: ", err)
+		}
+		defer file.Close()
+		writer = file
+	}
+
+	dumpClient := collector.NewDumpClient(writer)
+	discoveries := []discovery.Discovery{
+		discovery.NewClusterDiscovery(dumpClient),
+		discovery.NewSAPSystemsDiscovery(dumpClient),
+		discovery.NewCloudDiscovery(dumpClient),
+		discovery.NewSubscriptionDiscovery(dumpClient),
+		discovery.NewHostDiscovery(viper.GetString("ssh-address"), dumpClient),
+	}
+
+	for _, d := range discoveries {
+		result, err := d.Discover()
+		if err != nil {
+			log.Errorf("Error while running discovery '%s': %s", d.GetId(), err)
+			continue
+		}
+		log.Info(result)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 	"github.com/trento-project/trento/agent"
+	"github.com/trento-project/trento/agent/discovery"
 	"github.com/trento-project/trento/agent/discovery/collector"
 )
 
-func LoadConfig() (*agent.Config, error) {
+// LoadCollectorConfig builds the collector endpoint configuration from the
+// flags shared by all the agent subcommands that talk to the server
+func LoadCollectorConfig() (*collector.Config, error) {
 	enablemTLS := viper.GetBool("enable-mtls")
 	cert := viper.GetString("cert")
 	key := viper.GetString("key")
@@ -34,6 +37,22 @@ func LoadConfig() (*agent.Config, error) {
 		}
 	}
 
+	return &collector.Config{
+		CollectorHost: viper.GetString("collector-host"),
+		CollectorPort: viper.GetInt("collector-port"),
+		EnablemTLS:    enablemTLS,
+		Cert:          cert,
+		Key:           key,
+		CA:            ca,
+	}, nil
+}
+
+func LoadConfig() (*agent.Config, error) {
+	collectorConfig, err := LoadCollectorConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	hostname, err := os.Hostname()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not read the hostname")
@@ -44,17 +63,26 @@ func LoadConfig() (*agent.Config, error) {
 		return nil, errors.New("ssh-address is required, cannot start agent")
 	}
 
+	discoveryPeriods := make(map[string]time.Duration)
+	for flagName, discoveryID := range map[string]string{
+		"host-discovery-period":         discovery.HostDiscoveryId,
+		"cluster-discovery-period":      discovery.ClusterDiscoveryId,
+		"sapsystem-discovery-period":    discovery.SAPDiscoveryId,
+		"cloud-discovery-period":        discovery.CloudDiscoveryId,
+		"subscription-discovery-period": discovery.SubscriptionDiscoveryId,
+	} {
+		if seconds := viper.GetInt(flagName); seconds > 0 {
+			discoveryPeriods[discoveryID] = time.Duration(seconds) * time.Second
+		}
+	}
+
 	return &agent.Config{
-		CollectorConfig: &collector.Config{
-			CollectorHost: viper.GetString("collector-host"),
-			CollectorPort: viper.GetInt("collector-port"),
-			EnablemTLS:    enablemTLS,
-			Cert:          cert,
-			Key:           key,
-			CA:            ca,
-		},
-		InstanceName:    hostname,
-		SSHAddress:      sshAddress,
-		DiscoveryPeriod: time.Duration(viper.GetInt("discovery-period")) * time.Second,
+		CollectorConfig:  collectorConfig,
+		InstanceName:     hostname,
+		SSHAddress:       sshAddress,
+		DiscoveryPeriod:  time.Duration(viper.GetInt("discovery-period")) * time.Second,
+		DiscoveryPeriods: discoveryPeriods,
+		ManageExporters:  viper.GetBool("manage-exporters"),
+		AnsibleFolder:    viper.GetString("ansible-folder"),
 	}, nil
 }
@@ -26,8 +26,10 @@ func (suite *AgentCmdTestSuite) SetupTest() {
 
 	cmd := NewAgentCmd()
 
-	cmd.Commands()[0].Run = func(cmd *cobra.Command, args []string) {
-		// do nothing
+	for _, command := range cmd.Commands() {
+		command.Run = func(cmd *cobra.Command, args []string) {
+			// do nothing
+		}
 	}
 
 	cmd.SetArgs([]string{
@@ -44,9 +46,11 @@ func (suite *AgentCmdTestSuite) TearDownTest() {
 	suite.cmd.Execute()
 
 	expectedConfig := &agent.Config{
-		InstanceName:    "some-hostname",
-		SSHAddress:      "some-ssh-address",
-		DiscoveryPeriod: 10 * time.Second,
+		InstanceName:     "some-hostname",
+		SSHAddress:       "some-ssh-address",
+		DiscoveryPeriod:  10 * time.Second,
+		DiscoveryPeriods: map[string]time.Duration{},
+		AnsibleFolder:    "/tmp/trento",
 		CollectorConfig: &collector.Config{
 			CollectorHost: "localhost",
 			CollectorPort: 1337,
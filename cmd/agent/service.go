@@ -0,0 +1,190 @@
+package agent
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+
+	"github.com/trento-project/trento/agent/discovery/collector"
+	"github.com/trento-project/trento/internal/exporters"
+)
+
+const (
+	agentConfigDir  = "/etc/trento"
+	agentConfigPath = "/etc/trento/agent.yaml"
+	agentUnitName   = "trento-agent.service"
+	agentUnitPath   = "/etc/systemd/system/trento-agent.service"
+)
+
+// agentUnitTemplate is the systemd unit the install subcommand writes; the
+// configuration lives in the agent.yaml file, so the unit never needs editing
+const agentUnitTemplate = `[Unit]
+Description=Trento Agent
+After=network-online.target
+
+[Service]
+Type=simple
+ExecStart=%s agent start
+Restart=on-failure
+RestartSec=5
+
+[Install]
+WantedBy=multi-user.target
+`
+
+// addServiceCmds registers the subcommands that manage the agent as a systemd
+// service, turning node onboarding into a single command
+func addServiceCmds(agentCmd *cobra.Command) {
+	installCmd := &cobra.Command{
+		Use:   "install",
+		Short: "Enroll with the server and install the agent as a systemd service",
+		Long: `Enrolls this host with the server using an enrollment token, writes the agent
+configuration file and the systemd unit, and starts the service. The token is
+served to administrators by the /api/agents/enrollment-token endpoint.`,
+		Run: install,
+	}
+
+	installCmd.Flags().String("enrollment-token", "", "Enrollment token handed out by the server.")
+	installCmd.Flags().String("ssh-address", "", "The address to which the trento-agent should be reachable for ssh connection by the runner for check execution.")
+	addCollectorFlags(installCmd)
+
+	uninstallCmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Stop the agent service and remove the systemd unit and configuration",
+		Run:   uninstall,
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the state of the agent service and its connection to the server",
+		Run:   status,
+	}
+
+	addCollectorFlags(statusCmd)
+
+	agentCmd.AddCommand(installCmd)
+	agentCmd.AddCommand(uninstallCmd)
+	agentCmd.AddCommand(statusCmd)
+}
+
+func install(*cobra.Command, []string) {
+	token := viper.GetString("enrollment-token")
+	if token == "" {
+		log.Fatal("An enrollment token is required, get one from the /api/agents/enrollment-token endpoint of the server.")
+	}
+
+	collectorConfig, err := LoadCollectorConfig()
+	if err != nil {
+		log.Fatal("Failed to create the collector configuration: ", err)
+	}
+
+	collectorClient, err := collector.NewCollectorClient(collectorConfig)
+	if err != nil {
+		log.Fatal("Failed to create the collector client: ", err)
+	}
+
+	log.Printf("Enrolling with the server at %s:%d...", collectorConfig.CollectorHost, collectorConfig.CollectorPort)
+	if err := collectorClient.Enroll(token); err != nil {
+		log.Fatal("Could not enroll with the server: ", err)
+	}
+
+	configContent, err := renderAgentConfig()
+	if err != nil {
+		log.Fatal("Failed to render the agent configuration: ", err)
+	}
+
+	if err := os.MkdirAll(agentConfigDir, 0755); err != nil {
+		log.Fatal("Failed to create the configuration directory: ", err)
+	}
+	if err := ioutil.WriteFile(agentConfigPath, configContent, 0600); err != nil {
+		log.Fatal("Failed to write the agent configuration: ", err)
+	}
+	log.Printf("Wrote the agent configuration to %s.", agentConfigPath)
+
+	executable, err := os.Executable()
+	if err != nil {
+		log.Fatal("Failed to locate the trento executable: ", err)
+	}
+	if err := ioutil.WriteFile(agentUnitPath, []byte(renderAgentUnit(executable)), 0644); err != nil {
+		log.Fatal("Failed to write the systemd unit: ", err)
+	}
+	log.Printf("Wrote the systemd unit to %s.", agentUnitPath)
+
+	systemctl("daemon-reload")
+	systemctl("enable", "--now", agentUnitName)
+
+	log.Printf("The agent is enrolled and running as %s, agent ID: %s.", agentUnitName, collectorClient.AgentID())
+}
+
+func uninstall(*cobra.Command, []string) {
+	if _, err := os.Stat(agentUnitPath); err == nil {
+		systemctl("disable", "--now", agentUnitName)
+
+		if err := os.Remove(agentUnitPath); err != nil {
+			log.Fatal("Failed to remove the systemd unit: ", err)
+		}
+		systemctl("daemon-reload")
+		log.Printf("Removed the %s unit.", agentUnitName)
+	} else {
+		log.Printf("The %s unit is not installed.", agentUnitName)
+	}
+
+	if err := os.Remove(agentConfigPath); err == nil {
+		log.Printf("Removed the agent configuration at %s.", agentConfigPath)
+	} else if !os.IsNotExist(err) {
+		log.Fatal("Failed to remove the agent configuration: ", err)
+	}
+}
+
+func status(*cobra.Command, []string) {
+	log.Printf("The %s unit is %s.", agentUnitName, exporters.Status(agentUnitName))
+
+	collectorConfig, err := LoadCollectorConfig()
+	if err != nil {
+		log.Fatal("Failed to create the collector configuration: ", err)
+	}
+
+	collectorClient, err := collector.NewCollectorClient(collectorConfig)
+	if err != nil {
+		log.Fatal("Failed to create the collector client: ", err)
+	}
+
+	if err := collectorClient.CheckConnection(); err != nil {
+		log.Printf("The server at %s:%d is not reachable: %s", collectorConfig.CollectorHost, collectorConfig.CollectorPort, err)
+		return
+	}
+
+	log.Printf("The server at %s:%d is reachable.", collectorConfig.CollectorHost, collectorConfig.CollectorPort)
+}
+
+// renderAgentConfig serializes the flags the service needs at runtime into the
+// configuration file the start subcommand picks up from /etc/trento
+func renderAgentConfig() ([]byte, error) {
+	return yaml.Marshal(map[string]interface{}{
+		"collector-host": viper.GetString("collector-host"),
+		"collector-port": viper.GetInt("collector-port"),
+		"enable-mtls":    viper.GetBool("enable-mtls"),
+		"cert":           viper.GetString("cert"),
+		"key":            viper.GetString("key"),
+		"ca":             viper.GetString("ca"),
+		"ssh-address":    viper.GetString("ssh-address"),
+	})
+}
+
+func renderAgentUnit(executable string) string {
+	return fmt.Sprintf(agentUnitTemplate, executable)
+}
+
+func systemctl(args ...string) {
+	output, err := exec.Command("systemctl", args...).CombinedOutput()
+	if err != nil {
+		log.Fatalf("systemctl %s failed: %s: %s", strings.Join(args, " "), err, output)
+	}
+}
@@ -0,0 +1,40 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestRenderAgentConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("collector-host", "collector.example.com")
+	viper.Set("collector-port", 8443)
+	viper.Set("enable-mtls", true)
+	viper.Set("cert", "some-cert")
+	viper.Set("key", "some-key")
+	viper.Set("ca", "some-ca")
+	viper.Set("ssh-address", "10.74.1.10")
+
+	content, err := renderAgentConfig()
+	assert.NoError(t, err)
+
+	var config map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal(content, &config))
+
+	assert.Equal(t, "collector.example.com", config["collector-host"])
+	assert.Equal(t, 8443, config["collector-port"])
+	assert.Equal(t, true, config["enable-mtls"])
+	assert.Equal(t, "10.74.1.10", config["ssh-address"])
+}
+
+func TestRenderAgentUnit(t *testing.T) {
+	unit := renderAgentUnit("/usr/bin/trento")
+
+	assert.Contains(t, unit, "ExecStart=/usr/bin/trento agent start")
+	assert.Contains(t, unit, "WantedBy=multi-user.target")
+}
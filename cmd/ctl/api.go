@@ -0,0 +1,271 @@
+package ctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v2"
+)
+
+const apiRequestTimeout = 30 * time.Second
+
+// apiClient is a thin client for the trento server API, used by the ctl
+// subcommands that operate on a running server instead of the database
+type apiClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newApiClient() *apiClient {
+	return &apiClient{
+		baseURL: viper.GetString("api-url"),
+		client:  &http.Client{Timeout: apiRequestTimeout},
+	}
+}
+
+func (c *apiClient) do(method, path string, body []byte) ([]byte, error) {
+	var bodyReader *bytes.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	} else {
+		bodyReader = bytes.NewReader([]byte{})
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("the server replied with %s: %s", resp.Status, responseBody)
+	}
+
+	return responseBody, nil
+}
+
+func (c *apiClient) get(path string, out interface{}) error {
+	body, err := c.do(http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, out)
+}
+
+// printOutput renders data in the format selected with the --output flag.
+// The table rendering is command specific and passed in as a callback, while
+// json and yaml are marshalled generically so they can be piped into jq & co.
+func printOutput(data interface{}, table func()) {
+	switch output := viper.GetString("output"); output {
+	case "json":
+		encoded, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			log.Fatal("Error while encoding the output: ", err)
+		}
+		fmt.Println(string(encoded))
+	case "yaml":
+		encoded, err := yaml.Marshal(data)
+		if err != nil {
+			log.Fatal("Error while encoding the output: ", err)
+		}
+		fmt.Print(string(encoded))
+	case "table":
+		table()
+	default:
+		log.Fatalf("Unknown output format %s, use one of: json, yaml, table.", output)
+	}
+}
+
+type jsonHostSummary struct {
+	ID            string `json:"id" yaml:"id"`
+	Name          string `json:"name" yaml:"name"`
+	Health        string `json:"health" yaml:"health"`
+	CloudProvider string `json:"cloud_provider" yaml:"cloud_provider"`
+	ClusterName   string `json:"cluster_name" yaml:"cluster_name"`
+	AgentVersion  string `json:"agent_version" yaml:"agent_version"`
+}
+
+func addListHostsCmd(ctlCmd *cobra.Command) {
+	listHostsCmd := &cobra.Command{
+		Use:   "list-hosts",
+		Short: "List the hosts discovered by the server",
+		Run: func(*cobra.Command, []string) {
+			var hosts []jsonHostSummary
+			if err := newApiClient().get("/api/hosts", &hosts); err != nil {
+				log.Fatal("Error while listing the hosts: ", err)
+			}
+
+			printOutput(hosts, func() {
+				w := newTabWriter()
+				fmt.Fprintln(w, "ID\tNAME\tHEALTH\tPROVIDER\tCLUSTER\tAGENT VERSION")
+				for _, host := range hosts {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+						host.ID, host.Name, host.Health, host.CloudProvider, host.ClusterName, host.AgentVersion)
+				}
+				w.Flush()
+			})
+		},
+	}
+
+	ctlCmd.AddCommand(listHostsCmd)
+}
+
+type jsonClusterSummary struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	ClusterType string `json:"cluster_type" yaml:"cluster_type"`
+	SID         string `json:"sid" yaml:"sid"`
+	HostsNumber int    `json:"hosts_number" yaml:"hosts_number"`
+	Health      string `json:"health" yaml:"health"`
+}
+
+func addListClustersCmd(ctlCmd *cobra.Command) {
+	listClustersCmd := &cobra.Command{
+		Use:   "list-clusters",
+		Short: "List the Pacemaker clusters discovered by the server",
+		Run: func(*cobra.Command, []string) {
+			var clusters []jsonClusterSummary
+			if err := newApiClient().get("/api/clusters", &clusters); err != nil {
+				log.Fatal("Error while listing the clusters: ", err)
+			}
+
+			printOutput(clusters, func() {
+				w := newTabWriter()
+				fmt.Fprintln(w, "ID\tNAME\tTYPE\tSID\tHOSTS\tHEALTH")
+				for _, cluster := range clusters {
+					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\n",
+						cluster.ID, cluster.Name, cluster.ClusterType, cluster.SID, cluster.HostsNumber, cluster.Health)
+				}
+				w.Flush()
+			})
+		},
+	}
+
+	ctlCmd.AddCommand(listClustersCmd)
+}
+
+func addTagCmd(ctlCmd *cobra.Command) {
+	tagCmd := &cobra.Command{
+		Use:   "tag <hosts|clusters|sapsystems|databases> <id> <tag>",
+		Short: "Tag a resource through the server API",
+		Args:  cobra.ExactArgs(3),
+		Run: func(_ *cobra.Command, args []string) {
+			resourceType, id, tag := args[0], args[1], args[2]
+
+			body, err := json.Marshal(map[string]string{"tag": tag})
+			if err != nil {
+				log.Fatal("Error while encoding the tag: ", err)
+			}
+
+			path := fmt.Sprintf("/api/%s/%s/tags", resourceType, id)
+			if _, err := newApiClient().do(http.MethodPost, path, body); err != nil {
+				log.Fatal("Error while tagging the resource: ", err)
+			}
+
+			log.Infof("Resource %s tagged with %s.", id, tag)
+		},
+	}
+
+	ctlCmd.AddCommand(tagCmd)
+}
+
+func addUploadCatalogCmd(ctlCmd *cobra.Command) {
+	uploadCatalogCmd := &cobra.Command{
+		Use:   "upload-catalog <file>",
+		Short: "Upload a checks catalog file through the server API",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			catalog, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				log.Fatal("Error while reading the checks catalog file: ", err)
+			}
+
+			if _, err := newApiClient().do(http.MethodPut, "/api/checks/catalog", catalog); err != nil {
+				log.Fatal("Error while uploading the checks catalog: ", err)
+			}
+
+			log.Info("Checks catalog uploaded.")
+		},
+	}
+
+	ctlCmd.AddCommand(uploadCatalogCmd)
+}
+
+func addRunChecksCmd(ctlCmd *cobra.Command) {
+	runChecksCmd := &cobra.Command{
+		Use:   "run-checks <cluster-id>",
+		Short: "Trigger a checks execution for a cluster",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			path := fmt.Sprintf("/api/clusters/%s/checks/execute", args[0])
+			if _, err := newApiClient().do(http.MethodPost, path, nil); err != nil {
+				log.Fatal("Error while requesting the checks execution: ", err)
+			}
+
+			log.Infof("Checks execution requested for cluster %s.", args[0])
+		},
+	}
+
+	ctlCmd.AddCommand(runChecksCmd)
+}
+
+func addHealthCmd(ctlCmd *cobra.Command) {
+	healthCmd := &cobra.Command{
+		Use:   "health",
+		Short: "Dump the landscape health summary",
+		Run: func(*cobra.Command, []string) {
+			var health map[string]interface{}
+			if err := newApiClient().get("/api/health", &health); err != nil {
+				log.Fatal("Error while fetching the landscape health: ", err)
+			}
+
+			printOutput(health, func() {
+				w := newTabWriter()
+				for _, key := range sortedKeys(health) {
+					fmt.Fprintf(w, "%s\t%v\n", key, health[key])
+				}
+				w.Flush()
+			})
+		},
+	}
+
+	ctlCmd.AddCommand(healthCmd)
+}
+
+func newTabWriter() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
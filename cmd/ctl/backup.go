@@ -0,0 +1,202 @@
+package ctl
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/trento-project/trento/web"
+	"gorm.io/gorm"
+)
+
+func addDumpCmd(ctlCmd *cobra.Command) {
+	var dumpPath string
+
+	dumpCmd := &cobra.Command{
+		Use:   "dump",
+		Short: "Export all the Trento entities to a portable archive",
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+			dumpPath := viper.GetString("path")
+
+			file, err := os.Create(dumpPath)
+			if err != nil {
+				log.Fatal("Error while creating the dump archive: ", err)
+			}
+			defer file.Close()
+
+			if err := dumpDB(db, web.DBTables, file); err != nil {
+				log.Fatal("Error while dumping the database: ", err)
+			}
+
+			log.Infof("Database dumped to %s.", dumpPath)
+		},
+	}
+
+	dumpCmd.Flags().StringVar(&dumpPath, "path", "trento-dump.tar.gz", "The path of the dump archive.")
+
+	ctlCmd.AddCommand(dumpCmd)
+}
+
+func addRestoreCmd(ctlCmd *cobra.Command) {
+	var dumpPath string
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the Trento entities from a dump archive",
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+			dumpPath := viper.GetString("path")
+
+			file, err := os.Open(dumpPath)
+			if err != nil {
+				log.Fatal("Error while opening the dump archive: ", err)
+			}
+			defer file.Close()
+
+			if err := web.MigrateDB(db); err != nil {
+				log.Fatal("Error while migrating the database: ", err)
+			}
+
+			if err := restoreDB(db, web.DBTables, file); err != nil {
+				log.Fatal("Error while restoring the database: ", err)
+			}
+
+			log.Infof("Database restored from %s.", dumpPath)
+		},
+	}
+
+	restoreCmd.Flags().StringVar(&dumpPath, "path", "trento-dump.tar.gz", "The path of the dump archive.")
+
+	ctlCmd.AddCommand(restoreCmd)
+}
+
+// dumpDB exports all the rows of the given tables as a gzipped tar archive,
+// with one JSON file per table
+func dumpDB(db *gorm.DB, tables []interface{}, w io.Writer) error {
+	gzWriter := gzip.NewWriter(w)
+	defer gzWriter.Close()
+	tarWriter := tar.NewWriter(gzWriter)
+	defer tarWriter.Close()
+
+	for _, t := range tables {
+		tableName, err := getTableName(db, t)
+		if err != nil {
+			return err
+		}
+
+		var rows []map[string]interface{}
+		if err := db.Table(tableName).Find(&rows).Error; err != nil {
+			return fmt.Errorf("could not dump table %s: %s", tableName, err)
+		}
+
+		// the master secret the connection settings are sealed with must never
+		// leave the database together with the ciphertexts it protects
+		if tableName == "settings" {
+			for _, row := range rows {
+				delete(row, "encryption_key")
+			}
+		}
+
+		data, err := json.Marshal(rows)
+		if err != nil {
+			return fmt.Errorf("could not marshal table %s: %s", tableName, err)
+		}
+
+		header := &tar.Header{
+			Name:    fmt.Sprintf("%s.json", tableName),
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(data); err != nil {
+			return err
+		}
+
+		log.Infof("Table %s dumped, %d rows.", tableName, len(rows))
+	}
+
+	return nil
+}
+
+// restoreDB re-imports a dump archive created by dumpDB, replacing the current
+// content of the dumped tables
+func restoreDB(db *gorm.DB, tables []interface{}, r io.Reader) error {
+	tableNames := make(map[string]bool)
+	for _, t := range tables {
+		tableName, err := getTableName(db, t)
+		if err != nil {
+			return err
+		}
+		tableNames[fmt.Sprintf("%s.json", tableName)] = true
+	}
+
+	gzReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gzReader.Close()
+	tarReader := tar.NewReader(gzReader)
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if !tableNames[header.Name] {
+				log.Warnf("Skipping unknown archive entry %s.", header.Name)
+				continue
+			}
+			tableName := header.Name[:len(header.Name)-len(".json")]
+
+			data, err := ioutil.ReadAll(tarReader)
+			if err != nil {
+				return err
+			}
+
+			var rows []map[string]interface{}
+			if err := json.Unmarshal(data, &rows); err != nil {
+				return fmt.Errorf("could not unmarshal table %s: %s", tableName, err)
+			}
+
+			if err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", tableName)).Error; err != nil {
+				return fmt.Errorf("could not truncate table %s: %s", tableName, err)
+			}
+
+			if len(rows) > 0 {
+				if err := tx.Table(tableName).Create(rows).Error; err != nil {
+					return fmt.Errorf("could not restore table %s: %s", tableName, err)
+				}
+			}
+
+			log.Infof("Table %s restored, %d rows.", tableName, len(rows))
+		}
+	})
+}
+
+func getTableName(db *gorm.DB, model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", err
+	}
+
+	return stmt.Schema.Table, nil
+}
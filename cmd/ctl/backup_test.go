@@ -0,0 +1,86 @@
+package ctl
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/trento-project/trento/web/entities"
+)
+
+func (suite *CtlTestSuite) TestDumpRestore() {
+	type DumpEntity struct {
+		ID            int
+		DummyProperty string
+	}
+
+	targetTables := []interface{}{&DumpEntity{}}
+	suite.tx.AutoMigrate(targetTables...)
+
+	var dumpEntities []DumpEntity
+	for i := 1; i <= 5; i++ {
+		dumpEntities = append(dumpEntities, DumpEntity{
+			ID:            i,
+			DummyProperty: fmt.Sprintf("prop-%d", i),
+		})
+	}
+
+	err := suite.tx.Create(&dumpEntities).Error
+	suite.NoError(err)
+
+	var archive bytes.Buffer
+	err = dumpDB(suite.tx, targetTables, &archive)
+	suite.NoError(err)
+
+	err = suite.tx.Exec("TRUNCATE TABLE dump_entities").Error
+	suite.NoError(err)
+	err = suite.tx.Create(&DumpEntity{ID: 42, DummyProperty: "leftover"}).Error
+	suite.NoError(err)
+
+	err = restoreDB(suite.tx, targetTables, &archive)
+	suite.NoError(err)
+
+	var restoredEntities []DumpEntity
+	err = suite.tx.Order("id").Find(&restoredEntities).Error
+	suite.NoError(err)
+
+	suite.Equal(5, len(restoredEntities))
+	suite.Equal(1, restoredEntities[0].ID)
+	suite.Equal("prop-1", restoredEntities[0].DummyProperty)
+	suite.Equal("prop-5", restoredEntities[4].DummyProperty)
+}
+
+func (suite *CtlTestSuite) TestDumpExcludesEncryptionKey() {
+	targetTables := []interface{}{&entities.Settings{}}
+	suite.tx.AutoMigrate(targetTables...)
+
+	err := suite.tx.Create(&entities.Settings{
+		InstallationID: "59fd8017-b7fd-477b-9ebe-b658c558f3e9",
+		EncryptionKey:  "super-secret-key",
+	}).Error
+	suite.NoError(err)
+
+	var archive bytes.Buffer
+	err = dumpDB(suite.tx, targetTables, &archive)
+	suite.NoError(err)
+
+	gzReader, err := gzip.NewReader(&archive)
+	suite.NoError(err)
+	tarReader := tar.NewReader(gzReader)
+
+	header, err := tarReader.Next()
+	suite.NoError(err)
+	suite.Equal("settings.json", header.Name)
+
+	data, err := ioutil.ReadAll(tarReader)
+	suite.NoError(err)
+
+	var rows []map[string]interface{}
+	suite.NoError(json.Unmarshal(data, &rows))
+	suite.Equal(1, len(rows))
+	suite.Equal("59fd8017-b7fd-477b-9ebe-b658c558f3e9", rows[0]["installation_id"])
+	suite.NotContains(rows[0], "encryption_key")
+}
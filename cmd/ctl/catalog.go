@@ -0,0 +1,101 @@
+package ctl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/version"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+const checksCatalogBundleVersion = 1
+
+// checksCatalogBundle is the offline distribution format of the checks catalog:
+// the raw catalog plus a checksum sealing its content, so that air-gapped
+// installations can verify a bundle before importing it
+type checksCatalogBundle struct {
+	Version  int             `json:"version"`
+	Checksum string          `json:"checksum"`
+	Checks   json.RawMessage `json:"checks"`
+}
+
+func addImportCatalogCmd(ctlCmd *cobra.Command) {
+	importCatalogCmd := &cobra.Command{
+		Use:   "import-catalog <bundle>",
+		Short: "Import the checks catalog from an offline bundle file",
+		Args:  cobra.ExactArgs(1),
+		Run: func(_ *cobra.Command, args []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				log.Fatal("Error while reading the checks catalog bundle: ", err)
+			}
+
+			imported, err := importCatalog(db, data)
+			if err != nil {
+				log.Fatal("Error while importing the checks catalog: ", err)
+			}
+
+			log.Infof("Checks catalog imported, %d checks.", imported)
+		},
+	}
+
+	ctlCmd.AddCommand(importCatalogCmd)
+}
+
+func importCatalog(db *gorm.DB, data []byte) (int, error) {
+	catalog, err := readChecksCatalogBundle(data)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptionsService := services.NewSubscriptionsService(db, nil)
+	settingsService := services.NewSettingsService(db)
+	premiumDetectionService := services.NewPremiumDetectionService(version.Flavor, subscriptionsService, settingsService)
+	alertsService := services.NewAlertsService(db, nil)
+	eventsService := services.NewEventsService(db, nil)
+	checksService := services.NewChecksService(db, premiumDetectionService, alertsService, eventsService, settingsService, services.NewTrashService(db))
+
+	if err := checksService.CreateChecksCatalog(catalog); err != nil {
+		return 0, err
+	}
+
+	return len(catalog), nil
+}
+
+func readChecksCatalogBundle(data []byte) (models.ChecksCatalog, error) {
+	var bundle checksCatalogBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("unable to parse the checks catalog bundle: %s", err)
+	}
+
+	if bundle.Version != checksCatalogBundleVersion {
+		return nil, fmt.Errorf("unsupported checks catalog bundle version %d", bundle.Version)
+	}
+
+	checksum := sha256.Sum256(bundle.Checks)
+	if hex.EncodeToString(checksum[:]) != bundle.Checksum {
+		return nil, fmt.Errorf("the checks catalog bundle checksum does not match its content")
+	}
+
+	var catalog models.ChecksCatalog
+	if err := json.Unmarshal(bundle.Checks, &catalog); err != nil {
+		return nil, fmt.Errorf("unable to parse the checks contained in the bundle: %s", err)
+	}
+
+	if len(catalog) == 0 {
+		return nil, fmt.Errorf("the checks catalog bundle does not contain any check")
+	}
+
+	return catalog, nil
+}
@@ -0,0 +1,47 @@
+package ctl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/trento-project/trento/web/entities"
+)
+
+func (suite *CtlTestSuite) TestImportCatalog() {
+	suite.tx.AutoMigrate(&entities.Check{})
+
+	checks := []byte(`[{"id": "check1", "name": "1.1.1", "group": "group 1"}]`)
+	checksum := sha256.Sum256(checks)
+
+	bundle, err := json.Marshal(map[string]interface{}{
+		"version":  checksCatalogBundleVersion,
+		"checksum": hex.EncodeToString(checksum[:]),
+		"checks":   json.RawMessage(checks),
+	})
+	suite.NoError(err)
+
+	imported, err := importCatalog(suite.tx, bundle)
+	suite.NoError(err)
+	suite.Equal(1, imported)
+
+	var storedChecks entities.CheckList
+	err = suite.tx.Find(&storedChecks).Error
+	suite.NoError(err)
+	suite.Len(storedChecks, 1)
+	suite.Equal("check1", storedChecks[0].ID)
+}
+
+func (suite *CtlTestSuite) TestImportCatalogChecksumMismatch() {
+	bundle := []byte(`{"version": 1, "checksum": "deadbeef", "checks": [{"id": "check1"}]}`)
+
+	_, err := importCatalog(suite.tx, bundle)
+	suite.EqualError(err, "the checks catalog bundle checksum does not match its content")
+}
+
+func (suite *CtlTestSuite) TestImportCatalogUnsupportedVersion() {
+	bundle := []byte(`{"version": 42, "checksum": "", "checks": []}`)
+
+	_, err := importCatalog(suite.tx, bundle)
+	suite.EqualError(err, "unsupported checks catalog bundle version 42")
+}
@@ -18,6 +18,7 @@ import (
 	dbCmd "github.com/trento-project/trento/cmd/db"
 	"github.com/trento-project/trento/internal"
 	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/internal/redact"
 	"github.com/trento-project/trento/web"
 
 	"github.com/trento-project/trento/web/datapipeline"
@@ -46,6 +47,27 @@ func NewCtlCmd() *cobra.Command {
 	addPruneChecksResultsCmd(ctlCmd)
 	addDBResetCmd(ctlCmd)
 	addDumpScenarioCmd(ctlCmd)
+	addDumpCmd(ctlCmd)
+	addRestoreCmd(ctlCmd)
+	addRebuildProjectionsCmd(ctlCmd)
+	addImportCatalogCmd(ctlCmd)
+	addRotateEncryptionKeyCmd(ctlCmd)
+	addCreateAdminCmd(ctlCmd)
+	addSeedCmd(ctlCmd)
+	addLoadtestCmd(ctlCmd)
+
+	ctlCmd.PersistentFlags().String("api-url", "http://localhost:8080", "Base URL of the trento server API, used by the client subcommands")
+	ctlCmd.PersistentFlags().String("output", "table", "Output format of the client subcommands (json, yaml, table)")
+	ctlCmd.RegisterFlagCompletionFunc("output", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"json", "yaml", "table"}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	addListHostsCmd(ctlCmd)
+	addListClustersCmd(ctlCmd)
+	addTagCmd(ctlCmd)
+	addUploadCatalogCmd(ctlCmd)
+	addRunChecksCmd(ctlCmd)
+	addHealthCmd(ctlCmd)
 
 	return ctlCmd
 }
@@ -130,6 +152,21 @@ func addDumpScenarioCmd(ctlCmd *cobra.Command) {
 	ctlCmd.AddCommand(dumpScenarioCmd)
 }
 
+func addRebuildProjectionsCmd(ctlCmd *cobra.Command) {
+	rebuildProjectionsCmd := &cobra.Command{
+		Use:   "rebuild-projections",
+		Short: "Truncate the projected read models and rebuild them by replaying the stored events",
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+
+			rebuildProjections(db)
+		},
+	}
+
+	ctlCmd.AddCommand(rebuildProjectionsCmd)
+}
+
 func getContext() context.Context {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
@@ -204,6 +241,36 @@ func dbReset(db *gorm.DB, tables []interface{}) {
 	log.Info("Database reset.")
 }
 
+func rebuildProjections(db *gorm.DB) {
+	log.Info("Rebuilding projections...")
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range datapipeline.ProjectedTables {
+			stmt := &gorm.Statement{DB: db}
+			stmt.Parse(t)
+			tableName := stmt.Schema.Table
+
+			err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", tableName)).Error
+			if err != nil {
+				return fmt.Errorf("could not truncate table %s: %s", tableName, err)
+			}
+			log.Infof("Table %s truncated.", tableName)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal("Error while truncating the projected read models: ", err)
+	}
+
+	projectorsRegistry := datapipeline.InitProjectorsRegistry(db)
+	replayed, err := datapipeline.ReplayEvents(db, projectorsRegistry)
+	if err != nil {
+		log.Fatal("Error while replaying the stored events: ", err)
+	}
+
+	log.Infof("Projections rebuilt, %d events replayed.", replayed)
+}
+
 func dumpScenario(db *gorm.DB, exportPath string, scenarioName string) {
 	events, err := getLatestEvents(db)
 	if err != nil {
@@ -221,10 +288,12 @@ func dumpScenario(db *gorm.DB, exportPath string, scenarioName string) {
 	}
 
 	for _, event := range events {
+		// scenarios are meant to be shared, so the payloads are scrubbed of
+		// any credential before being exported
 		data, err := json.MarshalIndent(map[string]interface{}{
 			"agent_id":       event.AgentID,
 			"discovery_type": event.DiscoveryType,
-			"payload":        event.Payload,
+			"payload":        json.RawMessage(redact.JSON(event.Payload)),
 		}, "", " ")
 		if err != nil {
 			log.Fatal("Error while marshaling event: ", err)
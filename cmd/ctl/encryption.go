@@ -0,0 +1,90 @@
+package ctl
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal/secrets"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func addRotateEncryptionKeyCmd(ctlCmd *cobra.Command) {
+	rotateEncryptionKeyCmd := &cobra.Command{
+		Use:   "rotate-encryption-key",
+		Short: "Replace the key the stored secrets are encrypted with, re-sealing all of them",
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+
+			rotateEncryptionKey(db)
+		},
+	}
+
+	ctlCmd.AddCommand(rotateEncryptionKeyCmd)
+}
+
+// rotateEncryptionKey replaces the database-stored fallback key; installations
+// sealing with an externally supplied master secret manage its rotation
+// outside the database, this command does not apply to them
+func rotateEncryptionKey(db *gorm.DB) {
+	settingsService := services.NewSettingsService(db)
+
+	currentKey, err := settingsService.GetEncryptionKey()
+	if err != nil {
+		log.Fatal("Error while loading the current encryption key: ", err)
+	}
+
+	newKey, err := secrets.NewMasterSecret()
+	if err != nil {
+		log.Fatal("Error while generating the new encryption key: ", err)
+	}
+
+	currentSealer := secrets.NewSealer(currentKey)
+	newSealer := secrets.NewSealer(newKey)
+
+	var resealed int64
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var connSettingsList []models.ConnectionSettings
+		if err := tx.Find(&connSettingsList).Error; err != nil {
+			return err
+		}
+
+		for _, connSettings := range connSettingsList {
+			var resealedUser string
+			var err error
+
+			if secrets.IsSealed(connSettings.User) {
+				resealedUser, err = currentSealer.Rekey(connSettings.User, newSealer)
+			} else {
+				// Rows predating encryption at rest get sealed now
+				resealedUser, err = newSealer.Seal(connSettings.User)
+			}
+			if err != nil {
+				return err
+			}
+
+			err = tx.Model(&models.ConnectionSettings{}).
+				Where("id", connSettings.ID).
+				Where("node", connSettings.Node).
+				Update("user", resealedUser).
+				Error
+			if err != nil {
+				return err
+			}
+			resealed = resealed + 1
+		}
+
+		return tx.Model(&entities.Settings{}).
+			Where("encryption_key", currentKey).
+			Update("encryption_key", newKey).
+			Error
+	})
+	if err != nil {
+		log.Fatal("Error while rotating the encryption key: ", err)
+	}
+
+	log.Infof("Encryption key rotated, %d connection settings re-sealed.", resealed)
+}
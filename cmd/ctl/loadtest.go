@@ -0,0 +1,221 @@
+package ctl
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/trento-project/trento/agent/discovery/mocks"
+	"github.com/trento-project/trento/contracts"
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/datapipeline"
+)
+
+func addLoadtestCmd(ctlCmd *cobra.Command) {
+	var agents int
+	var interval time.Duration
+	var duration time.Duration
+	var collectorURL string
+
+	loadtestCmd := &cobra.Command{
+		Use:   "loadtest",
+		Short: "Generate synthetic agent traffic against a collector endpoint",
+		Long: `Simulates a fleet of agents publishing host discoveries and heartbeats against
+the collector endpoint of a running server, reporting request latencies and
+errors. Useful to size deployments and to catch projector bottlenecks.`,
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+
+			runLoadtest(ctx,
+				viper.GetString("collector-url"),
+				viper.GetInt("agents"),
+				viper.GetDuration("interval"),
+				viper.GetDuration("duration"),
+			)
+		},
+	}
+
+	loadtestCmd.Flags().IntVar(&agents, "agents", 10, "Number of agents to simulate.")
+	loadtestCmd.Flags().DurationVar(&interval, "interval", 10*time.Second, "Interval between the publications of each simulated agent.")
+	loadtestCmd.Flags().DurationVar(&duration, "duration", 1*time.Minute, "How long the load test runs.")
+	loadtestCmd.Flags().StringVar(&collectorURL, "collector-url", "http://localhost:8081", "Base URL of the collector endpoint of the server under test.")
+
+	ctlCmd.AddCommand(loadtestCmd)
+}
+
+// loadtestStats aggregates the outcome of the requests across the simulated agents
+type loadtestStats struct {
+	mutex     sync.Mutex
+	requests  int
+	errors    int
+	latencies []time.Duration
+}
+
+func (stats *loadtestStats) record(latency time.Duration, err error) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	stats.requests++
+	if err != nil {
+		stats.errors++
+		log.Debugf("Request failed: %s", err)
+		return
+	}
+	stats.latencies = append(stats.latencies, latency)
+}
+
+func runLoadtest(ctx context.Context, collectorURL string, agents int, interval time.Duration, duration time.Duration) {
+	log.Infof("Simulating %d agents against %s every %s for %s.", agents, collectorURL, interval, duration)
+
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	stats := &loadtestStats{}
+
+	var wg sync.WaitGroup
+	for index := 0; index < agents; index++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			simulateAgent(ctx, httpClient, collectorURL, index, interval, stats)
+		}(index)
+	}
+	wg.Wait()
+
+	reportLoadtest(stats)
+}
+
+func simulateAgent(ctx context.Context, httpClient *http.Client, collectorURL string, index int, interval time.Duration, stats *loadtestStats) {
+	agentID := uuid.NewSHA1(internal.TrentoNamespace, []byte(fmt.Sprintf("loadtest-agent-%d", index))).String()
+
+	discoveredHost := mocks.NewDiscoveredHostMock()
+	discoveredHost.HostName = fmt.Sprintf("loadtest-%04d", index)
+
+	// spread the first publications over the interval so the simulated fleet
+	// does not hit the collector in lockstep
+	select {
+	case <-time.After(time.Duration(rand.Int63n(int64(interval)))):
+	case <-ctx.Done():
+		return
+	}
+
+	for {
+		measure(stats, func() error {
+			return publishDiscovery(ctx, httpClient, collectorURL, agentID, datapipeline.HostDiscovery, discoveredHost)
+		})
+		measure(stats, func() error {
+			return publishHeartbeat(ctx, httpClient, collectorURL, agentID)
+		})
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func measure(stats *loadtestStats, request func() error) {
+	start := time.Now()
+	err := request()
+	// requests cut short by the test window ending are not failures
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return
+	}
+	stats.record(time.Since(start), err)
+}
+
+func publishDiscovery(ctx context.Context, httpClient *http.Client, collectorURL string, agentID string, discoveryType string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"agent_id":       agentID,
+		"discovery_type": discoveryType,
+		"payload":        json.RawMessage(payloadJSON),
+		"event_id":       uuid.New().String(),
+		"checksum":       fmt.Sprintf("%x", sha256.Sum256(payloadJSON)),
+		"schema_version": contracts.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/api/collect", collectorURL)
+	return doLoadtestRequest(ctx, httpClient, url, requestBody)
+}
+
+func publishHeartbeat(ctx context.Context, httpClient *http.Client, collectorURL string, agentID string) error {
+	url := fmt.Sprintf("%s/api/hosts/%s/heartbeat", collectorURL, agentID)
+	return doLoadtestRequest(ctx, httpClient, url, nil)
+}
+
+func doLoadtestRequest(ctx context.Context, httpClient *http.Client, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("the collector replied with %s", resp.Status)
+	}
+
+	return nil
+}
+
+func reportLoadtest(stats *loadtestStats) {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+
+	log.Infof("Requests sent: %d, errors: %d", stats.requests, stats.errors)
+	if len(stats.latencies) == 0 {
+		return
+	}
+
+	sort.Slice(stats.latencies, func(i, j int) bool {
+		return stats.latencies[i] < stats.latencies[j]
+	})
+
+	var total time.Duration
+	for _, latency := range stats.latencies {
+		total += latency
+	}
+
+	log.Infof("Latency avg: %s, p50: %s, p95: %s, max: %s",
+		total/time.Duration(len(stats.latencies)),
+		percentileLatency(stats.latencies, 50),
+		percentileLatency(stats.latencies, 95),
+		stats.latencies[len(stats.latencies)-1],
+	)
+}
+
+// percentileLatency returns the given percentile of the sorted latencies
+func percentileLatency(latencies []time.Duration, percentile int) time.Duration {
+	index := len(latencies) * percentile / 100
+	if index >= len(latencies) {
+		index = len(latencies) - 1
+	}
+	return latencies[index]
+}
@@ -0,0 +1,32 @@
+package ctl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLoadtest(t *testing.T) {
+	var collected, heartbeats int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/collect":
+			atomic.AddInt64(&collected, 1)
+		default:
+			atomic.AddInt64(&heartbeats, 1)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	runLoadtest(context.Background(), server.URL, 3, 50*time.Millisecond, 200*time.Millisecond)
+
+	assert.GreaterOrEqual(t, atomic.LoadInt64(&collected), int64(3))
+	assert.GreaterOrEqual(t, atomic.LoadInt64(&heartbeats), int64(3))
+}
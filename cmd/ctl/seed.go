@@ -0,0 +1,115 @@
+package ctl
+
+import (
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/trento-project/trento/web"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// demoScenario is a bundled synthetic landscape (an Azure HANA scale-up
+// cluster plus a NetWeaver application host) in the dump-scenario format
+//
+//go:embed demo/*.json
+var demoScenario embed.FS
+
+type scenarioEvent struct {
+	AgentID       string          `json:"agent_id"`
+	DiscoveryType string          `json:"discovery_type"`
+	Payload       json.RawMessage `json:"payload"`
+}
+
+func addSeedCmd(ctlCmd *cobra.Command) {
+	var scenarioPath string
+
+	seedCmd := &cobra.Command{
+		Use:   "seed",
+		Short: "Load a synthetic demo landscape through the collector pipeline",
+		Long: `Stores the discovery events of a synthetic landscape and projects them like the
+collector would, so the console can be evaluated without real SAP systems.
+By default the bundled demo landscape is loaded; a scenario exported with
+dump-scenario can be loaded instead with --scenario-path.`,
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+
+			if err := web.MigrateDB(db); err != nil {
+				log.Fatal("Error while migrating the database: ", err)
+			}
+
+			var scenario fs.FS
+			if scenarioPath := viper.GetString("scenario-path"); scenarioPath != "" {
+				scenario = os.DirFS(scenarioPath)
+			} else {
+				scenario, _ = fs.Sub(demoScenario, "demo")
+			}
+
+			if err := seed(db, scenario); err != nil {
+				log.Fatal("Error while seeding the landscape: ", err)
+			}
+		},
+	}
+
+	seedCmd.Flags().StringVar(&scenarioPath, "scenario-path", "", "Load a scenario exported with dump-scenario instead of the bundled demo landscape.")
+
+	ctlCmd.AddCommand(seedCmd)
+}
+
+func seed(db *gorm.DB, scenario fs.FS) error {
+	fileNames, err := fs.Glob(scenario, "*.json")
+	if err != nil {
+		return err
+	}
+
+	agents := make(map[string]bool)
+	for _, fileName := range fileNames {
+		data, err := fs.ReadFile(scenario, fileName)
+		if err != nil {
+			return err
+		}
+
+		var event scenarioEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			return err
+		}
+
+		err = db.Create(&datapipeline.DataCollectedEvent{
+			AgentID:       event.AgentID,
+			DiscoveryType: event.DiscoveryType,
+			Payload:       datatypes.JSON(event.Payload),
+		}).Error
+		if err != nil {
+			return err
+		}
+		agents[event.AgentID] = true
+	}
+
+	// fresh heartbeats make the seeded hosts show up as running
+	for agentID := range agents {
+		err := db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entities.HostHeartbeat{
+			AgentID: agentID,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	projectorsRegistry := datapipeline.InitProjectorsRegistry(db)
+	replayed, err := datapipeline.ReplayEvents(db, projectorsRegistry)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Landscape seeded, %d discovery events projected.", replayed)
+	return nil
+}
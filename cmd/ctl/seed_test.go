@@ -0,0 +1,33 @@
+package ctl
+
+import (
+	"io/fs"
+
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+)
+
+func (suite *CtlTestSuite) TestSeed() {
+	suite.tx.AutoMigrate(&datapipeline.DataCollectedEvent{}, &datapipeline.Subscription{},
+		&entities.HostHeartbeat{}, &entities.ResourceEvent{}, &entities.Alert{})
+	suite.tx.AutoMigrate(datapipeline.ProjectedTables...)
+
+	demo, err := fs.Sub(demoScenario, "demo")
+	suite.NoError(err)
+
+	err = seed(suite.tx, demo)
+	suite.NoError(err)
+
+	var hosts []entities.Host
+	suite.NoError(suite.tx.Find(&hosts).Error)
+	suite.Len(hosts, 3)
+
+	var clusters []entities.Cluster
+	suite.NoError(suite.tx.Find(&clusters).Error)
+	suite.Len(clusters, 1)
+	suite.Equal("hana_cluster", clusters[0].Name)
+
+	var heartbeats []entities.HostHeartbeat
+	suite.NoError(suite.tx.Find(&heartbeats).Error)
+	suite.Len(heartbeats, 3)
+}
@@ -0,0 +1,73 @@
+package ctl
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+func addCreateAdminCmd(ctlCmd *cobra.Command) {
+	var username, password string
+
+	createAdminCmd := &cobra.Command{
+		Use:   "create-admin",
+		Short: "Bootstrap a local admin user account",
+		Run: func(*cobra.Command, []string) {
+			ctx := getContext()
+			db := initDB(ctx)
+			username := viper.GetString("username")
+			password := viper.GetString("password")
+
+			createAdmin(db, username, password)
+		},
+	}
+
+	createAdminCmd.Flags().StringVar(&username, "username", "admin", "The username of the admin account.")
+	createAdminCmd.Flags().StringVar(&password, "password", "", "The password of the admin account. If empty, a random one is generated and printed.")
+
+	ctlCmd.AddCommand(createAdminCmd)
+}
+
+func createAdmin(db *gorm.DB, username, password string) {
+	usersService := services.NewUsersService(db)
+
+	generated := false
+	if password == "" {
+		randomPassword := make([]byte, 16)
+		if _, err := rand.Read(randomPassword); err != nil {
+			log.Fatal("Error while generating a random password: ", err)
+		}
+		password = hex.EncodeToString(randomPassword)
+		generated = true
+	}
+
+	user, err := usersService.Create(username, password, true)
+	if errors.Is(err, services.ErrUsernameAlreadyTaken) {
+		log.Fatalf("The username %s is already taken.", username)
+	}
+	if err != nil {
+		log.Fatal("Error while creating the admin account: ", err)
+	}
+
+	if generated {
+		// a generated password is only meant to bootstrap the account,
+		// so its rotation is enforced on the first login
+		if err := usersService.RequirePasswordChange(user.Username); err != nil {
+			log.Fatal("Error while flagging the admin account for password rotation: ", err)
+		}
+
+		// printed directly instead of logged, so that the log redaction
+		// does not scrub it
+		fmt.Printf("Generated password: %s\n", password)
+	}
+
+	log.Infof("Admin account %s created.", user.Username)
+}
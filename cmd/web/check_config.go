@@ -0,0 +1,136 @@
+package web
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/trento-project/trento/internal/db"
+	"github.com/trento-project/trento/internal/grafana"
+	"github.com/trento-project/trento/web"
+)
+
+// configCheck is a single validation step of the check-config command
+type configCheck struct {
+	name string
+	run  func(config *web.Config) error
+}
+
+func addCheckConfigCmd(webCmd *cobra.Command) {
+	checkConfigCmd := &cobra.Command{
+		Use:   "check-config",
+		Short: "Validates the web server configuration without starting the daemon",
+		Long: `Loads the configuration like 'trento web serve' would and verifies that the database
+is reachable, the mTLS certificate files are usable, the Grafana API answers and
+the listening ports are available, printing an actionable error for every failed check.`,
+		Run: checkConfig,
+	}
+
+	// the serve flags are registered on the serve command only, so the
+	// settings being validated are redeclared here with the same defaults
+	checkConfigCmd.Flags().String("host", "0.0.0.0", "The host the HTTP service would bind to")
+	checkConfigCmd.Flags().IntP("port", "p", 8080, "The port the HTTP service would listen on")
+	checkConfigCmd.Flags().Int("collector-port", 8081, "The port the data collector service would listen on")
+	checkConfigCmd.Flags().Bool("enable-mtls", false, "Enable mTLS authentication between server and agents")
+	checkConfigCmd.Flags().String("cert", "", "mTLS server certificate")
+	checkConfigCmd.Flags().String("key", "", "mTLS server key")
+	checkConfigCmd.Flags().String("ca", "", "mTLS Certificate Authority")
+	checkConfigCmd.Flags().String("grafana-api-url", "http://localhost:3000", "Grafana API URL")
+	checkConfigCmd.Flags().String("grafana-user", "admin", "Grafana user")
+	checkConfigCmd.Flags().String("grafana-password", "", "Grafana password")
+	checkConfigCmd.Flags().String("metrics-provider", "grafana", "Provider of the embedded metrics panels. Options: grafana, prometheus, disabled")
+	checkConfigCmd.Flags().String("proxy-url", "", "Proxy all the outbound HTTP calls go through")
+	checkConfigCmd.Flags().String("grafana-proxy-url", "", "Proxy for the Grafana API calls, overriding proxy-url")
+
+	webCmd.AddCommand(checkConfigCmd)
+}
+
+func checkConfig(*cobra.Command, []string) {
+	config, err := LoadConfig()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	checks := []configCheck{
+		{"database connectivity", checkDatabase},
+		{"mTLS certificates", checkCertificates},
+		{"grafana reachability", checkGrafana},
+		{"port availability", checkPorts},
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if err := check.run(config); err != nil {
+			log.Errorf("FAIL %s: %s", check.name, err)
+			failed++
+			continue
+		}
+		log.Infof("OK   %s", check.name)
+	}
+
+	if failed > 0 {
+		log.Fatalf("%d configuration checks failed.", failed)
+	}
+	log.Info("All configuration checks passed.")
+}
+
+func checkDatabase(config *web.Config) error {
+	if err := db.Ping(config.DBConfig); err != nil {
+		return fmt.Errorf("could not connect to postgres://%s:%d/%s as user %s: %s; verify the db-* flags and that the database accepts connections",
+			config.DBConfig.Host, config.DBConfig.Port, config.DBConfig.DBName, config.DBConfig.User, err)
+	}
+
+	return nil
+}
+
+func checkCertificates(config *web.Config) error {
+	if !config.EnablemTLS {
+		return nil
+	}
+
+	if _, err := tls.LoadX509KeyPair(config.Cert, config.Key); err != nil {
+		return fmt.Errorf("could not load the certificate %s with the key %s: %s", config.Cert, config.Key, err)
+	}
+
+	caCert, err := ioutil.ReadFile(config.CA)
+	if err != nil {
+		return fmt.Errorf("could not read the CA certificate %s: %s", config.CA, err)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(caCert) {
+		return fmt.Errorf("the CA certificate %s does not contain any PEM encoded certificate", config.CA)
+	}
+
+	return nil
+}
+
+func checkGrafana(config *web.Config) error {
+	if config.MetricsProvider != "grafana" {
+		return nil
+	}
+	if config.AirGapped {
+		return nil
+	}
+
+	if err := grafana.CheckConnectivity(config.GrafanaConfig); err != nil {
+		return fmt.Errorf("%s; verify the grafana-api-url flag and that Grafana is running", err)
+	}
+
+	return nil
+}
+
+func checkPorts(config *web.Config) error {
+	for _, port := range []int{config.Port, config.CollectorPort} {
+		address := fmt.Sprintf("%s:%d", config.Host, port)
+		listener, err := net.Listen("tcp", address)
+		if err != nil {
+			return fmt.Errorf("could not listen on %s: %s; another process is probably bound to the port", address, err)
+		}
+		listener.Close()
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package web
 
 import (
 	"fmt"
+	"io/ioutil"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
@@ -16,6 +18,24 @@ func LoadConfig() (*web.Config, error) {
 	key := viper.GetString("key")
 	ca := viper.GetString("ca")
 
+	grafanaProxyURL := viper.GetString("grafana-proxy-url")
+	if grafanaProxyURL == "" {
+		grafanaProxyURL = viper.GetString("proxy-url")
+	}
+
+	encryptionKey := viper.GetString("encryption-key")
+	if keyFile := viper.GetString("encryption-key-file"); keyFile != "" {
+		content, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read the encryption key file")
+		}
+		encryptionKey = strings.TrimSpace(string(content))
+	}
+
+	if viper.GetBool("telemetry-local-only") && viper.GetString("telemetry-export-path") == "" {
+		return nil, fmt.Errorf("you must provide a telemetry export path to only export telemetry locally")
+	}
+
 	if enablemTLS {
 		var err error
 
@@ -47,7 +67,43 @@ func LoadConfig() (*web.Config, error) {
 			ApiURL:    viper.GetString("grafana-api-url"),
 			User:      viper.GetString("grafana-user"),
 			Password:  viper.GetString("grafana-password"),
+			ProxyURL:  grafanaProxyURL,
 		},
-		PrometheusURL: viper.GetString("prometheus-url"),
+		PrometheusURL:       viper.GetString("prometheus-url"),
+		MetricsProvider:     viper.GetString("metrics-provider"),
+		ProjectorWorkers:    viper.GetInt("projector-workers"),
+		RunnerWorkers:       viper.GetInt("runner-workers"),
+		ChecksExecutionMode: viper.GetString("checks-execution-mode"),
+		TelemetryExportPath: viper.GetString("telemetry-export-path"),
+		TelemetryLocalOnly:  viper.GetBool("telemetry-local-only"),
+		AirGapped:           viper.GetBool("air-gapped"),
+
+		SubscriptionValidationURL: viper.GetString("subscription-validation-url"),
+
+		MessageQueueURL:     viper.GetString("mq-url"),
+		MessageQueueSubject: viper.GetString("mq-subject"),
+		EventBusURL:         viper.GetString("eventbus-url"),
+		EventBusSubject:     viper.GetString("eventbus-subject"),
+
+		ProxyURL:          viper.GetString("proxy-url"),
+		TelemetryProxyURL: viper.GetString("telemetry-proxy-url"),
+		SCCProxyURL:       viper.GetString("scc-proxy-url"),
+
+		EncryptionKey: encryptionKey,
+
+		VaultAddress:  viper.GetString("vault-address"),
+		VaultToken:    viper.GetString("vault-token"),
+		VaultRoleID:   viper.GetString("vault-role-id"),
+		VaultSecretID: viper.GetString("vault-secret-id"),
+
+		AlertingPagerDutyKey:        viper.GetString("alerting-pagerduty-key"),
+		AlertingPagerDutySeverities: viper.GetStringSlice("alerting-pagerduty-severities"),
+		AlertingOpsgenieAPIKey:      viper.GetString("alerting-opsgenie-api-key"),
+		AlertingOpsgenieURL:         viper.GetString("alerting-opsgenie-url"),
+		AlertingOpsgenieSeverities:  viper.GetStringSlice("alerting-opsgenie-severities"),
+
+		AlertingGroupWindow:            viper.GetDuration("alerting-group-window"),
+		AlertingPagerDutyEscalateAfter: viper.GetDuration("alerting-pagerduty-escalate-after"),
+		AlertingOpsgenieEscalateAfter:  viper.GetDuration("alerting-opsgenie-escalate-after"),
 	}, nil
 }
@@ -26,8 +26,12 @@ func (suite *WebCmdTestSuite) SetupTest() {
 
 	cmd := NewWebCmd()
 
-	cmd.Commands()[0].Run = func(cmd *cobra.Command, args []string) {
-		// do nothing
+	for _, command := range cmd.Commands() {
+		if command.Name() == "serve" {
+			command.Run = func(cmd *cobra.Command, args []string) {
+				// do nothing
+			}
+		}
 	}
 
 	cmd.SetArgs([]string{
@@ -64,7 +68,22 @@ func (suite *WebCmdTestSuite) TearDownTest() {
 			User:      "adminuser",
 			Password:  "password",
 		},
-		PrometheusURL: "http://prometheus-host:9090",
+		PrometheusURL:       "http://prometheus-host:9090",
+		MetricsProvider:     "prometheus",
+		ProjectorWorkers:    50,
+		RunnerWorkers:       10,
+		ChecksExecutionMode: "agent",
+		TelemetryExportPath: "/tmp/telemetry",
+		TelemetryLocalOnly:  true,
+		AirGapped:           true,
+
+		SubscriptionValidationURL: "https://scc.suse.com",
+
+		MessageQueueSubject: "trento.discoveries",
+		EventBusSubject:     "trento.events",
+
+		AlertingPagerDutySeverities: []string{},
+		AlertingOpsgenieSeverities:  []string{},
 	}
 	config, err := LoadConfig()
 	suite.NoError(err)
@@ -92,6 +111,14 @@ func (suite *WebCmdTestSuite) TestConfigFromFlags() {
 		"--grafana-user=adminuser",
 		"--grafana-password=password",
 		"--prometheus-url=http://prometheus-host:9090",
+		"--metrics-provider=prometheus",
+		"--projector-workers=50",
+		"--runner-workers=10",
+		"--checks-execution-mode=agent",
+		"--telemetry-export-path=/tmp/telemetry",
+		"--telemetry-local-only",
+		"--air-gapped",
+		"--subscription-validation-url=https://scc.suse.com",
 	})
 }
 
@@ -113,6 +140,14 @@ func (suite *WebCmdTestSuite) TestConfigFromEnv() {
 	os.Setenv("TRENTO_GRAFANA_USER", "adminuser")
 	os.Setenv("TRENTO_GRAFANA_PASSWORD", "password")
 	os.Setenv("TRENTO_PROMETHEUS_URL", "http://prometheus-host:9090")
+	os.Setenv("TRENTO_METRICS_PROVIDER", "prometheus")
+	os.Setenv("TRENTO_PROJECTOR_WORKERS", "50")
+	os.Setenv("TRENTO_RUNNER_WORKERS", "10")
+	os.Setenv("TRENTO_CHECKS_EXECUTION_MODE", "agent")
+	os.Setenv("TRENTO_TELEMETRY_EXPORT_PATH", "/tmp/telemetry")
+	os.Setenv("TRENTO_TELEMETRY_LOCAL_ONLY", "true")
+	os.Setenv("TRENTO_AIR_GAPPED", "true")
+	os.Setenv("TRENTO_SUBSCRIPTION_VALIDATION_URL", "https://scc.suse.com")
 }
 
 func (suite *WebCmdTestSuite) TestConfigFromFile() {
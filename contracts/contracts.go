@@ -0,0 +1,52 @@
+// Package contracts pins the payloads exchanged between the agents and the
+// server collector endpoint. The payload types are aliases of the discovery
+// structures the agents publish, so a breaking change on either side fails to
+// compile or breaks the golden fixtures shipped with this package instead of
+// surfacing as a runtime projection error.
+package contracts
+
+import (
+	"encoding/json"
+
+	"github.com/trento-project/trento/internal/cloud"
+	"github.com/trento-project/trento/internal/cluster"
+	"github.com/trento-project/trento/internal/hosts"
+	"github.com/trento-project/trento/internal/sapsystem"
+	"github.com/trento-project/trento/internal/subscription"
+)
+
+// Version identifies the revision of the payload contracts. It is bumped on
+// every breaking change of a payload shape.
+const Version = 1
+
+const (
+	ClusterDiscovery      = "ha_cluster_discovery"
+	SAPsystemDiscovery    = "sap_system_discovery"
+	HostDiscovery         = "host_discovery"
+	SubscriptionDiscovery = "subscription_discovery"
+	CloudDiscovery        = "cloud_discovery"
+)
+
+// DiscoveryEvent is the envelope every discovery publication is wrapped in
+type DiscoveryEvent struct {
+	AgentID       string          `json:"agent_id"`
+	DiscoveryType string          `json:"discovery_type"`
+	Payload       json.RawMessage `json:"payload"`
+	// EventID is a client-generated unique identifier, used by the server to
+	// drop duplicate submissions caused by agent retries
+	EventID string `json:"event_id,omitempty"`
+	// Checksum is the sha256 of the payload
+	Checksum string `json:"checksum,omitempty"`
+	// SchemaVersion is the contracts revision the payload complies with.
+	// Older agents do not send it, which is equivalent to version 1.
+	SchemaVersion int `json:"schema_version,omitempty"`
+}
+
+// Payload types, one per discovery type
+type (
+	DiscoveredHost          = hosts.DiscoveredHost
+	DiscoveredCluster       = cluster.Cluster
+	DiscoveredCloudInstance = cloud.CloudInstance
+	DiscoveredSAPSystems    = sapsystem.SAPSystemsList
+	DiscoveredSubscriptions = subscription.Subscriptions
+)
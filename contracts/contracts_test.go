@@ -0,0 +1,51 @@
+package contracts_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/trento-project/trento/contracts"
+	"github.com/trento-project/trento/web/datapipeline"
+)
+
+// TestPayloadContracts round-trips every golden fixture through its Go type
+// and validates it against the server-side JSON schema: a field added, renamed
+// or dropped on either side breaks here instead of at projection time
+func TestPayloadContracts(t *testing.T) {
+	cases := []struct {
+		fixture       string
+		discoveryType string
+		payload       interface{}
+	}{
+		{"host_discovery", contracts.HostDiscovery, &contracts.DiscoveredHost{}},
+		{"ha_cluster_discovery", contracts.ClusterDiscovery, &contracts.DiscoveredCluster{}},
+		{"cloud_discovery", contracts.CloudDiscovery, &contracts.DiscoveredCloudInstance{}},
+		{"subscription_discovery", contracts.SubscriptionDiscovery, &contracts.DiscoveredSubscriptions{}},
+		{"sap_system_discovery_database", contracts.SAPsystemDiscovery, &contracts.DiscoveredSAPSystems{}},
+		{"sap_system_discovery_application", contracts.SAPsystemDiscovery, &contracts.DiscoveredSAPSystems{}},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.fixture, func(t *testing.T) {
+			data, err := contracts.Fixture(testCase.fixture)
+			require.NoError(t, err)
+
+			var event contracts.DiscoveryEvent
+			require.NoError(t, json.Unmarshal(data, &event))
+			assert.Equal(t, testCase.discoveryType, event.DiscoveryType)
+
+			decoder := json.NewDecoder(bytes.NewReader(event.Payload))
+			decoder.DisallowUnknownFields()
+			require.NoError(t, decoder.Decode(testCase.payload), "the payload carries fields the Go type does not know")
+
+			remarshalled, err := json.Marshal(testCase.payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(event.Payload), string(remarshalled), "the payload does not round-trip through the Go type")
+
+			assert.Empty(t, datapipeline.ValidatePayload(testCase.discoveryType, event.Payload))
+		})
+	}
+}
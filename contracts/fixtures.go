@@ -0,0 +1,17 @@
+package contracts
+
+import (
+	"embed"
+	"fmt"
+)
+
+//go:embed fixtures
+var fixturesFS embed.FS
+
+// Fixture returns a golden publication by name (a discovery type, with a
+// _database/_application suffix for the two SAP system variants), as a full
+// DiscoveryEvent envelope. Both the agent and the server tests assert against
+// these fixtures, so a payload change breaks loudly on either side.
+func Fixture(name string) ([]byte, error) {
+	return fixturesFS.ReadFile(fmt.Sprintf("fixtures/%s.json", name))
+}
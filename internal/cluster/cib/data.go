@@ -9,6 +9,9 @@ https://clusterlabs.org/pacemaker/doc/en-US/Pacemaker/2.0/html-single/Pacemaker_
 */
 
 type Root struct {
+	// Raw holds the unparsed cibadmin XML output, so the server can keep a
+	// snapshot of the exact Pacemaker configuration
+	Raw           []byte `xml:"-"`
 	Configuration struct {
 		CrmConfig struct {
 			ClusterProperties []Attribute `xml:"cluster_property_set>nvpair"`
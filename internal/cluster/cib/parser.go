@@ -27,6 +27,8 @@ func (p *cibAdminParser) Parse() (Root, error) {
 		return CIB, errors.Wrap(err, "could not parse cibadmin status from XML")
 	}
 
+	CIB.Raw = cibXML
+
 	return CIB, nil
 }
 
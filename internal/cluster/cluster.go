@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
@@ -17,6 +18,7 @@ const (
 	cibAdmPath             string = "/usr/sbin/cibadmin"
 	crmmonAdmPath          string = "/usr/sbin/crm_mon"
 	corosyncKeyPath        string = "/etc/corosync/authkey"
+	corosyncConfPath       string = "/etc/corosync/corosync.conf"
 	clusterNameProperty    string = "cib-bootstrap-options-cluster-name"
 	stonithEnabled         string = "cib-bootstrap-options-stonith-enabled"
 	stonithResourceMissing string = "notconfigured"
@@ -26,29 +28,32 @@ const (
 )
 
 type DiscoveryTools struct {
-	CibAdmPath      string
-	CrmmonAdmPath   string
-	CorosyncKeyPath string
-	SBDPath         string
-	SBDConfigPath   string
+	CibAdmPath       string
+	CrmmonAdmPath    string
+	CorosyncKeyPath  string
+	CorosyncConfPath string
+	SBDPath          string
+	SBDConfigPath    string
 }
 
 type Cluster struct {
-	Cib    cib.Root    `mapstructure:"cib,omitempty"`
-	Crmmon crmmon.Root `mapstructure:"crmmon,omitempty"`
-	SBD    SBD         `mapstructure:"sbd,omitempty"`
-	Id     string      `mapstructure:"id"`
-	Name   string      `mapstructure:"name"`
-	DC     bool        `mapstructure:"dc"`
+	Cib          cib.Root    `mapstructure:"cib,omitempty"`
+	Crmmon       crmmon.Root `mapstructure:"crmmon,omitempty"`
+	CorosyncConf string      `mapstructure:"corosync_conf,omitempty"`
+	SBD          SBD         `mapstructure:"sbd,omitempty"`
+	Id           string      `mapstructure:"id"`
+	Name         string      `mapstructure:"name"`
+	DC           bool        `mapstructure:"dc"`
 }
 
 func NewCluster() (Cluster, error) {
 	return NewClusterWithDiscoveryTools(&DiscoveryTools{
-		CibAdmPath:      cibAdmPath,
-		CrmmonAdmPath:   crmmonAdmPath,
-		CorosyncKeyPath: corosyncKeyPath,
-		SBDPath:         SBDPath,
-		SBDConfigPath:   SBDConfigPath,
+		CibAdmPath:       cibAdmPath,
+		CrmmonAdmPath:    crmmonAdmPath,
+		CorosyncKeyPath:  corosyncKeyPath,
+		CorosyncConfPath: corosyncConfPath,
+		SBDPath:          SBDPath,
+		SBDConfigPath:    SBDConfigPath,
 	})
 }
 
@@ -73,6 +78,12 @@ func NewClusterWithDiscoveryTools(discoveryTools *DiscoveryTools) (Cluster, erro
 
 	cluster.Crmmon = crmmonConfig
 
+	// The corosync.conf snapshot is a best effort: the discovery still works
+	// if the file cannot be read
+	if corosyncConf, err := ioutil.ReadFile(discoveryTools.CorosyncConfPath); err == nil {
+		cluster.CorosyncConf = string(corosyncConf)
+	}
+
 	// Set MD5-hashed key based on the corosync auth key
 	cluster.Id, err = getCorosyncAuthkeyMd5(discoveryTools.CorosyncKeyPath)
 	if err != nil {
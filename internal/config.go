@@ -8,6 +8,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/mitchellh/go-homedir"
 	"github.com/spf13/viper"
 )
@@ -67,11 +68,38 @@ func InitConfig(configName string) error {
 
 	if configFile := viper.ConfigFileUsed(); configFile != "" {
 		log.Infof("Using config file: %s", configFile)
+		watchConfig()
 	}
 
 	return nil
 }
 
+// reloadHooks are invoked whenever the watched configuration file changes,
+// so that components can re-apply the settings that are safe to change on a
+// live process
+var reloadHooks []func()
+
+// OnConfigReload registers a hook to be invoked when the configuration file
+// is reloaded. Hooks must re-read the settings they care about from viper.
+func OnConfigReload(hook func()) {
+	reloadHooks = append(reloadHooks, hook)
+}
+
+// watchConfig reloads the configuration file on change.
+// Only a subset of the settings can be applied without a restart: the log
+// level is always re-applied, everything else is up to the registered hooks.
+func watchConfig() {
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		log.Infof("Config file %s changed, reloading settings", e.Name)
+		SetLogLevel(viper.GetString("log-level"))
+
+		for _, hook := range reloadHooks {
+			hook()
+		}
+	})
+	viper.WatchConfig()
+}
+
 func BindEnv() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
 	viper.SetEnvPrefix("TRENTO")
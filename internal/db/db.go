@@ -19,13 +19,21 @@ type Config struct {
 	DBName   string
 }
 
-func InitDB(ctx context.Context, config *Config) (*gorm.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+// dsn builds the postgres connection string.
+// TimeZone=utc makes every session read and write timestamps as UTC,
+// regardless of the server timezone, so all the projected timestamps are
+// stored consistently and the display conversion is left to the consumers
+func (config *Config) dsn() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable TimeZone=utc",
 		config.Host,
 		config.Port,
 		config.User,
 		config.Password,
 		config.DBName)
+}
+
+func InitDB(ctx context.Context, config *Config) (*gorm.DB, error) {
+	dsn := config.dsn()
 
 	var db *gorm.DB
 	var err error
@@ -57,3 +65,19 @@ func InitDB(ctx context.Context, config *Config) (*gorm.DB, error) {
 
 	return db, err
 }
+
+// Ping attempts a single connection to the database, without the retry loop
+// InitDB wraps the startup with. It is meant for quick connectivity checks.
+func Ping(config *Config) error {
+	db, err := gorm.Open(postgres.Open(config.dsn()), &gorm.Config{})
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+
+	return sqlDB.Close()
+}
@@ -0,0 +1,55 @@
+package exporters
+
+import (
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate mockery --all
+
+const (
+	NodeExporterUnit   = "prometheus-node_exporter.service"
+	HANADBExporterUnit = "prometheus-hanadb_exporter.service"
+
+	StatusActive  = "active"
+	StatusUnknown = "unknown"
+)
+
+// ManagedUnits is the list of exporter systemd units the agent knows how to manage
+var ManagedUnits = []string{NodeExporterUnit, HANADBExporterUnit}
+
+type CustomCommand func(name string, arg ...string) *exec.Cmd
+
+var customExecCommand CustomCommand = exec.Command
+
+// Status returns the systemd active state of a unit (active, inactive, failed...)
+func Status(unit string) string {
+	// systemctl is-active exits with a non-zero code when the unit is not
+	// active, but the state is still printed on stdout
+	output, _ := customExecCommand("systemctl", "is-active", unit).Output()
+
+	status := strings.TrimSpace(string(output))
+	if status == "" {
+		return StatusUnknown
+	}
+
+	return status
+}
+
+// Enable enables and starts an exporter unit
+func Enable(unit string) error {
+	log.Infof("Enabling and starting the %s unit...", unit)
+	return customExecCommand("systemctl", "enable", "--now", unit).Run()
+}
+
+// Statuses returns the systemd state of all the managed exporter units
+func Statuses() map[string]string {
+	statuses := make(map[string]string)
+	for _, unit := range ManagedUnits {
+		statuses[unit] = Status(unit)
+	}
+
+	return statuses
+}
@@ -0,0 +1,72 @@
+package exporters
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/internal/exporters/mocks"
+)
+
+func TestStatus(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "systemctl", "is-active", NodeExporterUnit).Return(
+		exec.Command("echo", "active"),
+	)
+
+	status := Status(NodeExporterUnit)
+
+	assert.Equal(t, "active", status)
+}
+
+func TestStatusUnknown(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "systemctl", "is-active", NodeExporterUnit).Return(
+		exec.Command("error"),
+	)
+
+	status := Status(NodeExporterUnit)
+
+	assert.Equal(t, StatusUnknown, status)
+}
+
+func TestStatuses(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "systemctl", "is-active", NodeExporterUnit).Return(
+		exec.Command("echo", "active"),
+	)
+	mockCommand.On("Execute", "systemctl", "is-active", HANADBExporterUnit).Return(
+		exec.Command("echo", "inactive"),
+	)
+
+	statuses := Statuses()
+
+	assert.Equal(t, map[string]string{
+		NodeExporterUnit:   "active",
+		HANADBExporterUnit: "inactive",
+	}, statuses)
+}
+
+func TestEnable(t *testing.T) {
+	mockCommand := new(mocks.CustomCommand)
+
+	customExecCommand = mockCommand.Execute
+
+	mockCommand.On("Execute", "systemctl", "enable", "--now", HANADBExporterUnit).Return(
+		exec.Command("true"),
+	)
+
+	err := Enable(HANADBExporterUnit)
+
+	assert.NoError(t, err)
+	mockCommand.AssertExpectations(t)
+}
@@ -5,26 +5,44 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"sync"
 
 	_ "embed"
 	"net/http"
-	"net/url"
 	"time"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/internal/httpproxy"
+	"github.com/trento-project/trento/internal/resilience"
 )
 
 //go:embed node_exporter.json
 var nodeDashboard []byte
 
+//go:embed hana.json
+var hanaDashboard []byte
+
+const serviceAccountName = "trento-provisioner"
+const folderTitle = "Trento"
+const datasourceName = "Prometheus"
+
+// apiPolicy shields the provisioning flow from a slow or flapping Grafana API
+var apiPolicy = resilience.NewPolicy("grafana", resilience.DefaultConfig())
+
 type Config struct {
-	PublicURL string
-	ApiURL    string
-	User      string
-	Password  string
+	PublicURL     string
+	ApiURL        string
+	User          string
+	Password      string
+	PrometheusURL string
+	// ProxyURL routes the Grafana API calls through a proxy. When empty, the
+	// proxy environment applies
+	ProxyURL string
 }
 
 func (config Config) BaseUrl() string {
@@ -34,36 +52,41 @@ func (config Config) BaseUrl() string {
 	return config.ApiURL
 }
 
-func InitGrafana(ctx context.Context, config *Config) error {
-	return retry.Do(
-		func() error {
-			log.Info("Initializing Grafana Dashboards")
-			token, err := createToken(config)
-			if err != nil {
-				log.Error("Failed to create Grafana token")
-				return err
-			}
-
-			dashboardsURL := fmt.Sprintf("%s/%s", config.ApiURL, "api/dashboards/db")
-			req, err := http.NewRequest("POST", dashboardsURL, bytes.NewBuffer(nodeDashboard))
-			if err != nil {
-				return err
-			}
+// ProvisioningStatus reports the outcome of the last Grafana provisioning attempt
+type ProvisioningStatus struct {
+	Provisioned bool      `json:"provisioned"`
+	Folder      string    `json:"folder"`
+	Datasource  string    `json:"datasource"`
+	Dashboards  []string  `json:"dashboards"`
+	LastAttempt time.Time `json:"last_attempt"`
+	Error       string    `json:"error,omitempty"`
+}
 
-			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
-			req.Header.Add("Content-Type", "application/json")
+var statusMutex sync.RWMutex
+var provisioningStatus = ProvisioningStatus{}
 
-			client := &http.Client{}
-			resp, err := client.Do(req)
+func GetProvisioningStatus() ProvisioningStatus {
+	statusMutex.RLock()
+	defer statusMutex.RUnlock()
 
-			if resp.StatusCode != http.StatusOK {
-				return fmt.Errorf("failed to create Grafana Dashboard: %s", resp.Status)
-			}
+	return provisioningStatus
+}
 
-			return err
+func setProvisioningStatus(status ProvisioningStatus) {
+	statusMutex.Lock()
+	defer statusMutex.Unlock()
+
+	provisioningStatus = status
+}
+
+func InitGrafana(ctx context.Context, config *Config) error {
+	err := retry.Do(
+		func() error {
+			log.Info("Provisioning Grafana folder, datasource and dashboards")
+			return provision(config)
 		},
 		retry.OnRetry(func(_ uint, err error) {
-			log.Info("Grafana Dashboards initialization failed")
+			log.Info("Grafana provisioning failed")
 			log.Error(err)
 		}),
 		retry.Delay(2*time.Second),
@@ -72,57 +95,275 @@ func InitGrafana(ctx context.Context, config *Config) error {
 		retry.LastErrorOnly(true),
 		retry.Context(ctx),
 	)
+
+	if err != nil {
+		setProvisioningStatus(ProvisioningStatus{
+			LastAttempt: time.Now(),
+			Error:       err.Error(),
+		})
+	}
+
+	return err
 }
 
-func createToken(config *Config) (string, error) {
+func provision(config *Config) error {
+	token, err := createServiceAccountToken(config)
+	if err != nil {
+		log.Error("Failed to create Grafana service account token")
+		return err
+	}
+
+	folderUID, err := provisionFolder(config, token)
+	if err != nil {
+		return err
+	}
+
+	if err := provisionDatasource(config, token); err != nil {
+		return err
+	}
+
+	var dashboardTitles []string
+	for _, dashboard := range [][]byte{nodeDashboard, hanaDashboard} {
+		title, err := provisionDashboard(config, token, folderUID, dashboard)
+		if err != nil {
+			return err
+		}
+		dashboardTitles = append(dashboardTitles, title)
+	}
+
+	setProvisioningStatus(ProvisioningStatus{
+		Provisioned: true,
+		Folder:      folderTitle,
+		Datasource:  datasourceName,
+		Dashboards:  dashboardTitles,
+		LastAttempt: time.Now(),
+	})
+
+	return nil
+}
+
+// createServiceAccountToken creates a short-lived token bound to the Trento
+// service account, creating the service account itself on the first run
+func createServiceAccountToken(config *Config) (string, error) {
+	accountRequest, _ := json.Marshal(map[string]interface{}{
+		"name": serviceAccountName,
+		"role": "Admin",
+	})
+
+	body, statusCode, err := doAuthenticatedRequest(config, "", "POST", "api/serviceaccounts", accountRequest)
+	if err != nil {
+		return "", err
+	}
+
+	var accountID int64
+	switch statusCode {
+	case http.StatusCreated:
+		parsedAccount := struct {
+			ID int64 `json:"id"`
+		}{}
+		if err := json.Unmarshal(body, &parsedAccount); err != nil {
+			return "", err
+		}
+		accountID = parsedAccount.ID
+	case http.StatusBadRequest, http.StatusConflict:
+		// the service account already exists, look it up
+		body, statusCode, err := doAuthenticatedRequest(
+			config, "", "GET", fmt.Sprintf("api/serviceaccounts/search?query=%s", serviceAccountName), nil)
+		if err != nil {
+			return "", err
+		}
+		if statusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to look up the Grafana service account: %d", statusCode)
+		}
+		parsedSearch := struct {
+			ServiceAccounts []struct {
+				ID int64 `json:"id"`
+			} `json:"serviceAccounts"`
+		}{}
+		if err := json.Unmarshal(body, &parsedSearch); err != nil {
+			return "", err
+		}
+		if len(parsedSearch.ServiceAccounts) == 0 {
+			return "", fmt.Errorf("could not find the %s Grafana service account", serviceAccountName)
+		}
+		accountID = parsedSearch.ServiceAccounts[0].ID
+	default:
+		return "", fmt.Errorf("failed to create the Grafana service account: %d", statusCode)
+	}
+
 	tokenName, err := uuid.NewUUID()
 	if err != nil {
 		return "", err
 	}
 
 	tokenRequest, _ := json.Marshal(map[string]interface{}{
-		"role":          "Admin",
 		"name":          tokenName.String(),
 		"secondsToLive": 60,
 	})
 
-	u, err := url.Parse(config.ApiURL)
+	body, statusCode, err = doAuthenticatedRequest(
+		config, "", "POST", fmt.Sprintf("api/serviceaccounts/%d/tokens", accountID), tokenRequest)
 	if err != nil {
-		log.Fatal("Invalid Grafana URL provided")
+		return "", err
 	}
-	authenticatedURL := fmt.Sprintf("%s://%s:%s@%s%s/api/auth/keys", u.Scheme, config.User, config.Password, u.Host, u.Path)
-	req, err := http.NewRequest("POST", authenticatedURL, bytes.NewBuffer(tokenRequest))
-	if err != nil {
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to create the Grafana service account token: %d", statusCode)
+	}
+
+	parsedToken := struct {
+		Key string `json:"key"`
+	}{}
+	if err := json.Unmarshal(body, &parsedToken); err != nil {
 		return "", err
 	}
 
-	req.Header.Add("Content-Type", "application/json")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	return parsedToken.Key, nil
+}
+
+func provisionFolder(config *Config, token string) (string, error) {
+	folderRequest, _ := json.Marshal(map[string]interface{}{
+		"uid":   "trento",
+		"title": folderTitle,
+	})
+
+	body, statusCode, err := doAuthenticatedRequest(config, token, "POST", "api/folders", folderRequest)
 	if err != nil {
 		return "", err
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to create Grafana token: %s", resp.Status)
+	switch statusCode {
+	case http.StatusOK:
+		parsedFolder := struct {
+			UID string `json:"uid"`
+		}{}
+		if err := json.Unmarshal(body, &parsedFolder); err != nil {
+			return "", err
+		}
+		return parsedFolder.UID, nil
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		// the folder is already there
+		return "trento", nil
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	return "", fmt.Errorf("failed to create the Grafana folder: %d", statusCode)
+}
+
+func provisionDatasource(config *Config, token string) error {
+	datasourceRequest, _ := json.Marshal(map[string]interface{}{
+		"name":      datasourceName,
+		"type":      "prometheus",
+		"url":       config.PrometheusURL,
+		"access":    "proxy",
+		"isDefault": true,
+	})
+
+	_, statusCode, err := doAuthenticatedRequest(config, token, "POST", "api/datasources", datasourceRequest)
 	if err != nil {
+		return err
+	}
+
+	// 409 means the datasource exists already
+	if statusCode != http.StatusOK && statusCode != http.StatusConflict {
+		return fmt.Errorf("failed to create the Grafana datasource: %d", statusCode)
+	}
+
+	return nil
+}
+
+func provisionDashboard(config *Config, token string, folderUID string, dashboard []byte) (string, error) {
+	var dashboardRequest map[string]interface{}
+	if err := json.Unmarshal(dashboard, &dashboardRequest); err != nil {
 		return "", err
 	}
+	dashboardRequest["folderUid"] = folderUID
 
-	fmt.Println(string(body))
+	payload, err := json.Marshal(dashboardRequest)
+	if err != nil {
+		return "", err
+	}
 
-	parsedBody := struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-		Key  string `json:"key"`
-	}{}
-	err = json.Unmarshal(body, &parsedBody)
+	_, statusCode, err := doAuthenticatedRequest(config, token, "POST", "api/dashboards/db", payload)
 	if err != nil {
 		return "", err
 	}
+	if statusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to create the Grafana dashboard: %d", statusCode)
+	}
+
+	title := ""
+	if parsedDashboard, ok := dashboardRequest["Dashboard"].(map[string]interface{}); ok {
+		title, _ = parsedDashboard["title"].(string)
+	}
+
+	return title, nil
+}
+
+// CheckConnectivity performs a single request against the Grafana health
+// endpoint, without retries, to verify the API is reachable with the
+// configured URL and proxy
+func CheckConnectivity(config *Config) error {
+	requestURL := fmt.Sprintf("%s/api/health", config.ApiURL)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+
+	client := httpproxy.NewClient(config.ProxyURL)
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("the Grafana health endpoint %s replied with %s", requestURL, resp.Status)
+	}
+
+	return nil
+}
+
+// doAuthenticatedRequest performs a request against the Grafana HTTP API.
+// It authenticates with the given token or, when the token is empty, with the
+// basic auth admin credentials
+func doAuthenticatedRequest(config *Config, token string, method string, path string, payload []byte) ([]byte, int, error) {
+	requestURL := fmt.Sprintf("%s/%s", config.ApiURL, path)
+
+	var body []byte
+	var statusCode int
+
+	err := apiPolicy.Execute(context.Background(), func(ctx context.Context) error {
+		var reader io.Reader
+		if payload != nil {
+			reader = bytes.NewReader(payload)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, reader)
+		if err != nil {
+			return err
+		}
+
+		if token != "" {
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+		} else {
+			req.SetBasicAuth(config.User, config.Password)
+		}
+		req.Header.Add("Content-Type", "application/json")
+
+		client := httpproxy.NewClient(config.ProxyURL)
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		statusCode = resp.StatusCode
+		body, err = ioutil.ReadAll(resp.Body)
+		return err
+	})
 
-	return parsedBody.Key, nil
+	return body, statusCode, err
 }
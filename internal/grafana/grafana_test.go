@@ -0,0 +1,84 @@
+package grafana
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvision(t *testing.T) {
+	var provisionedDashboards []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/serviceaccounts":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": 1, "name": "trento-provisioner"}`))
+		case "/api/serviceaccounts/1/tokens":
+			w.Write([]byte(`{"id": 1, "key": "some-token"}`))
+		case "/api/folders":
+			assert.Equal(t, "Bearer some-token", r.Header.Get("Authorization"))
+			w.Write([]byte(`{"uid": "trento", "title": "Trento"}`))
+		case "/api/datasources":
+			w.Write([]byte(`{"id": 1}`))
+		case "/api/dashboards/db":
+			var request map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&request)
+			assert.Equal(t, "trento", request["folderUid"])
+
+			dashboard := request["Dashboard"].(map[string]interface{})
+			provisionedDashboards = append(provisionedDashboards, dashboard["title"].(string))
+			w.Write([]byte(`{"status": "success"}`))
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		ApiURL:        server.URL,
+		User:          "admin",
+		Password:      "admin",
+		PrometheusURL: "http://localhost:9090",
+	}
+
+	err := provision(config)
+	assert.NoError(t, err)
+
+	assert.Contains(t, provisionedDashboards, "HANA Database")
+
+	status := GetProvisioningStatus()
+	assert.True(t, status.Provisioned)
+	assert.Equal(t, "Trento", status.Folder)
+	assert.Equal(t, "Prometheus", status.Datasource)
+	assert.Empty(t, status.Error)
+}
+
+func TestProvisionExistingServiceAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/serviceaccounts" && r.Method == "POST":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message": "service account already exists"}`))
+		case r.URL.Path == "/api/serviceaccounts/search":
+			w.Write([]byte(`{"serviceAccounts": [{"id": 5}]}`))
+		case r.URL.Path == "/api/serviceaccounts/5/tokens":
+			w.Write([]byte(`{"id": 2, "key": "other-token"}`))
+		default:
+			w.Write([]byte(`{"uid": "trento"}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &Config{
+		ApiURL:   server.URL,
+		User:     "admin",
+		Password: "admin",
+	}
+
+	err := provision(config)
+	assert.NoError(t, err)
+}
@@ -1,12 +1,38 @@
 package hosts
 
 type DiscoveredHost struct {
-	SSHAddress      string   `json:"ssh_address"`
-	OSVersion       string   `json:"os_version"`
-	HostIpAddresses []string `json:"ip_addresses"`
-	HostName        string   `json:"hostname"`
-	CPUCount        int      `json:"cpu_count"`
-	SocketCount     int      `json:"socket_count"`
-	TotalMemoryMB   int      `json:"total_memory_mb"`
-	AgentVersion    string   `json:"agent_version"`
+	SSHAddress        string             `json:"ssh_address"`
+	OSVersion         string             `json:"os_version"`
+	KernelVersion     string             `json:"kernel_version"`
+	HostIpAddresses   []string           `json:"ip_addresses"`
+	NetworkInterfaces []NetworkInterface `json:"network_interfaces"`
+	DefaultGateways   []string           `json:"default_gateways"`
+	HostName          string             `json:"hostname"`
+	CPUCount          int                `json:"cpu_count"`
+	SocketCount       int                `json:"socket_count"`
+	TotalMemoryMB     int                `json:"total_memory_mb"`
+	AgentVersion      string             `json:"agent_version"`
+	ExportersStatus   map[string]string  `json:"exporters_status"`
+	// ConfigFilesChecksums maps the path of key HA configuration files to
+	// the checksum of their content, to spot divergence between nodes
+	ConfigFilesChecksums map[string]string `json:"config_files_checksums"`
+	// InstalledPackages maps the names of the HA/SAP stack packages to
+	// their installed versions
+	InstalledPackages map[string]string `json:"installed_packages"`
+	// SystemSettings maps the kernel and OS settings relevant to the SAP
+	// notes to their current values: sysctl entries ("sysctl:" prefix),
+	// systemd unit states ("service:" prefix) and the transparent
+	// hugepages state ("thp")
+	SystemSettings map[string]string `json:"system_settings"`
+}
+
+type NetworkInterface struct {
+	Name       string   `json:"name"`
+	MACAddress string   `json:"mac_address"`
+	MTU        int      `json:"mtu"`
+	Addresses  []string `json:"addresses"`
+	// BondMaster is the name of the bonding interface this one is enslaved to, if any
+	BondMaster string `json:"bond_master,omitempty"`
+	// VlanID is the 802.1q tag of VLAN interfaces, 0 otherwise
+	VlanID int `json:"vlan_id,omitempty"`
 }
@@ -0,0 +1,9 @@
+package hosts
+
+import "time"
+
+type Heartbeat struct {
+	AgentVersion       string    `json:"agent_version"`
+	EnabledDiscoveries []string  `json:"enabled_discoveries"`
+	AgentTime          time.Time `json:"agent_time"`
+}
@@ -0,0 +1,142 @@
+// Package markdown renders the small markdown subset used by the checks
+// remediation texts into HTML that is safe to embed: every input is
+// escaped first, and only http(s) and absolute-path link targets survive.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	imagePattern  = regexp.MustCompile(`!\[([^\]]*)\]\(([^()\s]+)\)`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^()\s]+)\)`)
+	boldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicPattern = regexp.MustCompile(`\*([^*]+)\*`)
+)
+
+// Render converts markdown into sanitized HTML. Supported constructs:
+// paragraphs, #/##/### headings, - lists, fenced and inline code, bold,
+// italic, links and images
+func Render(source string) string {
+	if source == "" {
+		return ""
+	}
+
+	var out strings.Builder
+	var paragraph []string
+	var list []string
+	var code []string
+	inCode := false
+
+	flushParagraph := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		fmt.Fprintf(&out, "<p>%s</p>", renderInline(strings.Join(paragraph, " ")))
+		paragraph = nil
+	}
+	flushList := func() {
+		if len(list) == 0 {
+			return
+		}
+		out.WriteString("<ul>")
+		for _, item := range list {
+			fmt.Fprintf(&out, "<li>%s</li>", renderInline(item))
+		}
+		out.WriteString("</ul>")
+		list = nil
+	}
+
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			if inCode {
+				fmt.Fprintf(&out, "<pre><code>%s</code></pre>", html.EscapeString(strings.Join(code, "\n")))
+				code = nil
+			} else {
+				flushParagraph()
+				flushList()
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			code = append(code, line)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			flushList()
+		case strings.HasPrefix(trimmed, "### "):
+			flushParagraph()
+			flushList()
+			fmt.Fprintf(&out, "<h3>%s</h3>", renderInline(strings.TrimPrefix(trimmed, "### ")))
+		case strings.HasPrefix(trimmed, "## "):
+			flushParagraph()
+			flushList()
+			fmt.Fprintf(&out, "<h2>%s</h2>", renderInline(strings.TrimPrefix(trimmed, "## ")))
+		case strings.HasPrefix(trimmed, "# "):
+			flushParagraph()
+			flushList()
+			fmt.Fprintf(&out, "<h1>%s</h1>", renderInline(strings.TrimPrefix(trimmed, "# ")))
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			flushParagraph()
+			list = append(list, trimmed[2:])
+		default:
+			flushList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+
+	if inCode {
+		fmt.Fprintf(&out, "<pre><code>%s</code></pre>", html.EscapeString(strings.Join(code, "\n")))
+	}
+	flushParagraph()
+	flushList()
+
+	return out.String()
+}
+
+func renderInline(text string) string {
+	text = html.EscapeString(text)
+
+	text = codePattern.ReplaceAllString(text, "<code>$1</code>")
+
+	text = imagePattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := imagePattern.FindStringSubmatch(match)
+		if !safeURL(groups[2]) {
+			return match
+		}
+		return fmt.Sprintf(`<img src="%s" alt="%s">`, groups[2], groups[1])
+	})
+
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		groups := linkPattern.FindStringSubmatch(match)
+		if !safeURL(groups[2]) {
+			return match
+		}
+		return fmt.Sprintf(`<a href="%s" target="_blank" rel="noopener noreferrer">%s</a>`, groups[2], groups[1])
+	})
+
+	text = boldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = italicPattern.ReplaceAllString(text, "<em>$1</em>")
+
+	return text
+}
+
+// safeURL only admits targets that cannot smuggle scripts in, like
+// javascript: URLs would
+func safeURL(url string) bool {
+	url = strings.ToLower(url)
+
+	return strings.HasPrefix(url, "http://") ||
+		strings.HasPrefix(url, "https://") ||
+		strings.HasPrefix(url, "/")
+}
@@ -0,0 +1,70 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEmpty(t *testing.T) {
+	assert.Equal(t, "", Render(""))
+}
+
+func TestRenderParagraphsAndHeadings(t *testing.T) {
+	source := `# Remediation
+
+Restart the cluster
+resources.
+
+## Details`
+
+	assert.Equal(t,
+		"<h1>Remediation</h1><p>Restart the cluster resources.</p><h2>Details</h2>",
+		Render(source))
+}
+
+func TestRenderEmphasisAndInlineCode(t *testing.T) {
+	assert.Equal(t,
+		"<p>Run <code>crm status</code> and check the <strong>DC</strong> node <em>first</em></p>",
+		Render("Run `crm status` and check the **DC** node *first*"))
+}
+
+func TestRenderList(t *testing.T) {
+	source := `- stop the resource
+- start it again`
+
+	assert.Equal(t,
+		"<ul><li>stop the resource</li><li>start it again</li></ul>",
+		Render(source))
+}
+
+func TestRenderFencedCode(t *testing.T) {
+	source := "```\ncrm resource restart <resource>\n```"
+
+	assert.Equal(t,
+		"<pre><code>crm resource restart &lt;resource&gt;</code></pre>",
+		Render(source))
+}
+
+func TestRenderLinksAndImages(t *testing.T) {
+	assert.Equal(t,
+		`<p>See <a href="https://documentation.suse.com" target="_blank" rel="noopener noreferrer">the docs</a></p>`,
+		Render("See [the docs](https://documentation.suse.com)"))
+
+	assert.Equal(t,
+		`<p><img src="/static/diagram.png" alt="diagram"></p>`,
+		Render("![diagram](/static/diagram.png)"))
+}
+
+func TestRenderRejectsUnsafeLinks(t *testing.T) {
+	// the markup of unsafe targets is kept as escaped text instead of
+	// becoming a clickable link
+	assert.NotContains(t, Render("[click me](javascript:doEvil)"), "<a")
+	assert.NotContains(t, Render("![oops](data:text/html;base64,Zm9v)"), "<img")
+}
+
+func TestRenderEscapesRawHTML(t *testing.T) {
+	rendered := Render(`<script>alert("boom")</script>`)
+
+	assert.Equal(t, "<p>&lt;script&gt;alert(&#34;boom&#34;)&lt;/script&gt;</p>", rendered)
+}
@@ -0,0 +1,144 @@
+// Package redact scrubs credentials and other sensitive values from log
+// lines, error payloads and exported data, so that debug output can be shared
+// with support without leaking secrets.
+package redact
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Placeholder replaces the redacted values
+const Placeholder = "<REDACTED>"
+
+// sensitiveKeyFragments match any key containing them, regardless of prefixes
+// like ssh_ or grafana_
+var sensitiveKeyFragments = []string{
+	"password", "passwd", "secret", "token", "credential",
+	"authorization", "private_key", "api_key",
+}
+
+// sensitiveKeys match exactly, as fragments like "user" would be too greedy
+var sensitiveKeys = []string{
+	"user", "username", "ssh_user",
+}
+
+var sensitivePattern = regexp.MustCompile(
+	`(?i)("?(?:[a-z0-9_-]*(?:password|passwd|secret|token|credential|authorization|private_key|api_key)[a-z0-9_-]*|user|username|ssh_user)"?\s*[:=]\s*)("[^"]*"|'[^']*'|[^\s,;&"']+)`)
+
+// String scrubs the values of known sensitive key=value and "key": "value"
+// occurrences in a free-form string
+func String(s string) string {
+	return sensitivePattern.ReplaceAllStringFunc(s, func(match string) string {
+		submatches := sensitivePattern.FindStringSubmatch(match)
+		prefix, value := submatches[1], submatches[2]
+
+		switch {
+		case strings.HasPrefix(value, `"`):
+			return prefix + `"` + Placeholder + `"`
+		case strings.HasPrefix(value, `'`):
+			return prefix + `'` + Placeholder + `'`
+		default:
+			return prefix + Placeholder
+		}
+	})
+}
+
+// Map returns a deep copy of the given map with the values of the sensitive
+// keys replaced, recursing into nested maps and lists
+func Map(data map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(data))
+
+	for key, value := range data {
+		if isSensitiveKey(key) {
+			redacted[key] = Placeholder
+			continue
+		}
+		redacted[key] = redactValue(value)
+	}
+
+	return redacted
+}
+
+// JSON scrubs the sensitive fields of a JSON document. Unparseable documents
+// are scrubbed as plain strings.
+func JSON(data []byte) []byte {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return []byte(String(string(data)))
+	}
+
+	redacted, err := json.Marshal(Map(payload))
+	if err != nil {
+		return []byte(String(string(data)))
+	}
+
+	return redacted
+}
+
+// NewFormatter wraps a logrus formatter, scrubbing the message and the fields
+// of every entry before it is formatted
+func NewFormatter(inner log.Formatter) log.Formatter {
+	return &formatter{inner: inner}
+}
+
+type formatter struct {
+	inner log.Formatter
+}
+
+func (f *formatter) Format(entry *log.Entry) ([]byte, error) {
+	redactedEntry := *entry
+	redactedEntry.Message = String(entry.Message)
+
+	if len(entry.Data) > 0 {
+		data := make(log.Fields, len(entry.Data))
+		for key, value := range entry.Data {
+			if isSensitiveKey(key) {
+				data[key] = Placeholder
+				continue
+			}
+			data[key] = redactValue(value)
+		}
+		redactedEntry.Data = data
+	}
+
+	return f.inner.Format(&redactedEntry)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		return Map(typedValue)
+	case []interface{}:
+		redacted := make([]interface{}, len(typedValue))
+		for i, item := range typedValue {
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	case string:
+		return String(typedValue)
+	default:
+		return value
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, fragment := range sensitiveKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+
+	for _, sensitiveKey := range sensitiveKeys {
+		if key == sensitiveKey {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package redact
+
+import (
+	"bytes"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestString(t *testing.T) {
+	assert.Equal(t,
+		"connecting with user=<REDACTED> password=<REDACTED>",
+		String("connecting with user=cloudadmin password=s3cret"))
+
+	assert.Equal(t,
+		`{"user": "<REDACTED>", "grafana_password": "<REDACTED>"}`,
+		String(`{"user": "cloudadmin", "grafana_password": "s3cret"}`))
+
+	assert.Equal(t,
+		"vault login failed: secret_id=<REDACTED>",
+		String("vault login failed: secret_id=4ff8c8e2"))
+
+	assert.Equal(t,
+		"could not find cluster some_cluster",
+		String("could not find cluster some_cluster"))
+}
+
+func TestMap(t *testing.T) {
+	redacted := Map(map[string]interface{}{
+		"hostname": "host1",
+		"ssh_user": "cloudadmin",
+		"cloud": map[string]interface{}{
+			"provider":     "azure",
+			"access_token": "abcd1234",
+		},
+		"subscriptions": []interface{}{
+			map[string]interface{}{"identifier": "SLES_SAP", "password": "s3cret"},
+		},
+		"cpu_count": 4,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"hostname": "host1",
+		"ssh_user": Placeholder,
+		"cloud": map[string]interface{}{
+			"provider":     "azure",
+			"access_token": Placeholder,
+		},
+		"subscriptions": []interface{}{
+			map[string]interface{}{"identifier": "SLES_SAP", "password": Placeholder},
+		},
+		"cpu_count": 4,
+	}, redacted)
+}
+
+func TestJSON(t *testing.T) {
+	redacted := JSON([]byte(`{"hostname": "host1", "user": "cloudadmin"}`))
+
+	assert.JSONEq(t, `{"hostname": "host1", "user": "<REDACTED>"}`, string(redacted))
+}
+
+func TestJSONUnparseable(t *testing.T) {
+	redacted := JSON([]byte("not json, but password=s3cret anyway"))
+
+	assert.Equal(t, "not json, but password=<REDACTED> anyway", string(redacted))
+}
+
+func TestFormatter(t *testing.T) {
+	var buffer bytes.Buffer
+
+	logger := log.New()
+	logger.SetOutput(&buffer)
+	logger.SetFormatter(NewFormatter(&log.TextFormatter{DisableTimestamp: true}))
+
+	logger.WithFields(log.Fields{
+		"user":    "cloudadmin",
+		"cluster": "hana_cluster",
+	}).Info("connecting with password=s3cret")
+
+	output := buffer.String()
+	assert.NotContains(t, output, "s3cret")
+	assert.NotContains(t, output, "cloudadmin")
+	assert.Contains(t, output, "cluster=hana_cluster")
+	assert.Contains(t, output, Placeholder)
+}
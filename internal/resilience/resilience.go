@@ -0,0 +1,159 @@
+// Package resilience provides a shared policy for outbound calls: per-attempt
+// timeouts, retries with exponential backoff and a circuit breaker that stops
+// hammering an external dependency that keeps failing, so one slow or broken
+// integration cannot stall the rest of the server.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrCircuitOpen is returned without attempting the call while the circuit
+// breaker cooldown is running
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type Config struct {
+	// Attempts is how many times a failing call is tried in total
+	Attempts uint
+	// AttemptTimeout bounds every single attempt. Zero disables the timeout
+	AttemptTimeout time.Duration
+	// Delay is the backoff base delay, doubled on every retry
+	Delay time.Duration
+	// BreakerThreshold is the number of consecutive failed calls after which
+	// the circuit opens
+	BreakerThreshold uint
+	// BreakerCooldown is how long an open circuit rejects calls before a
+	// probe call is let through again
+	BreakerCooldown time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Attempts:         3,
+		AttemptTimeout:   10 * time.Second,
+		Delay:            500 * time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+// Metrics are cumulative counters of what a policy did since its creation
+type Metrics struct {
+	// Calls counts every Execute invocation, including the rejected ones
+	Calls uint64
+	// Failures counts the calls that exhausted all their attempts
+	Failures uint64
+	// Retries counts the single repeated attempts
+	Retries uint64
+	// Rejections counts the calls refused by the open circuit
+	Rejections  uint64
+	CircuitOpen bool
+}
+
+// Policy wraps the outbound calls of one external dependency. It is safe for
+// concurrent use
+type Policy struct {
+	name   string
+	config Config
+
+	mu                  sync.Mutex
+	consecutiveFailures uint
+	openedAt            time.Time
+	metrics             Metrics
+}
+
+func NewPolicy(name string, config Config) *Policy {
+	return &Policy{name: name, config: config}
+}
+
+// Execute runs the call under the policy: each attempt is bounded by the
+// attempt timeout, failed attempts are retried with exponential backoff and,
+// once the consecutive failures reach the breaker threshold, further calls
+// are rejected with ErrCircuitOpen until the cooldown elapses
+func (p *Policy) Execute(ctx context.Context, call func(ctx context.Context) error) error {
+	if !p.admit() {
+		return ErrCircuitOpen
+	}
+
+	err := retry.Do(
+		func() error {
+			attemptCtx := ctx
+			if p.config.AttemptTimeout > 0 {
+				var cancel context.CancelFunc
+				attemptCtx, cancel = context.WithTimeout(ctx, p.config.AttemptTimeout)
+				defer cancel()
+			}
+
+			return call(attemptCtx)
+		},
+		retry.Attempts(p.config.Attempts),
+		retry.Delay(p.config.Delay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.Context(ctx),
+		retry.OnRetry(func(_ uint, err error) {
+			p.mu.Lock()
+			p.metrics.Retries++
+			p.mu.Unlock()
+			log.Debugf("Outbound call to %s failed, retrying: %s", p.name, err)
+		}),
+	)
+
+	p.record(err)
+
+	return err
+}
+
+// Metrics returns a snapshot of the policy counters
+func (p *Policy) Metrics() Metrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	metrics := p.metrics
+	metrics.CircuitOpen = p.consecutiveFailures >= p.config.BreakerThreshold &&
+		time.Since(p.openedAt) < p.config.BreakerCooldown
+
+	return metrics
+}
+
+func (p *Policy) admit() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.metrics.Calls++
+
+	if p.consecutiveFailures >= p.config.BreakerThreshold {
+		if time.Since(p.openedAt) < p.config.BreakerCooldown {
+			p.metrics.Rejections++
+			return false
+		}
+		// cooldown over: let a probe call through, keeping the failure count
+		// so that another failure reopens the circuit right away
+	}
+
+	return true
+}
+
+func (p *Policy) record(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		p.consecutiveFailures = 0
+		return
+	}
+
+	p.metrics.Failures++
+	p.consecutiveFailures++
+	if p.consecutiveFailures >= p.config.BreakerThreshold {
+		p.openedAt = time.Now()
+		log.Warnf("Outbound calls to %s keep failing, opening the circuit for %s",
+			p.name, p.config.BreakerCooldown)
+	}
+}
@@ -0,0 +1,93 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfig() Config {
+	return Config{
+		Attempts:         3,
+		AttemptTimeout:   time.Second,
+		Delay:            time.Millisecond,
+		BreakerThreshold: 2,
+		BreakerCooldown:  100 * time.Millisecond,
+	}
+}
+
+func TestExecute_RetriesUntilSuccess(t *testing.T) {
+	policy := NewPolicy("flaky", testConfig())
+
+	attempts := 0
+	err := policy.Execute(context.Background(), func(ctx context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient error")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	metrics := policy.Metrics()
+	assert.EqualValues(t, 1, metrics.Calls)
+	assert.EqualValues(t, 2, metrics.Retries)
+	assert.EqualValues(t, 0, metrics.Failures)
+	assert.False(t, metrics.CircuitOpen)
+}
+
+func TestExecute_AttemptTimeout(t *testing.T) {
+	config := testConfig()
+	config.Attempts = 1
+	config.AttemptTimeout = 10 * time.Millisecond
+	policy := NewPolicy("slow", config)
+
+	err := policy.Execute(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestExecute_CircuitOpensAndRecovers(t *testing.T) {
+	policy := NewPolicy("broken", testConfig())
+
+	failing := func(ctx context.Context) error {
+		return errors.New("dependency down")
+	}
+
+	// two failed calls reach the breaker threshold
+	assert.Error(t, policy.Execute(context.Background(), failing))
+	assert.Error(t, policy.Execute(context.Background(), failing))
+
+	attempted := false
+	err := policy.Execute(context.Background(), func(ctx context.Context) error {
+		attempted = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, attempted)
+	assert.True(t, policy.Metrics().CircuitOpen)
+
+	// after the cooldown a probe call goes through and closes the circuit
+	time.Sleep(policy.config.BreakerCooldown)
+
+	err = policy.Execute(context.Background(), func(ctx context.Context) error {
+		attempted = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, attempted)
+	assert.False(t, policy.Metrics().CircuitOpen)
+
+	metrics := policy.Metrics()
+	assert.EqualValues(t, 4, metrics.Calls)
+	assert.EqualValues(t, 2, metrics.Failures)
+	assert.EqualValues(t, 1, metrics.Rejections)
+}
@@ -0,0 +1,73 @@
+// Package scc provides a minimal client for the subscription endpoints
+// exposed by the SUSE Customer Center API or a local RMT mirror.
+package scc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/trento-project/trento/internal/resilience"
+)
+
+// SubscriptionInfo is the current state of a product subscription as known
+// by the registration service
+type SubscriptionInfo struct {
+	Identifier string `json:"identifier"`
+	Status     string `json:"status"`
+	ExpiresAt  string `json:"expires_at"`
+}
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	policy     *resilience.Policy
+}
+
+func NewClient(baseURL string, httpClient *http.Client) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		policy:     resilience.NewPolicy("scc", resilience.DefaultConfig()),
+	}
+}
+
+// GetSubscriptionInfo fetches the current state of the subscription covering
+// the given product from the registration service
+func (c *Client) GetSubscriptionInfo(identifier, version, arch string) (*SubscriptionInfo, error) {
+	endpoint := fmt.Sprintf("%s/connect/subscriptions/info?identifier=%s&version=%s&arch=%s",
+		c.baseURL, url.QueryEscape(identifier), url.QueryEscape(version), url.QueryEscape(arch))
+
+	var info SubscriptionInfo
+	err := c.policy.Execute(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return errors.Wrap(err, "error while querying the registration service")
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return errors.Errorf("unexpected response code %d from the registration service", resp.StatusCode)
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+			return errors.Wrap(err, "error while decoding the registration service response")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &info, nil
+}
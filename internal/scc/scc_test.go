@@ -0,0 +1,44 @@
+package scc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubscriptionInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/connect/subscriptions/info", r.URL.Path)
+		assert.Equal(t, "SLES_SAP", r.URL.Query().Get("identifier"))
+		assert.Equal(t, "15.2", r.URL.Query().Get("version"))
+		assert.Equal(t, "x86_64", r.URL.Query().Get("arch"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"identifier": "SLES_SAP", "status": "ACTIVE", "expires_at": "2026-09-17 13:41:34 UTC"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, http.DefaultClient)
+	info, err := client.GetSubscriptionInfo("SLES_SAP", "15.2", "x86_64")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &SubscriptionInfo{
+		Identifier: "SLES_SAP",
+		Status:     "ACTIVE",
+		ExpiresAt:  "2026-09-17 13:41:34 UTC",
+	}, info)
+}
+
+func TestGetSubscriptionInfoUnexpectedResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, http.DefaultClient)
+	_, err := client.GetSubscriptionInfo("SLES_SAP", "15.2", "x86_64")
+
+	assert.EqualError(t, err, "unexpected response code 404 from the registration service")
+}
@@ -0,0 +1,182 @@
+// Package secrets implements the envelope encryption used to persist
+// sensitive values at rest: every value is sealed with a fresh random data
+// key, which is in turn sealed with the server-managed master key. Rotating
+// the master key therefore only requires re-sealing the small data keys, not
+// the values themselves.
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix marks a value as sealed by this package, so that legacy
+// plaintext rows can still be recognized and read
+const sealedPrefix = "TRENTOSEC1;"
+
+var ErrNotSealed = errors.New("the value is not sealed")
+
+type Sealer struct {
+	masterKey []byte
+}
+
+// NewSealer derives the AES-256 master key from the given secret string
+func NewSealer(masterSecret string) *Sealer {
+	masterKey := sha256.Sum256([]byte(masterSecret))
+
+	return &Sealer{masterKey: masterKey[:]}
+}
+
+// NewMasterSecret generates a random secret suitable as a sealer master
+// secret
+func NewMasterSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// IsSealed tells whether a stored value was sealed by this package
+func IsSealed(value string) bool {
+	return strings.HasPrefix(value, sealedPrefix)
+}
+
+// Seal envelope-encrypts the plaintext: the value is encrypted with a fresh
+// data key, and the data key is encrypted with the master key
+func (s *Sealer) Seal(plaintext string) (string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+
+	sealedKey, err := encrypt(s.masterKey, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	sealedValue, err := encrypt(dataKey, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s;%s",
+		sealedPrefix,
+		base64.StdEncoding.EncodeToString(sealedKey),
+		base64.StdEncoding.EncodeToString(sealedValue),
+	), nil
+}
+
+// Open decrypts a value produced by Seal
+func (s *Sealer) Open(sealed string) (string, error) {
+	sealedKey, sealedValue, err := decode(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := decrypt(s.masterKey, sealedKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := decrypt(dataKey, sealedValue)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rekey re-seals the data key of a sealed value with the given new sealer,
+// leaving the value ciphertext untouched
+func (s *Sealer) Rekey(sealed string, to *Sealer) (string, error) {
+	sealedKey, sealedValue, err := decode(sealed)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := decrypt(s.masterKey, sealedKey)
+	if err != nil {
+		return "", err
+	}
+
+	resealedKey, err := encrypt(to.masterKey, dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s%s;%s",
+		sealedPrefix,
+		base64.StdEncoding.EncodeToString(resealedKey),
+		base64.StdEncoding.EncodeToString(sealedValue),
+	), nil
+}
+
+func decode(sealed string) ([]byte, []byte, error) {
+	if !IsSealed(sealed) {
+		return nil, nil, ErrNotSealed
+	}
+
+	parts := strings.Split(strings.TrimPrefix(sealed, sealedPrefix), ";")
+	if len(parts) != 2 {
+		return nil, nil, errors.New("malformed sealed value")
+	}
+
+	sealedKey, err := base64.StdEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sealedValue, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sealedKey, sealedValue, nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, sealed, nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	sealer := NewSealer("some-master-secret")
+
+	sealed, err := sealer.Seal("cloudadmin")
+	assert.NoError(t, err)
+	assert.True(t, IsSealed(sealed))
+	assert.NotContains(t, sealed, "cloudadmin")
+
+	plaintext, err := sealer.Open(sealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudadmin", plaintext)
+}
+
+func TestOpenWithWrongKey(t *testing.T) {
+	sealer := NewSealer("some-master-secret")
+
+	sealed, err := sealer.Seal("cloudadmin")
+	assert.NoError(t, err)
+
+	_, err = NewSealer("another-master-secret").Open(sealed)
+	assert.Error(t, err)
+}
+
+func TestOpenPlaintext(t *testing.T) {
+	sealer := NewSealer("some-master-secret")
+
+	_, err := sealer.Open("cloudadmin")
+	assert.ErrorIs(t, err, ErrNotSealed)
+}
+
+func TestRekey(t *testing.T) {
+	oldSealer := NewSealer("some-master-secret")
+	newSealer := NewSealer("another-master-secret")
+
+	sealed, err := oldSealer.Seal("cloudadmin")
+	assert.NoError(t, err)
+
+	resealed, err := oldSealer.Rekey(sealed, newSealer)
+	assert.NoError(t, err)
+
+	_, err = oldSealer.Open(resealed)
+	assert.Error(t, err)
+
+	plaintext, err := newSealer.Open(resealed)
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudadmin", plaintext)
+}
+
+func TestNewMasterSecret(t *testing.T) {
+	first, err := NewMasterSecret()
+	assert.NoError(t, err)
+	assert.Len(t, first, 64)
+
+	second, err := NewMasterSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, first, second)
+}
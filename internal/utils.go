@@ -13,6 +13,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/internal/redact"
 )
 
 func SetLogLevel(level string) {
@@ -34,8 +35,8 @@ func SetLogLevel(level string) {
 func SetLogFormatter(timestampFormat string) {
 	customFormatter := new(log.TextFormatter)
 	customFormatter.TimestampFormat = timestampFormat
-	log.SetFormatter(customFormatter)
 	customFormatter.FullTimestamp = true
+	log.SetFormatter(redact.NewFormatter(customFormatter))
 }
 
 func Contains(s []string, str string) bool {
@@ -0,0 +1,176 @@
+// Package vault implements a minimal HashiCorp Vault client, so that the
+// stored connection settings can reference Vault secrets instead of carrying
+// literal SSH credentials, and the runner resolves them at execution time.
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// referencePrefix marks a connection settings value as a Vault reference,
+// in the form vault:<secret path>#<field>, e.g. vault:secret/data/trento/hana#user
+const referencePrefix = "vault:"
+
+type Config struct {
+	// Address is the base URL of the Vault server, e.g. https://vault.internal:8200
+	Address string
+	// Token authenticates directly with the token auth method
+	Token string
+	// RoleID and SecretID authenticate with the AppRole auth method,
+	// as an alternative to Token
+	RoleID   string
+	SecretID string
+}
+
+//go:generate mockery --name=Client --inpackage --filename=vault_mock.go
+
+// Client reads secrets from a Vault server
+type Client interface {
+	GetSecret(path, field string) (string, error)
+}
+
+type client struct {
+	config     Config
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	token string
+}
+
+func NewClient(config Config, httpClient *http.Client) (*client, error) {
+	if config.Address == "" {
+		return nil, fmt.Errorf("you must provide the vault server address")
+	}
+	if config.Token == "" && (config.RoleID == "" || config.SecretID == "") {
+		return nil, fmt.Errorf("you must provide either a vault token or an approle role id and secret id")
+	}
+
+	return &client{
+		config:     config,
+		httpClient: httpClient,
+	}, nil
+}
+
+// IsReference tells whether a connection settings value is a Vault reference
+// rather than a literal value
+func IsReference(value string) bool {
+	return strings.HasPrefix(value, referencePrefix)
+}
+
+// ParseReference splits a vault:<path>#<field> reference into the secret path
+// and the field to read from it
+func ParseReference(reference string) (string, string, error) {
+	if !IsReference(reference) {
+		return "", "", fmt.Errorf("%s is not a vault reference", reference)
+	}
+
+	parts := strings.SplitN(strings.TrimPrefix(reference, referencePrefix), "#", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("malformed vault reference %s, expected vault:<path>#<field>", reference)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// GetSecret reads a single field of the secret stored at the given path,
+// supporting both KV version 1 and version 2 response payloads
+func (c *client) GetSecret(path, field string) (string, error) {
+	token, err := c.getToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/%s", c.config.Address, path), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response code %d while reading the vault secret %s", resp.StatusCode, path)
+	}
+
+	var payload struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", err
+	}
+
+	data := payload.Data
+	// KV version 2 nests the secret fields in an inner data object
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		data = inner
+	}
+
+	value, ok := data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("the vault secret %s does not contain the %s field", path, field)
+	}
+
+	return value, nil
+}
+
+// getToken returns the configured token, or logs in with the approle auth
+// method caching the resulting client token
+func (c *client) getToken() (string, error) {
+	if c.config.Token != "" {
+		return c.config.Token, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token != "" {
+		return c.token, nil
+	}
+
+	loginBody, err := json.Marshal(map[string]string{
+		"role_id":   c.config.RoleID,
+		"secret_id": c.config.SecretID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.httpClient.Post(
+		fmt.Sprintf("%s/v1/auth/approle/login", c.config.Address),
+		"application/json",
+		bytes.NewReader(loginBody),
+	)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected response code %d while logging in to vault", resp.StatusCode)
+	}
+
+	var payload struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", err
+	}
+	if payload.Auth.ClientToken == "" {
+		return "", fmt.Errorf("the vault approle login did not return a client token")
+	}
+
+	c.token = payload.Auth.ClientToken
+
+	return c.token, nil
+}
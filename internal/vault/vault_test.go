@@ -0,0 +1,132 @@
+package vault
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	path, field, err := ParseReference("vault:secret/data/trento/hana#user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "secret/data/trento/hana", path)
+	assert.Equal(t, "user", field)
+}
+
+func TestParseReferenceMalformed(t *testing.T) {
+	_, _, err := ParseReference("cloudadmin")
+	assert.EqualError(t, err, "cloudadmin is not a vault reference")
+
+	_, _, err = ParseReference("vault:secret/data/trento/hana")
+	assert.Error(t, err)
+
+	_, _, err = ParseReference("vault:#user")
+	assert.Error(t, err)
+}
+
+func TestIsReference(t *testing.T) {
+	assert.True(t, IsReference("vault:secret/data/trento/hana#user"))
+	assert.False(t, IsReference("cloudadmin"))
+}
+
+func TestNewClientWithoutAuth(t *testing.T) {
+	_, err := NewClient(Config{Address: "https://vault.internal:8200"}, http.DefaultClient)
+
+	assert.EqualError(t, err, "you must provide either a vault token or an approle role id and secret id")
+}
+
+func TestGetSecretWithToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/secret/data/trento/hana", r.URL.Path)
+		assert.Equal(t, "s.token", r.Header.Get("X-Vault-Token"))
+
+		// KV version 2 payload
+		w.Write([]byte(`{"data": {"data": {"user": "cloudadmin"}, "metadata": {"version": 1}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Address: server.URL, Token: "s.token"}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	value, err := client.GetSecret("secret/data/trento/hana", "user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudadmin", value)
+}
+
+func TestGetSecretKVVersion1(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"user": "cloudadmin"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Address: server.URL, Token: "s.token"}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	value, err := client.GetSecret("kv/trento/hana", "user")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudadmin", value)
+}
+
+func TestGetSecretMissingField(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"data": {"password": "secret"}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Address: server.URL, Token: "s.token"}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = client.GetSecret("secret/data/trento/hana", "user")
+
+	assert.EqualError(t, err, "the vault secret secret/data/trento/hana does not contain the user field")
+}
+
+func TestGetSecretWithAppRole(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/approle/login" {
+			logins++
+			w.Write([]byte(`{"auth": {"client_token": "s.approle-token"}}`))
+			return
+		}
+
+		assert.Equal(t, "s.approle-token", r.Header.Get("X-Vault-Token"))
+		w.Write([]byte(`{"data": {"data": {"user": "cloudadmin"}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{
+		Address:  server.URL,
+		RoleID:   "role-id",
+		SecretID: "secret-id",
+	}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	value, err := client.GetSecret("secret/data/trento/hana", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "cloudadmin", value)
+
+	// The approle client token is cached across calls
+	_, err = client.GetSecret("secret/data/trento/hana", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, logins)
+}
+
+func TestGetSecretUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Address: server.URL, Token: "s.token"}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = client.GetSecret("secret/data/trento/hana", "user")
+
+	assert.EqualError(t, err, "unexpected response code 403 while reading the vault secret secret/data/trento/hana")
+}
@@ -8,6 +8,7 @@ Trento Callback executor.
 :since: 2021-09-16
 """
 
+import json
 import os
 import yaml
 import requests
@@ -127,6 +128,7 @@ class CallbackModule(CallbackBase):
         host = os.getenv('TRENTO_WEB_API_HOST')
         port = os.getenv('TRENTO_WEB_API_PORT')
         self._trento_api_url = "http://{}:{}".format(host, port)
+        self._results_file = os.getenv('TRENTO_RESULTS_FILE')
 
     def v2_playbook_on_start(self, playbook):
         """
@@ -204,6 +206,11 @@ class CallbackModule(CallbackBase):
         if not self._is_test_execution():
             return
 
+        if self._results_file:
+            self._display.banner("Writing Trento results to {}".format(self._results_file))
+            self._write_results(self.results.results)
+            return
+
         self._display.banner("Publishing Trento results")
         self._post_results(self.results.results)
 
@@ -278,6 +285,14 @@ class CallbackModule(CallbackBase):
                     self.results.set_host_state(group, host, True)
                     self.results.add_result(group, check_id, host, "skipped")
 
+    def _write_results(self, results):
+        """
+        Write the results to a local file instead of posting them, used by
+        the agents to collect the outcome of the locally executed checks
+        """
+        with open(self._results_file, "w") as file_ptr:
+            json.dump(results, file_ptr)
+
     def _post_results(self, results):
         """
         Post results to the trento web api server
@@ -12,6 +12,7 @@ import (
 const (
 	TrentoWebApiHost     = "TRENTO_WEB_API_HOST"
 	TrentoWebApiPort     = "TRENTO_WEB_API_PORT"
+	TrentoResultsFileEnv = "TRENTO_RESULTS_FILE"
 	AnsibleConfigFileEnv = "ANSIBLE_CONFIG"
 )
 
@@ -69,6 +70,13 @@ func (a *AnsibleRunner) SetTrentoApiData(host string, port int) {
 	a.setEnv(TrentoWebApiPort, fmt.Sprintf("%d", port))
 }
 
+// SetResultsFile makes the trento callback plugin write the results to a
+// local file instead of posting them to the web API, used by the agents to
+// collect the outcome of the locally executed checks
+func (a *AnsibleRunner) SetResultsFile(path string) {
+	a.setEnv(TrentoResultsFileEnv, path)
+}
+
 func (a *AnsibleRunner) RunPlaybook() error {
 	var cmdItems []string
 
@@ -56,7 +56,7 @@ func NewRunner(config *Config) (*Runner, error) {
 func (c *Runner) Start() error {
 	var wg sync.WaitGroup
 
-	if err := createAnsibleFiles(c.config.AnsibleFolder); err != nil {
+	if err := CreateAnsibleFiles(c.config.AnsibleFolder); err != nil {
 		return err
 	}
 
@@ -101,7 +101,9 @@ func (c *Runner) Stop() {
 	c.ctxCancel()
 }
 
-func createAnsibleFiles(folder string) error {
+// CreateAnsibleFiles extracts the embedded ansible file structure into the
+// given folder. It is also used by the agent to execute the checks locally.
+func CreateAnsibleFiles(folder string) error {
 	log.Infof("Creating the ansible file structure in %s", folder)
 	// Clean the folder if it stores old files
 	ansibleFolder := path.Join(folder, "ansible")
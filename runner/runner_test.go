@@ -17,7 +17,7 @@ const (
 // once we have something fixed
 func TestCreateAnsibleFiles(t *testing.T) {
 	tmpDir, _ := ioutil.TempDir(os.TempDir(), "trentotest")
-	err := createAnsibleFiles(tmpDir)
+	err := CreateAnsibleFiles(tmpDir)
 
 	assert.DirExists(t, path.Join(tmpDir, "ansible"))
 	assert.NoError(t, err)
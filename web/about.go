@@ -8,7 +8,7 @@ import (
 	"github.com/trento-project/trento/web/services"
 )
 
-func NewAboutHandler(s services.SubscriptionsService) gin.HandlerFunc {
+func NewAboutHandler(s services.SubscriptionsService, settings services.SettingsService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		premiumData, err := s.GetPremiumData()
 		if err != nil {
@@ -16,11 +16,37 @@ func NewAboutHandler(s services.SubscriptionsService) gin.HandlerFunc {
 			return
 		}
 
+		telemetryOptOut, err := settings.IsTelemetryOptedOut()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
 		c.HTML(http.StatusOK, "about.html.tmpl", gin.H{
-			"Title":       defaultLayoutData.Title,
-			"Version":     defaultLayoutData.Version,
-			"PremiumData": premiumData,
-			"Flavor":      defaultLayoutData.Flavor,
+			"Title":           defaultLayoutData.Title,
+			"Version":         defaultLayoutData.Version,
+			"PremiumData":     premiumData,
+			"Flavor":          defaultLayoutData.Flavor,
+			"TelemetryOptOut": telemetryOptOut,
+			"CSRFField":       CSRFField(c),
 		})
 	}
 }
+
+// TelemetryToggleHandler flips the telemetry opt-out setting
+func TelemetryToggleHandler(settings services.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		telemetryOptOut, err := settings.IsTelemetryOptedOut()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if err := settings.SetTelemetryOptOut(!telemetryOptOut); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/about")
+	}
+}
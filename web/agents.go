@@ -0,0 +1,32 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/version"
+	"github.com/trento-project/trento/web/services"
+)
+
+func NewAgentsListHandler(agentsService services.AgentsService, preferencesService services.PreferencesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary, err := agentsService.GetSummary()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		preferences, err := preferencesService.GetPreferences(preferencesSubject(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.HTML(http.StatusOK, "agents.html.tmpl", gin.H{
+			"Agents":        summary,
+			"ServerVersion": version.Version,
+			"Timezone":      preferences.Timezone,
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package web
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONAgentChecksResults struct {
+	ExecutionID int64                    `json:"execution_id" binding:"required"`
+	Results     map[string]*models.Check `json:"results" binding:"required"`
+}
+
+type JSONAgentConfig struct {
+	AgentID             string         `json:"agent_id"`
+	DiscoveryPeriods    map[string]int `json:"discovery_periods"`
+	EnabledDiscoveries  []string       `json:"enabled_discoveries"`
+	CollectorHost       string         `json:"collector_host"`
+	CollectorPort       int            `json:"collector_port"`
+	AutoUpdateDisabled  bool           `json:"auto_update_disabled,omitempty"`
+	DesiredAgentVersion string         `json:"desired_agent_version,omitempty"`
+	UpdateRepository    string         `json:"update_repository,omitempty"`
+	// ChecksExecutionMode tells the agent whether it has to execute the
+	// selected checks itself ("agent") or the server runs them over SSH
+	ChecksExecutionMode string `json:"checks_execution_mode,omitempty"`
+}
+
+// ApiGetAgentConfigHandler godoc
+// @Summary Get the server-driven configuration of an agent
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} JSONAgentConfig
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/config [get]
+func ApiGetAgentConfigHandler(agentsService services.AgentsService, checksExecutionMode string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config, err := agentsService.GetConfig(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONAgentConfig{
+			AgentID:             config.AgentID,
+			DiscoveryPeriods:    config.DiscoveryPeriods,
+			EnabledDiscoveries:  config.EnabledDiscoveries,
+			CollectorHost:       config.CollectorHost,
+			CollectorPort:       config.CollectorPort,
+			AutoUpdateDisabled:  config.AutoUpdateDisabled,
+			DesiredAgentVersion: config.DesiredAgentVersion,
+			UpdateRepository:    config.UpdateRepository,
+			ChecksExecutionMode: checksExecutionMode,
+		})
+	}
+}
+
+// ApiUpdateAgentConfigHandler godoc
+// @Summary Update the server-driven configuration of an agent
+// @Accept json
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Param Body body JSONAgentConfig true "Agent configuration"
+// @Success 200 {object} JSONAgentConfig
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/config [put]
+func ApiUpdateAgentConfigHandler(agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var config JSONAgentConfig
+
+		err := c.BindJSON(&config)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = agentsService.UpdateConfig(&models.AgentConfig{
+			AgentID:            c.Param("id"),
+			DiscoveryPeriods:   config.DiscoveryPeriods,
+			EnabledDiscoveries: config.EnabledDiscoveries,
+			CollectorHost:      config.CollectorHost,
+			CollectorPort:      config.CollectorPort,
+			AutoUpdateDisabled: config.AutoUpdateDisabled,
+		})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		config.AgentID = c.Param("id")
+		c.JSON(http.StatusOK, &config)
+	}
+}
+
+type JSONAgentUpdateSettings struct {
+	DesiredAgentVersion string `json:"desired_agent_version"`
+	RepositoryURL       string `json:"repository_url"`
+}
+
+// ApiGetAgentsUpdateSettingsHandler godoc
+// @Summary Get the agent version and package repository advertised to the fleet
+// @Produce json
+// @Success 200 {object} JSONAgentUpdateSettings
+// @Failure 500 {object} map[string]string
+// @Router /agents/update-settings [get]
+func ApiGetAgentsUpdateSettingsHandler(agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		updateSettings, err := agentsService.GetUpdateSettings()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONAgentUpdateSettings{
+			DesiredAgentVersion: updateSettings.DesiredAgentVersion,
+			RepositoryURL:       updateSettings.RepositoryURL,
+		})
+	}
+}
+
+// ApiUpdateAgentsUpdateSettingsHandler godoc
+// @Summary Set the agent version and package repository advertised to the fleet
+// @Accept json
+// @Produce json
+// @Param Body body JSONAgentUpdateSettings true "Agent update settings"
+// @Success 200 {object} JSONAgentUpdateSettings
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /agents/update-settings [put]
+func ApiUpdateAgentsUpdateSettingsHandler(agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var updateSettings JSONAgentUpdateSettings
+
+		err := c.BindJSON(&updateSettings)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = agentsService.SetUpdateSettings(&models.AgentUpdateSettings{
+			DesiredAgentVersion: updateSettings.DesiredAgentVersion,
+			RepositoryURL:       updateSettings.RepositoryURL,
+		})
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &updateSettings)
+	}
+}
+
+type JSONEnrollmentToken struct {
+	EnrollmentToken string `json:"enrollment_token"`
+}
+
+// ApiGetEnrollmentTokenHandler godoc
+// @Summary Get the token new agents have to present to enroll
+// @Produce json
+// @Success 200 {object} JSONEnrollmentToken
+// @Failure 500 {object} map[string]string
+// @Router /agents/enrollment-token [get]
+func ApiGetEnrollmentTokenHandler(settingsService services.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := settingsService.GetEnrollmentToken()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONEnrollmentToken{EnrollmentToken: token})
+	}
+}
+
+type JSONAgentEnrollment struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ApiEnrollAgentHandler godoc
+// @Summary Enroll a new agent, validating its enrollment token
+// @Accept json
+// @Param id path string true "Agent ID"
+// @Param Body body JSONAgentEnrollment true "Enrollment request"
+// @Success 204 {object} interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Router /agents/{id}/enroll [post]
+func ApiEnrollAgentHandler(settingsService services.SettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var enrollment JSONAgentEnrollment
+
+		err := c.BindJSON(&enrollment)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		token, err := settingsService.GetEnrollmentToken()
+		if err != nil {
+			// the collector engine has no error rendering middleware,
+			// the statuses have to be written explicitly
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(enrollment.Token), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the enrollment token is not valid"})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+type JSONAgentError struct {
+	Type       string    `json:"type" binding:"required"`
+	Message    string    `json:"message" binding:"required"`
+	ReportedAt time.Time `json:"reported_at"`
+}
+
+// ApiCreateAgentErrorsHandler godoc
+// @Summary Store the errors an agent reported about itself
+// @Accept json
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Param Body body []JSONAgentError true "Agent errors"
+// @Success 202 {object} []JSONAgentError
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/errors [post]
+func ApiCreateAgentErrorsHandler(agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var jsonErrors []JSONAgentError
+
+		err := c.BindJSON(&jsonErrors)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		agentErrors := make([]*models.AgentError, 0, len(jsonErrors))
+		for _, jsonError := range jsonErrors {
+			reportedAt := jsonError.ReportedAt
+			if reportedAt.IsZero() {
+				reportedAt = time.Now()
+			}
+
+			agentErrors = append(agentErrors, &models.AgentError{
+				Type:       jsonError.Type,
+				Message:    jsonError.Message,
+				ReportedAt: reportedAt,
+			})
+		}
+
+		err = agentsService.StoreErrors(c.Param("id"), agentErrors)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, &jsonErrors)
+	}
+}
+
+// ApiHostAgentErrorsHandler godoc
+// @Summary Get the errors the agent of a host reported about itself
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} []models.AgentError
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/agent-errors [get]
+func ApiHostAgentErrorsHandler(hostsService services.HostsService, agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		agentErrors, err := agentsService.GetErrors(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, agentErrors)
+	}
+}
+
+// ApiAgentsSummaryHandler godoc
+// @Summary Get the fleet-wide status summary of all the registered agents
+// @Produce json
+// @Success 200 {object} []models.AgentSummary
+// @Failure 500 {object} map[string]string
+// @Router /agents/summary [get]
+func ApiAgentsSummaryHandler(agentsService services.AgentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		summary, err := agentsService.GetSummary()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, summary)
+	}
+}
+
+// ApiGetAgentChecksHandler godoc
+// @Summary Get the checks an agent has to execute locally
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} models.AgentChecks
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/checks [get]
+func ApiGetAgentChecksHandler(agentChecksService services.AgentChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		agentChecks, err := agentChecksService.GetPendingChecks(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, agentChecks)
+	}
+}
+
+// ApiCreateAgentChecksResultsHandler godoc
+// @Summary Store the checks results executed locally by an agent
+// @Accept json
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Param Body body JSONAgentChecksResults true "Agent checks results"
+// @Success 202 {object} JSONAgentChecksResults
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/checks/results [post]
+func ApiCreateAgentChecksResultsHandler(agentChecksService services.AgentChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONAgentChecksResults
+
+		err := c.BindJSON(&r)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = agentChecksService.StoreAgentChecksResults(c.Param("id"), r.ExecutionID, r.Results)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, &r)
+	}
+}
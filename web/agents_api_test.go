@@ -0,0 +1,410 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiGetAgentConfig(t *testing.T) {
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("GetConfig", "agent-1").Return(&models.AgentConfig{
+		AgentID: "agent-1",
+		DiscoveryPeriods: map[string]int{
+			"cluster_discovery": 30,
+		},
+		EnabledDiscoveries: []string{"cluster_discovery"},
+		CollectorHost:      "collector.example.com",
+		CollectorPort:      8443,
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/agent-1/config", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"agent_id": "agent-1",
+		"discovery_periods": {"cluster_discovery": 30},
+		"enabled_discoveries": ["cluster_discovery"],
+		"collector_host": "collector.example.com",
+		"collector_port": 8443
+	}`, resp.Body.String())
+}
+
+func TestApiUpdateAgentConfig(t *testing.T) {
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("UpdateConfig", &models.AgentConfig{
+		AgentID: "agent-1",
+		DiscoveryPeriods: map[string]int{
+			"sap_system_discovery": 300,
+		},
+		CollectorHost: "collector.example.com",
+		CollectorPort: 8443,
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{
+		"discovery_periods": {"sap_system_discovery": 300},
+		"collector_host": "collector.example.com",
+		"collector_port": 8443
+	}`)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/agents/agent-1/config", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockAgentsService.AssertExpectations(t)
+}
+
+func TestApiUpdateAgentConfigBadRequest(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.agentsService = new(services.MockAgentsService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/agents/agent-1/config", bytes.NewBuffer([]byte("not-json")))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiGetAgentsUpdateSettings(t *testing.T) {
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("GetUpdateSettings").Return(&models.AgentUpdateSettings{
+		DesiredAgentVersion: "0.9.0",
+		RepositoryURL:       "https://updates.example.com/trento",
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/update-settings", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"desired_agent_version": "0.9.0",
+		"repository_url": "https://updates.example.com/trento"
+	}`, resp.Body.String())
+}
+
+func TestApiUpdateAgentsUpdateSettings(t *testing.T) {
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("SetUpdateSettings", &models.AgentUpdateSettings{
+		DesiredAgentVersion: "0.9.0",
+		RepositoryURL:       "https://updates.example.com/trento",
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{
+		"desired_agent_version": "0.9.0",
+		"repository_url": "https://updates.example.com/trento"
+	}`)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/agents/update-settings", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockAgentsService.AssertExpectations(t)
+}
+
+func TestApiGetEnrollmentToken(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/enrollment-token", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"enrollment_token": "some-enrollment-token"}`, resp.Body.String())
+}
+
+func TestApiEnrollAgent(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/agents/agent-1/enroll",
+		bytes.NewBuffer([]byte(`{"token": "some-enrollment-token"}`)))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+}
+
+func TestApiEnrollAgentInvalidToken(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/agents/agent-1/enroll",
+		bytes.NewBuffer([]byte(`{"token": "a-wrong-token"}`)))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 403, resp.Code)
+}
+
+func TestApiAgentsSummary(t *testing.T) {
+	lastContact := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("GetSummary").Return([]*models.AgentSummary{
+		{
+			AgentID:            "agent-1",
+			Hostname:           "vmhana01",
+			AgentVersion:       "1.2.0",
+			VersionMismatch:    true,
+			LastContact:        &lastContact,
+			ClockSkew:          150,
+			EnabledDiscoveries: []string{"cluster_discovery"},
+			EventsCount:        42,
+			PayloadBytes:       2048,
+			ErrorsCount:        3,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/summary", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[{
+		"agent_id": "agent-1",
+		"hostname": "vmhana01",
+		"agent_version": "1.2.0",
+		"version_mismatch": true,
+		"last_contact": "2022-02-01T10:30:00Z",
+		"clock_skew": 150,
+		"enabled_discoveries": ["cluster_discovery"],
+		"events_count": 42,
+		"payload_bytes": 2048,
+		"errors_count": 3
+	}]`, resp.Body.String())
+}
+
+func TestApiCreateAgentErrors(t *testing.T) {
+	reportedAt := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("StoreErrors", "agent-1", []*models.AgentError{
+		{
+			Type:       models.AgentErrorTypeDiscovery,
+			Message:    "sap_system_discovery failed: permission denied",
+			ReportedAt: reportedAt,
+		},
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`[{
+		"type": "discovery",
+		"message": "sap_system_discovery failed: permission denied",
+		"reported_at": "2022-02-01T10:30:00Z"
+	}]`)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/agents/agent-1/errors", bytes.NewBuffer(body))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+	mockAgentsService.AssertExpectations(t)
+}
+
+func TestApiCreateAgentErrorsBadRequest(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.agentsService = new(services.MockAgentsService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/agents/agent-1/errors", bytes.NewBuffer([]byte(`[{"message": "no type"}]`)))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiHostAgentErrors(t *testing.T) {
+	reportedAt := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "agent-1").Return(&models.Host{ID: "agent-1", Name: "vmhana01"}, nil)
+
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("GetErrors", "agent-1").Return([]*models.AgentError{
+		{
+			Type:       models.AgentErrorTypeExporter,
+			Message:    "node_exporter crashed",
+			ReportedAt: reportedAt,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/agent-1/agent-errors", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[{
+		"type": "exporter",
+		"message": "node_exporter crashed",
+		"reported_at": "2022-02-01T10:30:00Z"
+	}]`, resp.Body.String())
+}
+
+func TestApiHostAgentErrorsNotFound(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "missinghost").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.agentsService = new(services.MockAgentsService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/missinghost/agent-errors", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiGetAgentChecks(t *testing.T) {
+	mockAgentChecksService := new(services.MockAgentChecksService)
+	mockAgentChecksService.On("GetPendingChecks", "agent-1").Return(&models.AgentChecks{
+		ExecutionID: 1,
+		Checks: models.ChecksCatalog{
+			&models.Check{
+				ID:             "check1",
+				Implementation: "test -f /etc/check1",
+			},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.agentChecksService = mockAgentChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/agent-1/checks", nil)
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"execution_id": 1,
+		"checks": [
+			{"id": "check1", "implementation": "test -f /etc/check1", "premium": false}
+		]
+	}`, resp.Body.String())
+}
+
+func TestApiCreateAgentChecksResults(t *testing.T) {
+	mockAgentChecksService := new(services.MockAgentChecksService)
+	mockAgentChecksService.On("StoreAgentChecksResults", "agent-1", int64(1), map[string]*models.Check{
+		"check1": {Result: models.CheckPassing},
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.agentChecksService = mockAgentChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{
+		"execution_id": 1,
+		"results": {"check1": {"result": "passing"}}
+	}`)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/agents/agent-1/checks/results", bytes.NewBuffer(body))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+	mockAgentChecksService.AssertExpectations(t)
+}
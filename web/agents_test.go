@@ -0,0 +1,57 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestAgentsListHandler(t *testing.T) {
+	lastContact := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	mockAgentsService := new(services.MockAgentsService)
+	mockAgentsService.On("GetSummary").Return([]*models.AgentSummary{
+		{
+			AgentID:            "agent-1",
+			Hostname:           "vmhana01",
+			AgentVersion:       "1.2.0",
+			VersionMismatch:    true,
+			LastContact:        &lastContact,
+			ClockSkew:          150,
+			EnabledDiscoveries: []string{"cluster_discovery", "host_discovery"},
+			EventsCount:        42,
+			PayloadBytes:       2048,
+		},
+		{
+			AgentID:  "agent-2",
+			Hostname: "vmhana02",
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.agentsService = mockAgentsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/agents", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "<h1>Agents</h1>")
+	assert.Contains(t, resp.Body.String(), "vmhana01")
+	assert.Contains(t, resp.Body.String(), "1.2.0")
+	assert.Contains(t, resp.Body.String(), "Feb 01, 2022 10:30:00 UTC")
+	assert.Contains(t, resp.Body.String(), "cluster_discovery")
+	// an agent that never sent a heartbeat is still listed
+	assert.Contains(t, resp.Body.String(), "vmhana02")
+	assert.Contains(t, resp.Body.String(), "Never")
+}
@@ -0,0 +1,113 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONAlertRule struct {
+	Name       string   `json:"name" binding:"required"`
+	Condition  string   `json:"condition" binding:"required"`
+	Value      string   `json:"value"`
+	Threshold  int      `json:"threshold"`
+	ResourceID string   `json:"resource_id"`
+	Tags       []string `json:"tags"`
+	Severity   string   `json:"severity"`
+	ForMinutes int      `json:"for_minutes"`
+}
+
+// ApiAlertRulesListHandler godoc
+// @Summary List the user-defined alert rules
+// @Produce json
+// @Success 200 {object} models.AlertRuleList
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules [get]
+func ApiAlertRulesListHandler(alertRulesService services.AlertRulesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rules, err := alertRulesService.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, rules)
+	}
+}
+
+// ApiAlertRuleCreateHandler godoc
+// @Summary Create an alert rule
+// @Accept json
+// @Produce json
+// @Param Body body JSONAlertRule true "The rule to create"
+// @Success 201 {object} models.AlertRule
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /alerts/rules [post]
+func ApiAlertRuleCreateHandler(alertRulesService services.AlertRulesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONAlertRule
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		rule, err := alertRulesService.Create(&models.AlertRule{
+			Name:       r.Name,
+			Condition:  r.Condition,
+			Value:      r.Value,
+			Threshold:  r.Threshold,
+			ResourceID: r.ResourceID,
+			Tags:       r.Tags,
+			Severity:   r.Severity,
+			ForMinutes: r.ForMinutes,
+		})
+		if errors.Is(err, services.ErrInvalidAlertRuleCondition) ||
+			errors.Is(err, services.ErrInvalidAlertRuleThreshold) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, rule)
+	}
+}
+
+// ApiAlertRuleDeleteHandler godoc
+// @Summary Delete an alert rule
+// @Produce json
+// @Param id path int true "Alert rule id"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /alerts/rules/{id} [delete]
+func ApiAlertRuleDeleteHandler(alertRulesService services.AlertRulesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid alert rule id"))
+			return
+		}
+
+		err = alertRulesService.Delete(id)
+		if errors.Is(err, services.ErrAlertRuleNotFound) {
+			_ = c.Error(NotFoundError("could not find alert rule"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
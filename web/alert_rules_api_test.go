@@ -0,0 +1,132 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiAlertRulesListHandler(t *testing.T) {
+	mockAlertRulesService := new(services.MockAlertRulesService)
+	mockAlertRulesService.On("GetAll").Return(models.AlertRuleList{
+		{
+			ID:        1,
+			Name:      "cluster down",
+			Condition: models.AlertRuleConditionClusterHealth,
+			Value:     "critical",
+			Severity:  "critical",
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.alertRulesService = mockAlertRulesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/alerts/rules", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"name":"cluster down"`)
+
+	mockAlertRulesService.AssertExpectations(t)
+}
+
+func TestApiAlertRuleCreateHandler(t *testing.T) {
+	mockAlertRulesService := new(services.MockAlertRulesService)
+	mockAlertRulesService.On("Create", &models.AlertRule{
+		Name:      "quorum at risk",
+		Condition: models.AlertRuleConditionOnlineHostsBelow,
+		Threshold: 2,
+		Tags:      []string{"prod"},
+	}).Return(&models.AlertRule{
+		ID:        1,
+		Name:      "quorum at risk",
+		Condition: models.AlertRuleConditionOnlineHostsBelow,
+		Threshold: 2,
+		Tags:      []string{"prod"},
+		Severity:  "critical",
+		Value:     "critical",
+	}, nil)
+	mockAlertRulesService.On("Create", &models.AlertRule{
+		Name:      "bad",
+		Condition: "cluster_exploded",
+	}).Return(nil, services.ErrInvalidAlertRuleCondition)
+
+	deps := setupTestDependencies()
+	deps.alertRulesService = mockAlertRulesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/alerts/rules",
+		bytes.NewBufferString(`{"name": "quorum at risk", "condition": "online_hosts_below", "threshold": 2, "tags": ["prod"]}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"severity":"critical"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/alerts/rules",
+		bytes.NewBufferString(`{"name": "bad", "condition": "cluster_exploded"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/alerts/rules",
+		bytes.NewBufferString(`{"condition": "cluster_health"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockAlertRulesService.AssertExpectations(t)
+}
+
+func TestApiAlertRuleDeleteHandler(t *testing.T) {
+	mockAlertRulesService := new(services.MockAlertRulesService)
+	mockAlertRulesService.On("Delete", int64(1)).Return(nil)
+	mockAlertRulesService.On("Delete", int64(2)).Return(services.ErrAlertRuleNotFound)
+
+	deps := setupTestDependencies()
+	deps.alertRulesService = mockAlertRulesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/alerts/rules/1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/alerts/rules/2", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/alerts/rules/not_a_number", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockAlertRulesService.AssertExpectations(t)
+}
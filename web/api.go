@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
 )
 
 //go:generate swag init -g api.go -o ../docs/api
@@ -23,3 +25,22 @@ import (
 func ApiPingHandler(c *gin.Context) {
 	c.String(http.StatusOK, "pong")
 }
+
+// ApiLandscapeHealthHandler godoc
+// @Summary Retrieve the aggregated health of the whole landscape
+// @Accept json
+// @Produce json
+// @Success 200 {object} models.LandscapeHealth
+// @Failure 500 {object} map[string]string
+// @Router /health [get]
+func ApiLandscapeHealthHandler(healthSummaryService services.HealthSummaryService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		landscapeHealth, err := healthSummaryService.GetLandscapeHealth()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, landscapeHealth)
+	}
+}
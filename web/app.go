@@ -21,7 +21,10 @@ import (
 
 	trentoDB "github.com/trento-project/trento/internal/db"
 	"github.com/trento-project/trento/internal/grafana"
+	"github.com/trento-project/trento/internal/httpproxy"
 	trentoPrometheus "github.com/trento-project/trento/internal/prometheus"
+	"github.com/trento-project/trento/internal/scc"
+	"github.com/trento-project/trento/internal/vault"
 	"github.com/trento-project/trento/version"
 	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
@@ -45,7 +48,14 @@ var DBTables = []interface{}{
 	&entities.Check{}, &datapipeline.DataCollectedEvent{}, &datapipeline.Subscription{},
 	&entities.HostTelemetry{}, &entities.Cluster{}, &entities.Host{}, &entities.HostHeartbeat{},
 	&entities.SlesSubscription{}, &entities.SAPSystemInstance{}, &entities.ChecksResult{},
-	&entities.HealthState{},
+	&entities.HealthState{}, &entities.RetentionPolicy{}, &entities.Preferences{},
+	&entities.Alert{}, &entities.AgentConfig{}, &entities.AgentError{}, &datapipeline.MalformedEvent{},
+	&entities.ChecksExecution{}, &entities.AgentChecksResult{}, &entities.FactsRequest{},
+	&entities.ClusterConfigSnapshot{}, &entities.PacemakerResource{}, &entities.ClusterVIP{},
+	&entities.FeatureFlag{}, &entities.ResourceEvent{}, &entities.Report{},
+	&entities.User{}, &entities.Landscape{},
+	&entities.CustomFieldDefinition{}, &entities.CustomFieldValue{}, &entities.Note{},
+	&entities.Incident{}, &entities.AlertRule{}, &entities.CheckSelectionTemplate{},
 }
 
 type App struct {
@@ -55,16 +65,87 @@ type App struct {
 }
 
 type Config struct {
-	Host          string
-	Port          int
-	CollectorPort int
-	EnablemTLS    bool
-	Cert          string
-	Key           string
-	CA            string
-	DBConfig      *trentoDB.Config
-	GrafanaConfig *grafana.Config
-	PrometheusURL string
+	Host             string
+	Port             int
+	CollectorPort    int
+	EnablemTLS       bool
+	Cert             string
+	Key              string
+	CA               string
+	DBConfig         *trentoDB.Config
+	GrafanaConfig    *grafana.Config
+	PrometheusURL    string
+	MetricsProvider  string
+	ProjectorWorkers int
+	RunnerWorkers    int
+	// ChecksExecutionMode selects who runs the checks: "ssh" (the embedded
+	// runner connects to the nodes) or "agent" (the agents pull the checks
+	// over the collector channel and run them locally)
+	ChecksExecutionMode string
+	TelemetryExportPath string
+	TelemetryLocalOnly  bool
+	AirGapped           bool
+
+	// SubscriptionValidationURL is the base URL of the SUSE Customer Center
+	// API or a local RMT mirror. When empty, the discovered subscriptions are
+	// not revalidated remotely.
+	SubscriptionValidationURL string
+
+	// MessageQueueURL points to a NATS server where the agents publish their
+	// discovery payloads, as an alternative to the HTTP collector endpoint.
+	// When empty, the message queue ingestion is disabled.
+	MessageQueueURL     string
+	MessageQueueSubject string
+
+	// EventBusURL points to a NATS server where the recorded domain events
+	// are published for downstream integrations. When empty, the events are
+	// only stored locally.
+	EventBusURL     string
+	EventBusSubject string
+
+	// ProxyURL routes all the outbound HTTP calls through a proxy, with
+	// optional per-destination overrides. When empty, the proxy environment
+	// (HTTP_PROXY, HTTPS_PROXY, NO_PROXY) applies.
+	ProxyURL          string
+	TelemetryProxyURL string
+	SCCProxyURL       string
+
+	// EncryptionKey is the externally supplied master secret the sensitive
+	// values at rest are sealed with, e.g. read from a mounted secret file.
+	// When empty, a generated key stored in the settings table is used as a
+	// fallback — which does not protect against an attacker who can read
+	// the whole database, as the key sits next to the sealed values.
+	EncryptionKey string
+
+	// VaultAddress points to a HashiCorp Vault server the connection settings
+	// can reference secrets from. When empty, only literal connection
+	// settings values are supported. The client authenticates either with
+	// VaultToken or with the VaultRoleID/VaultSecretID AppRole pair.
+	VaultAddress  string
+	VaultToken    string
+	VaultRoleID   string
+	VaultSecretID string
+
+	// AlertingPagerDutyKey is a PagerDuty Events API v2 routing key the
+	// stored alerts are pushed to, with automatic resolution when they
+	// clear. When empty, the PagerDuty channel is disabled.
+	AlertingPagerDutyKey        string
+	AlertingPagerDutySeverities []string
+
+	// AlertingOpsgenieAPIKey enables the Opsgenie alert channel, with the
+	// base URL selecting the region. When empty, the channel is disabled.
+	AlertingOpsgenieAPIKey     string
+	AlertingOpsgenieURL        string
+	AlertingOpsgenieSeverities []string
+
+	// AlertingGroupWindow suppresses further notifications about the same
+	// resource within the window. Zero disables the grouping.
+	AlertingGroupWindow time.Duration
+
+	// The escalation delays hold the per-channel notifications back until
+	// an alert stayed firing that long. Zero notifies immediately.
+	AlertingPagerDutyEscalateAfter time.Duration
+	AlertingOpsgenieEscalateAfter  time.Duration
 }
 
 type Dependencies struct {
@@ -85,6 +166,33 @@ type Dependencies struct {
 	telemetryPublisher      telemetry.Publisher
 	premiumDetectionService services.PremiumDetectionService
 	prometheusService       services.PrometheusService
+	retentionService        services.RetentionService
+	searchService           services.SearchService
+	preferencesService      services.PreferencesService
+	alertsService           services.AlertsService
+	agentsService           services.AgentsService
+	connectionsService      services.ConnectionsService
+	runnerService           services.RunnerService
+	agentChecksService      services.AgentChecksService
+	factsService            services.FactsService
+	clusterConfigsService   services.ClusterConfigsService
+	runtimeSettingsService  services.RuntimeSettingsService
+	featureFlagsService     services.FeatureFlagsService
+	eventsService           services.EventsService
+	reportsService          services.ReportsService
+	usersService            services.UsersService
+	landscapesService       services.LandscapesService
+	customFieldsService     services.CustomFieldsService
+	notesService            services.NotesService
+	incidentsService        services.IncidentsService
+	alertRulesService       services.AlertRulesService
+	checkTemplatesService   services.CheckTemplatesService
+	fencingService          services.FencingService
+	setupService            services.SetupService
+	gcService               services.GarbageCollectionService
+	clusterIdentityService  services.ClusterIdentityService
+	trashService            services.TrashService
+	alertRouter             *services.AlertRouter
 }
 
 func DefaultDependencies(ctx context.Context, config *Config) Dependencies {
@@ -104,6 +212,10 @@ func DefaultDependencies(ctx context.Context, config *Config) Dependencies {
 		log.Fatalf("failed to migrate database: %s", err)
 	}
 
+	if config.GrafanaConfig.PrometheusURL == "" {
+		config.GrafanaConfig.PrometheusURL = config.PrometheusURL
+	}
+
 	if err := grafana.InitGrafana(ctx, config.GrafanaConfig); err != nil {
 		log.Warnf("failed initialazing grafana: %s", err)
 	}
@@ -114,28 +226,140 @@ func DefaultDependencies(ctx context.Context, config *Config) Dependencies {
 	}
 
 	projectorRegistry := datapipeline.InitProjectorsRegistry(db)
-	projectorWorkersPool := datapipeline.NewProjectorsWorkerPool(projectorRegistry)
+	projectorWorkersPool := datapipeline.NewProjectorsWorkerPool(projectorRegistry, config.ProjectorWorkers)
+
+	listCache := services.NewListCache()
+	projectorWorkersPool.AddListener(func(event *datapipeline.DataCollectedEvent) {
+		listCache.Clear()
+	})
 
 	prometheusService := services.NewPrometheusService(db, prom)
-	settingsService := services.NewSettingsService(db)
-	tagsService := services.NewTagsService(db)
-	subscriptionsService := services.NewSubscriptionsService(db)
-	hostsService := services.NewHostsService(db, prometheusService)
+	settingsService := services.NewSettingsServiceWithEncryptionKey(db, config.EncryptionKey)
+	var eventBusPublisher services.EventBusPublisher
+	if config.EventBusURL != "" {
+		eventBusPublisher, err = services.NewNATSEventBusPublisher(config.EventBusURL, config.EventBusSubject)
+		if err != nil {
+			log.Fatalf("failed connecting to the event bus: %s", err)
+		}
+	}
+	eventsService := services.NewEventsService(db, eventBusPublisher)
+	trashService := services.NewTrashService(db)
+	tagsService := services.NewTagsService(db, eventsService, trashService)
+	var subscriptionValidator services.SubscriptionValidator
+	if config.SubscriptionValidationURL != "" && !config.AirGapped {
+		subscriptionValidator = scc.NewClient(config.SubscriptionValidationURL,
+			httpproxy.NewClient(destinationProxyURL(config.SCCProxyURL, config.ProxyURL)))
+	}
+	subscriptionsService := services.NewSubscriptionsService(db, subscriptionValidator)
+	hostsService := services.NewHostsService(db, prometheusService, listCache, eventsService)
 	sapSystemsService := services.NewSAPSystemsService(db)
 	premiumDetection := services.NewPremiumDetectionService(version.Flavor, subscriptionsService, settingsService)
-	checksService := services.NewChecksService(db, premiumDetection)
-	clustersService := services.NewClustersService(db, checksService)
+	var alertRoutes []services.ChannelRoute
+	if config.AlertingPagerDutyKey != "" && !config.AirGapped {
+		alertRoutes = append(alertRoutes, services.ChannelRoute{
+			Channel: services.NewPagerDutyChannel(services.PagerDutyEventsURL,
+				config.AlertingPagerDutyKey, httpproxy.NewClient(config.ProxyURL)),
+			Severities:    config.AlertingPagerDutySeverities,
+			GroupWindow:   config.AlertingGroupWindow,
+			EscalateAfter: config.AlertingPagerDutyEscalateAfter,
+		})
+	}
+	if config.AlertingOpsgenieAPIKey != "" && !config.AirGapped {
+		opsgenieURL := config.AlertingOpsgenieURL
+		if opsgenieURL == "" {
+			opsgenieURL = services.OpsgenieAPIURL
+		}
+		alertRoutes = append(alertRoutes, services.ChannelRoute{
+			Channel: services.NewOpsgenieChannel(opsgenieURL,
+				config.AlertingOpsgenieAPIKey, httpproxy.NewClient(config.ProxyURL)),
+			Severities:    config.AlertingOpsgenieSeverities,
+			GroupWindow:   config.AlertingGroupWindow,
+			EscalateAfter: config.AlertingOpsgenieEscalateAfter,
+		})
+	}
+	var alertRouter *services.AlertRouter
+	if len(alertRoutes) > 0 {
+		alertRouter = services.NewAlertRouter(alertRoutes...)
+	}
+	alertsService := services.NewAlertsService(db, alertRouter)
+	checksService := services.NewChecksService(db, premiumDetection, alertsService, eventsService, settingsService, trashService)
+	clustersService := services.NewClustersService(db, checksService, listCache)
 	collectorService := services.NewCollectorService(db, projectorWorkersPool.GetChannel())
 	telemetryRegistry := telemetry.NewTelemetryRegistry(db)
-	telemetryPublisher := telemetry.NewTelemetryPublisher()
-	healthSummaryService := services.NewHealthSummaryService(sapSystemsService, clustersService, hostsService)
+	var telemetryPublisher telemetry.Publisher = telemetry.NewTelemetryPublisher(
+		httpproxy.NewClient(destinationProxyURL(config.TelemetryProxyURL, config.ProxyURL)))
+	if config.AirGapped {
+		log.Info("Air-gapped mode enabled: telemetry will not be published to the collection service")
+		telemetryPublisher = telemetry.NewNoopPublisher()
+	}
+	if config.TelemetryExportPath != "" {
+		filePublisher := telemetry.NewFilePublisher(config.TelemetryExportPath)
+		if config.TelemetryLocalOnly {
+			telemetryPublisher = filePublisher
+		} else {
+			telemetryPublisher = telemetry.NewMultiPublisher(telemetryPublisher, filePublisher)
+		}
+	}
+	healthSummaryService := services.NewHealthSummaryService(sapSystemsService, clustersService, hostsService, settingsService, listCache)
+	retentionService := services.NewRetentionService(db)
+	searchService := services.NewSearchService(db)
+	preferencesService := services.NewPreferencesService(db)
+	agentsService := services.NewAgentsService(db)
+	connectionsService := services.NewConnectionsService(db, services.NewSSHConnectionProbe())
+	var vaultClient vault.Client
+	if config.VaultAddress != "" {
+		var err error
+		vaultClient, err = vault.NewClient(vault.Config{
+			Address:  config.VaultAddress,
+			Token:    config.VaultToken,
+			RoleID:   config.VaultRoleID,
+			SecretID: config.VaultSecretID,
+		}, httpproxy.NewClient(""))
+		if err != nil {
+			log.Fatalf("Error while creating the vault client: %s", err)
+		}
+	}
+	runnerService := services.NewRunnerService(db, checksService, services.NewSSHRemoteExecutor(), vaultClient, config.RunnerWorkers)
+	agentChecksService := services.NewAgentChecksService(db, checksService)
+	factsService := services.NewFactsService(db)
+	clusterConfigsService := services.NewClusterConfigsService(db)
+	runtimeSettingsService := services.NewRuntimeSettingsService()
+	featureFlagsService := services.NewFeatureFlagsService(db)
+	reportsService := services.NewReportsService(db, hostsService, clustersService, sapSystemsService,
+		healthSummaryService, checksService, subscriptionsService)
+	usersService := services.NewUsersService(db)
+	landscapesService := services.NewLandscapesService(db, hostsService, clustersService, sapSystemsService)
+	customFieldsService := services.NewCustomFieldsService(db)
+	notesService := services.NewNotesService(db, eventsService)
+	incidentsService := services.NewIncidentsService(db)
+	alertRulesService := services.NewAlertRulesService(db, hostsService, clustersService, alertsService)
+	checkTemplatesService := services.NewCheckTemplatesService(db, checksService)
+	fencingService := services.NewFencingService(db, checksService, services.NewSSHRemoteExecutor())
+	setupService := services.NewSetupService(db, usersService, settingsService)
+	gcService := services.NewGarbageCollectionService(db, trashService)
+	clusterIdentityService := services.NewClusterIdentityService(db)
 
 	return Dependencies{
 		webEngine, collectorEngine, store, projectorWorkersPool,
 		checksService, subscriptionsService, tagsService,
 		collectorService, sapSystemsService, clustersService, hostsService, settingsService, healthSummaryService,
 		telemetryRegistry, telemetryPublisher, premiumDetection, prometheusService,
+		retentionService, searchService, preferencesService, alertsService, agentsService, connectionsService,
+		runnerService, agentChecksService, factsService, clusterConfigsService,
+		runtimeSettingsService, featureFlagsService, eventsService, reportsService, usersService,
+		landscapesService, customFieldsService, notesService, incidentsService, alertRulesService,
+		checkTemplatesService, fencingService, setupService, gcService, clusterIdentityService, trashService, alertRouter,
+	}
+}
+
+// destinationProxyURL picks the per-destination proxy override, falling back
+// to the global one
+func destinationProxyURL(destinationURL, defaultURL string) string {
+	if destinationURL != "" {
+		return destinationURL
 	}
+
+	return defaultURL
 }
 
 func NewNamedEngine(instance string) *gin.Engine {
@@ -179,33 +403,114 @@ func NewAppWithDeps(config *Config, deps Dependencies) (*App, error) {
 	webEngine.HTMLRender = NewLayoutRender(templatesFS, "templates/*.tmpl")
 	webEngine.Use(ErrorHandler)
 	webEngine.Use(sessions.Sessions("session", deps.store))
+	webEngine.Use(CSRFMiddleware())
+	webEngine.Use(LocaleMiddleware())
+	webEngine.Use(TagScopeMiddleware(deps.usersService))
 	webEngine.StaticFS("/static", http.FS(assetsFS))
 	webEngine.Use(EulaMiddleware(deps.premiumDetectionService))
 	webEngine.GET("/", HomeHandler)
-	webEngine.GET("/about", NewAboutHandler(deps.subscriptionsService))
+	webEngine.GET("/about", NewAboutHandler(deps.subscriptionsService, deps.settingsService))
+	webEngine.POST("/toggle-telemetry", TelemetryToggleHandler(deps.settingsService))
 	webEngine.GET("/eula", EulaShowHandler())
 	webEngine.POST("/accept-eula", EulaAcceptHandler(deps.settingsService))
+	webEngine.GET("/agents", NewAgentsListHandler(deps.agentsService, deps.preferencesService))
 	webEngine.GET("/hosts", NewHostListHandler(deps.hostsService))
-	webEngine.GET("/hosts/:id", NewHostHandler(deps.hostsService, deps.subscriptionsService, config.GrafanaConfig.BaseUrl()))
+	metricsProvider := NewMetricsProvider(config.MetricsProvider, config.GrafanaConfig.BaseUrl(), config.PrometheusURL)
+	webEngine.GET("/hosts/:id", NewHostHandler(deps.hostsService, deps.subscriptionsService, deps.alertsService, metricsProvider))
 	webEngine.GET("/catalog", NewChecksCatalogHandler(deps.checksService))
 	webEngine.GET("/clusters", NewClusterListHandler(deps.clustersService))
-	webEngine.GET("/clusters/:id", NewClusterHandler(deps.clustersService))
+	webEngine.GET("/clusters/:id", NewClusterHandler(deps.clustersService, deps.alertsService, deps.checksService, deps.preferencesService))
 	webEngine.GET("/sapsystems", NewSAPSystemListHandler(deps.sapSystemsService))
 	webEngine.GET("/sapsystems/:id", NewSAPResourceHandler(deps.hostsService, deps.sapSystemsService))
 	webEngine.GET("/databases", NewHANADatabaseListHandler(deps.sapSystemsService))
 	webEngine.GET("/databases/:id", NewSAPResourceHandler(deps.hostsService, deps.sapSystemsService))
 
+	graphQLSchema, err := NewGraphQLSchema(deps.hostsService, deps.clustersService,
+		deps.sapSystemsService, deps.checksService)
+	if err != nil {
+		return nil, err
+	}
+
 	apiGroup := webEngine.Group("/api")
 	{
 		apiGroup.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 		apiGroup.GET("/ping", ApiPingHandler)
+		apiGroup.POST("/graphql", ApiGraphQLHandler(graphQLSchema))
+		apiGroup.GET("/health", ApiLandscapeHealthHandler(deps.healthSummaryService))
 		apiGroup.GET("/tags", ApiListTag(deps.tagsService))
 		apiGroup.POST("/hosts/:id/tags", ApiHostCreateTagHandler(deps.hostsService, deps.tagsService))
 		apiGroup.DELETE("/hosts/:id/tags/:tag", ApiHostDeleteTagHandler(deps.hostsService, deps.tagsService))
+		apiGroup.GET("/hosts", ApiHostsListHandler(deps.hostsService, deps.subscriptionsService))
+		apiGroup.GET("/hosts/packages", ApiHostsPackagesHandler(deps.hostsService))
+		apiGroup.GET("/hosts/:id", ApiHostByIdHandler(deps.hostsService, deps.subscriptionsService))
+		apiGroup.DELETE("/hosts/:id", ApiDeleteHostHandler(deps.hostsService, deps.gcService))
+		apiGroup.GET("/hosts/:id/exporters", ApiHostExportersStatusHandler(deps.hostsService))
+		apiGroup.GET("/hosts/:id/compliance", ApiHostComplianceHandler(deps.hostsService))
+		apiGroup.GET("/hosts/:id/results", ApiHostCheckResultsHandler(deps.hostsService, deps.checksService))
+		apiGroup.GET("/hosts/:id/agent-errors", ApiHostAgentErrorsHandler(deps.hostsService, deps.agentsService))
+		apiGroup.GET("/hosts/:id/events", ApiHostEventsHandler(deps.hostsService, deps.eventsService))
+		apiGroup.GET("/subscriptions/expiring", ApiExpiringSubscriptionsHandler(deps.subscriptionsService))
+		apiGroup.POST("/reports", ApiCreateReportHandler(deps.reportsService))
+		apiGroup.GET("/reports", ApiReportsListHandler(deps.reportsService))
+		apiGroup.GET("/reports/:id/download", ApiReportDownloadHandler(deps.reportsService))
+		apiGroup.GET("/users", ApiUsersListHandler(deps.usersService))
+		apiGroup.POST("/users", ApiUserCreateHandler(deps.usersService))
+		apiGroup.GET("/users/:username", ApiUserGetHandler(deps.usersService))
+		apiGroup.PUT("/users/:username", ApiUserUpdateHandler(deps.usersService))
+		apiGroup.DELETE("/users/:username", ApiUserDeleteHandler(deps.usersService))
+		apiGroup.GET("/setup", ApiSetupStatusHandler(deps.setupService))
+		apiGroup.POST("/setup/:step", ApiSetupCompleteStepHandler(deps.setupService))
+		apiGroup.GET("/landscapes", ApiLandscapesListHandler(deps.landscapesService))
+		apiGroup.GET("/landscapes/:sid", ApiLandscapeGetHandler(deps.landscapesService))
+		apiGroup.PUT("/landscapes/:sid/position", ApiLandscapeSetPositionHandler(deps.landscapesService))
+		apiGroup.GET("/custom-fields/:resource_type", ApiCustomFieldsDefinitionsHandler(deps.customFieldsService))
+		apiGroup.POST("/custom-fields/:resource_type", ApiCustomFieldDefinitionCreateHandler(deps.customFieldsService))
+		apiGroup.DELETE("/custom-fields/:resource_type/:name", ApiCustomFieldDefinitionDeleteHandler(deps.customFieldsService))
+		apiGroup.GET("/hosts/:id/fields", ApiResourceFieldsHandler(models.TagHostResourceType, deps.customFieldsService))
+		apiGroup.PUT("/hosts/:id/fields/:name", ApiResourceFieldSetHandler(models.TagHostResourceType, deps.customFieldsService))
+		apiGroup.DELETE("/hosts/:id/fields/:name", ApiResourceFieldDeleteHandler(models.TagHostResourceType, deps.customFieldsService))
+		apiGroup.GET("/clusters", ApiClustersListHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/fields", ApiResourceFieldsHandler(models.TagClusterResourceType, deps.customFieldsService))
+		apiGroup.PUT("/clusters/:id/fields/:name", ApiResourceFieldSetHandler(models.TagClusterResourceType, deps.customFieldsService))
+		apiGroup.DELETE("/clusters/:id/fields/:name", ApiResourceFieldDeleteHandler(models.TagClusterResourceType, deps.customFieldsService))
+		apiGroup.GET("/sapsystems/:id/fields", ApiResourceFieldsHandler(models.TagSAPSystemResourceType, deps.customFieldsService))
+		apiGroup.PUT("/sapsystems/:id/fields/:name", ApiResourceFieldSetHandler(models.TagSAPSystemResourceType, deps.customFieldsService))
+		apiGroup.DELETE("/sapsystems/:id/fields/:name", ApiResourceFieldDeleteHandler(models.TagSAPSystemResourceType, deps.customFieldsService))
+		apiGroup.GET("/databases/:id/fields", ApiResourceFieldsHandler(models.TagDatabaseResourceType, deps.customFieldsService))
+		apiGroup.PUT("/databases/:id/fields/:name", ApiResourceFieldSetHandler(models.TagDatabaseResourceType, deps.customFieldsService))
+		apiGroup.DELETE("/databases/:id/fields/:name", ApiResourceFieldDeleteHandler(models.TagDatabaseResourceType, deps.customFieldsService))
+		apiGroup.GET("/hosts/:id/notes", ApiResourceNotesHandler(models.TagHostResourceType, deps.notesService))
+		apiGroup.POST("/hosts/:id/notes", ApiHostCreateNoteHandler(deps.hostsService, deps.notesService))
+		apiGroup.GET("/clusters/:cluster_id/notes", ApiResourceNotesHandler(models.TagClusterResourceType, deps.notesService))
+		apiGroup.POST("/clusters/:id/notes", ApiClusterCreateNoteHandler(deps.clustersService, deps.notesService))
+		apiGroup.GET("/sapsystems/:id/notes", ApiResourceNotesHandler(models.TagSAPSystemResourceType, deps.notesService))
+		apiGroup.POST("/sapsystems/:id/notes", ApiSAPSystemCreateNoteHandler(deps.sapSystemsService, deps.notesService))
+		apiGroup.DELETE("/notes/:id", ApiNoteDeleteHandler(deps.notesService))
+		apiGroup.GET("/incidents", ApiIncidentsListHandler(deps.incidentsService))
+		apiGroup.GET("/alerts/rules", ApiAlertRulesListHandler(deps.alertRulesService))
+		apiGroup.POST("/alerts/rules", ApiAlertRuleCreateHandler(deps.alertRulesService))
+		apiGroup.DELETE("/alerts/rules/:id", ApiAlertRuleDeleteHandler(deps.alertRulesService))
+		apiGroup.GET("/checks/templates", ApiCheckTemplatesListHandler(deps.checkTemplatesService))
+		apiGroup.POST("/checks/templates", ApiCheckTemplateCreateHandler(deps.checkTemplatesService))
+		apiGroup.DELETE("/checks/templates/:id", ApiCheckTemplateDeleteHandler(deps.checkTemplatesService))
+		apiGroup.GET("/checks/templates/divergence", ApiCheckTemplatesDivergenceHandler(deps.checkTemplatesService))
+		apiGroup.GET("/agents/outdated", ApiOutdatedAgentsHandler(deps.hostsService))
+		apiGroup.GET("/agents/summary", ApiAgentsSummaryHandler(deps.agentsService))
+		apiGroup.GET("/agents/enrollment-token", ApiGetEnrollmentTokenHandler(deps.settingsService))
+		apiGroup.GET("/agents/update-settings", ApiGetAgentsUpdateSettingsHandler(deps.agentsService))
+		apiGroup.PUT("/agents/update-settings", ApiUpdateAgentsUpdateSettingsHandler(deps.agentsService))
+		apiGroup.GET("/agents/:id/config", ApiGetAgentConfigHandler(deps.agentsService, config.ChecksExecutionMode))
+		apiGroup.PUT("/agents/:id/config", ApiUpdateAgentConfigHandler(deps.agentsService))
 		apiGroup.POST("/clusters/:id/tags", ApiClusterCreateTagHandler(deps.clustersService, deps.tagsService))
 		apiGroup.DELETE("/clusters/:id/tags/:tag", ApiClusterDeleteTagHandler(deps.clustersService, deps.tagsService))
 		apiGroup.GET("/clusters/:cluster_id/results", ApiClusterCheckResultsHandler(deps.checksService))
+		apiGroup.GET("/clusters/:cluster_id/results/diff", ApiClusterCheckResultsDiffHandler(deps.checksService))
 		apiGroup.GET("/clusters/settings", ApiGetClustersSettingsHandler(deps.clustersService))
+		apiGroup.PUT("/clusters/settings", ApiBulkUpdateClustersSettingsHandler(deps.clustersService))
+		apiGroup.GET("/clusters/rebuilds", ApiClusterRebuildsHandler(deps.clusterIdentityService))
+		apiGroup.POST("/clusters/merge", ApiClusterMergeHandler(deps.clusterIdentityService))
+		apiGroup.GET("/sapsystems/:id", ApiSAPSystemByIdHandler(deps.sapSystemsService))
+		apiGroup.GET("/sapsystems/:id/topology", ApiSAPSystemTopologyHandler(deps.sapSystemsService))
 		apiGroup.POST("/sapsystems/:id/tags", ApiSAPSystemCreateTagHandler(deps.sapSystemsService, deps.tagsService))
 		apiGroup.DELETE("/sapsystems/:id/tags/:tag", ApiSAPSystemDeleteTagHandler(deps.sapSystemsService, deps.tagsService))
 		apiGroup.GET("/sapsystems/health", ApiSAPSystemsHealthSummaryHandler(deps.healthSummaryService))
@@ -213,15 +518,59 @@ func NewAppWithDeps(config *Config, deps Dependencies) (*App, error) {
 		apiGroup.DELETE("/databases/:id/tags/:tag", ApiDatabaseDeleteTagHandler(deps.sapSystemsService, deps.tagsService))
 		apiGroup.GET("/checks/:id/settings", ApiCheckGetSettingsByIdHandler(deps.clustersService))
 		apiGroup.POST("/checks/:id/settings", ApiCheckCreateSettingsByIdHandler(deps.checksService))
+		apiGroup.POST("/checks/:id/settings/validate", ApiValidateConnectionSettingsHandler(deps.connectionsService))
+		apiGroup.POST("/clusters/:id/checks/execute", ApiRequestChecksExecutionHandler(deps.checksService))
+		apiGroup.POST("/clusters/:id/fencing/validate", ApiClusterValidateFencingHandler(deps.fencingService))
+		apiGroup.GET("/executions/:id", ApiChecksExecutionHandler(deps.checksService))
+		apiGroup.GET("/clusters/:cluster_id/executions", ApiClusterChecksExecutionsHandler(deps.checksService))
+		apiGroup.GET("/clusters/:cluster_id/resources", ApiClusterResourcesHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/vips", ApiClusterVIPsHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/freshness", ApiClusterFreshnessHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/events", ApiClusterEventsHandler(deps.clustersService, deps.eventsService))
+		apiGroup.GET("/clusters/:cluster_id/readiness", ApiClusterReadinessHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/checks/recommended", ApiClusterRecommendedChecksHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/drift", ApiClusterDriftHandler(deps.clustersService))
+		apiGroup.GET("/clusters/:cluster_id/cib", ApiClusterConfigSnapshotHandler(deps.clusterConfigsService))
+		apiGroup.GET("/clusters/:cluster_id/cib/diff", ApiClusterConfigDiffHandler(deps.clusterConfigsService))
+		apiGroup.GET("/facts/probes", ApiFactsProbesListHandler(deps.factsService))
+		apiGroup.GET("/facts/:id", ApiFactsRequestHandler(deps.factsService))
+		apiGroup.POST("/hosts/:id/facts", ApiRequestFactsGatheringHandler(deps.hostsService, deps.factsService))
 		apiGroup.PUT("/checks/catalog", ApiCreateChecksCatalogHandler(deps.checksService))
 		apiGroup.GET("/checks/catalog", ApiChecksCatalogHandler(deps.checksService))
 		apiGroup.POST("/checks/:id/results", ApiCreateChecksResultHandler(deps.checksService))
 		apiGroup.GET("/prometheus/targets", ApiGetPrometheusHttpSdTargets(deps.prometheusService))
+		apiGroup.POST("/prometheus/alerts", FeatureFlagMiddleware(deps.featureFlagsService, models.FeatureAlerting), ApiAlertmanagerWebhookHandler(deps.alertsService))
+		apiGroup.GET("/settings/grafana", ApiGrafanaSettingsHandler())
+		apiGroup.GET("/settings", ApiGetSettingsHandler(deps.settingsService, deps.premiumDetectionService, config.GrafanaConfig.PublicURL, config.AirGapped))
+		apiGroup.GET("/settings/telemetry/preview", ApiTelemetryPreviewHandler(telemetry.NewEngine(
+			app.InstallationID, deps.telemetryPublisher, deps.telemetryRegistry, deps.premiumDetectionService)))
+		apiGroup.PUT("/settings", ApiUpdateSettingsHandler(deps.settingsService, deps.premiumDetectionService, config.GrafanaConfig.PublicURL, config.AirGapped))
+		apiGroup.GET("/settings/features", ApiGetFeaturesHandler(deps.featureFlagsService))
+		apiGroup.PUT("/settings/features/:name", ApiUpdateFeatureHandler(deps.featureFlagsService))
+		apiGroup.GET("/settings/retention", ApiGetRetentionSettingsHandler(deps.retentionService))
+		apiGroup.PUT("/settings/retention", ApiUpdateRetentionSettingsHandler(deps.retentionService))
+		apiGroup.GET("/search", ApiSearchHandler(deps.searchService))
+		apiGroup.GET("/preferences", ApiGetPreferencesHandler(deps.preferencesService))
+		apiGroup.PUT("/preferences", ApiUpdatePreferencesHandler(deps.preferencesService))
+		apiGroup.POST("/internal/projections/:name/rebuild", ApiProjectionRebuildHandler(deps.collectorService))
+		apiGroup.GET("/internal/gc", ApiGarbageCollectionReportHandler(deps.gcService))
+		apiGroup.POST("/internal/gc", ApiGarbageCollectionRunHandler(deps.gcService))
+		apiGroup.GET("/trash", ApiTrashListHandler(deps.trashService))
+		apiGroup.POST("/trash/:type/:id/restore", ApiTrashRestoreHandler(deps.trashService))
+		apiGroup.PUT("/internal/loglevel", ApiUpdateLogLevelHandler(deps.runtimeSettingsService))
+		apiGroup.GET("/internal/runtime", ApiRuntimeSettingsHandler(deps.runtimeSettingsService))
 	}
 
 	collectorEngine := deps.collectorEngine
 	collectorEngine.POST("/api/collect", ApiCollectDataHandler(deps.collectorService))
 	collectorEngine.POST("/api/hosts/:id/heartbeat", ApiHostHeartbeatHandler(deps.hostsService))
+	collectorEngine.GET("/api/agents/:id/config", ApiGetAgentConfigHandler(deps.agentsService, config.ChecksExecutionMode))
+	collectorEngine.POST("/api/agents/:id/enroll", ApiEnrollAgentHandler(deps.settingsService))
+	collectorEngine.POST("/api/agents/:id/errors", ApiCreateAgentErrorsHandler(deps.agentsService))
+	collectorEngine.GET("/api/agents/:id/checks", ApiGetAgentChecksHandler(deps.agentChecksService))
+	collectorEngine.POST("/api/agents/:id/checks/results", ApiCreateAgentChecksResultsHandler(deps.agentChecksService))
+	collectorEngine.GET("/api/agents/:id/facts", ApiGetAgentFactsRequestsHandler(deps.factsService))
+	collectorEngine.POST("/api/agents/:id/facts/:fact_id", ApiCreateAgentFactsOutputHandler(deps.factsService))
 	collectorEngine.GET("/api/ping", ApiPingHandler)
 
 	return app, nil
@@ -297,6 +646,55 @@ func (a *App) Start(ctx context.Context) error {
 		return nil
 	})
 
+	if a.config.ChecksExecutionMode == "agent" {
+		log.Info("Agent-executed checks mode enabled, the embedded SSH runner stays idle")
+	} else if !a.Dependencies.featureFlagsService.IsEnabled(models.FeatureEmbeddedRunner) {
+		log.Infof("The %s feature is disabled, the embedded SSH runner stays idle", models.FeatureEmbeddedRunner)
+	} else {
+		g.Go(func() error {
+			a.Dependencies.runnerService.Run(ctx)
+			return nil
+		})
+	}
+
+	if a.config.MessageQueueURL != "" {
+		g.Go(func() error {
+			return StartMessageQueueConsumer(ctx, a.config.MessageQueueURL, a.config.MessageQueueSubject, a.Dependencies.collectorService)
+		})
+	}
+
+	g.Go(func() error {
+		a.startGarbageCollector(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		a.startRetentionPruner(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		a.startEventsCompaction(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		a.startAlertRulesEvaluator(ctx)
+		return nil
+	})
+
+	g.Go(func() error {
+		a.startCheckTemplatesApplier(ctx)
+		return nil
+	})
+
+	if a.alertRouter != nil {
+		g.Go(func() error {
+			a.startAlertEscalations(ctx)
+			return nil
+		})
+	}
+
 	go func() {
 		<-ctx.Done()
 		log.Info("Web server is shutting down.")
@@ -308,6 +706,131 @@ func (a *App) Start(ctx context.Context) error {
 	return g.Wait()
 }
 
+const garbageCollectionInterval = 6 * time.Hour
+
+// startGarbageCollector periodically removes the orphaned records left behind
+// by projector bugs or partial host removals
+func (a *App) startGarbageCollector(ctx context.Context) {
+	ticker := time.NewTicker(garbageCollectionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := a.gcService.Collect(); err != nil {
+				log.Errorf("failed to collect orphaned records: %s", err)
+			}
+			if _, err := a.trashService.PurgeExpired(); err != nil {
+				log.Errorf("failed to purge the expired trash entries: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const retentionPrunerInterval = 1 * time.Hour
+
+// startRetentionPruner periodically removes the rows that outlived the
+// configured retention policies
+func (a *App) startRetentionPruner(ctx context.Context) {
+	ticker := time.NewTicker(retentionPrunerInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.retentionService.PruneExpired(); err != nil {
+			log.Errorf("failed to prune expired rows: %s", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const alertRulesEvaluationInterval = 1 * time.Minute
+
+// startAlertRulesEvaluator periodically evaluates the user-defined alert
+// rules against the projected data
+func (a *App) startAlertRulesEvaluator(ctx context.Context) {
+	ticker := time.NewTicker(alertRulesEvaluationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.alertRulesService.Evaluate(); err != nil {
+				log.Errorf("failed to evaluate the alert rules: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const checkTemplatesApplyInterval = 1 * time.Minute
+
+// startCheckTemplatesApplier periodically applies the check selection
+// templates to the newly discovered clusters matching their criteria
+func (a *App) startCheckTemplatesApplier(ctx context.Context) {
+	ticker := time.NewTicker(checkTemplatesApplyInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := a.checkTemplatesService.Apply(); err != nil {
+				log.Errorf("failed to apply the check selection templates: %s", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const alertEscalationInterval = 1 * time.Minute
+
+// startAlertEscalations periodically pushes the still-firing alerts to the
+// channels configured with an escalation delay
+func (a *App) startAlertEscalations(ctx context.Context) {
+	ticker := time.NewTicker(alertEscalationInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.alertRouter.CheckEscalations()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+const eventsCompactionInterval = 6 * time.Hour
+
+// startEventsCompaction periodically discards the already-projected historical
+// events, keeping the latest event per agent and discovery type as a snapshot
+func (a *App) startEventsCompaction(ctx context.Context) {
+	ticker := time.NewTicker(eventsCompactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			compacted, err := a.collectorService.CompactEvents()
+			if err != nil {
+				log.Errorf("failed to compact events: %s", err)
+				continue
+			}
+			log.Debugf("Compacted %d events", compacted)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func getTLSConfig(cert string, key string, ca string) (*tls.Config, error) {
 	caCert, err := ioutil.ReadFile(ca)
 	if err != nil {
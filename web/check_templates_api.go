@@ -0,0 +1,120 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONCheckSelectionTemplate struct {
+	Name           string   `json:"name" binding:"required"`
+	Provider       string   `json:"provider"`
+	ClusterType    string   `json:"cluster_type"`
+	Tags           []string `json:"tags"`
+	SelectedChecks []string `json:"selected_checks" binding:"required"`
+}
+
+// ApiCheckTemplatesListHandler godoc
+// @Summary List the check selection templates
+// @Produce json
+// @Success 200 {object} []models.CheckSelectionTemplate
+// @Failure 500 {object} map[string]string
+// @Router /checks/templates [get]
+func ApiCheckTemplatesListHandler(checkTemplatesService services.CheckTemplatesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		templates, err := checkTemplatesService.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, templates)
+	}
+}
+
+// ApiCheckTemplateCreateHandler godoc
+// @Summary Create a check selection template
+// @Accept json
+// @Produce json
+// @Param Body body JSONCheckSelectionTemplate true "The template to create"
+// @Success 201 {object} models.CheckSelectionTemplate
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /checks/templates [post]
+func ApiCheckTemplateCreateHandler(checkTemplatesService services.CheckTemplatesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var t JSONCheckSelectionTemplate
+
+		if err := c.BindJSON(&t); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		template := &models.CheckSelectionTemplate{
+			Name:           t.Name,
+			Provider:       t.Provider,
+			ClusterType:    t.ClusterType,
+			Tags:           t.Tags,
+			SelectedChecks: t.SelectedChecks,
+		}
+		if err := checkTemplatesService.Create(template); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, template)
+	}
+}
+
+// ApiCheckTemplateDeleteHandler godoc
+// @Summary Delete a check selection template
+// @Produce json
+// @Param id path int true "Template id"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /checks/templates/{id} [delete]
+func ApiCheckTemplateDeleteHandler(checkTemplatesService services.CheckTemplatesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid template id"))
+			return
+		}
+
+		err = checkTemplatesService.Delete(id)
+		if errors.Is(err, services.ErrCheckTemplateNotFound) {
+			_ = c.Error(NotFoundError("could not find check selection template"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ApiCheckTemplatesDivergenceHandler godoc
+// @Summary List the clusters whose check selection diverges from their template
+// @Produce json
+// @Success 200 {object} []models.CheckTemplateDivergence
+// @Failure 500 {object} map[string]string
+// @Router /checks/templates/divergence [get]
+func ApiCheckTemplatesDivergenceHandler(checkTemplatesService services.CheckTemplatesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		divergences, err := checkTemplatesService.GetDivergences()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, divergences)
+	}
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiCheckTemplatesListHandler(t *testing.T) {
+	mockCheckTemplatesService := new(services.MockCheckTemplatesService)
+	mockCheckTemplatesService.On("GetAll").Return([]*models.CheckSelectionTemplate{
+		{
+			ID:             1,
+			Name:           "Azure HANA scale-up production",
+			Provider:       models.CheckProviderAzure,
+			ClusterType:    models.ClusterTypeHANAScaleUp,
+			SelectedChecks: []string{"ABCDEF"},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.checkTemplatesService = mockCheckTemplatesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/templates", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"name":"Azure HANA scale-up production"`)
+
+	mockCheckTemplatesService.AssertExpectations(t)
+}
+
+func TestApiCheckTemplateCreateHandler(t *testing.T) {
+	mockCheckTemplatesService := new(services.MockCheckTemplatesService)
+	mockCheckTemplatesService.On("Create", &models.CheckSelectionTemplate{
+		Name:           "azure scale-up",
+		Provider:       models.CheckProviderAzure,
+		SelectedChecks: []string{"ABCDEF", "123456"},
+	}).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.checkTemplatesService = mockCheckTemplatesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/templates",
+		bytes.NewBufferString(`{"name": "azure scale-up", "provider": "azure", "selected_checks": ["ABCDEF", "123456"]}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+
+	// the name and the selected checks are mandatory
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/checks/templates",
+		bytes.NewBufferString(`{"provider": "azure"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockCheckTemplatesService.AssertExpectations(t)
+}
+
+func TestApiCheckTemplateDeleteHandler(t *testing.T) {
+	mockCheckTemplatesService := new(services.MockCheckTemplatesService)
+	mockCheckTemplatesService.On("Delete", int64(1)).Return(nil)
+	mockCheckTemplatesService.On("Delete", int64(404)).Return(services.ErrCheckTemplateNotFound)
+
+	deps := setupTestDependencies()
+	deps.checkTemplatesService = mockCheckTemplatesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/checks/templates/1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/checks/templates/404", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/checks/templates/not_a_number", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockCheckTemplatesService.AssertExpectations(t)
+}
+
+func TestApiCheckTemplatesDivergenceHandler(t *testing.T) {
+	mockCheckTemplatesService := new(services.MockCheckTemplatesService)
+	mockCheckTemplatesService.On("GetDivergences").Return([]*models.CheckTemplateDivergence{
+		{
+			ClusterID:     "azure-cluster",
+			ClusterName:   "hana_cluster",
+			TemplateID:    1,
+			TemplateName:  "azure scale-up",
+			MissingChecks: []string{"123456"},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.checkTemplatesService = mockCheckTemplatesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/templates/divergence", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"missing_checks":["123456"]`)
+
+	mockCheckTemplatesService.AssertExpectations(t)
+}
@@ -1,11 +1,16 @@
 package web
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
+	"gorm.io/gorm"
 
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/markdown"
 	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
@@ -27,6 +32,21 @@ type JSONCheck struct {
 	Implementation string `json:"implementation,omitempty"`
 	Labels         string `json:"labels,omitempty"`
 	Premium        bool   `json:"premium,omitempty"`
+	// Provider restricts the check to one platform (azure/aws/on-prem)
+	Provider string   `json:"provider,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	// Translations carries per-locale description and remediation
+	// variants, keyed by locale
+	Translations map[string]JSONCheckTranslation `json:"translations,omitempty"`
+}
+
+type JSONCheckTranslation struct {
+	Description string `json:"description,omitempty"`
+	Remediation string `json:"remediation,omitempty"`
+}
+
+type JSONConnectionSettingsValidation struct {
+	ConnectionSettings map[string]string `json:"connection_settings" binding:"required"`
 }
 
 type JSONChecksGroup struct {
@@ -54,9 +74,39 @@ type JSONCheckResult struct {
 	Description string                `json:"description,omitempty"`
 }
 
+// catalogFilters is built from the catalog query parameters and selects
+// the subset of the catalog matching a cluster platform
+type catalogFilters struct {
+	provider string
+	premium  string
+	tags     []string
+}
+
+func (f *catalogFilters) matches(check *models.Check) bool {
+	// checks without a provider apply to every platform
+	if f.provider != "" && check.Provider != "" && check.Provider != f.provider {
+		return false
+	}
+
+	if f.premium != "" && check.Premium != (f.premium == "true") {
+		return false
+	}
+
+	for _, tag := range f.tags {
+		if !internal.Contains(check.Tags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ApiCheckCatalogHandler godoc
 // @Summary Get the whole checks' catalog
 // @Produce json
+// @Param provider query string false "Only return checks applicable to this provider (azure/aws/on-prem)"
+// @Param premium query bool false "Only return premium (or only non-premium) checks"
+// @Param tag query []string false "Only return checks carrying all the given tags"
 // @Success 200 {object} JSONChecksGroupedCatalog
 // @Error 500
 // @Router /checks/catalog [get]
@@ -70,8 +120,30 @@ func ApiChecksCatalogHandler(s services.ChecksService) gin.HandlerFunc {
 			return
 		}
 
+		filters := &catalogFilters{
+			provider: c.Query("provider"),
+			premium:  c.Query("premium"),
+			tags:     c.QueryArray("tag"),
+		}
+
+		locale := LocaleFromContext(c)
 		for _, group := range checkGroups.OrderByName() {
-			g := JSONChecksGroup{Group: group.Group, Checks: group.Checks}
+			checks := make([]*models.Check, 0, len(group.Checks))
+			for _, check := range group.Checks {
+				if !filters.matches(check) {
+					continue
+				}
+				// the remediation is the main content operators read, so it is
+				// localized and its markdown rendered server-side
+				localized := check.Localize(locale)
+				localized.RemediationHTML = markdown.Render(localized.Remediation)
+				checks = append(checks, localized)
+			}
+			if len(checks) == 0 {
+				continue
+			}
+
+			g := JSONChecksGroup{Group: group.Group, Checks: checks}
 			groupedCatalog = append(groupedCatalog, &g)
 		}
 
@@ -79,6 +151,30 @@ func ApiChecksCatalogHandler(s services.ChecksService) gin.HandlerFunc {
 	}
 }
 
+// ApiClusterRecommendedChecksHandler godoc
+// @Summary Get the catalog checks recommended for a cluster, based on its discovered platform
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} models.ChecksCatalog
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/checks/recommended [get]
+func ApiClusterRecommendedChecksHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		recommended, err := s.GetRecommendedChecksByID(c.Param("cluster_id"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if recommended == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, recommended)
+	}
+}
+
 // ApiCreateChecksCatalogHandler godoc
 // @Summary Create/Updates the checks catalog
 // @Produce json
@@ -109,6 +205,17 @@ func ApiCreateChecksCatalogHandler(s services.ChecksService) gin.HandlerFunc {
 				Implementation: checkData.Implementation,
 				Labels:         checkData.Labels,
 				Premium:        checkData.Premium,
+				Provider:       checkData.Provider,
+				Tags:           checkData.Tags,
+			}
+			if len(checkData.Translations) > 0 {
+				newCheck.Translations = make(map[string]*models.CheckTranslation)
+				for locale, translation := range checkData.Translations {
+					newCheck.Translations[locale] = &models.CheckTranslation{
+						Description: translation.Description,
+						Remediation: translation.Remediation,
+					}
+				}
 			}
 			catalog = append(catalog, newCheck)
 		}
@@ -144,6 +251,204 @@ func ApiClusterCheckResultsHandler(s services.ChecksService) gin.HandlerFunc {
 	}
 }
 
+// ApiValidateConnectionSettingsHandler godoc
+// @Summary Probe the cluster nodes with the given connection settings
+// @Accept json
+// @Produce json
+// @Param id path string true "Cluster Id"
+// @Param Body body JSONConnectionSettingsValidation true "Connection settings to validate"
+// @Success 200 {object} map[string]models.ConnectionStatus
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /checks/{id}/settings/validate [post]
+func ApiValidateConnectionSettingsHandler(s services.ConnectionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONConnectionSettingsValidation
+
+		id := c.Param("id")
+
+		err := c.BindJSON(&r)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		statuses, err := s.ValidateConnectionSettings(id, r.ConnectionSettings)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, statuses)
+	}
+}
+
+// ApiHostCheckResultsHandler godoc
+// @Summary Get the latest check results of a single host
+// @Produce json
+// @Param id path string true "Host id"
+// @Success 200 {object} models.ChecksCatalog
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/results [get]
+func ApiHostCheckResultsHandler(hostsService services.HostsService, checksService services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		if host.ClusterID == "" {
+			c.JSON(http.StatusOK, models.ChecksCatalog{})
+			return
+		}
+
+		hostResults, err := checksService.GetChecksResultAndMetadataByHost(host.ClusterID, host.Name)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusOK, models.ChecksCatalog{})
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, hostResults)
+	}
+}
+
+// ApiClusterCheckResultsDiffHandler godoc
+// @Summary Get the checks that changed state between two executions
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param from query int false "Id of the older execution; defaults to the execution right before 'to'"
+// @Param to query int false "Id of the newer execution; defaults to the latest one"
+// @Success 200 {object} models.ChecksResultsDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/results/diff [get]
+func ApiClusterCheckResultsDiffHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		fromId, err := executionIdQueryParam(c, "from")
+		if err != nil {
+			_ = c.Error(BadRequestError("from must be a number"))
+			return
+		}
+
+		toId, err := executionIdQueryParam(c, "to")
+		if err != nil {
+			_ = c.Error(BadRequestError("to must be a number"))
+			return
+		}
+
+		diff, err := s.GetChecksResultsDiff(clusterId, fromId, toId)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = c.Error(NotFoundError("no checks results found for the given executions"))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+// executionIdQueryParam reads an optional checks execution id from the query
+// string, 0 meaning not provided
+func executionIdQueryParam(c *gin.Context, name string) (int64, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(value, 10, 64)
+}
+
+// ApiRequestChecksExecutionHandler godoc
+// @Summary Request an immediate check execution for a cluster
+// @Produce json
+// @Param id path string true "Cluster Id"
+// @Success 202 {object} models.ChecksExecution
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{id}/checks/execute [post]
+func ApiRequestChecksExecutionHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("id")
+
+		execution, err := s.RequestChecksExecution(clusterId, "api")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, execution)
+	}
+}
+
+// ApiClusterChecksExecutionsHandler godoc
+// @Summary Get the check executions of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} []models.ChecksExecution
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/executions [get]
+func ApiClusterChecksExecutionsHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		executions, err := s.GetChecksExecutionsByCluster(clusterId)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, executions)
+	}
+}
+
+// ApiChecksExecutionHandler godoc
+// @Summary Get the state of a requested check execution
+// @Produce json
+// @Param id path int true "Execution Id"
+// @Success 200 {object} models.ChecksExecution
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /executions/{id} [get]
+func ApiChecksExecutionHandler(s services.ChecksService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("id must be a number"))
+			return
+		}
+
+		execution, err := s.GetChecksExecutionByID(id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if execution == nil {
+			_ = c.Error(NotFoundError("could not find execution"))
+			return
+		}
+
+		c.JSON(http.StatusOK, execution)
+	}
+}
+
 // ApiCreateChecksResultHandler godoc
 // @Summary Create a checks result entry
 // @Produce json
@@ -488,3 +488,463 @@ func TestApiCheckCreateConnectionByIdHandler(t *testing.T) {
 
 	mockChecksService.AssertExpectations(t)
 }
+
+func TestApiClusterCheckResultsDiffHandler(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksResultsDiff", "cluster1", int64(1), int64(2)).Return(
+		&models.ChecksResultsDiff{
+			FromID: 1,
+			ToID:   2,
+			Changes: []*models.CheckStateChange{
+				{
+					CheckID:  "check1",
+					Host:     "host1",
+					Previous: models.CheckPassing,
+					Current:  models.CheckCritical,
+				},
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/results/diff?from=1&to=2", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"from": 1,
+		"to": 2,
+		"changes": [
+			{
+				"check_id": "check1",
+				"host": "host1",
+				"previous": "passing",
+				"current": "critical"
+			}
+		]
+	}`, resp.Body.String())
+}
+
+func TestApiClusterCheckResultsDiffHandlerInvalidParams(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/results/diff?from=yesterday", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiHostCheckResultsHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "agent1").Return(&models.Host{
+		ID:        "agent1",
+		Name:      "host1",
+		ClusterID: "cluster1",
+	}, nil)
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksResultAndMetadataByHost", "cluster1", "host1").Return(
+		models.ChecksCatalog{
+			&models.Check{
+				ID:          "check1",
+				Group:       "group1",
+				Description: "description1",
+				Result:      models.CheckCritical,
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/agent1/results", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[
+		{
+			"id": "check1",
+			"group": "group1",
+			"description": "description1",
+			"result": "critical",
+			"premium": false
+		}
+	]`, resp.Body.String())
+}
+
+func TestApiHostCheckResultsHandlerNotFound(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "other").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/other/results", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiValidateConnectionSettingsHandler(t *testing.T) {
+	mockConnectionsService := new(services.MockConnectionsService)
+	mockConnectionsService.On(
+		"ValidateConnectionSettings", "cluster1", map[string]string{"node1": "user1"}).Return(
+		map[string]*models.ConnectionStatus{
+			"node1": {
+				Node:      "node1",
+				User:      "user1",
+				Reachable: true,
+			},
+			"node2": {
+				Node:      "node2",
+				User:      "root",
+				Reachable: false,
+				Msg:       "ssh: handshake failed",
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.connectionsService = mockConnectionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sendData := JSONConnectionSettingsValidation{
+		ConnectionSettings: map[string]string{"node1": "user1"},
+	}
+	body, _ := json.Marshal(&sendData)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/cluster1/settings/validate", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"node1": {
+			"node": "node1",
+			"user": "user1",
+			"reachable": true,
+			"msg": ""
+		},
+		"node2": {
+			"node": "node2",
+			"user": "root",
+			"reachable": false,
+			"msg": "ssh: handshake failed"
+		}
+	}`, resp.Body.String())
+
+	mockConnectionsService.AssertExpectations(t)
+}
+
+func TestApiValidateConnectionSettingsHandlerBadRequest(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/checks/cluster1/settings/validate", bytes.NewBufferString("not-json"))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiRequestChecksExecutionHandler(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("RequestChecksExecution", "cluster1", "api").Return(&models.ChecksExecution{
+		ID:        1,
+		ClusterID: "cluster1",
+		Status:    models.ExecutionStatusRequested,
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/clusters/cluster1/checks/execute", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+
+	var execution models.ChecksExecution
+	json.Unmarshal(resp.Body.Bytes(), &execution)
+	assert.Equal(t, int64(1), execution.ID)
+	assert.Equal(t, "cluster1", execution.ClusterID)
+	assert.Equal(t, models.ExecutionStatusRequested, execution.Status)
+
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiChecksExecutionHandler(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksExecutionByID", int64(1)).Return(&models.ChecksExecution{
+		ID:        1,
+		ClusterID: "cluster1",
+		Status:    models.ExecutionStatusCompleted,
+	}, nil)
+	mockChecksService.On("GetChecksExecutionByID", int64(2)).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/executions/1", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	var execution models.ChecksExecution
+	json.Unmarshal(resp.Body.Bytes(), &execution)
+	assert.Equal(t, models.ExecutionStatusCompleted, execution.Status)
+
+	// 404 scenario
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/executions/2", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	// 400 scenario
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/executions/latest", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiChecksCatalogHandlerLocalized(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksCatalogByGroup").Return(
+		models.GroupedCheckList{
+			&models.GroupedChecks{
+				Group: "group1",
+				Checks: models.ChecksCatalog{
+					&models.Check{
+						ID:          "ABCDEF",
+						Name:        "name1",
+						Group:       "group1",
+						Description: "description1",
+						Remediation: "Run `crm status` on the **DC** node",
+						Translations: map[string]*models.CheckTranslation{
+							"de": {
+								Description: "beschreibung1",
+							},
+						},
+					},
+				},
+			},
+		}, nil,
+	)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/checks/catalog", nil)
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	var catalog JSONChecksGroupedCatalog
+	err = json.Unmarshal(resp.Body.Bytes(), &catalog)
+	assert.NoError(t, err)
+	assert.Len(t, catalog, 1)
+	assert.Len(t, catalog[0].Checks, 1)
+
+	check := catalog[0].Checks[0]
+	assert.Equal(t, "beschreibung1", check.Description)
+	// the remediation falls back to the English source and comes with its
+	// markdown rendered server-side
+	assert.Equal(t, "Run `crm status` on the **DC** node", check.Remediation)
+	assert.Equal(t,
+		"<p>Run <code>crm status</code> on the <strong>DC</strong> node</p>",
+		check.RemediationHTML)
+
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiChecksCatalogHandlerFiltered(t *testing.T) {
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetChecksCatalogByGroup").Return(
+		models.GroupedCheckList{
+			&models.GroupedChecks{
+				Group: "group1",
+				Checks: models.ChecksCatalog{
+					&models.Check{
+						ID:       "AZURE1",
+						Name:     "name1",
+						Group:    "group1",
+						Provider: models.CheckProviderAzure,
+						Tags:     []string{"hana", "fencing"},
+					},
+					&models.Check{
+						ID:       "AWS1",
+						Name:     "name2",
+						Group:    "group1",
+						Provider: models.CheckProviderAws,
+					},
+					&models.Check{
+						ID:    "GENERIC1",
+						Name:  "name3",
+						Group: "group1",
+					},
+				},
+			},
+			&models.GroupedChecks{
+				Group: "group2",
+				Checks: models.ChecksCatalog{
+					&models.Check{
+						ID:       "PREMIUM1",
+						Name:     "name4",
+						Group:    "group2",
+						Provider: models.CheckProviderOnPrem,
+						Premium:  true,
+					},
+				},
+			},
+		}, nil,
+	)
+
+	deps := setupTestDependencies()
+	deps.checksService = mockChecksService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalogIDs := func(url string) []string {
+		resp := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", url, nil)
+		app.webEngine.ServeHTTP(resp, req)
+		assert.Equal(t, 200, resp.Code)
+
+		var catalog JSONChecksGroupedCatalog
+		assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &catalog))
+
+		var ids []string
+		for _, group := range catalog {
+			for _, check := range group.Checks {
+				ids = append(ids, check.ID)
+			}
+		}
+		return ids
+	}
+
+	// provider filters keep the provider-less checks, as they apply everywhere
+	assert.ElementsMatch(t,
+		[]string{"AZURE1", "GENERIC1"},
+		catalogIDs("/api/checks/catalog?provider=azure"))
+
+	assert.ElementsMatch(t,
+		[]string{"PREMIUM1"},
+		catalogIDs("/api/checks/catalog?premium=true"))
+
+	assert.ElementsMatch(t,
+		[]string{"AZURE1", "AWS1", "GENERIC1"},
+		catalogIDs("/api/checks/catalog?premium=false"))
+
+	assert.ElementsMatch(t,
+		[]string{"AZURE1"},
+		catalogIDs("/api/checks/catalog?tag=hana&tag=fencing"))
+
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiClusterRecommendedChecksHandler(t *testing.T) {
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetRecommendedChecksByID", "cluster_id").Return(
+		models.ChecksCatalog{
+			&models.Check{ID: "ABCDEF", Name: "name1", Group: "group1"},
+		}, nil,
+	)
+	mockClustersService.On("GetRecommendedChecksByID", "not_found").Return(
+		models.ChecksCatalog(nil), nil,
+	)
+
+	deps := setupTestDependencies()
+	deps.clustersService = mockClustersService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster_id/checks/recommended", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	var recommended models.ChecksCatalog
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &recommended))
+	assert.Len(t, recommended, 1)
+	assert.Equal(t, "ABCDEF", recommended[0].ID)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/not_found/checks/recommended", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockClustersService.AssertExpectations(t)
+}
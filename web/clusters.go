@@ -35,6 +35,7 @@ func NewClusterListHandler(clustersService services.ClustersService) gin.Handler
 			ClusterType: query["cluster_type"],
 			Health:      query["health"],
 			Tags:        query["tags"],
+			ScopeTags:   TagScopeFromContext(c),
 		}
 
 		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -105,7 +106,8 @@ func NewClusterListHandler(clustersService services.ClustersService) gin.Handler
 	}
 }
 
-func NewClusterHandler(clusterService services.ClustersService) gin.HandlerFunc {
+func NewClusterHandler(clusterService services.ClustersService, alertsService services.AlertsService,
+	checksService services.ChecksService, preferencesService services.PreferencesService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		clusterID := c.Param("id")
 
@@ -122,6 +124,7 @@ func NewClusterHandler(clusterService services.ClustersService) gin.HandlerFunc
 
 		if cluster.ClusterType == models.ClusterTypeUnknown {
 			c.Redirect(http.StatusFound, "/clusters")
+			return
 		}
 
 		hContainer := &HealthContainer{
@@ -131,10 +134,46 @@ func NewClusterHandler(clusterService services.ClustersService) gin.HandlerFunc
 			Layout:        "vertical",
 		}
 
-		c.HTML(http.StatusOK, "cluster_hana.html.tmpl", gin.H{
+		metricAlerts, err := alertsService.GetFiringByResource(models.TagClusterResourceType, clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		executions, err := checksService.GetChecksExecutionsByCluster(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		var lastExecution *models.ChecksExecution
+		if len(executions) > 0 {
+			lastExecution = executions[0]
+		}
+
+		var template string
+		switch cluster.ClusterType {
+		case models.ClusterTypeHANAScaleUp, models.ClusterTypeHANAScaleOut:
+			template = "cluster_hana.html.tmpl"
+		case models.ClusterTypeASCSERS:
+			template = "cluster_ascs_ers.html.tmpl"
+		default:
+			template = "cluster_generic.html.tmpl"
+		}
+
+		preferences, err := preferencesService.GetPreferences(preferencesSubject(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.HTML(http.StatusOK, template, gin.H{
 			"Cluster":         cluster,
 			"HealthContainer": hContainer,
 			"Alerts":          GetAlerts(c),
+			"MetricAlerts":    metricAlerts,
+			"LastExecution":   lastExecution,
+			"Timezone":        preferences.Timezone,
 		})
 	}
 }
@@ -1,7 +1,10 @@
 package web
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/trento-project/trento/web/models"
@@ -29,3 +32,416 @@ func ApiGetClustersSettingsHandler(clusters services.ClustersService) gin.Handle
 		c.JSON(http.StatusOK, clustersSettings)
 	}
 }
+
+type JSONClusterSummary struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	ClusterType     string   `json:"cluster_type"`
+	SID             string   `json:"sid"`
+	HostsNumber     int      `json:"hosts_number"`
+	ResourcesNumber int      `json:"resources_number"`
+	Health          string   `json:"health"`
+	Tags            []string `json:"tags,omitempty"`
+}
+
+// ApiClustersListHandler godoc
+// @Summary List the discovered Pacemaker clusters
+// @Produce json
+// @Param name query []string false "Return only the clusters with one of the given names"
+// @Param sid query []string false "Return only the clusters running one of the given SIDs"
+// @Success 200 {object} []JSONClusterSummary
+// @Failure 500 {object} map[string]string
+// @Router /clusters [get]
+func ApiClustersListHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var clustersFilter *services.ClustersFilter
+
+		names := c.QueryArray("name")
+		sids := c.QueryArray("sid")
+		scopeTags := TagScopeFromContext(c)
+		if len(names) > 0 || len(sids) > 0 || len(scopeTags) > 0 {
+			clustersFilter = &services.ClustersFilter{
+				Name:      names,
+				SIDs:      sids,
+				ScopeTags: scopeTags,
+			}
+		}
+
+		clusters, err := s.GetAll(clustersFilter, nil)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		clusterSummaries := make([]*JSONClusterSummary, 0)
+		for _, cluster := range clusters {
+			clusterSummaries = append(clusterSummaries, &JSONClusterSummary{
+				ID:              cluster.ID,
+				Name:            cluster.Name,
+				ClusterType:     cluster.ClusterType,
+				SID:             cluster.SID,
+				HostsNumber:     cluster.HostsNumber,
+				ResourcesNumber: cluster.ResourcesNumber,
+				Health:          cluster.Health,
+				Tags:            cluster.Tags,
+			})
+		}
+
+		c.JSON(http.StatusOK, clusterSummaries)
+	}
+}
+
+// ApiClusterResourcesHandler godoc
+// @Summary Get the Pacemaker resources of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} []models.PacemakerResource
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/resources [get]
+func ApiClusterResourcesHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resources, err := s.GetResourcesByClusterID(c.Param("cluster_id"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, resources)
+	}
+}
+
+// ApiClusterVIPsHandler godoc
+// @Summary Get the virtual IPs of a cluster and the nodes currently hosting them
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} []models.ClusterVIP
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/vips [get]
+func ApiClusterVIPsHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		vips, err := s.GetVIPsByClusterID(c.Param("cluster_id"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, vips)
+	}
+}
+
+// ApiClusterFreshnessHandler godoc
+// @Summary Get when the cluster discovery last updated a cluster and whether the data is stale
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} models.DiscoveryFreshness
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/freshness [get]
+func ApiClusterFreshnessHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		freshness, err := s.GetFreshnessByClusterID(c.Param("cluster_id"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if freshness == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, freshness)
+	}
+}
+
+type JSONClustersSettingsBulkUpdate struct {
+	Filter         models.ClustersSettingsBulkFilter `json:"filter"`
+	SelectedChecks []string                          `json:"selected_checks"`
+	ConnectionUser string                            `json:"connection_user"`
+}
+
+type JSONClustersSettingsBulkUpdateResponse struct {
+	UpdatedClusters []string `json:"updated_clusters"`
+}
+
+// ApiBulkUpdateClustersSettingsHandler godoc
+// @Summary Update the selected checks and connection settings of all the clusters matching the filter, transactionally
+// @Accept json
+// @Produce json
+// @Param Body body JSONClustersSettingsBulkUpdate true "The settings to apply and the clusters to apply them to"
+// @Success 200 {object} JSONClustersSettingsBulkUpdateResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/settings [put]
+func ApiBulkUpdateClustersSettingsHandler(clusters services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var u JSONClustersSettingsBulkUpdate
+
+		if err := c.BindJSON(&u); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if u.SelectedChecks == nil && u.ConnectionUser == "" {
+			_ = c.Error(BadRequestError("no settings to update"))
+			return
+		}
+
+		updatedIDs, err := clusters.BulkUpdateSettings(&models.ClustersSettingsBulkUpdate{
+			Filter:         u.Filter,
+			SelectedChecks: u.SelectedChecks,
+			ConnectionUser: u.ConnectionUser,
+		})
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONClustersSettingsBulkUpdateResponse{
+			UpdatedClusters: updatedIDs,
+		})
+	}
+}
+
+// ApiClusterDriftHandler godoc
+// @Summary Get the configuration drift between the nodes of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} models.ClusterDrift
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/drift [get]
+func ApiClusterDriftHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		drift, err := s.GetDriftByClusterID(c.Param("cluster_id"))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if drift == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, drift)
+	}
+}
+
+type JSONFencingValidation struct {
+	ClusterID string `json:"cluster_id"`
+	Agent     string `json:"agent"`
+	Node      string `json:"node"`
+	Valid     bool   `json:"valid"`
+	Msg       string `json:"msg,omitempty"`
+}
+
+// ApiClusterValidateFencingHandler godoc
+// @Summary Dry-run the cloud fence agent of a cluster to verify its credentials actually work
+// @Produce json
+// @Param id path string true "Cluster Id"
+// @Success 200 {object} JSONFencingValidation
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{id}/fencing/validate [post]
+func ApiClusterValidateFencingHandler(s services.FencingService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		validation, err := s.ValidateFencingCredentials(c.Param("id"))
+		if errors.Is(err, services.ErrFencingAgentNotSupported) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if validation == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONFencingValidation{
+			ClusterID: validation.ClusterID,
+			Agent:     validation.Agent,
+			Node:      validation.Node,
+			Valid:     validation.Valid,
+			Msg:       validation.Msg,
+		})
+	}
+}
+
+// ApiClusterReadinessHandler godoc
+// @Summary Get the takeover readiness score of a HANA cluster with its contributing factors
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Success 200 {object} models.ClusterReadiness
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/readiness [get]
+func ApiClusterReadinessHandler(s services.ClustersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		readiness, err := s.GetReadinessByClusterID(c.Param("cluster_id"))
+		if errors.Is(err, services.ErrClusterNotHANA) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if readiness == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		c.JSON(http.StatusOK, readiness)
+	}
+}
+
+// ApiClusterConfigSnapshotHandler godoc
+// @Summary Get the raw CIB XML and corosync.conf of a cluster, optionally as they were at a given time
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param at query string false "RFC3339 timestamp to get the configuration that was current at that time"
+// @Success 200 {object} models.ClusterConfigSnapshot
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/cib [get]
+func ApiClusterConfigSnapshotHandler(s services.ClusterConfigsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		var at *time.Time
+		if atParam := c.Query("at"); atParam != "" {
+			parsedAt, err := time.Parse(time.RFC3339, atParam)
+			if err != nil {
+				_ = c.Error(BadRequestError("at must be a RFC3339 timestamp"))
+				return
+			}
+			at = &parsedAt
+		}
+
+		snapshot, err := s.GetConfigSnapshot(clusterId, at)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if snapshot == nil {
+			_ = c.Error(NotFoundError("could not find a configuration snapshot"))
+			return
+		}
+
+		c.JSON(http.StatusOK, snapshot)
+	}
+}
+
+// ApiClusterConfigDiffHandler godoc
+// @Summary Compare two configuration snapshots of a cluster, by default the latest one against the previous one
+// @Produce json
+// @Param cluster_id path string true "Cluster Id"
+// @Param from query int false "Id of the older snapshot"
+// @Param to query int false "Id of the newer snapshot"
+// @Success 200 {object} models.ClusterConfigDiff
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/cib/diff [get]
+func ApiClusterConfigDiffHandler(s services.ClusterConfigsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterId := c.Param("cluster_id")
+
+		fromId, err := parseSnapshotIdParam(c, "from")
+		if err != nil {
+			_ = c.Error(BadRequestError("from must be a number"))
+			return
+		}
+
+		toId, err := parseSnapshotIdParam(c, "to")
+		if err != nil {
+			_ = c.Error(BadRequestError("to must be a number"))
+			return
+		}
+
+		diff, err := s.GetConfigSnapshotsDiff(clusterId, fromId, toId)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if diff == nil {
+			_ = c.Error(NotFoundError("not enough configuration snapshots to compare"))
+			return
+		}
+
+		c.JSON(http.StatusOK, diff)
+	}
+}
+
+func parseSnapshotIdParam(c *gin.Context, name string) (int64, error) {
+	param := c.Query(name)
+	if param == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseInt(param, 10, 64)
+}
+
+// ApiClusterRebuildsHandler godoc
+// @Summary List the clusters that reappeared under a new ID after a rebuild, paired with their old entry
+// @Produce json
+// @Success 200 {object} []models.ClusterRebuild
+// @Failure 500 {object} map[string]string
+// @Router /clusters/rebuilds [get]
+func ApiClusterRebuildsHandler(s services.ClusterIdentityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rebuilds, err := s.FindRebuilds()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, rebuilds)
+	}
+}
+
+type JSONClusterMerge struct {
+	OldClusterID string `json:"old_cluster_id" binding:"required"`
+	NewClusterID string `json:"new_cluster_id" binding:"required"`
+}
+
+// ApiClusterMergeHandler godoc
+// @Summary Merge the tags, check settings and history of a stale cluster entry into the cluster that replaced it
+// @Accept json
+// @Produce json
+// @Param Body body JSONClusterMerge true "The old cluster entry and the cluster to merge it into"
+// @Success 204 {object} interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/merge [post]
+func ApiClusterMergeHandler(s services.ClusterIdentityService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var m JSONClusterMerge
+
+		if err := c.BindJSON(&m); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err := s.Merge(m.OldClusterID, m.NewClusterID)
+		if errors.Is(err, services.ErrMergeClusterNotFound) {
+			_ = c.Error(NotFoundError(err.Error()))
+			return
+		}
+		if errors.Is(err, services.ErrMergeSourceStillRegistered) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -1,10 +1,12 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/web/models"
@@ -83,6 +85,354 @@ func (suite *ClustersApiTestCase) Test_AnErrorOccurs() {
 	suite.JSONEq(`{"error":"KABOOM"}`, resp.Body.String())
 }
 
+func (suite *ClustersApiTestCase) Test_ClustersList() {
+	suite.mockClusterService.On("GetAll", (*services.ClustersFilter)(nil), (*services.Page)(nil)).Return(models.ClusterList{
+		{
+			ID:              "cluster1",
+			Name:            "hana_cluster",
+			ClusterType:     models.ClusterTypeHANAScaleUp,
+			SID:             "PRD",
+			HostsNumber:     2,
+			ResourcesNumber: 10,
+			Health:          models.HealthSummaryHealthPassing,
+			Tags:            []string{"env:production"},
+		},
+	}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`[{
+		"id": "cluster1",
+		"name": "hana_cluster",
+		"cluster_type": "HANA scale-up",
+		"sid": "PRD",
+		"hosts_number": 2,
+		"resources_number": 10,
+		"health": "passing",
+		"tags": ["env:production"]
+	}]`, resp.Body.String())
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClustersListFiltered() {
+	suite.mockClusterService.On("GetAll", &services.ClustersFilter{
+		Name: []string{"hana_cluster"},
+		SIDs: []string{"PRD"},
+	}, (*services.Page)(nil)).Return(models.ClusterList{}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters?name=hana_cluster&sid=PRD", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`[]`, resp.Body.String())
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterResources() {
+	suite.mockClusterService.On("GetResourcesByClusterID", "cluster1").Return([]*models.PacemakerResource{
+		{
+			ID:                 "rsc_SAPHana_PRD_HDB00",
+			ClusterID:          "cluster1",
+			Node:               "host1",
+			Type:               "ocf::suse:SAPHana",
+			Role:               "Master",
+			Status:             "active",
+			FailCount:          2,
+			MigrationThreshold: 5000,
+		},
+	}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/resources", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"id":"rsc_SAPHana_PRD_HDB00"`)
+	suite.Contains(resp.Body.String(), `"migration_threshold":5000`)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterVIPs() {
+	suite.mockClusterService.On("GetVIPsByClusterID", "cluster1").Return([]*models.ClusterVIP{
+		{
+			ClusterID:  "cluster1",
+			ResourceID: "rsc_ip_PRD_HDB00",
+			Address:    "10.74.1.12",
+			Node:       "host1",
+			Status:     "active",
+		},
+	}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/vips", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"resource_id":"rsc_ip_PRD_HDB00"`)
+	suite.Contains(resp.Body.String(), `"address":"10.74.1.12"`)
+	suite.Contains(resp.Body.String(), `"node":"host1"`)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterFreshness() {
+	suite.mockClusterService.On("GetFreshnessByClusterID", "cluster1").Return(&models.DiscoveryFreshness{
+		DiscoveryType: "ha_cluster_discovery",
+		LastUpdated:   time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		Stale:         true,
+	}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/freshness", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`{
+		"discovery_type": "ha_cluster_discovery",
+		"last_updated": "2022-02-01T10:30:00Z",
+		"stale": true
+	}`, resp.Body.String())
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterFreshnessNotFound() {
+	suite.mockClusterService.On("GetFreshnessByClusterID", "other").Return(nil, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/other/freshness", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterReadiness() {
+	suite.mockClusterService.On("GetReadinessByClusterID", "cluster1").Return(&models.ClusterReadiness{
+		ClusterID: "cluster1",
+		Score:     75,
+		Ready:     false,
+		Factors: []*models.ReadinessFactor{
+			{
+				Name:    models.ReadinessFactorSRSync,
+				Health:  models.ReadinessHealthPassing,
+				Message: "System replication is in sync",
+			},
+			{
+				Name:    models.ReadinessFactorFencing,
+				Health:  models.ReadinessHealthCritical,
+				Message: "Fencing is not configured",
+			},
+		},
+	}, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/readiness", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`{
+		"cluster_id": "cluster1",
+		"score": 75,
+		"ready": false,
+		"factors": [
+			{
+				"name": "hana_sr_sync",
+				"health": "passing",
+				"message": "System replication is in sync"
+			},
+			{
+				"name": "fencing",
+				"health": "critical",
+				"message": "Fencing is not configured"
+			}
+		]
+	}`, resp.Body.String())
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterReadinessNotFound() {
+	suite.mockClusterService.On("GetReadinessByClusterID", "other").Return(nil, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/other/readiness", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterReadinessNotHANA() {
+	suite.mockClusterService.On("GetReadinessByClusterID", "ascs_cluster").Return(nil, services.ErrClusterNotHANA)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/ascs_cluster/readiness", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterConfigSnapshot() {
+	mockClusterConfigsService := new(services.MockClusterConfigsService)
+	mockClusterConfigsService.On("GetConfigSnapshot", "cluster1", (*time.Time)(nil)).Return(&models.ClusterConfigSnapshot{
+		ID:           2,
+		ClusterID:    "cluster1",
+		CibXML:       "<cib epoch=\"2\"/>",
+		CorosyncConf: "totem {}",
+	}, nil)
+
+	suite.deps.clusterConfigsService = mockClusterConfigsService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/cib", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"cluster_id":"cluster1"`)
+	suite.Contains(resp.Body.String(), `"corosync_conf":"totem {}"`)
+
+	mockClusterConfigsService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterConfigSnapshotAt() {
+	at := time.Date(2022, 1, 11, 10, 0, 0, 0, time.UTC)
+
+	mockClusterConfigsService := new(services.MockClusterConfigsService)
+	mockClusterConfigsService.On("GetConfigSnapshot", "cluster1", &at).Return(nil, nil)
+
+	suite.deps.clusterConfigsService = mockClusterConfigsService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/cib?at=2022-01-11T10:00:00Z", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	// malformed timestamps are rejected before hitting the service
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster1/cib?at=yesterday", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+
+	mockClusterConfigsService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterConfigDiff() {
+	mockClusterConfigsService := new(services.MockClusterConfigsService)
+	mockClusterConfigsService.On("GetConfigSnapshotsDiff", "cluster1", int64(0), int64(0)).Return(&models.ClusterConfigDiff{
+		From:            &models.ClusterConfigSnapshot{ID: 1},
+		To:              &models.ClusterConfigSnapshot{ID: 2},
+		CibChanged:      true,
+		CorosyncChanged: false,
+	}, nil)
+	mockClusterConfigsService.On("GetConfigSnapshotsDiff", "cluster2", int64(1), int64(3)).Return(nil, nil)
+
+	suite.deps.clusterConfigsService = mockClusterConfigsService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/cib/diff", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"cib_changed":true`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster2/cib/diff?from=1&to=3", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/cluster1/cib/diff?from=abc", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+
+	mockClusterConfigsService.AssertExpectations(suite.T())
+}
+
 func mockedClustersSettings() models.ClustersSettings {
 	return models.ClustersSettings{
 		{
@@ -109,3 +459,213 @@ func mockedClustersSettings() models.ClustersSettings {
 		},
 	}
 }
+
+func (suite *ClustersApiTestCase) Test_BulkUpdateClustersSettings() {
+	suite.mockClusterService.On("BulkUpdateSettings", &models.ClustersSettingsBulkUpdate{
+		Filter: models.ClustersSettingsBulkFilter{
+			Tags: []string{"azure"},
+		},
+		ConnectionUser: "cloudadmin",
+	}).Return([]string{"cluster1", "cluster2"}, nil)
+
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/clusters/settings",
+		bytes.NewBufferString(`{"filter": {"tags": ["azure"]}, "connection_user": "cloudadmin"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`{"updated_clusters": ["cluster1", "cluster2"]}`, resp.Body.String())
+
+	// a body without any settings to apply is rejected
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/clusters/settings",
+		bytes.NewBufferString(`{"filter": {"tags": ["azure"]}}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ValidateFencing() {
+	mockFencingService := new(services.MockFencingService)
+	mockFencingService.On("ValidateFencingCredentials", "cluster1").Return(&models.FencingValidation{
+		ClusterID: "cluster1",
+		Agent:     "fence_azure_arm",
+		Node:      "node02",
+		Valid:     false,
+		Msg:       "Error performing operation: Permission denied",
+	}, nil)
+	mockFencingService.On("ValidateFencingCredentials", "sbd_cluster").
+		Return(nil, services.ErrFencingAgentNotSupported)
+	mockFencingService.On("ValidateFencingCredentials", "other").Return(nil, nil)
+	suite.deps.fencingService = mockFencingService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/clusters/cluster1/fencing/validate", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`{
+		"cluster_id": "cluster1",
+		"agent": "fence_azure_arm",
+		"node": "node02",
+		"valid": false,
+		"msg": "Error performing operation: Permission denied"
+	}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/clusters/sbd_cluster/fencing/validate", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/clusters/other/fencing/validate", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	mockFencingService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterDrift() {
+	suite.mockClusterService.On("GetDriftByClusterID", "cluster1").Return(&models.ClusterDrift{
+		ClusterID: "cluster1",
+		Drifted:   true,
+		Fields: []*models.ClusterDriftField{
+			{
+				Name: "kernel_version",
+				Values: map[string]string{
+					"node01": "5.3.18-24.102-default",
+					"node02": "5.3.18-24.99-default",
+				},
+				Drifted: true,
+			},
+		},
+	}, nil)
+	suite.mockClusterService.On("GetDriftByClusterID", "other").Return(nil, nil)
+	suite.deps.clustersService = suite.mockClusterService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster1/drift", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"drifted":true`)
+	suite.Contains(resp.Body.String(), `"kernel_version"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/clusters/other/drift", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+
+	suite.mockClusterService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterRebuilds() {
+	mockIdentityService := new(services.MockClusterIdentityService)
+	mockIdentityService.On("FindRebuilds").Return([]*models.ClusterRebuild{
+		{
+			OldClusterID:   "old-cluster",
+			OldClusterName: "hana_cluster",
+			NewClusterID:   "new-cluster",
+			NewClusterName: "hana_cluster",
+			SID:            "PRD",
+			Nodes:          []string{"node1", "node2"},
+		},
+	}, nil)
+	suite.deps.clusterIdentityService = mockIdentityService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/rebuilds", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`[{
+		"old_cluster_id": "old-cluster",
+		"old_cluster_name": "hana_cluster",
+		"new_cluster_id": "new-cluster",
+		"new_cluster_name": "hana_cluster",
+		"sid": "PRD",
+		"nodes": ["node1", "node2"]
+	}]`, resp.Body.String())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterMerge() {
+	mockIdentityService := new(services.MockClusterIdentityService)
+	mockIdentityService.On("Merge", "old-cluster", "new-cluster").Return(nil)
+	suite.deps.clusterIdentityService = mockIdentityService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"old_cluster_id": "old-cluster", "new_cluster_id": "new-cluster"}`)
+	req := httptest.NewRequest("POST", "/api/clusters/merge", body)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(204, resp.Code)
+	mockIdentityService.AssertExpectations(suite.T())
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterMergeNotFound() {
+	mockIdentityService := new(services.MockClusterIdentityService)
+	mockIdentityService.On("Merge", "old-cluster", "not-there").Return(services.ErrMergeClusterNotFound)
+	suite.deps.clusterIdentityService = mockIdentityService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"old_cluster_id": "old-cluster", "new_cluster_id": "not-there"}`)
+	req := httptest.NewRequest("POST", "/api/clusters/merge", body)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(404, resp.Code)
+}
+
+func (suite *ClustersApiTestCase) Test_ClusterMergeStillRegistered() {
+	mockIdentityService := new(services.MockClusterIdentityService)
+	mockIdentityService.On("Merge", "live-cluster", "new-cluster").Return(services.ErrMergeSourceStillRegistered)
+	suite.deps.clusterIdentityService = mockIdentityService
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body := bytes.NewBufferString(`{"old_cluster_id": "live-cluster", "new_cluster_id": "new-cluster"}`)
+	req := httptest.NewRequest("POST", "/api/clusters/merge", body)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+}
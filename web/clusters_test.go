@@ -479,3 +479,42 @@ func TestClusterHandlerHANA(t *testing.T) {
 	assert.Regexp(t, regexp.MustCompile("<td>dummy_failed</td><td>dummy</td><td>Started</td><td>failed</td><td>0</td>"), minified)
 	assert.Regexp(t, regexp.MustCompile("<h4>Stopped resources</h4><div.*><div.*><span .*>dummy_failed</span>"), minified)
 }
+
+func TestClusterHandlerTimezonePreference(t *testing.T) {
+	clusterID := "47d1190ffb4f781974c8356d7f863b03"
+
+	clustersService := new(services.MockClustersService)
+	clustersService.On("GetByID", clusterID).Return(&models.Cluster{
+		ID:          clusterID,
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		SID:         "PRD",
+		Details: &models.HANAClusterDetails{
+			CIBLastWritten: time.Date(2021, time.June, 30, 18, 11, 37, 0, time.UTC),
+		},
+	}, nil)
+
+	preferences := models.NewDefaultPreferences()
+	preferences.Timezone = "Europe/Berlin"
+	preferencesService := new(services.MockPreferencesService)
+	preferencesService.On("GetPreferences", mock.Anything).Return(preferences, nil)
+
+	deps := setupTestDependencies()
+	deps.clustersService = clustersService
+	deps.preferencesService = preferencesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/clusters/"+clusterID, nil)
+	req.Header.Set("Accept", "text/html")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Jun 30, 2021 20:11:37 CEST")
+}
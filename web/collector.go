@@ -1,6 +1,8 @@
 package web
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -19,7 +21,38 @@ func ApiCollectDataHandler(collectorService services.CollectorService) gin.Handl
 			return
 		}
 
+		validationErrors := datapipeline.ValidatePayload(e.DiscoveryType, e.Payload)
+		if len(validationErrors) > 0 {
+			storeErr := collectorService.StoreMalformedEvent(&datapipeline.MalformedEvent{
+				AgentID:          e.AgentID,
+				DiscoveryType:    e.DiscoveryType,
+				Payload:          e.Payload,
+				ValidationErrors: validationErrors,
+			})
+			if storeErr != nil {
+				_ = c.Error(storeErr)
+				return
+			}
+
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"error":             "payload does not comply with the discovery schema",
+				"validation_errors": validationErrors,
+			})
+			return
+		}
+
 		err = collectorService.StoreEvent(&e)
+		if errors.Is(err, datapipeline.ErrUnsupportedSchemaVersion) {
+			// the collector engine has no error rendering middleware,
+			// the status has to be written explicitly
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, datapipeline.ErrEventsQueueFull) {
+			c.Header("Retry-After", "5")
+			c.AbortWithStatus(http.StatusServiceUnavailable)
+			return
+		}
 		if err != nil {
 			_ = c.Error(err)
 			return
@@ -28,3 +61,32 @@ func ApiCollectDataHandler(collectorService services.CollectorService) gin.Handl
 		c.Writer.WriteHeader(http.StatusAccepted)
 	}
 }
+
+// ApiProjectionRebuildHandler godoc
+// @Summary Rebuild a projected read model by replaying the stored events
+// @Produce json
+// @Param name path string true "Projection name"
+// @Success 200 {object} map[string]interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /internal/projections/{name}/rebuild [post]
+func ApiProjectionRebuildHandler(collectorService services.CollectorService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+
+		replayedEvents, err := collectorService.RebuildProjection(name)
+		if errors.Is(err, datapipeline.ErrProjectionNotFound) {
+			_ = c.Error(NotFoundError(fmt.Sprintf("could not find projection %s", name)))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"projection":      name,
+			"replayed_events": replayedEvents,
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/services"
+)
+
+const (
+	mqStreamName    = "TRENTO_DISCOVERIES"
+	mqConsumerQueue = "trento-server"
+	// mqRedeliveryDelay is how long a negatively acknowledged discovery
+	// payload stays in the stream before it is delivered again
+	mqRedeliveryDelay = 5 * time.Second
+)
+
+// StartMessageQueueConsumer subscribes to the discoveries subject and feeds
+// the published payloads to the collector service, as an alternative to the
+// HTTP collector endpoint. Events are acknowledged only once stored, so the
+// server can be restarted without losing agent submissions, and the queue
+// group allows multiple server instances to share the stream
+func StartMessageQueueConsumer(ctx context.Context, url, subject string, collectorService services.CollectorService) error {
+	conn, err := nats.Connect(url,
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	js, err := conn.JetStream()
+	if err != nil {
+		return err
+	}
+
+	_, err = js.StreamInfo(mqStreamName)
+	if errors.Is(err, nats.ErrStreamNotFound) {
+		_, err = js.AddStream(&nats.StreamConfig{
+			Name:     mqStreamName,
+			Subjects: []string{subject},
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	subscription, err := js.QueueSubscribe(subject, mqConsumerQueue, func(msg *nats.Msg) {
+		err := processDiscoveryMessage(collectorService, msg.Data)
+		if err != nil {
+			log.Errorf("Could not store the published discovery payload, requesting a redelivery: %s", err)
+			if err := msg.NakWithDelay(mqRedeliveryDelay); err != nil {
+				log.Errorf("Could not negatively acknowledge the discovery payload: %s", err)
+			}
+			return
+		}
+
+		if err := msg.Ack(); err != nil {
+			log.Errorf("Could not acknowledge the discovery payload: %s", err)
+		}
+	}, nats.ManualAck(), nats.AckExplicit())
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Consuming agent discoveries from %s, subject %s", url, subject)
+
+	<-ctx.Done()
+
+	if err := subscription.Drain(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// processDiscoveryMessage stores a single published discovery payload. A nil
+// return means the message can be acknowledged: payloads that can never be
+// stored, like malformed ones, are recorded and consumed instead of being
+// redelivered forever
+func processDiscoveryMessage(collectorService services.CollectorService, data []byte) error {
+	var event datapipeline.DataCollectedEvent
+
+	err := json.Unmarshal(data, &event)
+	if err != nil {
+		log.Warnf("Discarding an unparseable discovery payload: %s", err)
+		return nil
+	}
+
+	validationErrors := datapipeline.ValidatePayload(event.DiscoveryType, event.Payload)
+	if len(validationErrors) > 0 {
+		return collectorService.StoreMalformedEvent(&datapipeline.MalformedEvent{
+			AgentID:          event.AgentID,
+			DiscoveryType:    event.DiscoveryType,
+			Payload:          event.Payload,
+			ValidationErrors: validationErrors,
+		})
+	}
+
+	return collectorService.StoreEvent(&event)
+}
@@ -0,0 +1,71 @@
+package web
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestProcessDiscoveryMessage(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreEvent", mock.Anything).Return(nil)
+
+	message, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: datapipeline.HostDiscovery,
+		Payload:       []byte(`{"hostname": "suse01", "cpu_count": 4}`),
+	})
+
+	err := processDiscoveryMessage(collectorService, message)
+
+	assert.NoError(t, err)
+	collectorService.AssertCalled(t, "StoreEvent", mock.Anything)
+}
+
+func TestProcessDiscoveryMessage_MalformedPayload(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreMalformedEvent", mock.Anything).Return(nil)
+
+	message, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: datapipeline.HostDiscovery,
+		Payload:       []byte(`{"cpu_count": "not-a-number"}`),
+	})
+
+	// invalid payloads are recorded and consumed, a redelivery would not fix them
+	err := processDiscoveryMessage(collectorService, message)
+
+	assert.NoError(t, err)
+	collectorService.AssertCalled(t, "StoreMalformedEvent", mock.Anything)
+	collectorService.AssertNotCalled(t, "StoreEvent", mock.Anything)
+}
+
+func TestProcessDiscoveryMessage_UnparseableMessage(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+
+	err := processDiscoveryMessage(collectorService, []byte("not json"))
+
+	assert.NoError(t, err)
+	collectorService.AssertNotCalled(t, "StoreEvent", mock.Anything)
+	collectorService.AssertNotCalled(t, "StoreMalformedEvent", mock.Anything)
+}
+
+func TestProcessDiscoveryMessage_QueueFull(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreEvent", mock.Anything).Return(datapipeline.ErrEventsQueueFull)
+
+	message, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: datapipeline.HostDiscovery,
+		Payload:       []byte(`{"hostname": "suse01", "cpu_count": 4}`),
+	})
+
+	// a full projectors queue is transient, the message has to be redelivered
+	err := processDiscoveryMessage(collectorService, message)
+
+	assert.ErrorIs(t, err, datapipeline.ErrEventsQueueFull)
+}
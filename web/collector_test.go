@@ -37,3 +37,126 @@ func TestApiCollectDataHandler(t *testing.T) {
 
 	assert.Equal(t, 202, resp.Code)
 }
+
+func TestApiCollectDataHandlerUnsupportedSchemaVersion(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreEvent", mock.Anything).Return(datapipeline.ErrUnsupportedSchemaVersion)
+
+	deps := setupTestDependencies()
+	deps.collectorService = collectorService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: "discovery",
+		Payload:       []byte("{}"),
+		SchemaVersion: 99,
+	})
+	req := httptest.NewRequest("POST", "/api/collect", bytes.NewBuffer(body))
+
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiProjectionRebuild(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("RebuildProjection", "hosts").Return(int64(3), nil)
+
+	deps := setupTestDependencies()
+	deps.collectorService = collectorService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/internal/projections/hosts/rebuild", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"projection": "hosts", "replayed_events": 3}`, resp.Body.String())
+}
+
+func TestApiProjectionRebuildNotFound(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("RebuildProjection", "unknown").Return(int64(0), datapipeline.ErrProjectionNotFound)
+
+	deps := setupTestDependencies()
+	deps.collectorService = collectorService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/internal/projections/unknown/rebuild", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiCollectDataHandlerBackpressure(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreEvent", mock.Anything).Return(datapipeline.ErrEventsQueueFull)
+
+	deps := setupTestDependencies()
+	deps.collectorService = collectorService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: "discovery",
+		Payload:       []byte("{}"),
+	})
+	req := httptest.NewRequest("POST", "/api/collect", bytes.NewBuffer(body))
+
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 503, resp.Code)
+	assert.Equal(t, "5", resp.Header().Get("Retry-After"))
+}
+
+func TestApiCollectDataHandlerRejectsMalformedPayloads(t *testing.T) {
+	collectorService := new(services.MockCollectorService)
+	collectorService.On("StoreMalformedEvent", mock.Anything).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.collectorService = collectorService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body, _ := json.Marshal(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: datapipeline.HostDiscovery,
+		Payload:       []byte(`{"cpu_count": "four"}`),
+	})
+	req := httptest.NewRequest("POST", "/api/collect", bytes.NewBuffer(body))
+
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 422, resp.Code)
+	assert.Contains(t, resp.Body.String(), "validation_errors")
+	collectorService.AssertNotCalled(t, "StoreEvent", mock.Anything)
+	collectorService.AssertCalled(t, "StoreMalformedEvent", mock.Anything)
+}
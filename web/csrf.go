@@ -0,0 +1,92 @@
+package web
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	csrfTokenKey    = "csrf_token"
+	csrfTokenHeader = "X-CSRF-Token"
+)
+
+// CSRFMiddleware protects the session-cookie-based browser endpoints from
+// cross-site request forgery: a per-session token is issued on safe requests
+// and has to be sent back by the POST forms, either as the csrf_token form
+// field or as the X-CSRF-Token header. The /api endpoints are exempt, as they
+// are not authenticated by the session cookie.
+func CSRFMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/api") {
+			c.Next()
+			return
+		}
+
+		session := sessions.Default(c)
+
+		token, _ := session.Get(csrfTokenKey).(string)
+		if token == "" {
+			var err error
+			if token, err = newCSRFToken(); err != nil {
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+
+			session.Set(csrfTokenKey, token)
+			if err := session.Save(); err != nil {
+				_ = c.Error(err)
+				c.Abort()
+				return
+			}
+		}
+		c.Set(csrfTokenKey, token)
+
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		requestToken := c.GetHeader(csrfTokenHeader)
+		if requestToken == "" {
+			requestToken = c.PostForm(csrfTokenKey)
+		}
+
+		if subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			_ = c.Error(ForbiddenError("the CSRF token is missing or invalid"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// CSRFToken returns the token bound to the session of the current request
+func CSRFToken(c *gin.Context) string {
+	return c.GetString(csrfTokenKey)
+}
+
+// CSRFField renders the hidden input the browser POST forms have to embed
+func CSRFField(c *gin.Context) template.HTML {
+	return template.HTML(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`,
+		csrfTokenKey, template.HTMLEscapeString(CSRFToken(c))))
+}
+
+func newCSRFToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+var csrfFieldPattern = regexp.MustCompile(`name="csrf_token" value="([0-9a-f]+)"`)
+
+// extractCSRFToken reads the CSRF token out of the hidden form field of a
+// rendered page
+func extractCSRFToken(t *testing.T, body string) string {
+	matches := csrfFieldPattern.FindStringSubmatch(body)
+	if len(matches) != 2 {
+		t.Fatal("could not find the CSRF token field in the rendered page")
+	}
+
+	return matches[1]
+}
+
+func TestCSRFMiddlewareRejectsMissingToken(t *testing.T) {
+	mockedSettingsService := new(services.MockSettingsService)
+	mockedSettingsService.On("AcceptEula").Return(nil)
+	mockedSettingsService.On("IsEulaAccepted").Return(false, nil)
+	mockedSettingsService.On("InitializeIdentifier").Return(uuid.MustParse("59fd8017-b7fd-477b-9ebe-b658c558f3e9"), nil)
+	deps := setupTestDependencies()
+	deps.settingsService = mockedSettingsService
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/accept-eula", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 403, resp.Code)
+	assert.Contains(t, resp.Body.String(), "the CSRF token is missing or invalid")
+	mockedSettingsService.AssertNotCalled(t, "AcceptEula")
+}
+
+func TestCSRFMiddlewareRejectsForeignToken(t *testing.T) {
+	mockedSettingsService := new(services.MockSettingsService)
+	mockedSettingsService.On("AcceptEula").Return(nil)
+	mockedSettingsService.On("IsEulaAccepted").Return(false, nil)
+	mockedSettingsService.On("InitializeIdentifier").Return(uuid.MustParse("59fd8017-b7fd-477b-9ebe-b658c558f3e9"), nil)
+	deps := setupTestDependencies()
+	deps.settingsService = mockedSettingsService
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a token without the matching session cookie does not pass
+	eulaResp := httptest.NewRecorder()
+	eulaReq := httptest.NewRequest("GET", "/eula", nil)
+	app.webEngine.ServeHTTP(eulaResp, eulaReq)
+	token := extractCSRFToken(t, eulaResp.Body.String())
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/accept-eula",
+		strings.NewReader("csrf_token="+token))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 403, resp.Code)
+	mockedSettingsService.AssertNotCalled(t, "AcceptEula")
+}
+
+func TestCSRFMiddlewareAcceptsHeaderToken(t *testing.T) {
+	mockedSettingsService := new(services.MockSettingsService)
+	mockedSettingsService.On("IsEulaAccepted").Return(true, nil)
+	mockedSettingsService.On("IsTelemetryOptedOut").Return(false, nil)
+	mockedSettingsService.On("SetTelemetryOptOut", true).Return(nil)
+	mockedSettingsService.On("InitializeIdentifier").Return(uuid.MustParse("59fd8017-b7fd-477b-9ebe-b658c558f3e9"), nil)
+	subscriptionsMocks := new(services.MockSubscriptionsService)
+	subscriptionsMocks.On("GetPremiumData").Return(&models.PremiumData{}, nil)
+	deps := setupTestDependencies()
+	deps.settingsService = mockedSettingsService
+	deps.subscriptionsService = subscriptionsMocks
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aboutResp := httptest.NewRecorder()
+	aboutReq := httptest.NewRequest("GET", "/about", nil)
+	app.webEngine.ServeHTTP(aboutResp, aboutReq)
+	token := extractCSRFToken(t, aboutResp.Body.String())
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/toggle-telemetry", nil)
+	req.Header.Set("X-CSRF-Token", token)
+	for _, cookie := range aboutResp.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 302, resp.Code)
+	mockedSettingsService.AssertCalled(t, "SetTelemetryOptOut", true)
+}
@@ -0,0 +1,188 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONCustomFieldDefinition struct {
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type" binding:"required"`
+}
+
+type JSONCustomFieldValue struct {
+	Value string `json:"value" binding:"required"`
+}
+
+// ApiCustomFieldsDefinitionsHandler godoc
+// @Summary List the custom fields defined on a resource type
+// @Produce json
+// @Param resource_type path string true "Resource type" Enums(hosts, clusters, sapsystems, databases)
+// @Success 200 {object} models.CustomFieldDefinitionList
+// @Failure 500 {object} map[string]string
+// @Router /custom-fields/{resource_type} [get]
+func ApiCustomFieldsDefinitionsHandler(customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		definitions, err := customFieldsService.GetDefinitions(c.Param("resource_type"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, definitions)
+	}
+}
+
+// ApiCustomFieldDefinitionCreateHandler godoc
+// @Summary Define a custom field on a resource type
+// @Accept json
+// @Produce json
+// @Param resource_type path string true "Resource type" Enums(hosts, clusters, sapsystems, databases)
+// @Param Body body JSONCustomFieldDefinition true "The custom field to define"
+// @Success 201 {object} JSONCustomFieldDefinition
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /custom-fields/{resource_type} [post]
+func ApiCustomFieldDefinitionCreateHandler(customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCustomFieldDefinition
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err := customFieldsService.CreateDefinition(c.Param("resource_type"), r.Name, r.Type)
+		if isCustomFieldUserError(err) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, &r)
+	}
+}
+
+// ApiCustomFieldDefinitionDeleteHandler godoc
+// @Summary Delete a custom field definition and all its stored values
+// @Produce json
+// @Param resource_type path string true "Resource type" Enums(hosts, clusters, sapsystems, databases)
+// @Param name path string true "Custom field name"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /custom-fields/{resource_type}/{name} [delete]
+func ApiCustomFieldDefinitionDeleteHandler(customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := customFieldsService.DeleteDefinition(c.Param("resource_type"), c.Param("name"))
+		if errors.Is(err, services.ErrCustomFieldNotDefined) {
+			_ = c.Error(NotFoundError("could not find custom field"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// resourceID returns the resource id path parameter; the clusters GET routes
+// use :cluster_id instead of :id, so both names are accepted
+func resourceID(c *gin.Context) string {
+	if id := c.Param("id"); id != "" {
+		return id
+	}
+
+	return c.Param("cluster_id")
+}
+
+// ApiResourceFieldsHandler returns the custom field values of one resource;
+// the resource type is fixed by the route it is mounted on, like the tags
+// handlers
+func ApiResourceFieldsHandler(resourceType string, customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields, err := customFieldsService.GetValues(resourceType, resourceID(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, fields)
+	}
+}
+
+// ApiResourceFieldSetHandler sets a custom field value on one resource,
+// validating it against the field definition
+func ApiResourceFieldSetHandler(resourceType string, customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCustomFieldValue
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err := customFieldsService.SetValue(resourceType, resourceID(c), c.Param("name"), r.Value)
+		if errors.Is(err, services.ErrCustomFieldNotDefined) {
+			_ = c.Error(NotFoundError("could not find custom field"))
+			return
+		}
+		if errors.Is(err, services.ErrCustomFieldInvalidValue) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &r)
+	}
+}
+
+// ApiResourceFieldDeleteHandler clears a custom field value on one resource
+func ApiResourceFieldDeleteHandler(resourceType string, customFieldsService services.CustomFieldsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := customFieldsService.DeleteValue(resourceType, resourceID(c), c.Param("name"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ParseCustomFieldsQuery turns the repeated "name:value" query parameters of
+// the list endpoints into the custom fields filter map, ignoring the
+// malformed entries
+func ParseCustomFieldsQuery(params []string) map[string]string {
+	fields := make(map[string]string)
+	for _, param := range params {
+		parts := strings.SplitN(param, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		fields[parts[0]] = parts[1]
+	}
+
+	return fields
+}
+
+// isCustomFieldUserError tells whether the error is caused by the request
+// content rather than by the server
+func isCustomFieldUserError(err error) bool {
+	return errors.Is(err, services.ErrCustomFieldAlreadyDefined) ||
+		errors.Is(err, services.ErrCustomFieldInvalidType) ||
+		errors.Is(err, services.ErrCustomFieldInvalidResourceType)
+}
@@ -0,0 +1,139 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func customFieldsTestApp(t *testing.T, mockCustomFieldsService *services.MockCustomFieldsService) *App {
+	deps := setupTestDependencies()
+	deps.customFieldsService = mockCustomFieldsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func TestApiCustomFieldsDefinitionsHandler(t *testing.T) {
+	mockCustomFieldsService := new(services.MockCustomFieldsService)
+	mockCustomFieldsService.On("GetDefinitions", "hosts").Return(models.CustomFieldDefinitionList{
+		{
+			ResourceType: "hosts",
+			Name:         "owner",
+			Type:         models.CustomFieldTypeString,
+		},
+	}, nil)
+
+	app := customFieldsTestApp(t, mockCustomFieldsService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/custom-fields/hosts", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[{
+		"resource_type": "hosts",
+		"name": "owner",
+		"type": "string"
+	}]`, resp.Body.String())
+
+	mockCustomFieldsService.AssertExpectations(t)
+}
+
+func TestApiCustomFieldDefinitionCreateHandler(t *testing.T) {
+	mockCustomFieldsService := new(services.MockCustomFieldsService)
+	mockCustomFieldsService.On("CreateDefinition", "hosts", "owner", "string").Return(nil)
+	mockCustomFieldsService.On("CreateDefinition", "hosts", "sla", "boolean").
+		Return(services.ErrCustomFieldInvalidType)
+
+	app := customFieldsTestApp(t, mockCustomFieldsService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/custom-fields/hosts",
+		bytes.NewBufferString(`{"name": "owner", "type": "string"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/custom-fields/hosts",
+		bytes.NewBufferString(`{"name": "sla", "type": "boolean"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockCustomFieldsService.AssertExpectations(t)
+}
+
+func TestApiCustomFieldDefinitionDeleteHandler(t *testing.T) {
+	mockCustomFieldsService := new(services.MockCustomFieldsService)
+	mockCustomFieldsService.On("DeleteDefinition", "hosts", "owner").Return(nil)
+	mockCustomFieldsService.On("DeleteDefinition", "hosts", "ghost").
+		Return(services.ErrCustomFieldNotDefined)
+
+	app := customFieldsTestApp(t, mockCustomFieldsService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/custom-fields/hosts/owner", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/custom-fields/hosts/ghost", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockCustomFieldsService.AssertExpectations(t)
+}
+
+func TestApiResourceFieldsHandlers(t *testing.T) {
+	mockCustomFieldsService := new(services.MockCustomFieldsService)
+	mockCustomFieldsService.On("GetValues", "hosts", "1").
+		Return(map[string]string{"owner": "team-hana"}, nil)
+	mockCustomFieldsService.On("SetValue", "clusters", "2", "owner", "team-hana").Return(nil)
+	mockCustomFieldsService.On("SetValue", "clusters", "2", "ghost", "value").
+		Return(services.ErrCustomFieldNotDefined)
+	mockCustomFieldsService.On("DeleteValue", "sapsystems", "3", "owner").Return(nil)
+
+	app := customFieldsTestApp(t, mockCustomFieldsService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/1/fields", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"owner": "team-hana"}`, resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/clusters/2/fields/owner",
+		bytes.NewBufferString(`{"value": "team-hana"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/clusters/2/fields/ghost",
+		bytes.NewBufferString(`{"value": "value"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/sapsystems/3/fields/owner", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	mockCustomFieldsService.AssertExpectations(t)
+}
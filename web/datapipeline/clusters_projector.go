@@ -2,7 +2,9 @@ package datapipeline
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +12,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/internal/cluster"
 	"github.com/trento-project/trento/internal/cluster/cib"
+	"github.com/trento-project/trento/internal/cluster/crmmon"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/datatypes"
@@ -62,11 +65,431 @@ func clustersProjector_ClusterDiscoveryHandler(event *DataCollectedEvent, db *go
 		return err
 	}
 
+	err = storeClusterConfigSnapshot(db, &cluster)
+	if err != nil {
+		log.Errorf("can't store cluster config snapshot: %s", err)
+		return err
+	}
+
+	// failovers are detected against the currently projected read models,
+	// so this must happen before they are replaced with the new discovery
+	err = detectFailovers(db, clusterReadModel, &cluster)
+	if err != nil {
+		log.Errorf("can't detect failovers: %s", err)
+		return err
+	}
+
+	err = detectSBDFailures(db, clusterReadModel)
+	if err != nil {
+		log.Errorf("can't detect SBD failures: %s", err)
+		return err
+	}
+
+	err = projectClusterResources(db, &cluster)
+	if err != nil {
+		log.Errorf("can't project cluster resources: %s", err)
+		return err
+	}
+
+	err = projectClusterVIPs(db, &cluster)
+	if err != nil {
+		log.Errorf("can't project cluster virtual IPs: %s", err)
+		return err
+	}
+
+	err = recordClusterDiscoveryChange(db, clusterReadModel)
+	if err != nil {
+		log.Errorf("can't record cluster discovery change: %s", err)
+		return err
+	}
+
 	return db.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(clusterReadModel).Error
 }
 
+// detectFailovers compares the incoming discovery with the currently projected
+// cluster and records a failover event and a firing alert when the HANA
+// primary or a resource moved to another node
+func detectFailovers(db *gorm.DB, clusterReadModel *entities.Cluster, c *cluster.Cluster) error {
+	var previousCluster entities.Cluster
+
+	err := db.Where("id = ?", clusterReadModel.ID).First(&previousCluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var failovers []string
+
+	previousPrimary := parseHANAPrimaryNode(&previousCluster)
+	currentPrimary := parseHANAPrimaryNode(clusterReadModel)
+	if previousPrimary != "" && currentPrimary != "" && previousPrimary != currentPrimary {
+		failovers = append(failovers,
+			fmt.Sprintf("HANA primary moved from node %s to node %s", previousPrimary, currentPrimary))
+	}
+
+	var previousResources []entities.PacemakerResource
+	err = db.Where("cluster_id", clusterReadModel.ID).Find(&previousResources).Error
+	if err != nil {
+		return err
+	}
+
+	previousNodes := make(map[string]string)
+	for _, r := range previousResources {
+		previousNodes[r.ID] = r.Node
+	}
+
+	resources := c.Crmmon.Resources
+	for _, g := range c.Crmmon.Groups {
+		resources = append(resources, g.Resources...)
+	}
+	for _, cl := range c.Crmmon.Clones {
+		resources = append(resources, cl.Resources...)
+	}
+
+	for _, r := range resources {
+		if r.Node == nil || r.Node.Name == "" {
+			continue
+		}
+
+		previousNode, ok := previousNodes[r.Id]
+		if ok && previousNode != "" && previousNode != r.Node.Name {
+			failovers = append(failovers,
+				fmt.Sprintf("Resource %s moved from node %s to node %s", r.Id, previousNode, r.Node.Name))
+		}
+	}
+
+	for _, failover := range failovers {
+		err = db.Create(&entities.ResourceEvent{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterReadModel.ID,
+			Type:         models.ResourceEventTypeFailover,
+			Message:      failover,
+			OccurredAt:   time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		fingerprint := fmt.Sprintf("%x",
+			sha256.Sum256([]byte(fmt.Sprintf("%s/%s", clusterReadModel.ID, failover))))
+
+		err = db.Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).Create(&entities.Alert{
+			Fingerprint:  fingerprint,
+			Status:       models.AlertStatusFiring,
+			Name:         "cluster_failover",
+			Severity:     models.HealthSummaryHealthWarning,
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterReadModel.ID,
+			Summary:      failover,
+			StartsAt:     time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// detectSBDFailures compares the incoming SBD device health with the
+// currently projected one and records an event and a firing alert for every
+// device that became unreadable, as a broken SBD device silently disables
+// STONITH until a real failover exposes it
+func detectSBDFailures(db *gorm.DB, clusterReadModel *entities.Cluster) error {
+	var previousCluster entities.Cluster
+
+	err := db.Where("id = ?", clusterReadModel.ID).First(&previousCluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	previousStatuses := make(map[string]string)
+	if previousCluster.SBDDevices != nil {
+		var previousDevices []*entities.SBDDevice
+		if err := json.Unmarshal(previousCluster.SBDDevices, &previousDevices); err == nil {
+			for _, device := range previousDevices {
+				previousStatuses[device.Device] = device.Status
+			}
+		}
+	}
+
+	var currentDevices []*entities.SBDDevice
+	if clusterReadModel.SBDDevices != nil {
+		if err := json.Unmarshal(clusterReadModel.SBDDevices, &currentDevices); err != nil {
+			return nil
+		}
+	}
+
+	for _, device := range currentDevices {
+		if device.Status == cluster.SBDStatusHealthy ||
+			previousStatuses[device.Device] != cluster.SBDStatusHealthy {
+			continue
+		}
+
+		message := fmt.Sprintf("SBD device %s became unhealthy", device.Device)
+
+		err = db.Create(&entities.ResourceEvent{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterReadModel.ID,
+			Type:         models.ResourceEventTypeHealth,
+			Message:      message,
+			OccurredAt:   time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		fingerprint := fmt.Sprintf("%x",
+			sha256.Sum256([]byte(fmt.Sprintf("%s/%s", clusterReadModel.ID, device.Device))))
+
+		err = db.Clauses(clause.OnConflict{
+			UpdateAll: true,
+		}).Create(&entities.Alert{
+			Fingerprint:  fingerprint,
+			Status:       models.AlertStatusFiring,
+			Name:         "sbd_device_unhealthy",
+			Severity:     models.HealthSummaryHealthCritical,
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterReadModel.ID,
+			Summary:      message,
+			StartsAt:     time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseHANAPrimaryNode returns the name of the node currently holding the HANA
+// primary role, or an empty string for non-HANA clusters
+func parseHANAPrimaryNode(c *entities.Cluster) string {
+	if c.ClusterType != models.ClusterTypeHANAScaleUp && c.ClusterType != models.ClusterTypeHANAScaleOut {
+		return ""
+	}
+
+	var details entities.HANAClusterDetails
+	if err := json.Unmarshal(c.Details, &details); err != nil {
+		return ""
+	}
+
+	for _, node := range details.Nodes {
+		if node.HANAStatus == models.HANAStatusPrimary {
+			return node.Name
+		}
+	}
+
+	return ""
+}
+
+// recordClusterDiscoveryChange adds a timeline event when a new discovery
+// reports different cluster data than the currently projected one
+func recordClusterDiscoveryChange(db *gorm.DB, clusterReadModel *entities.Cluster) error {
+	var previousCluster entities.Cluster
+
+	err := db.Where("id = ?", clusterReadModel.ID).First(&previousCluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if previousCluster.Name == clusterReadModel.Name &&
+		previousCluster.ClusterType == clusterReadModel.ClusterType &&
+		previousCluster.HostsNumber == clusterReadModel.HostsNumber &&
+		previousCluster.ResourcesNumber == clusterReadModel.ResourcesNumber &&
+		bytes.Equal(previousCluster.Details, clusterReadModel.Details) {
+		return nil
+	}
+
+	return db.Create(&entities.ResourceEvent{
+		ResourceType: models.TagClusterResourceType,
+		ResourceID:   clusterReadModel.ID,
+		Type:         models.ResourceEventTypeDiscovery,
+		Message:      "Discovered cluster data changed",
+		OccurredAt:   time.Now(),
+	}).Error
+}
+
+// storeClusterConfigSnapshot appends a new raw configuration snapshot when the
+// CIB XML or the corosync.conf content changed since the last discovery
+func storeClusterConfigSnapshot(db *gorm.DB, c *cluster.Cluster) error {
+	cibXML := string(c.Cib.Raw)
+	if cibXML == "" && c.CorosyncConf == "" {
+		return nil
+	}
+
+	var lastSnapshot entities.ClusterConfigSnapshot
+	err := db.Where("cluster_id", c.Id).Last(&lastSnapshot).Error
+	if err == nil && lastSnapshot.CibXML == cibXML && lastSnapshot.CorosyncConf == c.CorosyncConf {
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return db.Create(&entities.ClusterConfigSnapshot{
+		ClusterID:    c.Id,
+		CibXML:       cibXML,
+		CorosyncConf: c.CorosyncConf,
+	}).Error
+}
+
+// projectClusterResources replaces the per-resource read models of a cluster
+// with the resources found in the current discovery
+func projectClusterResources(db *gorm.DB, c *cluster.Cluster) error {
+	resources := c.Crmmon.Resources
+	for _, g := range c.Crmmon.Groups {
+		resources = append(resources, g.Resources...)
+	}
+
+	for _, cl := range c.Crmmon.Clones {
+		resources = append(resources, cl.Resources...)
+	}
+
+	var resourceReadModels []*entities.PacemakerResource
+	for _, r := range resources {
+		resourceReadModel := &entities.PacemakerResource{
+			ID:        r.Id,
+			ClusterID: c.Id,
+			Type:      r.Agent,
+			Role:      r.Role,
+			Status:    parseResourceStatus(r),
+		}
+
+		if r.Node != nil {
+			resourceReadModel.Node = r.Node.Name
+		}
+
+		for _, nh := range c.Crmmon.NodeHistory.Nodes {
+			if resourceReadModel.Node != "" && nh.Name != resourceReadModel.Node {
+				continue
+			}
+
+			for _, rh := range nh.ResourceHistory {
+				if rh.Name == r.Id {
+					resourceReadModel.FailCount = rh.FailCount
+					resourceReadModel.MigrationThreshold = rh.MigrationThreshold
+				}
+			}
+		}
+
+		resourceReadModels = append(resourceReadModels, resourceReadModel)
+	}
+
+	err := db.Where("cluster_id", c.Id).Delete(&entities.PacemakerResource{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(resourceReadModels) == 0 {
+		return nil
+	}
+
+	return db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(resourceReadModels).Error
+}
+
+// projectClusterVIPs replaces the virtual IP read models of a cluster,
+// resolving each configured IPaddr2 resource to the node currently running it
+func projectClusterVIPs(db *gorm.DB, c *cluster.Cluster) error {
+	primitives := c.Cib.Configuration.Resources.Primitives
+	for _, g := range c.Cib.Configuration.Resources.Groups {
+		primitives = append(primitives, g.Primitives...)
+	}
+	for _, cl := range c.Cib.Configuration.Resources.Clones {
+		primitives = append(primitives, cl.Primitive)
+	}
+	for _, m := range c.Cib.Configuration.Resources.Masters {
+		primitives = append(primitives, m.Primitive)
+	}
+
+	addresses := make(map[string]string)
+	for _, p := range primitives {
+		if p.Type != "IPaddr2" {
+			continue
+		}
+
+		for _, a := range p.InstanceAttributes {
+			if a.Name == "ip" {
+				addresses[p.Id] = a.Value
+			}
+		}
+	}
+
+	resources := c.Crmmon.Resources
+	for _, g := range c.Crmmon.Groups {
+		resources = append(resources, g.Resources...)
+	}
+	for _, cl := range c.Crmmon.Clones {
+		resources = append(resources, cl.Resources...)
+	}
+
+	var vipReadModels []*entities.ClusterVIP
+	for _, r := range resources {
+		address, ok := addresses[r.Id]
+		if !ok {
+			continue
+		}
+
+		vipReadModel := &entities.ClusterVIP{
+			ClusterID:  c.Id,
+			ResourceID: r.Id,
+			Address:    address,
+			Status:     parseResourceStatus(r),
+		}
+
+		if r.Node != nil {
+			vipReadModel.Node = r.Node.Name
+		}
+
+		vipReadModels = append(vipReadModels, vipReadModel)
+	}
+
+	err := db.Where("cluster_id", c.Id).Delete(&entities.ClusterVIP{}).Error
+	if err != nil {
+		return err
+	}
+
+	if len(vipReadModels) == 0 {
+		return nil
+	}
+
+	return db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(vipReadModels).Error
+}
+
+// parseResourceStatus maps the crm_mon resource flags to a status string
+func parseResourceStatus(r crmmon.Resource) string {
+	switch {
+	case r.Active:
+		return "active"
+	case r.Blocked:
+		return "blocked"
+	case r.Failed:
+		return "failed"
+	case r.FailureIgnored:
+		return "failure_ignored"
+	case r.Orphaned:
+		return "orphaned"
+	}
+
+	return ""
+}
+
 // transformClusterData transforms the cluster data into the read model
 func transformClusterData(cluster *cluster.Cluster) (*entities.Cluster, error) {
 	if cluster.Id == "" {
@@ -76,6 +499,14 @@ func transformClusterData(cluster *cluster.Cluster) (*entities.Cluster, error) {
 	clusterDetail, _ := parseClusterDetails(cluster)
 	log.Debugf("%s", clusterDetail)
 
+	fencingType, fencingStatus := analyzeFencing(cluster)
+
+	var sbdDevices datatypes.JSON
+	if devices := parseSBDDevices(cluster); len(devices) > 0 {
+		jsonSBDDevices, _ := json.Marshal(devices)
+		sbdDevices = (datatypes.JSON)(jsonSBDDevices)
+	}
+
 	return &entities.Cluster{
 		ID:          cluster.Id,
 		Name:        cluster.Name,
@@ -84,6 +515,10 @@ func transformClusterData(cluster *cluster.Cluster) (*entities.Cluster, error) {
 		SID:             parseClusterSID(cluster),
 		ResourcesNumber: cluster.Crmmon.Summary.Resources.Number,
 		HostsNumber:     cluster.Crmmon.Summary.Nodes.Number,
+		FencingType:     fencingType,
+		FencingStatus:   fencingStatus,
+		SBDWatchdog:     parseSBDWatchdog(cluster),
+		SBDDevices:      sbdDevices,
 		Details:         (datatypes.JSON)(clusterDetail),
 	}, nil
 }
@@ -110,11 +545,44 @@ func detectClusterType(cluster *cluster.Cluster) string {
 		return models.ClusterTypeHANAScaleUp
 	case hasSapHanaTopology && hasSAPHanaController:
 		return models.ClusterTypeHANAScaleOut
+	case isASCSERSCluster(cluster):
+		return models.ClusterTypeASCSERS
+	case cluster.Crmmon.Summary.Nodes.Number > 0:
+		return models.ClusterTypeGeneric
 	default:
 		return models.ClusterTypeUnknown
 	}
 }
 
+// isASCSERSCluster detects an ENSA cluster by looking for SAPInstance
+// resources running both an ASCS and an ERS instance
+func isASCSERSCluster(c *cluster.Cluster) bool {
+	var hasASCS, hasERS bool
+
+	for _, g := range c.Cib.Configuration.Resources.Groups {
+		for _, p := range g.Primitives {
+			if p.Type != "SAPInstance" {
+				continue
+			}
+
+			for _, a := range p.InstanceAttributes {
+				if a.Name != "InstanceName" {
+					continue
+				}
+
+				switch {
+				case strings.Contains(a.Value, "_ASCS"):
+					hasASCS = true
+				case strings.Contains(a.Value, "_ERS"):
+					hasERS = true
+				}
+			}
+		}
+	}
+
+	return hasASCS && hasERS
+}
+
 // parseClusterSID returns the SIDs of the cluster
 // TODO: HANA scale-out has multiple SIDs, we will need to implement this in the future
 func parseClusterSID(c *cluster.Cluster) string {
@@ -128,6 +596,21 @@ func parseClusterSID(c *cluster.Cluster) string {
 		}
 	}
 
+	// ASCS/ERS clusters carry the SID in the SAPInstance name, e.g. NWP_ASCS00_sapnwpas
+	for _, g := range c.Cib.Configuration.Resources.Groups {
+		for _, p := range g.Primitives {
+			if p.Type != "SAPInstance" {
+				continue
+			}
+
+			for _, a := range p.InstanceAttributes {
+				if a.Name == "InstanceName" && a.Value != "" {
+					return strings.Split(a.Value, "_")[0]
+				}
+			}
+		}
+	}
+
 	return ""
 }
 
@@ -136,11 +619,110 @@ func parseClusterDetails(c *cluster.Cluster) (json.RawMessage, error) {
 	switch detectClusterType(c) {
 	case models.ClusterTypeHANAScaleUp, models.ClusterTypeHANAScaleOut:
 		return parseHANAClusterDetails(c)
+	case models.ClusterTypeASCSERS:
+		return parseASCSERSClusterDetails(c)
+	case models.ClusterTypeGeneric:
+		return parseGenericClusterDetails(c)
 	default:
 		return json.RawMessage{}, nil
 	}
 }
 
+// parseASCSERSClusterDetails parses the details of an ENSA cluster
+func parseASCSERSClusterDetails(c *cluster.Cluster) (json.RawMessage, error) {
+	resources := c.Crmmon.Resources
+	for _, g := range c.Crmmon.Groups {
+		resources = append(resources, g.Resources...)
+	}
+
+	instanceNames := make(map[string]string)
+	for _, g := range c.Cib.Configuration.Resources.Groups {
+		for _, p := range g.Primitives {
+			for _, a := range p.InstanceAttributes {
+				if a.Name == "InstanceName" {
+					instanceNames[p.Id] = a.Value
+				}
+			}
+		}
+	}
+
+	var nodes []*entities.ASCSERSClusterNode
+	var ascsNode, ersNode string
+	for _, n := range c.Crmmon.Nodes {
+		node := &entities.ASCSERSClusterNode{
+			Name: n.Name,
+		}
+
+		for _, r := range resources {
+			if r.Node == nil || r.Node.Name != n.Name {
+				continue
+			}
+
+			node.Resources = append(node.Resources, &entities.ClusterResource{
+				ID:     r.Id,
+				Type:   r.Agent,
+				Role:   r.Role,
+				Status: parseResourceStatus(r),
+			})
+
+			if r.Agent == "ocf::heartbeat:IPaddr2" {
+				primitives := c.Cib.Configuration.Resources.Primitives
+				for _, g := range c.Cib.Configuration.Resources.Groups {
+					primitives = append(primitives, g.Primitives...)
+				}
+
+				for _, p := range primitives {
+					if r.Id == p.Id && len(p.InstanceAttributes) > 0 {
+						node.VirtualIPs = append(node.VirtualIPs, p.InstanceAttributes[0].Value)
+					}
+				}
+			}
+
+			switch {
+			case strings.Contains(instanceNames[r.Id], "_ASCS"):
+				node.Roles = append(node.Roles, "ASCS")
+				ascsNode = n.Name
+			case strings.Contains(instanceNames[r.Id], "_ERS"):
+				node.Roles = append(node.Roles, "ERS")
+				ersNode = n.Name
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	clusterDetail := &entities.ASCSERSClusterDetails{
+		FencingType:      parseClusterFencingType(c),
+		Distributed:      ascsNode != "" && ersNode != "" && ascsNode != ersNode,
+		StoppedResources: parseClusterStoppedResources(c),
+		Nodes:            nodes,
+	}
+
+	return json.Marshal(clusterDetail)
+}
+
+// parseGenericClusterDetails parses the details of a Pacemaker cluster not
+// running any recognized SAP workload
+func parseGenericClusterDetails(c *cluster.Cluster) (json.RawMessage, error) {
+	var nodes []*entities.GenericClusterNode
+	for _, n := range c.Crmmon.Nodes {
+		nodes = append(nodes, &entities.GenericClusterNode{
+			Name:             n.Name,
+			Online:           n.Online,
+			Standby:          n.Standby,
+			ResourcesRunning: n.ResourcesRunning,
+		})
+	}
+
+	clusterDetail := &entities.GenericClusterDetails{
+		FencingType:      parseClusterFencingType(c),
+		StoppedResources: parseClusterStoppedResources(c),
+		Nodes:            nodes,
+	}
+
+	return json.Marshal(clusterDetail)
+}
+
 // parseHANAClusterDetails parses the HANA cluster details
 func parseHANAClusterDetails(c *cluster.Cluster) (json.RawMessage, error) {
 	sid := parseClusterSID(c)
@@ -203,22 +785,10 @@ func parseClusterNodes(c *cluster.Cluster) []*entities.HANAClusterNode {
 			}
 			if r.Node.Name == n.Name {
 				resource := &entities.ClusterResource{
-					ID:   r.Id,
-					Type: r.Agent,
-					Role: r.Role,
-				}
-
-				switch {
-				case r.Active:
-					resource.Status = "active"
-				case r.Blocked:
-					resource.Status = "blocked"
-				case r.Failed:
-					resource.Status = "failed"
-				case r.FailureIgnored:
-					resource.Status = "failure_ignored"
-				case r.Orphaned:
-					resource.Status = "orphaned"
+					ID:     r.Id,
+					Type:   r.Agent,
+					Role:   r.Role,
+					Status: parseResourceStatus(r),
 				}
 
 				var primitives []cib.Primitive
@@ -373,8 +943,11 @@ func parseSBDDevices(c *cluster.Cluster) []*entities.SBDDevice {
 	var sbdDevices []*entities.SBDDevice
 	for _, s := range c.SBD.Devices {
 		sbdDevice := &entities.SBDDevice{
-			Device: s.Device,
-			Status: s.Status,
+			Device:          s.Device,
+			Status:          s.Status,
+			UUID:            s.Dump.Uuid,
+			TimeoutWatchdog: s.Dump.TimeoutWatchdog,
+			TimeoutMsgwait:  s.Dump.TimeoutMsgwait,
 		}
 		sbdDevices = append(sbdDevices, sbdDevice)
 	}
@@ -382,6 +955,15 @@ func parseSBDDevices(c *cluster.Cluster) []*entities.SBDDevice {
 	return sbdDevices
 }
 
+// parseSBDWatchdog returns the watchdog device configured for SBD
+func parseSBDWatchdog(c *cluster.Cluster) string {
+	if watchdog, ok := c.SBD.Config["SBD_WATCHDOG_DEV"].(string); ok {
+		return strings.Trim(watchdog, "\"")
+	}
+
+	return ""
+}
+
 func computeDiscoveredHealth(c *entities.Cluster) (string, error) {
 	switch c.ClusterType {
 	case models.ClusterTypeHANAScaleUp, models.ClusterTypeHANAScaleOut:
@@ -9,6 +9,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/trento-project/trento/internal/cluster"
+	"github.com/trento-project/trento/internal/cluster/cib"
+	"github.com/trento-project/trento/internal/cluster/crmmon"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -21,7 +23,7 @@ func TestClustersProjector_ClusterDiscoveryHandler(t *testing.T) {
 	tx := db.Begin()
 	defer tx.Rollback()
 
-	tx.AutoMigrate(&entities.Cluster{}, &entities.HealthState{})
+	tx.AutoMigrate(&entities.Cluster{}, &entities.HealthState{}, &entities.ResourceEvent{})
 	tx.Create(&entities.Cluster{
 		Name:        "test_cluster",
 		ID:          "test_id",
@@ -62,6 +64,274 @@ func TestClustersProjector_ClusterDiscoveryHandler(t *testing.T) {
 	assert.Equal(t, map[string]string{"hana_sr_health": "critical"}, partialHealth)
 }
 
+func TestClustersProjector_ClusterConfigSnapshotHistory(t *testing.T) {
+	db := helpers.SetupTestDatabase(t)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	tx.AutoMigrate(&entities.Cluster{}, &entities.HealthState{}, &entities.ClusterConfigSnapshot{}, &entities.ResourceEvent{})
+
+	jsonFile, err := os.Open("./test/fixtures/discovery/cluster/cluster_discovery_hana_scale_up.json")
+	if err != nil {
+		panic(err)
+	}
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+
+	var discoveredCluster cluster.Cluster
+	json.Unmarshal(byteValue, &discoveredCluster)
+	discoveredCluster.Cib.Raw = []byte(`<cib epoch="1"/>`)
+	discoveredCluster.CorosyncConf = "totem {\n  version: 2\n}"
+	payload, _ := json.Marshal(&discoveredCluster)
+
+	dataCollectedEvent := &DataCollectedEvent{
+		ID:            1,
+		AgentID:       "agent_id",
+		DiscoveryType: ClusterDiscovery,
+		Payload:       payload,
+	}
+
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+	// an unchanged configuration does not append a new snapshot
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	var snapshots []entities.ClusterConfigSnapshot
+	tx.Find(&snapshots)
+
+	assert.Len(t, snapshots, 1)
+	assert.Equal(t, "5dfbd28f35cbfb38969f9b99243ae8d4", snapshots[0].ClusterID)
+	assert.Equal(t, `<cib epoch="1"/>`, snapshots[0].CibXML)
+	assert.Equal(t, "totem {\n  version: 2\n}", snapshots[0].CorosyncConf)
+
+	discoveredCluster.Cib.Raw = []byte(`<cib epoch="2"/>`)
+	dataCollectedEvent.Payload, _ = json.Marshal(&discoveredCluster)
+
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	tx.Order("id").Find(&snapshots)
+
+	assert.Len(t, snapshots, 2)
+	assert.Equal(t, `<cib epoch="2"/>`, snapshots[1].CibXML)
+}
+
+func TestClustersProjector_PacemakerResources(t *testing.T) {
+	db := helpers.SetupTestDatabase(t)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	tx.AutoMigrate(&entities.Cluster{}, &entities.HealthState{}, &entities.ClusterConfigSnapshot{}, &entities.PacemakerResource{}, &entities.ResourceEvent{})
+
+	jsonFile, err := os.Open("./test/fixtures/discovery/cluster/cluster_discovery_hana_scale_up.json")
+	if err != nil {
+		panic(err)
+	}
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	dataCollectedEvent := &DataCollectedEvent{
+		ID:            1,
+		AgentID:       "agent_id",
+		DiscoveryType: ClusterDiscovery,
+		Payload:       byteValue,
+	}
+
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	var stonith entities.PacemakerResource
+	tx.Where("id", "stonith-sbd").First(&stonith)
+
+	assert.Equal(t, "5dfbd28f35cbfb38969f9b99243ae8d4", stonith.ClusterID)
+	assert.Equal(t, "stonith:external/sbd", stonith.Type)
+	assert.Equal(t, "Started", stonith.Role)
+	assert.Equal(t, "active", stonith.Status)
+	assert.Equal(t, "vmhana01", stonith.Node)
+
+	var stopped entities.PacemakerResource
+	tx.Where("id", "stopped_dummy_resource").First(&stopped)
+
+	assert.Equal(t, "", stopped.Node)
+	assert.Equal(t, "", stopped.Status)
+
+	// a new discovery replaces the projected resources
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	var count int64
+	tx.Model(&entities.PacemakerResource{}).Where("id", "stonith-sbd").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestClustersProjector_ClusterVIPs(t *testing.T) {
+	db := helpers.SetupTestDatabase(t)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	tx.AutoMigrate(&entities.Cluster{}, &entities.HealthState{}, &entities.ClusterConfigSnapshot{}, &entities.PacemakerResource{}, &entities.ClusterVIP{}, &entities.ResourceEvent{})
+
+	jsonFile, err := os.Open("./test/fixtures/discovery/cluster/cluster_discovery_hana_scale_up.json")
+	if err != nil {
+		panic(err)
+	}
+	byteValue, _ := ioutil.ReadAll(jsonFile)
+	dataCollectedEvent := &DataCollectedEvent{
+		ID:            1,
+		AgentID:       "agent_id",
+		DiscoveryType: ClusterDiscovery,
+		Payload:       byteValue,
+	}
+
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	var vips []entities.ClusterVIP
+	tx.Order("resource_id").Find(&vips)
+
+	assert.Len(t, vips, 1)
+	assert.Equal(t, "5dfbd28f35cbfb38969f9b99243ae8d4", vips[0].ClusterID)
+	assert.Equal(t, "rsc_ip_PRD_HDB00", vips[0].ResourceID)
+	assert.Equal(t, "10.74.1.12", vips[0].Address)
+	assert.Equal(t, "vmhana01", vips[0].Node)
+	assert.Equal(t, "active", vips[0].Status)
+
+	// a new discovery replaces the projected virtual IPs
+	clustersProjector_ClusterDiscoveryHandler(dataCollectedEvent, tx)
+
+	var count int64
+	tx.Model(&entities.ClusterVIP{}).Where("cluster_id", "5dfbd28f35cbfb38969f9b99243ae8d4").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestClustersProjector_DetectFailovers(t *testing.T) {
+	db := helpers.SetupTestDatabase(t)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	tx.AutoMigrate(&entities.Cluster{}, &entities.PacemakerResource{}, &entities.ResourceEvent{}, &entities.Alert{})
+
+	previousDetails, _ := json.Marshal(&entities.HANAClusterDetails{
+		Nodes: []*entities.HANAClusterNode{
+			{Name: "node01", HANAStatus: models.HANAStatusPrimary},
+			{Name: "node02", HANAStatus: models.HANAStatusSecondary},
+		},
+	})
+	tx.Create(&entities.Cluster{
+		ID:          "failover_cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Details:     previousDetails,
+	})
+	tx.Create(&entities.PacemakerResource{
+		ID:        "rsc_ip_PRD_HDB00",
+		ClusterID: "failover_cluster",
+		Node:      "node01",
+	})
+
+	currentDetails, _ := json.Marshal(&entities.HANAClusterDetails{
+		Nodes: []*entities.HANAClusterNode{
+			{Name: "node01", HANAStatus: models.HANAStatusFailed},
+			{Name: "node02", HANAStatus: models.HANAStatusPrimary},
+		},
+	})
+	clusterReadModel := &entities.Cluster{
+		ID:          "failover_cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Details:     currentDetails,
+	}
+
+	movedResource := crmmon.Resource{Id: "rsc_ip_PRD_HDB00"}
+	movedResource.Node = &struct {
+		Name   string `xml:"name,attr"`
+		Id     string `xml:"id,attr"`
+		Cached bool   `xml:"cached,attr"`
+	}{Name: "node02"}
+
+	discoveredCluster := &cluster.Cluster{
+		Crmmon: crmmon.Root{
+			Resources: []crmmon.Resource{movedResource},
+		},
+	}
+
+	err := detectFailovers(tx, clusterReadModel, discoveredCluster)
+	assert.NoError(t, err)
+
+	var events []entities.ResourceEvent
+	tx.Where("resource_id", "failover_cluster").Order("id").Find(&events)
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, models.ResourceEventTypeFailover, events[0].Type)
+	assert.Equal(t, "HANA primary moved from node node01 to node node02", events[0].Message)
+	assert.Equal(t, models.ResourceEventTypeFailover, events[1].Type)
+	assert.Equal(t, "Resource rsc_ip_PRD_HDB00 moved from node node01 to node node02", events[1].Message)
+
+	var alerts []entities.Alert
+	tx.Where("resource_id", "failover_cluster").Find(&alerts)
+
+	assert.Len(t, alerts, 2)
+	for _, alert := range alerts {
+		assert.Equal(t, "cluster_failover", alert.Name)
+		assert.Equal(t, models.AlertStatusFiring, alert.Status)
+	}
+
+	// an unchanged discovery does not raise anything
+	tx.Create(&entities.Cluster{ID: "quiet_cluster", ClusterType: models.ClusterTypeHANAScaleUp, Details: currentDetails})
+	err = detectFailovers(tx, &entities.Cluster{
+		ID:          "quiet_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Details:     currentDetails,
+	}, discoveredCluster)
+	assert.NoError(t, err)
+
+	var count int64
+	tx.Model(&entities.ResourceEvent{}).Where("resource_id", "quiet_cluster").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestClustersProjector_DetectSBDFailures(t *testing.T) {
+	db := helpers.SetupTestDatabase(t)
+
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	tx.AutoMigrate(&entities.Cluster{}, &entities.ResourceEvent{}, &entities.Alert{})
+
+	previousDevices, _ := json.Marshal([]*entities.SBDDevice{
+		{Device: "/dev/vdc", Status: cluster.SBDStatusHealthy},
+		{Device: "/dev/vdd", Status: cluster.SBDStatusUnhealthy},
+	})
+	tx.Create(&entities.Cluster{
+		ID:         "sbd_cluster",
+		SBDDevices: previousDevices,
+	})
+
+	currentDevices, _ := json.Marshal([]*entities.SBDDevice{
+		{Device: "/dev/vdc", Status: cluster.SBDStatusUnhealthy},
+		{Device: "/dev/vdd", Status: cluster.SBDStatusUnhealthy},
+	})
+
+	err := detectSBDFailures(tx, &entities.Cluster{
+		ID:         "sbd_cluster",
+		SBDDevices: currentDevices,
+	})
+	assert.NoError(t, err)
+
+	// only the healthy to unhealthy transition raises an event, the device
+	// that was already unhealthy does not fire again
+	var events []entities.ResourceEvent
+	tx.Where("resource_id", "sbd_cluster").Find(&events)
+
+	assert.Len(t, events, 1)
+	assert.Equal(t, models.ResourceEventTypeHealth, events[0].Type)
+	assert.Equal(t, "SBD device /dev/vdc became unhealthy", events[0].Message)
+
+	var alerts []entities.Alert
+	tx.Where("resource_id", "sbd_cluster").Find(&alerts)
+
+	assert.Len(t, alerts, 1)
+	assert.Equal(t, "sbd_device_unhealthy", alerts[0].Name)
+	assert.Equal(t, models.AlertStatusFiring, alerts[0].Status)
+	assert.Equal(t, models.HealthSummaryHealthCritical, alerts[0].Severity)
+}
+
 func TestTransformClusterData_HANAScaleUp(t *testing.T) {
 	jsonFile, err := os.Open("./test/fixtures/discovery/cluster/cluster_discovery_hana_scale_up.json")
 	if err != nil {
@@ -73,6 +343,21 @@ func TestTransformClusterData_HANAScaleUp(t *testing.T) {
 	json.Unmarshal(byteValue, &clusterIn)
 	clusterOut, _ := transformClusterData(&clusterIn)
 
+	expectedSBDDevices := []*entities.SBDDevice{
+		{
+			Device:          "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_649b292b-ae9d-49a4-8002-2e602a0ab56e",
+			Status:          "healthy",
+			UUID:            "708dc32b-b33e-4be7-b12f-148bcca62cd0",
+			TimeoutWatchdog: 5,
+			TimeoutMsgwait:  10,
+		},
+		{
+			Device: "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_649b292b-ae9d-49a4-8002-2e602a012345",
+			Status: "unhealthy",
+			UUID:   "708dc32b-b33e-4be7-b12f-148bcca1234",
+		},
+	}
+
 	expectedHANAClusterDetails, _ := json.Marshal(
 		&entities.HANAClusterDetails{
 			SystemReplicationMode:          "sync",
@@ -190,19 +475,12 @@ func TestTransformClusterData_HANAScaleUp(t *testing.T) {
 					HANAStatus: models.HANAStatusFailed,
 				},
 			},
-			SBDDevices: []*entities.SBDDevice{
-				{
-					Device: "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_649b292b-ae9d-49a4-8002-2e602a0ab56e",
-					Status: "healthy",
-				},
-				{
-					Device: "/dev/disk/by-id/scsi-SLIO-ORG_IBLOCK_649b292b-ae9d-49a4-8002-2e602a012345",
-					Status: "unhealthy",
-				},
-			},
+			SBDDevices: expectedSBDDevices,
 		},
 	)
 
+	expectedSBDDevicesJSON, _ := json.Marshal(expectedSBDDevices)
+
 	assert.EqualValues(t,
 		&entities.Cluster{
 			Name:            "hana_cluster",
@@ -211,6 +489,10 @@ func TestTransformClusterData_HANAScaleUp(t *testing.T) {
 			SID:             "PRD",
 			ResourcesNumber: 8,
 			HostsNumber:     2,
+			FencingType:     "external/sbd",
+			FencingStatus:   models.FencingStatusUnhealthy,
+			SBDWatchdog:     "/dev/watchdog",
+			SBDDevices:      expectedSBDDevicesJSON,
 			Details:         expectedHANAClusterDetails,
 		}, clusterOut)
 }
@@ -228,10 +510,11 @@ func TestTransformClusterData_Unknown(t *testing.T) {
 
 	assert.EqualValues(t,
 		&entities.Cluster{
-			Name:        "test_cluster",
-			ID:          "test_id",
-			ClusterType: models.ClusterTypeUnknown,
-			Details:     datatypes.JSON{},
+			Name:          "test_cluster",
+			ID:            "test_id",
+			ClusterType:   models.ClusterTypeUnknown,
+			FencingStatus: models.FencingStatusUnconfigured,
+			Details:       datatypes.JSON{},
 		}, clusterOut)
 }
 
@@ -340,3 +623,136 @@ func TestParseSecondaryHealthState(t *testing.T) {
 	state := parseHANAHealthState(nodes, "PRD")
 	assert.Equal(t, "4", state)
 }
+
+func ascsErsTestCluster() *cluster.Cluster {
+	c := &cluster.Cluster{
+		Id:   "ascs_ers_cluster_id",
+		Name: "netweaver_cluster",
+	}
+
+	c.Cib.Configuration.Resources.Groups = []cib.Group{
+		{
+			Id: "grp_NWP_ASCS00",
+			Primitives: []cib.Primitive{
+				{
+					Id:   "rsc_sap_NWP_ASCS00",
+					Type: "SAPInstance",
+					InstanceAttributes: []cib.Attribute{
+						{Name: "InstanceName", Value: "NWP_ASCS00_sapnwpas"},
+					},
+				},
+				{
+					Id:   "rsc_ip_NWP_ASCS00",
+					Type: "IPaddr2",
+					InstanceAttributes: []cib.Attribute{
+						{Name: "ip", Value: "10.74.1.20"},
+					},
+				},
+			},
+		},
+		{
+			Id: "grp_NWP_ERS10",
+			Primitives: []cib.Primitive{
+				{
+					Id:   "rsc_sap_NWP_ERS10",
+					Type: "SAPInstance",
+					InstanceAttributes: []cib.Attribute{
+						{Name: "InstanceName", Value: "NWP_ERS10_sapnwper"},
+					},
+				},
+			},
+		},
+	}
+
+	c.Crmmon.Summary.Nodes.Number = 2
+	c.Crmmon.Summary.Resources.Number = 3
+	c.Crmmon.Nodes = []crmmon.Node{
+		{Name: "node01", Online: true},
+		{Name: "node02", Online: true},
+	}
+
+	node01 := &struct {
+		Name   string `xml:"name,attr"`
+		Id     string `xml:"id,attr"`
+		Cached bool   `xml:"cached,attr"`
+	}{Name: "node01"}
+	node02 := &struct {
+		Name   string `xml:"name,attr"`
+		Id     string `xml:"id,attr"`
+		Cached bool   `xml:"cached,attr"`
+	}{Name: "node02"}
+
+	c.Crmmon.Groups = []crmmon.Group{
+		{
+			Id: "grp_NWP_ASCS00",
+			Resources: []crmmon.Resource{
+				{Id: "rsc_sap_NWP_ASCS00", Agent: "ocf::heartbeat:SAPInstance", Role: "Started", Active: true, Node: node01},
+				{Id: "rsc_ip_NWP_ASCS00", Agent: "ocf::heartbeat:IPaddr2", Role: "Started", Active: true, Node: node01},
+			},
+		},
+		{
+			Id: "grp_NWP_ERS10",
+			Resources: []crmmon.Resource{
+				{Id: "rsc_sap_NWP_ERS10", Agent: "ocf::heartbeat:SAPInstance", Role: "Started", Active: true, Node: node02},
+			},
+		},
+	}
+
+	return c
+}
+
+func TestTransformClusterData_ASCSERS(t *testing.T) {
+	clusterOut, err := transformClusterData(ascsErsTestCluster())
+	assert.NoError(t, err)
+
+	assert.Equal(t, models.ClusterTypeASCSERS, clusterOut.ClusterType)
+	assert.Equal(t, "NWP", clusterOut.SID)
+
+	var details entities.ASCSERSClusterDetails
+	assert.NoError(t, json.Unmarshal(clusterOut.Details, &details))
+
+	assert.True(t, details.Distributed)
+	assert.Len(t, details.Nodes, 2)
+	assert.Equal(t, "node01", details.Nodes[0].Name)
+	assert.Equal(t, []string{"ASCS"}, details.Nodes[0].Roles)
+	assert.Equal(t, []string{"10.74.1.20"}, details.Nodes[0].VirtualIPs)
+	assert.Equal(t, []string{"ERS"}, details.Nodes[1].Roles)
+}
+
+func TestTransformClusterData_ASCSERSNotDistributed(t *testing.T) {
+	c := ascsErsTestCluster()
+	c.Crmmon.Groups[1].Resources[0].Node = c.Crmmon.Groups[0].Resources[0].Node
+
+	clusterOut, err := transformClusterData(c)
+	assert.NoError(t, err)
+
+	var details entities.ASCSERSClusterDetails
+	assert.NoError(t, json.Unmarshal(clusterOut.Details, &details))
+
+	assert.False(t, details.Distributed)
+}
+
+func TestTransformClusterData_Generic(t *testing.T) {
+	c := &cluster.Cluster{
+		Id:   "generic_cluster_id",
+		Name: "generic_cluster",
+	}
+	c.Crmmon.Summary.Nodes.Number = 2
+	c.Crmmon.Nodes = []crmmon.Node{
+		{Name: "node01", Online: true, ResourcesRunning: 2},
+		{Name: "node02", Standby: true},
+	}
+
+	clusterOut, err := transformClusterData(c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, models.ClusterTypeGeneric, clusterOut.ClusterType)
+
+	var details entities.GenericClusterDetails
+	assert.NoError(t, json.Unmarshal(clusterOut.Details, &details))
+
+	assert.Len(t, details.Nodes, 2)
+	assert.True(t, details.Nodes[0].Online)
+	assert.Equal(t, 2, details.Nodes[0].ResourcesRunning)
+	assert.True(t, details.Nodes[1].Standby)
+}
@@ -0,0 +1,54 @@
+package datapipeline
+
+import (
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+// ProjectedTables lists the read models written by the registered projectors,
+// together with the projectors subscriptions. Truncating them and replaying
+// the stored events rebuilds the projections from scratch.
+var ProjectedTables = []interface{}{
+	&Subscription{}, &entities.Cluster{}, &entities.Host{}, &entities.HostTelemetry{},
+	&entities.SlesSubscription{}, &entities.SAPSystemInstance{},
+}
+
+// CompactEvents deletes the historical events that every projector has already
+// consumed, keeping the latest event per agent and discovery type.
+// Discovery payloads carry the full state, so the retained events act as a
+// snapshot: replaying them is enough to rebuild the current read models.
+func CompactEvents(db *gorm.DB) (int64, error) {
+	latestEvents := db.
+		Model(&DataCollectedEvent{}).
+		Select("MAX(id)").
+		Group("agent_id, discovery_type")
+
+	result := db.
+		Where("id NOT IN (?)", latestEvents).
+		Where(`id <= (SELECT MIN(last_projected_event_id) FROM subscriptions
+			WHERE subscriptions.agent_id = data_collected_events.agent_id)`).
+		Delete(&DataCollectedEvent{})
+
+	return result.RowsAffected, result.Error
+}
+
+// ReplayEvents projects the stored events again, in their original order,
+// through the given projectors
+func ReplayEvents(db *gorm.DB, projectors ProjectorRegistry) (int64, error) {
+	var events []*DataCollectedEvent
+	var replayed int64
+
+	result := db.
+		Order("id").
+		FindInBatches(&events, 500, func(_ *gorm.DB, _ int) error {
+			for _, event := range events {
+				for _, projector := range projectors {
+					projector.Project(event)
+				}
+				replayed++
+			}
+			return nil
+		})
+
+	return replayed, result.Error
+}
@@ -0,0 +1,86 @@
+package datapipeline
+
+import (
+	"testing"
+
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"gorm.io/gorm"
+)
+
+type CompactionTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tx *gorm.DB
+}
+
+func TestCompactionTestSuite(t *testing.T) {
+	suite.Run(t, new(CompactionTestSuite))
+}
+
+func (suite *CompactionTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&DataCollectedEvent{}, &Subscription{})
+}
+
+func (suite *CompactionTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(DataCollectedEvent{}, Subscription{})
+}
+
+func (suite *CompactionTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+}
+
+func (suite *CompactionTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+// TestCompactEvents tests that only the already-projected historical events are
+// discarded, keeping the latest event per agent and discovery type
+func (suite *CompactionTestSuite) TestCompactEvents() {
+	suite.tx.Create([]*DataCollectedEvent{
+		{ID: 1, AgentID: "agent_1", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+		{ID: 2, AgentID: "agent_1", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+		{ID: 3, AgentID: "agent_1", DiscoveryType: CloudDiscovery, Payload: []byte("{}")},
+		{ID: 4, AgentID: "agent_2", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+		{ID: 5, AgentID: "agent_2", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+	})
+
+	suite.tx.Create([]*Subscription{
+		{ProjectorID: "hosts", AgentID: "agent_1", LastProjectedEventID: 3},
+		{ProjectorID: "hosts", AgentID: "agent_2", LastProjectedEventID: 4},
+	})
+
+	compacted, err := CompactEvents(suite.tx)
+	suite.NoError(err)
+	suite.EqualValues(1, compacted)
+
+	var remainingIDs []int64
+	suite.tx.Model(&DataCollectedEvent{}).Order("id").Pluck("id", &remainingIDs)
+
+	// event 1 is compacted away, event 5 survives because the hosts projector
+	// has not consumed it yet
+	suite.Equal([]int64{2, 3, 4, 5}, remainingIDs)
+}
+
+// TestReplayEvents tests that the stored events are replayed in their original order
+func (suite *CompactionTestSuite) TestReplayEvents() {
+	suite.tx.Create([]*DataCollectedEvent{
+		{ID: 1, AgentID: "agent_1", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+		{ID: 2, AgentID: "agent_2", DiscoveryType: HostDiscovery, Payload: []byte("{}")},
+		{ID: 3, AgentID: "agent_1", DiscoveryType: CloudDiscovery, Payload: []byte("{}")},
+	})
+
+	var replayedIDs []int64
+	projector := new(MockProjector)
+	projector.On("Project", mock.Anything).Run(func(args mock.Arguments) {
+		replayedIDs = append(replayedIDs, args.Get(0).(*DataCollectedEvent).ID)
+	}).Return(nil)
+
+	replayed, err := ReplayEvents(suite.tx, ProjectorRegistry{projector})
+	suite.NoError(err)
+	suite.EqualValues(3, replayed)
+	suite.Equal([]int64{1, 2, 3}, replayedIDs)
+}
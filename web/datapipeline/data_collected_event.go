@@ -15,9 +15,16 @@ const (
 )
 
 type DataCollectedEvent struct {
-	ID            int64
-	CreatedAt     time.Time
+	ID        int64
+	CreatedAt time.Time
+	// EventID is a client-generated unique identifier, used to drop duplicate
+	// submissions caused by agent retries. Older agents do not send it.
+	EventID       string         `json:"event_id" gorm:"index"`
+	Checksum      string         `json:"checksum"`
 	AgentID       string         `json:"agent_id" binding:"required"`
 	DiscoveryType string         `json:"discovery_type" binding:"required"`
 	Payload       datatypes.JSON `json:"payload" binding:"required"`
+	// SchemaVersion is the payload contracts revision the agent published
+	// with. Older agents do not send it, which is equivalent to version 1.
+	SchemaVersion int `json:"schema_version"`
 }
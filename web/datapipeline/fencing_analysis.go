@@ -0,0 +1,39 @@
+package datapipeline
+
+import (
+	"strings"
+
+	"github.com/trento-project/trento/internal/cluster"
+	"github.com/trento-project/trento/web/models"
+)
+
+// analyzeFencing validates the discovered STONITH configuration of a cluster
+// server-side, independently of the checks catalog, and returns the fencing
+// type together with its health status
+func analyzeFencing(c *cluster.Cluster) (string, string) {
+	fencingType := parseClusterFencingType(c)
+
+	if fencingType == "" || !c.IsFencingEnabled() {
+		return fencingType, models.FencingStatusUnconfigured
+	}
+
+	for _, resource := range c.Crmmon.Resources {
+		if strings.HasPrefix(resource.Agent, "stonith:") && (resource.Failed || !resource.Active) {
+			return fencingType, models.FencingStatusUnhealthy
+		}
+	}
+
+	if c.IsFencingSBD() {
+		if len(c.SBD.Devices) == 0 {
+			return fencingType, models.FencingStatusUnhealthy
+		}
+
+		for _, device := range c.SBD.Devices {
+			if device.Status != cluster.SBDStatusHealthy {
+				return fencingType, models.FencingStatusUnhealthy
+			}
+		}
+	}
+
+	return fencingType, models.FencingStatusHealthy
+}
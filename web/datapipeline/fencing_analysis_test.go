@@ -0,0 +1,85 @@
+package datapipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/internal/cluster"
+	"github.com/trento-project/trento/internal/cluster/cib"
+	"github.com/trento-project/trento/internal/cluster/crmmon"
+	"github.com/trento-project/trento/web/models"
+)
+
+func fencingTestCluster(agent string, active bool, failed bool) *cluster.Cluster {
+	c := &cluster.Cluster{}
+	c.Cib.Configuration.CrmConfig.ClusterProperties = []cib.Attribute{
+		{
+			Id:    "cib-bootstrap-options-stonith-enabled",
+			Value: "true",
+		},
+	}
+	c.Crmmon.Resources = []crmmon.Resource{
+		{
+			Id:     "stonith-resource",
+			Agent:  agent,
+			Active: active,
+			Failed: failed,
+		},
+	}
+
+	return c
+}
+
+func TestAnalyzeFencing_Unconfigured(t *testing.T) {
+	fencingType, fencingStatus := analyzeFencing(&cluster.Cluster{})
+
+	assert.Equal(t, "", fencingType)
+	assert.Equal(t, models.FencingStatusUnconfigured, fencingStatus)
+
+	// a fencing resource without the stonith-enabled property is not enough
+	c := fencingTestCluster("stonith:fence_azure_arm", true, false)
+	c.Cib.Configuration.CrmConfig.ClusterProperties[0].Value = "false"
+
+	fencingType, fencingStatus = analyzeFencing(c)
+
+	assert.Equal(t, "fence_azure_arm", fencingType)
+	assert.Equal(t, models.FencingStatusUnconfigured, fencingStatus)
+}
+
+func TestAnalyzeFencing_Healthy(t *testing.T) {
+	fencingType, fencingStatus := analyzeFencing(fencingTestCluster("stonith:fence_azure_arm", true, false))
+
+	assert.Equal(t, "fence_azure_arm", fencingType)
+	assert.Equal(t, models.FencingStatusHealthy, fencingStatus)
+}
+
+func TestAnalyzeFencing_FailedResource(t *testing.T) {
+	_, fencingStatus := analyzeFencing(fencingTestCluster("stonith:fence_azure_arm", true, true))
+	assert.Equal(t, models.FencingStatusUnhealthy, fencingStatus)
+
+	_, fencingStatus = analyzeFencing(fencingTestCluster("stonith:fence_azure_arm", false, false))
+	assert.Equal(t, models.FencingStatusUnhealthy, fencingStatus)
+}
+
+func TestAnalyzeFencing_SBD(t *testing.T) {
+	c := fencingTestCluster("stonith:external/sbd", true, false)
+
+	// SBD fencing without any device is unhealthy
+	_, fencingStatus := analyzeFencing(c)
+	assert.Equal(t, models.FencingStatusUnhealthy, fencingStatus)
+
+	c.SBD.Devices = []*cluster.SBDDevice{
+		{Device: "/dev/sda", Status: cluster.SBDStatusHealthy},
+	}
+
+	fencingType, fencingStatus := analyzeFencing(c)
+	assert.Equal(t, "external/sbd", fencingType)
+	assert.Equal(t, models.FencingStatusHealthy, fencingStatus)
+
+	c.SBD.Devices = append(c.SBD.Devices, &cluster.SBDDevice{
+		Device: "/dev/sdb", Status: cluster.SBDStatusUnhealthy,
+	})
+
+	_, fencingStatus = analyzeFencing(c)
+	assert.Equal(t, models.FencingStatusUnhealthy, fencingStatus)
+}
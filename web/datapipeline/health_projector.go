@@ -3,6 +3,8 @@ package datapipeline
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -30,15 +32,75 @@ func ProjectHealth(db *gorm.DB, healthID, healthType, healthValue string) error
 		}
 	}
 
+	previousHealth := healthState.Health
+
 	partialHealths[healthType] = healthValue
 
 	partialHealthsJson, _ := json.Marshal(partialHealths)
 	healthState.Health = computeOverallHealth(partialHealths)
 	healthState.PartialHealths = (datatypes.JSON)(partialHealthsJson)
 
-	return db.Clauses(clause.OnConflict{
+	err = db.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(healthState).Error
+	if err != nil {
+		return err
+	}
+
+	// health states are cluster scoped, so transitions land in the cluster timeline
+	if previousHealth != "" && previousHealth != healthState.Health {
+		err = db.Create(&entities.ResourceEvent{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   healthID,
+			Type:         models.ResourceEventTypeHealth,
+			Message:      fmt.Sprintf("Health changed from %s to %s", previousHealth, healthState.Health),
+			OccurredAt:   time.Now(),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return trackIncident(db, models.TagClusterResourceType, healthID, healthState.Health)
+	}
+
+	return nil
+}
+
+// trackIncident opens an incident when the health turns critical and closes
+// the open one when it recovers, so downtime periods can be reported on
+func trackIncident(db *gorm.DB, resourceType, resourceID, health string) error {
+	var openIncident entities.Incident
+
+	err := db.
+		Where("resource_type = ?", resourceType).
+		Where("resource_id = ?", resourceID).
+		Where("closed_at IS NULL").
+		First(&openIncident).
+		Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	hasOpenIncident := err == nil
+
+	if health == models.HealthSummaryHealthCritical {
+		if hasOpenIncident {
+			return nil
+		}
+
+		return db.Create(&entities.Incident{
+			ResourceType: resourceType,
+			ResourceID:   resourceID,
+			OpenedAt:     time.Now(),
+		}).Error
+	}
+
+	if hasOpenIncident {
+		now := time.Now()
+		openIncident.ClosedAt = &now
+		return db.Save(&openIncident).Error
+	}
+
+	return nil
 }
 
 func computeOverallHealth(partialHealths map[string]string) string {
@@ -8,6 +8,7 @@ import (
 	_ "github.com/trento-project/trento/test"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
 )
 
@@ -24,11 +25,11 @@ func TestHealthProjectorTestSuite(t *testing.T) {
 func (suite *HealthProjectorTestSuite) SetupSuite() {
 	suite.db = helpers.SetupTestDatabase(suite.T())
 
-	suite.db.AutoMigrate(entities.HealthState{})
+	suite.db.AutoMigrate(entities.HealthState{}, entities.ResourceEvent{}, entities.Incident{})
 }
 
 func (suite *HealthProjectorTestSuite) TearDownSuite() {
-	suite.db.Migrator().DropTable(entities.HealthState{})
+	suite.db.Migrator().DropTable(entities.HealthState{}, entities.ResourceEvent{}, entities.Incident{})
 }
 
 func (suite *HealthProjectorTestSuite) SetupTest() {
@@ -74,6 +75,12 @@ func (suite *HealthProjectorTestSuite) Test_ProjectHealth_Update() {
 	suite.Equal("1", health.ID)
 	suite.Equal("critical", health.Health)
 	suite.Equal(map[string]string{"my_health_value": "critical"}, partialHealth)
+
+	var events []entities.ResourceEvent
+	suite.tx.Where("resource_id", "1").Find(&events)
+	suite.Len(events, 1)
+	suite.Equal(models.ResourceEventTypeHealth, events[0].Type)
+	suite.Equal("Health changed from passing to critical", events[0].Message)
 }
 
 func (suite *HealthProjectorTestSuite) Test_ProjectHealth_New() {
@@ -104,6 +111,42 @@ func (suite *HealthProjectorTestSuite) Test_ProjectHealth_New() {
 	)
 }
 
+func (suite *HealthProjectorTestSuite) Test_ProjectHealth_Incidents() {
+	partialHealths1, _ := json.Marshal(map[string]string{"my_health_value": "passing"})
+	suite.tx.Create(&entities.HealthState{
+		ID:             "1",
+		Health:         "passing",
+		PartialHealths: partialHealths1,
+	})
+
+	// turning critical opens an incident
+	err := ProjectHealth(suite.tx, "1", "my_health_value", "critical")
+	suite.NoError(err)
+
+	var incidents []entities.Incident
+	suite.tx.Find(&incidents)
+	suite.Len(incidents, 1)
+	suite.Equal("1", incidents[0].ResourceID)
+	suite.Nil(incidents[0].ClosedAt)
+
+	// staying critical through another projection does not open a second one
+	err = ProjectHealth(suite.tx, "1", "my_new_health", "critical")
+	suite.NoError(err)
+
+	suite.tx.Find(&incidents)
+	suite.Len(incidents, 1)
+
+	// recovering closes the open incident
+	err = ProjectHealth(suite.tx, "1", "my_health_value", "passing")
+	suite.NoError(err)
+	err = ProjectHealth(suite.tx, "1", "my_new_health", "passing")
+	suite.NoError(err)
+
+	suite.tx.Find(&incidents)
+	suite.Len(incidents, 1)
+	suite.NotNil(incidents[0].ClosedAt)
+}
+
 func (suite *HealthProjectorTestSuite) Test_ComputeOverallHealth_Passing() {
 	health := computeOverallHealth(
 		map[string]string{
@@ -35,19 +35,65 @@ func hostsProjector_HostDiscoveryHandler(dataCollectedEvent *DataCollectedEvent,
 		return err
 	}
 
+	jsonExportersStatus, err := json.Marshal(discoveredHost.ExportersStatus)
+	if err != nil {
+		log.Errorf("can't decode exporters status: %s", err)
+		return err
+	}
+
+	jsonNetworkInterfaces, err := json.Marshal(discoveredHost.NetworkInterfaces)
+	if err != nil {
+		log.Errorf("can't decode network interfaces: %s", err)
+		return err
+	}
+
+	jsonConfigFilesChecksums, err := json.Marshal(discoveredHost.ConfigFilesChecksums)
+	if err != nil {
+		log.Errorf("can't decode config files checksums: %s", err)
+		return err
+	}
+
+	jsonInstalledPackages, err := json.Marshal(discoveredHost.InstalledPackages)
+	if err != nil {
+		log.Errorf("can't decode installed packages: %s", err)
+		return err
+	}
+
+	jsonSystemSettings, err := json.Marshal(discoveredHost.SystemSettings)
+	if err != nil {
+		log.Errorf("can't decode system settings: %s", err)
+		return err
+	}
+
 	host := entities.Host{
-		AgentID:      dataCollectedEvent.AgentID,
-		SSHAddress:   discoveredHost.SSHAddress,
-		Name:         discoveredHost.HostName,
-		IPAddresses:  filterIPAddresses(discoveredHost.HostIpAddresses),
-		AgentVersion: discoveredHost.AgentVersion,
+		AgentID:              dataCollectedEvent.AgentID,
+		SSHAddress:           discoveredHost.SSHAddress,
+		Name:                 discoveredHost.HostName,
+		IPAddresses:          filterIPAddresses(discoveredHost.HostIpAddresses),
+		NetworkInterfaces:    (datatypes.JSON)(jsonNetworkInterfaces),
+		DefaultGateways:      discoveredHost.DefaultGateways,
+		OSVersion:            discoveredHost.OSVersion,
+		KernelVersion:        discoveredHost.KernelVersion,
+		AgentVersion:         discoveredHost.AgentVersion,
+		ExportersStatus:      (datatypes.JSON)(jsonExportersStatus),
+		ConfigFilesChecksums: (datatypes.JSON)(jsonConfigFilesChecksums),
+		InstalledPackages:    (datatypes.JSON)(jsonInstalledPackages),
+		SystemSettings:       (datatypes.JSON)(jsonSystemSettings),
 	}
 
 	return storeHost(db, host,
 		"name",
 		"ip_addresses",
+		"network_interfaces",
+		"default_gateways",
+		"os_version",
+		"kernel_version",
 		"agent_version",
 		"ssh_address",
+		"exporters_status",
+		"config_files_checksums",
+		"installed_packages",
+		"system_settings",
 	)
 }
 
@@ -60,6 +60,15 @@ func (s *HostsProjectorTestSuite) Test_HostDiscoveryHandler() {
 	s.Equal(discoveredHostMock.HostName, projectedHost.Name)
 	s.EqualValues(discoveredHostMock.HostIpAddresses, projectedHost.IPAddresses)
 	s.Equal(discoveredHostMock.AgentVersion, projectedHost.AgentVersion)
+	s.EqualValues(discoveredHostMock.DefaultGateways, projectedHost.DefaultGateways)
+
+	var networkInterfaces []*entities.NetworkInterface
+	s.NoError(json.Unmarshal(projectedHost.NetworkInterfaces, &networkInterfaces))
+	s.Len(networkInterfaces, 3)
+	s.Equal("eth0", networkInterfaces[0].Name)
+	s.Equal("00:16:3e:5f:8a:01", networkInterfaces[0].MACAddress)
+	s.Equal(42, networkInterfaces[1].VlanID)
+	s.Equal("bond0", networkInterfaces[2].BondMaster)
 
 	s.Equal("", projectedHost.CloudProvider)
 	s.Equal("", projectedHost.ClusterID)
@@ -0,0 +1,19 @@
+package datapipeline
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+)
+
+// MalformedEvent records an agent submission that failed schema validation,
+// so that broken payloads can be inspected instead of being silently dropped.
+type MalformedEvent struct {
+	ID               int64
+	CreatedAt        time.Time
+	AgentID          string
+	DiscoveryType    string
+	Payload          datatypes.JSON
+	ValidationErrors pq.StringArray `gorm:"type:text[]"`
+}
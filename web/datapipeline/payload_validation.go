@@ -0,0 +1,35 @@
+package datapipeline
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+//go:embed schemas
+var schemasFS embed.FS
+
+// ValidatePayload validates a discovery payload against the JSON schema
+// registered for its discovery type. It returns the list of schema violations,
+// which is empty when the payload is valid. Discovery types without a schema
+// are accepted as-is.
+func ValidatePayload(discoveryType string, payload []byte) []string {
+	schema, err := schemasFS.ReadFile(fmt.Sprintf("schemas/%s.json", discoveryType))
+	if err != nil {
+		return nil
+	}
+
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(payload))
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var violations []string
+	for _, resultError := range result.Errors() {
+		violations = append(violations, resultError.String())
+	}
+
+	return violations
+}
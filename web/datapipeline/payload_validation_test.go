@@ -0,0 +1,33 @@
+package datapipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePayload_ValidHostDiscovery(t *testing.T) {
+	violations := ValidatePayload(HostDiscovery, []byte(`{"hostname": "suse01", "cpu_count": 4}`))
+
+	assert.Empty(t, violations)
+}
+
+func TestValidatePayload_InvalidHostDiscovery(t *testing.T) {
+	violations := ValidatePayload(HostDiscovery, []byte(`{"cpu_count": "not-a-number"}`))
+
+	assert.Len(t, violations, 2)
+	assert.Contains(t, violations, "(root): hostname is required")
+	assert.Contains(t, violations, "cpu_count: Invalid type. Expected: integer, given: string")
+}
+
+func TestValidatePayload_InvalidSubscriptionDiscovery(t *testing.T) {
+	violations := ValidatePayload(SubscriptionDiscovery, []byte(`[{"version": "15.2"}]`))
+
+	assert.Equal(t, []string{"0: identifier is required"}, violations)
+}
+
+func TestValidatePayload_UnknownDiscoveryType(t *testing.T) {
+	violations := ValidatePayload("unknown_discovery", []byte(`"anything goes"`))
+
+	assert.Empty(t, violations)
+}
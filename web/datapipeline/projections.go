@@ -0,0 +1,71 @@
+package datapipeline
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+// ErrProjectionNotFound is returned when trying to rebuild a projection
+// which is not registered
+var ErrProjectionNotFound = errors.New("projection not found")
+
+// projectionDefinition couples a projector with the read model tables it
+// writes, so that a single projection can be truncated and rebuilt
+type projectionDefinition struct {
+	newProjector func(db *gorm.DB) *projector
+	tables       []interface{}
+}
+
+// the map keys must match the projector IDs, as they are used to reset
+// the projector subscriptions
+var projections = map[string]projectionDefinition{
+	"hosts":              {NewHostsProjector, []interface{}{&entities.Host{}}},
+	"clusters":           {NewClustersProjector, []interface{}{&entities.Cluster{}}},
+	"host_telemetry":     {NewHostTelemetryProjector, []interface{}{&entities.HostTelemetry{}}},
+	"sles_subscriptions": {NewSlesSubscriptionsProjector, []interface{}{&entities.SlesSubscription{}}},
+	"sapsystems":         {NewSAPSystemsProjector, []interface{}{&entities.SAPSystemInstance{}}},
+}
+
+// RebuildProjection truncates the read model written by a single projector and
+// rebuilds it by replaying the stored events.
+// It returns the number of replayed events.
+func RebuildProjection(db *gorm.DB, name string) (int64, error) {
+	definition, ok := projections[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrProjectionNotFound, name)
+	}
+
+	log.Infof("Rebuilding projection %s...", name)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, t := range definition.tables {
+			stmt := &gorm.Statement{DB: tx}
+			if err := stmt.Parse(t); err != nil {
+				return err
+			}
+
+			err := tx.Exec(fmt.Sprintf("TRUNCATE TABLE %s CASCADE", stmt.Schema.Table)).Error
+			if err != nil {
+				return fmt.Errorf("could not truncate table %s: %s", stmt.Schema.Table, err)
+			}
+		}
+
+		return tx.Where("projector_id = ?", name).Delete(&Subscription{}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	replayed, err := ReplayEvents(db, ProjectorRegistry{definition.newProjector(db)})
+	if err != nil {
+		return replayed, err
+	}
+
+	log.Infof("Projection %s rebuilt, %d events replayed.", name, replayed)
+
+	return replayed, nil
+}
@@ -0,0 +1,82 @@
+package datapipeline
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/agent/discovery/mocks"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+func TestRebuildProjection_UnknownProjection(t *testing.T) {
+	_, err := RebuildProjection(nil, "unknown_projection")
+
+	assert.ErrorIs(t, err, ErrProjectionNotFound)
+}
+
+type ProjectionsTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tx *gorm.DB
+}
+
+func TestProjectionsTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectionsTestSuite))
+}
+
+func (suite *ProjectionsTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&DataCollectedEvent{}, &Subscription{}, &entities.Host{})
+}
+
+func (suite *ProjectionsTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(DataCollectedEvent{}, Subscription{}, entities.Host{})
+}
+
+func (suite *ProjectionsTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+}
+
+func (suite *ProjectionsTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+// TestRebuildProjection tests that a single read model is truncated and
+// projected again from the stored events
+func (suite *ProjectionsTestSuite) TestRebuildProjection() {
+	discoveredHostMock := mocks.NewDiscoveredHostMock()
+	payload, _ := json.Marshal(discoveredHostMock)
+
+	suite.tx.Create(&DataCollectedEvent{
+		ID:            1,
+		AgentID:       "agent_id",
+		DiscoveryType: HostDiscovery,
+		Payload:       payload,
+	})
+
+	// simulate a read model corrupted by a projector bug
+	suite.tx.Create(&entities.Host{AgentID: "agent_id", Name: "corrupted"})
+	suite.tx.Create(&Subscription{
+		ProjectorID:          "hosts",
+		AgentID:              "agent_id",
+		LastProjectedEventID: 1,
+	})
+
+	replayed, err := RebuildProjection(suite.tx, "hosts")
+	suite.NoError(err)
+	suite.EqualValues(1, replayed)
+
+	var projectedHost entities.Host
+	suite.tx.First(&projectedHost)
+	suite.Equal(discoveredHostMock.HostName, projectedHost.Name)
+
+	var subscription Subscription
+	suite.tx.First(&subscription)
+	suite.Equal("hosts", subscription.ProjectorID)
+	suite.Equal(int64(1), subscription.LastProjectedEventID)
+}
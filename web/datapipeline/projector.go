@@ -17,23 +17,35 @@ type Projector interface {
 
 type ProjectorHandler func(dataCollectedEvent *DataCollectedEvent, db *gorm.DB) error
 
+type handlerKey struct {
+	discoveryType string
+	schemaVersion int
+}
+
 type projector struct {
 	ID       string
 	db       *gorm.DB
-	handlers map[string]ProjectorHandler
+	handlers map[handlerKey]ProjectorHandler
 }
 
 func NewProjector(ID string, db *gorm.DB) *projector {
 	return &projector{
 		ID:       ID,
 		db:       db,
-		handlers: make(map[string]ProjectorHandler),
+		handlers: make(map[handlerKey]ProjectorHandler),
 	}
 }
 
-// AddHandler registers a handler for a specific discovery type
+// AddHandler registers a handler for a specific discovery type, at schema
+// version 1
 func (p *projector) AddHandler(discoveryType string, handler ProjectorHandler) {
-	p.handlers[discoveryType] = handler
+	p.AddVersionedHandler(discoveryType, 1, handler)
+}
+
+// AddVersionedHandler registers a handler for a specific discovery type and
+// payload schema version
+func (p *projector) AddVersionedHandler(discoveryType string, schemaVersion int, handler ProjectorHandler) {
+	p.handlers[handlerKey{discoveryType, schemaVersion}] = handler
 }
 
 // Project processes the data collected event and calls the registered handlers
@@ -46,7 +58,18 @@ func (p *projector) Project(dataCollectedEvent *DataCollectedEvent) error {
 		}
 	}()
 
-	handler, ok := p.handlers[dataCollectedEvent.DiscoveryType]
+	schemaVersion := dataCollectedEvent.SchemaVersion
+	if schemaVersion == 0 {
+		schemaVersion = 1
+	}
+
+	// schema revisions are additive, so the latest handler at or below the
+	// version the event was published with applies
+	var handler ProjectorHandler
+	var ok bool
+	for version := schemaVersion; version >= 1 && !ok; version-- {
+		handler, ok = p.handlers[handlerKey{dataCollectedEvent.DiscoveryType, version}]
+	}
 
 	if !ok {
 		log.Debugf("Projector: %s is not interested in %s. Discarding event: %d", p.ID, dataCollectedEvent.DiscoveryType, dataCollectedEvent.ID)
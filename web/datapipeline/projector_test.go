@@ -57,6 +57,32 @@ func (suite *ProjectorTestSuite) TestProjector_Project() {
 	suite.NotEmpty(subscription.UpdatedAt)
 }
 
+// TestProjector_Project_VersionedHandlers tests that events are dispatched to
+// the handler registered for their schema version, falling back to the latest
+// lower one
+func (suite *ProjectorTestSuite) TestProjector_Project_VersionedHandlers() {
+	projector := NewProjector("dummy_projector", suite.tx)
+
+	var projectedVersions []int
+	projector.AddHandler("dummy_discovery_type", func(*DataCollectedEvent, *gorm.DB) error {
+		projectedVersions = append(projectedVersions, 1)
+		return nil
+	})
+	projector.AddVersionedHandler("dummy_discovery_type", 2, func(*DataCollectedEvent, *gorm.DB) error {
+		projectedVersions = append(projectedVersions, 2)
+		return nil
+	})
+
+	// old agents do not send a schema version
+	projector.Project(&DataCollectedEvent{ID: 1, DiscoveryType: "dummy_discovery_type", AgentID: "345"})
+	projector.Project(&DataCollectedEvent{ID: 2, DiscoveryType: "dummy_discovery_type", AgentID: "345", SchemaVersion: 1})
+	projector.Project(&DataCollectedEvent{ID: 3, DiscoveryType: "dummy_discovery_type", AgentID: "345", SchemaVersion: 2})
+	// no exact handler, the version 2 one applies
+	projector.Project(&DataCollectedEvent{ID: 4, DiscoveryType: "dummy_discovery_type", AgentID: "345", SchemaVersion: 3})
+
+	suite.Equal([]int{1, 1, 2, 2}, projectedVersions)
+}
+
 // TestProkector_Project tests that a projector does not update the subscription in case of error
 func (suite *ProjectorTestSuite) TestProjector_Project_Error() {
 	projector := NewProjector("dummy_projector", suite.tx)
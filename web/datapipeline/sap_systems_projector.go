@@ -1,12 +1,15 @@
 package datapipeline
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/internal/sapsystem"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -89,6 +92,12 @@ func SAPSystemsProjector_SAPSystemsDiscoveryHandler(dataCollectedEvent *DataColl
 			instance.SystemReplicationStatus = parseReplicationStatus(i.SystemReplication)
 			addSAPControlData(&instance, i.SAPControl)
 
+			processes, err := parseInstanceProcesses(i.SAPControl)
+			if err != nil {
+				return err
+			}
+			instance.Processes = processes
+
 			instances = append(instances, instance)
 		}
 
@@ -97,7 +106,7 @@ func SAPSystemsProjector_SAPSystemsDiscoveryHandler(dataCollectedEvent *DataColl
 			"id", "sid", "type", "features", "instance_number",
 			"system_replication", "system_replication_status",
 			"sap_hostname", "start_priority", "http_port", "https_port", "status",
-			"tenants", "db_host", "db_name")
+			"tenants", "db_host", "db_name", "processes")
 		if err != nil {
 			return err
 		}
@@ -173,6 +182,35 @@ func parseReplicationStatus(s sapsystem.SystemReplication) string {
 	}
 }
 
+// parseInstanceProcesses projects the sapcontrol GetProcessList data of an instance,
+// sorted by process name to keep the stored document stable across discoveries
+func parseInstanceProcesses(sapControl *sapsystem.SAPControl) (datatypes.JSON, error) {
+	var processes []*entities.SAPSystemInstanceProcess
+
+	for _, p := range sapControl.Processes {
+		processes = append(processes, &entities.SAPSystemInstanceProcess{
+			Name:        p.Name,
+			Description: p.Description,
+			Dispstatus:  string(p.Dispstatus),
+			Textstatus:  p.Textstatus,
+			Starttime:   p.Starttime,
+			Elapsedtime: p.Elapsedtime,
+			Pid:         int(p.Pid),
+		})
+	}
+
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Name < processes[j].Name
+	})
+
+	jsonProcesses, err := json.Marshal(processes)
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonProcesses, nil
+}
+
 func addSAPControlData(instance *entities.SAPSystemInstance, sapControl *sapsystem.SAPControl) {
 	for _, i := range sapControl.Instances {
 		if instance.InstanceNumber == fmt.Sprintf("%02d", i.InstanceNr) {
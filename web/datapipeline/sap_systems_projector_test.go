@@ -68,6 +68,16 @@ func (s *SAPSystemsProjectorTestSuite) Test_SAPSystemDiscoveryHandler_Database()
 	s.Equal("0.3", projectedSAPSystemInstance.StartPriority)
 	s.Equal(50013, projectedSAPSystemInstance.HttpPort)
 	s.Equal(50014, projectedSAPSystemInstance.HttpsPort)
+
+	var processes []*entities.SAPSystemInstanceProcess
+	err := json.Unmarshal(projectedSAPSystemInstance.Processes, &processes)
+	s.NoError(err)
+
+	s.Equal("hdbcompileserver", processes[0].Name)
+	s.Equal("HDB Compileserver", processes[0].Description)
+	s.Equal("SAPControl-GREEN", processes[0].Dispstatus)
+	s.Equal("Running", processes[0].Textstatus)
+	s.Equal("2021 09 28 15:53:04", processes[0].Starttime)
 }
 
 // Test_SAPSystemDiscoveryHandler_Database_Obsolete tests that old discovered SAP system instances
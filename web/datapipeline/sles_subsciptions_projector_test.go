@@ -2,11 +2,10 @@ package datapipeline
 
 import (
 	"encoding/json"
-	"io/ioutil"
-	"os"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/contracts"
 	_ "github.com/trento-project/trento/test"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
@@ -70,11 +69,10 @@ func (suite *SlesSubscriptionsProjectorTestSuite) TearDownTest() {
 
 func (suite *SlesSubscriptionsProjectorTestSuite) Test_SlesSubscriptionsProjector() {
 
-	jsonFile, err := os.Open("./test/fixtures/discovery/subscriptions/expected_published_subscriptions_discovery.json")
+	byteValue, err := contracts.Fixture("subscription_discovery")
 	if err != nil {
 		panic(err)
 	}
-	byteValue, _ := ioutil.ReadAll(jsonFile)
 	var dataCollectedEvent *DataCollectedEvent
 	json.Unmarshal(byteValue, &dataCollectedEvent)
 
@@ -104,11 +102,10 @@ func (suite *SlesSubscriptionsProjectorTestSuite) Test_SlesSubscriptionsProjecto
 
 func (suite *SlesSubscriptionsProjectorTestSuite) Test_SlesSubscriptionsProjectorDelete() {
 
-	jsonFile, err := os.Open("./test/fixtures/discovery/subscriptions/expected_published_subscriptions_discovery.json")
+	byteValue, err := contracts.Fixture("subscription_discovery")
 	if err != nil {
 		panic(err)
 	}
-	byteValue, _ := ioutil.ReadAll(jsonFile)
 	var dataCollectedEvent *DataCollectedEvent
 	json.Unmarshal(byteValue, &dataCollectedEvent)
 
@@ -2,56 +2,111 @@ package datapipeline
 
 import (
 	"context"
+	"errors"
+	"hash/fnv"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
-	"golang.org/x/sync/semaphore"
 )
 
-// TODO: tune workersNumber
-var workersNumber int64 = 100
+const DefaultProjectorWorkers = 100
+
+var queueSize = 64
 var drainTimeout = time.Second * 5
 
+// ErrEventsQueueFull is returned when the worker pool cannot accept new events,
+// so that the collector API can signal backpressure to the agents.
+var ErrEventsQueueFull = errors.New("the projector events queue is full")
+
+var ErrUnsupportedSchemaVersion = errors.New("the payload schema version is newer than the one supported by this server")
+
 type ProjectorsWorkerPool struct {
 	ch                 chan *DataCollectedEvent
+	workersNumber      int
+	queues             []chan *DataCollectedEvent
 	projectorsRegistry ProjectorRegistry
+	listeners          []func(*DataCollectedEvent)
 }
 
-func NewProjectorsWorkerPool(projectorsRegistry ProjectorRegistry) *ProjectorsWorkerPool {
+func NewProjectorsWorkerPool(projectorsRegistry ProjectorRegistry, workersNumber int) *ProjectorsWorkerPool {
+	if workersNumber <= 0 {
+		workersNumber = DefaultProjectorWorkers
+	}
+
+	queues := make([]chan *DataCollectedEvent, workersNumber)
+	for i := range queues {
+		queues[i] = make(chan *DataCollectedEvent, queueSize)
+	}
+
 	return &ProjectorsWorkerPool{
 		projectorsRegistry: projectorsRegistry,
-		ch:                 make(chan *DataCollectedEvent),
+		ch:                 make(chan *DataCollectedEvent, queueSize),
+		workersNumber:      workersNumber,
+		queues:             queues,
 	}
 }
 
-// Run runs a pool of workers to process events
+// AddListener registers a callback which gets notified after an event
+// has been projected by all the projectors.
+// Listeners must be registered before calling Run
+func (p *ProjectorsWorkerPool) AddListener(listener func(*DataCollectedEvent)) {
+	p.listeners = append(p.listeners, listener)
+}
+
+// Run runs a pool of workers to process events.
+// Events are sharded by agent ID: events coming from the same agent are always
+// handled by the same worker, preserving their order, while events from
+// different agents are projected in parallel.
 func (p *ProjectorsWorkerPool) Run(ctx context.Context) {
-	log.Infof("Starting projector pool. Workers limit: %d", workersNumber)
-	sem := semaphore.NewWeighted(workersNumber)
+	log.Infof("Starting projector pool. Workers: %d", p.workersNumber)
+
+	var wg sync.WaitGroup
+	for _, queue := range p.queues {
+		wg.Add(1)
+		go func(queue chan *DataCollectedEvent) {
+			defer wg.Done()
+			for event := range queue {
+				log.Infof("Projecting event: %d", event.ID)
+				for _, projector := range p.projectorsRegistry {
+					projector.Project(event)
+				}
+				for _, listener := range p.listeners {
+					listener(event)
+				}
+			}
+		}(queue)
+	}
 
 	for {
 		select {
 		case event := <-p.ch:
-			if err := sem.Acquire(ctx, 1); err != nil {
-				log.Debugf("Discarding event: %d, shutting down already.", event.ID)
-				break
+			// never block the dispatch on a single stuck shard: that would
+			// head-of-line block the events of every other agent. The event
+			// is already stored, so the read models catch up with the next
+			// publication of the agent, or with a projection rebuild.
+			select {
+			case p.queues[p.workerIndex(event.AgentID)] <- event:
+			default:
+				log.Warnf("Projector queue for agent %s is full, skipping projection of event %d", event.AgentID, event.ID)
 			}
-			log.Infof("Projecting event: %d", event.ID)
-
-			go func() {
-				defer sem.Release(1)
-				for _, projector := range p.projectorsRegistry {
-					projector.Project(event)
-				}
-			}()
 		case <-ctx.Done():
 			log.Infof("Projectors worker pool is shutting down... Waiting for active workers to drain.")
 
-			ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
-			defer cancel()
+			for _, queue := range p.queues {
+				close(queue)
+			}
+
+			drained := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(drained)
+			}()
 
-			if err := sem.Acquire(ctx, workersNumber); err != nil {
-				log.Warnf("Timed out while draining workers: %v", err)
+			select {
+			case <-drained:
+			case <-time.After(drainTimeout):
+				log.Warnf("Timed out while draining workers")
 			}
 
 			return
@@ -59,6 +114,13 @@ func (p *ProjectorsWorkerPool) Run(ctx context.Context) {
 	}
 }
 
+func (p *ProjectorsWorkerPool) workerIndex(agentID string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(agentID))
+
+	return int(hash.Sum32()) % p.workersNumber
+}
+
 // GetChannel returns the channel used by the worker pool
 func (p *ProjectorsWorkerPool) GetChannel() chan *DataCollectedEvent {
 	return p.ch
@@ -2,6 +2,7 @@ package datapipeline
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -10,11 +11,9 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
-// TestProjectorWorkersPool tests that the worker pool correctly spawns workers
-// when new events are added to the channel.
+// TestProjectorWorkersPool tests that the worker pool projects the events
+// added to the channel.
 func TestProjectorWorkersPool(t *testing.T) {
-	workersNumber = 2
-
 	var wg sync.WaitGroup
 	wg.Add(2)
 
@@ -27,13 +26,13 @@ func TestProjectorWorkersPool(t *testing.T) {
 		projector,
 	}
 
-	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry)
+	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry, 2)
 	ctx, cancel := context.WithCancel(context.Background())
 	go projectorsWorkersPool.Run(ctx)
 
 	ch := projectorsWorkersPool.GetChannel()
-	ch <- &DataCollectedEvent{}
-	ch <- &DataCollectedEvent{}
+	ch <- &DataCollectedEvent{AgentID: "agent_1"}
+	ch <- &DataCollectedEvent{AgentID: "agent_2"}
 
 	wg.Wait()
 
@@ -41,47 +40,92 @@ func TestProjectorWorkersPool(t *testing.T) {
 	cancel()
 }
 
-// TestProjectorWorkersPool_BoundedParallelism tests that no more than the workersNumber limit
-// of workers are spawned.
-func TestProjectorWorkersPool_BoundedParallelism(t *testing.T) {
-	workersNumber = 2
+// TestProjectorWorkersPool_PerAgentOrdering tests that events coming from the
+// same agent are projected in submission order.
+func TestProjectorWorkersPool_PerAgentOrdering(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(5)
+
+	var mu sync.Mutex
+	var projectedIDs []int64
+
+	projector := new(MockProjector)
+	projector.On("Project", mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		projectedIDs = append(projectedIDs, args.Get(0).(*DataCollectedEvent).ID)
+		mu.Unlock()
+		wg.Done()
+	}).Return(nil)
+
+	projectorRegistry := []Projector{
+		projector,
+	}
+
+	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	go projectorsWorkersPool.Run(ctx)
+
+	ch := projectorsWorkersPool.GetChannel()
+	for i := int64(1); i <= 5; i++ {
+		ch <- &DataCollectedEvent{ID: i, AgentID: "agent_1"}
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, projectedIDs)
+	cancel()
+}
+
+// TestProjectorWorkersPool_ParallelAcrossAgents tests that a slow agent does not
+// block the projection of events coming from other agents.
+func TestProjectorWorkersPool_ParallelAcrossAgents(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
 	quit := make(chan struct{})
 
+	slowAgent := "agent_0"
+
 	projector := new(MockProjector)
 	projector.On("Project", mock.Anything).Run(func(args mock.Arguments) {
-		<-quit
+		if args.Get(0).(*DataCollectedEvent).AgentID == slowAgent {
+			<-quit
+			return
+		}
+		wg.Done()
 	}).Return(nil)
 
 	projectorRegistry := []Projector{
 		projector,
 	}
 
-	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry)
+	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry, 2)
 	ctx, cancel := context.WithCancel(context.Background())
 	go projectorsWorkersPool.Run(ctx)
 
-	go func() {
-		ch := projectorsWorkersPool.GetChannel()
-		ch <- &DataCollectedEvent{}
-		ch <- &DataCollectedEvent{}
-		ch <- &DataCollectedEvent{}
-	}()
+	// pick an agent which is sharded on a different worker than the slow one
+	var fastAgent string
+	for i := 1; fastAgent == ""; i++ {
+		candidate := fmt.Sprintf("agent_%d", i)
+		if projectorsWorkersPool.workerIndex(candidate) != projectorsWorkersPool.workerIndex(slowAgent) {
+			fastAgent = candidate
+		}
+	}
 
-	time.Sleep(100 * time.Millisecond)
-	projector.AssertNumberOfCalls(t, "Project", 2)
+	ch := projectorsWorkersPool.GetChannel()
+	ch <- &DataCollectedEvent{AgentID: slowAgent}
+	ch <- &DataCollectedEvent{AgentID: fastAgent}
 
-	quit <- struct{}{}
-	time.Sleep(100 * time.Millisecond)
-	projector.AssertNumberOfCalls(t, "Project", 3)
+	wg.Wait()
 
+	projector.AssertNumberOfCalls(t, "Project", 2)
+	quit <- struct{}{}
 	cancel()
 }
 
-// TestProjectorWorkersPool_Drain tests that the workers are drained when the context is canceled
-// and that the worker pool shuts down gracefully.
+// TestProjectorWorkersPool_Drain tests that the queued events are drained when
+// the context is canceled and that the worker pool shuts down gracefully.
 func TestProjectorWorkersPool_Drain(t *testing.T) {
-	workersNumber = 2
-	drainTimeout = 200 * time.Millisecond
+	drainTimeout = 500 * time.Millisecond
 	done1 := false
 	done2 := false
 
@@ -90,7 +134,7 @@ func TestProjectorWorkersPool_Drain(t *testing.T) {
 	projector := new(MockProjector)
 	projector.On("Project", mock.Anything).Run(func(args mock.Arguments) {
 		<-startProcessing
-		time.Sleep(drainTimeout)
+		time.Sleep(50 * time.Millisecond)
 		if args.Get(0).(*DataCollectedEvent).ID == 1 {
 			done1 = true
 		} else {
@@ -102,23 +146,67 @@ func TestProjectorWorkersPool_Drain(t *testing.T) {
 		projector,
 	}
 
-	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry)
+	projectorsWorkersPool := NewProjectorsWorkerPool(projectorRegistry, 2)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go projectorsWorkersPool.Run(ctx)
 
 	ch := projectorsWorkersPool.GetChannel()
-	ch <- &DataCollectedEvent{ID: 1}
-	ch <- &DataCollectedEvent{ID: 2}
-
-	startProcessing <- struct{}{}
-	startProcessing <- struct{}{}
+	ch <- &DataCollectedEvent{ID: 1, AgentID: "agent_1"}
+	ch <- &DataCollectedEvent{ID: 2, AgentID: "agent_1"}
 
 	time.Sleep(100 * time.Millisecond)
 	cancel()
+	close(startProcessing)
 
 	time.Sleep(drainTimeout)
 	projector.AssertNumberOfCalls(t, "Project", 2)
 	assert.True(t, done1)
 	assert.True(t, done2)
 }
+
+// TestProjectorWorkersPool_FullShardDoesNotBlockDispatch tests that a stuck
+// shard only affects its own agents: once its queue is full, dispatching to
+// the other shards keeps going.
+func TestProjectorWorkersPool_FullShardDoesNotBlockDispatch(t *testing.T) {
+	oldQueueSize := queueSize
+	queueSize = 1
+	defer func() { queueSize = oldQueueSize }()
+
+	release := make(chan struct{})
+	var projected sync.WaitGroup
+	projected.Add(1)
+
+	projector := new(MockProjector)
+	projector.On("Project", mock.Anything).Run(func(args mock.Arguments) {
+		if args.Get(0).(*DataCollectedEvent).AgentID == "stuck_agent" {
+			<-release
+			return
+		}
+		projected.Done()
+	}).Return(nil)
+
+	projectorsWorkersPool := NewProjectorsWorkerPool([]Projector{projector}, 2)
+
+	// pick an agent sharded away from the stuck one
+	otherAgent := "other_agent"
+	for i := 0; projectorsWorkersPool.workerIndex(otherAgent) == projectorsWorkersPool.workerIndex("stuck_agent"); i++ {
+		otherAgent = fmt.Sprintf("other_agent_%d", i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go projectorsWorkersPool.Run(ctx)
+
+	ch := projectorsWorkersPool.GetChannel()
+	// the first event occupies the stuck worker, the next ones fill and
+	// overflow its shard queue
+	for i := 0; i < 3; i++ {
+		ch <- &DataCollectedEvent{AgentID: "stuck_agent"}
+	}
+	ch <- &DataCollectedEvent{AgentID: otherAgent}
+
+	projected.Wait()
+
+	close(release)
+	cancel()
+}
@@ -0,0 +1,18 @@
+package entities
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AgentChecksResult is the partial checks result reported by a single agent
+// for an execution, waiting for the other agents of the cluster to report
+type AgentChecksResult struct {
+	ExecutionID int64  `gorm:"primaryKey"`
+	AgentID     string `gorm:"primaryKey"`
+	ClusterID   string
+	HostName    string
+	Payload     datatypes.JSON
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,38 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+)
+
+type AgentConfig struct {
+	AgentID            string `gorm:"primaryKey"`
+	DiscoveryPeriods   datatypes.JSON
+	EnabledDiscoveries pq.StringArray `gorm:"type:text[]"`
+	CollectorHost      string
+	CollectorPort      int
+	AutoUpdateDisabled bool
+	UpdatedAt          time.Time
+}
+
+func (c *AgentConfig) ToModel() *models.AgentConfig {
+	var discoveryPeriods map[string]int
+	if c.DiscoveryPeriods != nil {
+		if err := json.Unmarshal(c.DiscoveryPeriods, &discoveryPeriods); err != nil {
+			discoveryPeriods = nil
+		}
+	}
+
+	return &models.AgentConfig{
+		AgentID:            c.AgentID,
+		DiscoveryPeriods:   discoveryPeriods,
+		EnabledDiscoveries: c.EnabledDiscoveries,
+		CollectorHost:      c.CollectorHost,
+		CollectorPort:      c.CollectorPort,
+		AutoUpdateDisabled: c.AutoUpdateDisabled,
+	}
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type AgentError struct {
+	ID         int64  `gorm:"primaryKey;autoIncrement"`
+	AgentID    string `gorm:"index"`
+	Type       string
+	Message    string
+	ReportedAt time.Time
+}
+
+func (e *AgentError) ToModel() *models.AgentError {
+	return &models.AgentError{
+		Type:       e.Type,
+		Message:    e.Message,
+		ReportedAt: e.ReportedAt,
+	}
+}
@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type Alert struct {
+	Fingerprint  string `gorm:"primaryKey"`
+	Status       string
+	Name         string
+	Severity     string
+	ResourceType string
+	ResourceID   string
+	Summary      string
+	StartsAt     time.Time
+	UpdatedAt    time.Time
+}
+
+func (a *Alert) ToModel() *models.Alert {
+	return &models.Alert{
+		Fingerprint:  a.Fingerprint,
+		Status:       a.Status,
+		Name:         a.Name,
+		Severity:     a.Severity,
+		ResourceType: a.ResourceType,
+		ResourceID:   a.ResourceID,
+		Summary:      a.Summary,
+		StartsAt:     a.StartsAt,
+	}
+}
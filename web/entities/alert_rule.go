@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"github.com/lib/pq"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type AlertRule struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement"`
+	Name       string
+	Condition  string
+	Value      string
+	Threshold  int
+	ResourceID string
+	Tags       pq.StringArray `gorm:"type:text[]"`
+	Severity   string
+	ForMinutes int
+}
+
+func (r *AlertRule) ToModel() *models.AlertRule {
+	return &models.AlertRule{
+		ID:         r.ID,
+		Name:       r.Name,
+		Condition:  r.Condition,
+		Value:      r.Value,
+		Threshold:  r.Threshold,
+		ResourceID: r.ResourceID,
+		Tags:       r.Tags,
+		Severity:   r.Severity,
+		ForMinutes: r.ForMinutes,
+	}
+}
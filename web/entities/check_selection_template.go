@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"github.com/lib/pq"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type CheckSelectionTemplate struct {
+	ID   int64  `gorm:"primaryKey;autoIncrement"`
+	Name string `gorm:"uniqueIndex"`
+	// Provider, ClusterType and Tags are the matching criteria; empty
+	// criteria act as wildcards
+	Provider       string
+	ClusterType    string
+	Tags           pq.StringArray `gorm:"type:text[]"`
+	SelectedChecks pq.StringArray `gorm:"type:text[]"`
+}
+
+func (t *CheckSelectionTemplate) ToModel() *models.CheckSelectionTemplate {
+	return &models.CheckSelectionTemplate{
+		ID:             t.ID,
+		Name:           t.Name,
+		Provider:       t.Provider,
+		ClusterType:    t.ClusterType,
+		Tags:           t.Tags,
+		SelectedChecks: t.SelectedChecks,
+	}
+}
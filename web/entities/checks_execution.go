@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type ChecksExecution struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement"`
+	ClusterID   string
+	Status      string
+	Actor       string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	Durations   datatypes.JSON
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (e *ChecksExecution) ToModel() *models.ChecksExecution {
+	var durations map[string]float64
+	if e.Durations != nil {
+		json.Unmarshal(e.Durations, &durations)
+	}
+
+	return &models.ChecksExecution{
+		ID:          e.ID,
+		ClusterID:   e.ClusterID,
+		Status:      e.Status,
+		Actor:       e.Actor,
+		StartedAt:   e.StartedAt,
+		CompletedAt: e.CompletedAt,
+		Durations:   durations,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
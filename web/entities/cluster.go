@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/trento-project/trento/web/models"
@@ -14,6 +15,10 @@ type Cluster struct {
 	SID             string `gorm:"column:sid"`
 	ResourcesNumber int
 	HostsNumber     int
+	FencingType     string
+	FencingStatus   string
+	SBDWatchdog     string
+	SBDDevices      datatypes.JSON
 	Health          *HealthState  `gorm:"foreignkey:id"`
 	Tags            []*models.Tag `gorm:"polymorphic:Resource;polymorphicValue:clusters"`
 	UpdatedAt       time.Time
@@ -33,6 +38,34 @@ type HANAClusterDetails struct {
 	SBDDevices                     []*SBDDevice       `json:"sbd_devices"`
 }
 
+type ASCSERSClusterDetails struct {
+	FencingType string `json:"fencing_type"`
+	// Distributed tells whether the ASCS and ERS instances run on different nodes
+	Distributed      bool                  `json:"distributed"`
+	StoppedResources []*ClusterResource    `json:"stopped_resources"`
+	Nodes            []*ASCSERSClusterNode `json:"nodes"`
+}
+
+type ASCSERSClusterNode struct {
+	Name       string             `json:"name"`
+	Roles      []string           `json:"roles"`
+	VirtualIPs []string           `json:"virtual_ips"`
+	Resources  []*ClusterResource `json:"resources"`
+}
+
+type GenericClusterDetails struct {
+	FencingType      string                `json:"fencing_type"`
+	StoppedResources []*ClusterResource    `json:"stopped_resources"`
+	Nodes            []*GenericClusterNode `json:"nodes"`
+}
+
+type GenericClusterNode struct {
+	Name             string `json:"name"`
+	Online           bool   `json:"online"`
+	Standby          bool   `json:"standby"`
+	ResourcesRunning int    `json:"resources_running"`
+}
+
 type ClusterResource struct {
 	ID        string `json:"id"`
 	Type      string `json:"type"`
@@ -51,8 +84,11 @@ type HANAClusterNode struct {
 }
 
 type SBDDevice struct {
-	Device string `json:"device"`
-	Status string `json:"status"`
+	Device          string `json:"device"`
+	Status          string `json:"status"`
+	UUID            string `json:"uuid,omitempty"`
+	TimeoutWatchdog int    `json:"timeout_watchdog,omitempty"`
+	TimeoutMsgwait  int    `json:"timeout_msgwait,omitempty"`
 }
 
 func (c *Cluster) ToModel() *models.Cluster {
@@ -67,6 +103,16 @@ func (c *Cluster) ToModel() *models.Cluster {
 		health = c.Health.Health
 	}
 
+	var sbdDevices []*models.SBDDevice
+	if c.SBDDevices != nil {
+		var devices []*SBDDevice
+		if err := json.Unmarshal(c.SBDDevices, &devices); err == nil {
+			for _, device := range devices {
+				sbdDevices = append(sbdDevices, device.ToModel())
+			}
+		}
+	}
+
 	return &models.Cluster{
 		ID:              c.ID,
 		Name:            c.Name,
@@ -74,6 +120,10 @@ func (c *Cluster) ToModel() *models.Cluster {
 		SID:             c.SID,
 		ResourcesNumber: c.ResourcesNumber,
 		HostsNumber:     c.HostsNumber,
+		FencingType:     c.FencingType,
+		FencingStatus:   c.FencingStatus,
+		SBDWatchdog:     c.SBDWatchdog,
+		SBDDevices:      sbdDevices,
 		Health:          health,
 		Tags:            tags,
 	}
@@ -108,6 +158,62 @@ func (h *HANAClusterDetails) ToModel() *models.HANAClusterDetails {
 	}
 }
 
+func (a *ASCSERSClusterDetails) ToModel() *models.ASCSERSClusterDetails {
+	var stoppedResources []*models.ClusterResource
+	for _, r := range a.StoppedResources {
+		stoppedResources = append(stoppedResources, r.ToModel())
+	}
+
+	var nodes []*models.ASCSERSClusterNode
+	for _, n := range a.Nodes {
+		nodes = append(nodes, n.ToModel())
+	}
+
+	return &models.ASCSERSClusterDetails{
+		FencingType:      a.FencingType,
+		Distributed:      a.Distributed,
+		StoppedResources: stoppedResources,
+		Nodes:            nodes,
+	}
+}
+
+func (n *ASCSERSClusterNode) ToModel() *models.ASCSERSClusterNode {
+	var resources []*models.ClusterResource
+	for _, r := range n.Resources {
+		resources = append(resources, r.ToModel())
+	}
+
+	return &models.ASCSERSClusterNode{
+		Name:       n.Name,
+		Roles:      n.Roles,
+		VirtualIPs: n.VirtualIPs,
+		Resources:  resources,
+	}
+}
+
+func (g *GenericClusterDetails) ToModel() *models.GenericClusterDetails {
+	var stoppedResources []*models.ClusterResource
+	for _, r := range g.StoppedResources {
+		stoppedResources = append(stoppedResources, r.ToModel())
+	}
+
+	var nodes []*models.GenericClusterNode
+	for _, n := range g.Nodes {
+		nodes = append(nodes, &models.GenericClusterNode{
+			Name:             n.Name,
+			Online:           n.Online,
+			Standby:          n.Standby,
+			ResourcesRunning: n.ResourcesRunning,
+		})
+	}
+
+	return &models.GenericClusterDetails{
+		FencingType:      g.FencingType,
+		StoppedResources: stoppedResources,
+		Nodes:            nodes,
+	}
+}
+
 func (r *ClusterResource) ToModel() *models.ClusterResource {
 	return &models.ClusterResource{
 		ID:        r.ID,
@@ -120,8 +226,11 @@ func (r *ClusterResource) ToModel() *models.ClusterResource {
 
 func (s *SBDDevice) ToModel() *models.SBDDevice {
 	return &models.SBDDevice{
-		Device: s.Device,
-		Status: s.Status,
+		Device:          s.Device,
+		Status:          s.Status,
+		UUID:            s.UUID,
+		TimeoutWatchdog: s.TimeoutWatchdog,
+		TimeoutMsgwait:  s.TimeoutMsgwait,
 	}
 }
 
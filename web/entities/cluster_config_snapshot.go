@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// ClusterConfigSnapshot stores the raw Pacemaker/corosync configuration of a
+// cluster as discovered at a point in time. A new row is appended whenever the
+// content changes, so the history is preserved
+type ClusterConfigSnapshot struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	ClusterID    string
+	CibXML       string
+	CorosyncConf string
+	CreatedAt    time.Time
+}
+
+func (s *ClusterConfigSnapshot) ToModel() *models.ClusterConfigSnapshot {
+	return &models.ClusterConfigSnapshot{
+		ID:           s.ID,
+		ClusterID:    s.ClusterID,
+		CibXML:       s.CibXML,
+		CorosyncConf: s.CorosyncConf,
+		CreatedAt:    s.CreatedAt,
+	}
+}
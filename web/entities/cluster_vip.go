@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type ClusterVIP struct {
+	ClusterID  string `gorm:"primaryKey"`
+	ResourceID string `gorm:"primaryKey"`
+	Address    string
+	Node       string
+	Status     string
+	UpdatedAt  time.Time
+}
+
+func (v *ClusterVIP) ToModel() *models.ClusterVIP {
+	return &models.ClusterVIP{
+		ClusterID:  v.ClusterID,
+		ResourceID: v.ResourceID,
+		Address:    v.Address,
+		Node:       v.Node,
+		Status:     v.Status,
+	}
+}
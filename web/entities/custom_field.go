@@ -0,0 +1,17 @@
+package entities
+
+// CustomFieldDefinition declares a typed custom field available on a resource
+// type, e.g. an "owner" string on hosts
+type CustomFieldDefinition struct {
+	ResourceType string `gorm:"primaryKey"`
+	Name         string `gorm:"primaryKey"`
+	Type         string
+}
+
+// CustomFieldValue holds the value of a custom field on one resource
+type CustomFieldValue struct {
+	ResourceType string `gorm:"primaryKey"`
+	ResourceID   string `gorm:"primaryKey"`
+	Name         string `gorm:"primaryKey"`
+	Value        string
+}
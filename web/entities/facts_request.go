@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type FactsRequest struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	AgentID   string
+	Probe     string
+	Command   string
+	Status    string
+	Output    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (f *FactsRequest) ToModel() *models.FactsRequest {
+	return &models.FactsRequest{
+		ID:        f.ID,
+		AgentID:   f.AgentID,
+		Probe:     f.Probe,
+		Command:   f.Command,
+		Status:    f.Status,
+		Output:    f.Output,
+		CreatedAt: f.CreatedAt,
+		UpdatedAt: f.UpdatedAt,
+	}
+}
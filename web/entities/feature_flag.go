@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type FeatureFlag struct {
+	Name      string `gorm:"primaryKey"`
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+func (f *FeatureFlag) ToModel() *models.FeatureFlag {
+	return &models.FeatureFlag{
+		Name:    f.Name,
+		Enabled: f.Enabled,
+	}
+}
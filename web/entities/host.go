@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/lib/pq"
@@ -9,28 +10,60 @@ import (
 )
 
 type Host struct {
-	AgentID            string `gorm:"primaryKey"`
-	SSHAddress         string
-	Name               string
-	IPAddresses        pq.StringArray `gorm:"type:text[]"`
-	CloudProvider      string
-	ClusterID          string
-	ClusterName        string
-	ClusterType        string
-	SAPSystemInstances SAPSystemInstances `gorm:"foreignkey:AgentID"`
-	AgentVersion       string
-	Heartbeat          *HostHeartbeat    `gorm:"foreignKey:AgentID"`
-	Subscription       *SlesSubscription `gorm:"foreignKey:AgentID"`
-	Tags               []*models.Tag     `gorm:"polymorphic:Resource;polymorphicValue:hosts"`
-	UpdatedAt          time.Time
-	CloudData          datatypes.JSON
+	AgentID              string `gorm:"primaryKey"`
+	SSHAddress           string
+	Name                 string
+	IPAddresses          pq.StringArray `gorm:"type:text[]"`
+	NetworkInterfaces    datatypes.JSON
+	DefaultGateways      pq.StringArray `gorm:"type:text[]"`
+	CloudProvider        string
+	OSVersion            string
+	KernelVersion        string
+	ClusterID            string
+	ClusterName          string
+	ClusterType          string
+	SAPSystemInstances   SAPSystemInstances `gorm:"foreignkey:AgentID"`
+	AgentVersion         string
+	Heartbeat            *HostHeartbeat    `gorm:"foreignKey:AgentID"`
+	Subscription         *SlesSubscription `gorm:"foreignKey:AgentID"`
+	Tags                 []*models.Tag     `gorm:"polymorphic:Resource;polymorphicValue:hosts"`
+	UpdatedAt            time.Time
+	CloudData            datatypes.JSON
+	ExportersStatus      datatypes.JSON
+	ConfigFilesChecksums datatypes.JSON
+	InstalledPackages    datatypes.JSON
+	SystemSettings       datatypes.JSON
 }
 
 type HostHeartbeat struct {
-	AgentID   string `gorm:"primaryKey"`
+	AgentID            string `gorm:"primaryKey"`
+	AgentVersion       string
+	EnabledDiscoveries pq.StringArray `gorm:"type:text[]"`
+	// ClockSkew is the difference between the server and the agent clocks in milliseconds
+	ClockSkew int64
 	UpdatedAt time.Time
 }
 
+type NetworkInterface struct {
+	Name       string   `json:"name"`
+	MACAddress string   `json:"mac_address"`
+	MTU        int      `json:"mtu"`
+	Addresses  []string `json:"addresses"`
+	BondMaster string   `json:"bond_master,omitempty"`
+	VlanID     int      `json:"vlan_id,omitempty"`
+}
+
+func (n *NetworkInterface) ToModel() *models.NetworkInterface {
+	return &models.NetworkInterface{
+		Name:       n.Name,
+		MACAddress: n.MACAddress,
+		MTU:        n.MTU,
+		Addresses:  n.Addresses,
+		BondMaster: n.BondMaster,
+		VlanID:     n.VlanID,
+	}
+}
+
 type AzureCloudData struct {
 	VMName          string `json:"vmname"`
 	ResourceGroup   string `json:"resource_group"`
@@ -49,16 +82,54 @@ func (h *Host) ToModel() *models.Host {
 		tags = append(tags, tag.Value)
 	}
 
+	var exportersStatus map[string]string
+	if h.ExportersStatus != nil {
+		if err := json.Unmarshal(h.ExportersStatus, &exportersStatus); err != nil {
+			exportersStatus = nil
+		}
+	}
+
+	var installedPackages map[string]string
+	if h.InstalledPackages != nil {
+		if err := json.Unmarshal(h.InstalledPackages, &installedPackages); err != nil {
+			installedPackages = nil
+		}
+	}
+
+	var systemSettings map[string]string
+	if h.SystemSettings != nil {
+		if err := json.Unmarshal(h.SystemSettings, &systemSettings); err != nil {
+			systemSettings = nil
+		}
+	}
+
+	var networkInterfaces []*models.NetworkInterface
+	if h.NetworkInterfaces != nil {
+		var interfaces []*NetworkInterface
+		if err := json.Unmarshal(h.NetworkInterfaces, &interfaces); err == nil {
+			for _, networkInterface := range interfaces {
+				networkInterfaces = append(networkInterfaces, networkInterface.ToModel())
+			}
+		}
+	}
+
 	return &models.Host{
-		ID:            h.AgentID,
-		Name:          h.Name,
-		IPAddresses:   h.IPAddresses,
-		CloudProvider: h.CloudProvider,
-		ClusterID:     h.ClusterID,
-		ClusterName:   h.ClusterName,
-		ClusterType:   h.ClusterType,
-		AgentVersion:  h.AgentVersion,
-		Tags:          tags,
-		SAPSystems:    h.SAPSystemInstances.ToModel(),
+		ID:                h.AgentID,
+		Name:              h.Name,
+		IPAddresses:       h.IPAddresses,
+		NetworkInterfaces: networkInterfaces,
+		DefaultGateways:   h.DefaultGateways,
+		CloudProvider:     h.CloudProvider,
+		OSVersion:         h.OSVersion,
+		KernelVersion:     h.KernelVersion,
+		ClusterID:         h.ClusterID,
+		ClusterName:       h.ClusterName,
+		ClusterType:       h.ClusterType,
+		AgentVersion:      h.AgentVersion,
+		Tags:              tags,
+		SAPSystems:        h.SAPSystemInstances.ToModel(),
+		ExportersStatus:   exportersStatus,
+		InstalledPackages: installedPackages,
+		SystemSettings:    systemSettings,
 	}
 }
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type Incident struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	ResourceType string
+	ResourceID   string `gorm:"index:idx_incidents_resource"`
+	OpenedAt     time.Time
+	// ClosedAt stays nil while the incident is still open
+	ClosedAt *time.Time
+}
+
+func (i *Incident) ToModel() *models.Incident {
+	return &models.Incident{
+		ID:           i.ID,
+		ResourceType: i.ResourceType,
+		ResourceID:   i.ResourceID,
+		OpenedAt:     i.OpenedAt,
+		ClosedAt:     i.ClosedAt,
+	}
+}
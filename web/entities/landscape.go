@@ -0,0 +1,8 @@
+package entities
+
+// Landscape stores the user-configurable ordering metadata of a SID, the
+// resources themselves are resolved from the discovered data
+type Landscape struct {
+	SID      string `gorm:"primaryKey"`
+	Position int
+}
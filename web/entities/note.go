@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type Note struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement"`
+	ResourceType string `gorm:"index:idx_notes_resource"`
+	ResourceID   string `gorm:"index:idx_notes_resource"`
+	Author       string
+	Content      string
+	CreatedAt    time.Time
+}
+
+func (n *Note) ToModel() *models.Note {
+	return &models.Note{
+		ID:           n.ID,
+		ResourceType: n.ResourceType,
+		ResourceID:   n.ResourceID,
+		Author:       n.Author,
+		Content:      n.Content,
+		CreatedAt:    n.CreatedAt,
+	}
+}
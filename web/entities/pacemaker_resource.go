@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+// PacemakerResource is the per-resource read model projected from the cluster
+// discovery, so single resources can be queried and alerted on without digging
+// into the cluster details blob
+type PacemakerResource struct {
+	ID        string `gorm:"primaryKey"`
+	ClusterID string `gorm:"primaryKey"`
+	// Node is part of the primary key because clone instances share the
+	// resource id while running on different nodes
+	Node               string `gorm:"primaryKey"`
+	Type               string
+	Role               string
+	Status             string
+	FailCount          int
+	MigrationThreshold int
+	UpdatedAt          time.Time
+}
+
+func (r *PacemakerResource) ToModel() *models.PacemakerResource {
+	return &models.PacemakerResource{
+		ID:                 r.ID,
+		ClusterID:          r.ClusterID,
+		Type:               r.Type,
+		Role:               r.Role,
+		Status:             r.Status,
+		Node:               r.Node,
+		FailCount:          r.FailCount,
+		MigrationThreshold: r.MigrationThreshold,
+	}
+}
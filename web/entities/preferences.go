@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+)
+
+type Preferences struct {
+	Subject   string `gorm:"primaryKey"`
+	Payload   datatypes.JSON
+	UpdatedAt time.Time
+}
+
+func (p *Preferences) ToModel() (*models.Preferences, error) {
+	var preferences models.Preferences
+	err := json.Unmarshal(p.Payload, &preferences)
+
+	return &preferences, err
+}
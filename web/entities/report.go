@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type Report struct {
+	ID        int64 `gorm:"primaryKey;autoIncrement"`
+	Name      string
+	Content   []byte
+	CreatedAt time.Time
+}
+
+func (r *Report) ToModel() *models.Report {
+	return &models.Report{
+		ID:        r.ID,
+		Name:      r.Name,
+		CreatedAt: r.CreatedAt,
+	}
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type ResourceEvent struct {
+	ID           int64 `gorm:"primaryKey;autoIncrement"`
+	ResourceType string
+	ResourceID   string `gorm:"index"`
+	Type         string
+	Message      string
+	OccurredAt   time.Time
+}
+
+func (e *ResourceEvent) ToModel() *models.ResourceEvent {
+	return &models.ResourceEvent{
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		Type:         e.Type,
+		Message:      e.Message,
+		OccurredAt:   e.OccurredAt,
+	}
+}
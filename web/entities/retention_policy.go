@@ -0,0 +1,17 @@
+package entities
+
+import (
+	"github.com/trento-project/trento/web/models"
+)
+
+type RetentionPolicy struct {
+	TableName     string `gorm:"primaryKey"`
+	RetentionDays uint
+}
+
+func (r *RetentionPolicy) ToModel() *models.RetentionPolicy {
+	return &models.RetentionPolicy{
+		Table:         r.TableName,
+		RetentionDays: r.RetentionDays,
+	}
+}
@@ -1,11 +1,13 @@
 package entities
 
 import (
+	"encoding/json"
 	"sort"
 	"time"
 
 	"github.com/lib/pq"
 	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
 )
 
 type SAPSystemInstance struct {
@@ -26,11 +28,34 @@ type SAPSystemInstance struct {
 	DBHost                  string
 	DBName                  string
 	Tenants                 pq.StringArray `gorm:"type:text[]"`
-	Host                    *Host          `gorm:"foreignKey:AgentID"`
+	Processes               datatypes.JSON
+	Host                    *Host `gorm:"foreignKey:AgentID"`
 	UpdatedAt               time.Time
 	Tags                    []*models.Tag `gorm:"foreignKey:ResourceID"`
 }
 
+type SAPSystemInstanceProcess struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Dispstatus  string `json:"dispstatus"`
+	Textstatus  string `json:"textstatus"`
+	Starttime   string `json:"starttime"`
+	Elapsedtime string `json:"elapsedtime"`
+	Pid         int    `json:"pid"`
+}
+
+func (p *SAPSystemInstanceProcess) ToModel() *models.SAPSystemInstanceProcess {
+	return &models.SAPSystemInstanceProcess{
+		Name:        p.Name,
+		Description: p.Description,
+		Dispstatus:  p.Dispstatus,
+		Textstatus:  p.Textstatus,
+		Starttime:   p.Starttime,
+		Elapsedtime: p.Elapsedtime,
+		Pid:         p.Pid,
+	}
+}
+
 type SAPSystemInstances []*SAPSystemInstance
 
 func (s SAPSystemInstances) ToModel() []*models.SAPSystem {
@@ -76,6 +101,15 @@ func (s SAPSystemInstances) ToModel() []*models.SAPSystem {
 			SID:                     i.SID,
 		}
 
+		if len(i.Processes) > 0 {
+			var processes []*SAPSystemInstanceProcess
+			if err := json.Unmarshal(i.Processes, &processes); err == nil {
+				for _, process := range processes {
+					sapSystemInstance.Processes = append(sapSystemInstance.Processes, process.ToModel())
+				}
+			}
+		}
+
 		if i.Host != nil {
 			sapSystemInstance.ClusterName = i.Host.ClusterName
 			sapSystemInstance.ClusterID = i.Host.ClusterID
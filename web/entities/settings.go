@@ -1,6 +1,17 @@
 package entities
 
+import "github.com/lib/pq"
+
 type Settings struct {
-	InstallationID string `gorm:"primaryKey"`
-	EulaAccepted   bool
+	InstallationID                string `gorm:"primaryKey"`
+	EulaAccepted                  bool
+	TelemetryOptOut               bool
+	HealthRollupUnknownAsCritical bool
+	EncryptionKey                 string
+	GrafanaPublicURL              string
+	CollectorTLSEnabled           bool
+	SetupCompletedSteps           pq.StringArray `gorm:"type:text[]"`
+	DesiredAgentVersion           string
+	AgentUpdateRepository         string
+	EnrollmentToken               string
 }
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+)
+
+// TrashEntry is a snapshot of a deleted resource, kept around for a retention
+// window so an accidental removal can be undone
+type TrashEntry struct {
+	ID           int64  `gorm:"primaryKey;autoIncrement"`
+	ResourceType string `gorm:"index:idx_trash_resource"`
+	ResourceID   string `gorm:"index:idx_trash_resource"`
+	Payload      datatypes.JSON
+	DeletedAt    time.Time
+}
+
+func (e *TrashEntry) ToModel() *models.TrashEntry {
+	return &models.TrashEntry{
+		ResourceType: e.ResourceType,
+		ResourceID:   e.ResourceID,
+		DeletedAt:    e.DeletedAt,
+	}
+}
@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+type User struct {
+	ID                     int64  `gorm:"primaryKey;autoIncrement"`
+	Username               string `gorm:"uniqueIndex"`
+	PasswordHash           string
+	Admin                  bool
+	PasswordChangeRequired bool
+	TagScope               pq.StringArray `gorm:"type:text[]"`
+	CreatedAt              time.Time
+	UpdatedAt              time.Time
+}
+
+func (u *User) ToModel() *models.User {
+	return &models.User{
+		ID:                     u.ID,
+		Username:               u.Username,
+		Admin:                  u.Admin,
+		PasswordChangeRequired: u.PasswordChangeRequired,
+		TagScope:               u.TagScope,
+		CreatedAt:              u.CreatedAt,
+	}
+}
@@ -1,9 +1,12 @@
 package web
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/trento-project/trento/internal/redact"
+	"github.com/trento-project/trento/web/i18n"
 )
 
 func ErrorHandler(c *gin.Context) {
@@ -22,11 +25,22 @@ func ErrorHandler(c *gin.Context) {
 	case *HttpError:
 		code = e.code
 		template = e.template
+		e.msg = i18n.T(LocaleFromContext(c), e.msg)
 	default:
 		code = 500
 		template = "error.html.tmpl"
 	}
 
+	// scrub any credential that may have leaked into the error messages
+	// before they are rendered back to the client
+	for _, ginErr := range c.Errors {
+		if httpErr, ok := ginErr.Err.(*HttpError); ok {
+			httpErr.msg = redact.String(httpErr.msg)
+			continue
+		}
+		ginErr.Err = errors.New(redact.String(ginErr.Err.Error()))
+	}
+
 	c.Negotiate(code, gin.Negotiate{
 		Offered:  []string{gin.MIMEJSON, gin.MIMEHTML, gin.MIMEPlain},
 		HTMLName: template,
@@ -70,6 +84,14 @@ func BadRequestError(msg string) *HttpError {
 	}
 }
 
+func ForbiddenError(msg string) *HttpError {
+	return &HttpError{
+		msg,
+		http.StatusForbidden,
+		"error.html.tmpl",
+	}
+}
+
 func InternalServerError(msg string) *HttpError {
 	return &HttpError{
 		msg,
@@ -45,6 +45,24 @@ func TestErrorHandlerContentNegotiation(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "2nd error message</br>")
 }
 
+func TestErrorHandlerRedactsSecrets(t *testing.T) {
+	engine := gin.Default()
+	engine.Use(ErrorHandler)
+	engine.GET("/", func(c *gin.Context) {
+		c.Error(errors.New("ssh: unable to authenticate with user=cloudadmin password=s3cret"))
+		c.Error(BadRequestError("vault login failed with secret_id=4ff8c8e2"))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/", nil)
+	engine.ServeHTTP(w, req)
+
+	assert.NotContains(t, w.Body.String(), "cloudadmin")
+	assert.NotContains(t, w.Body.String(), "s3cret")
+	assert.NotContains(t, w.Body.String(), "4ff8c8e2")
+	assert.Contains(t, w.Body.String(), "ssh: unable to authenticate")
+}
+
 func TestErrorHandlerWithHttpError(t *testing.T) {
 	engine := gin.Default()
 	engine.Use(ErrorHandler)
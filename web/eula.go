@@ -10,7 +10,9 @@ import (
 
 func EulaShowHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.HTML(http.StatusOK, "eula.html.tmpl", gin.H{})
+		c.HTML(http.StatusOK, "eula.html.tmpl", gin.H{
+			"CSRFField": CSRFField(c),
+		})
 	}
 }
 
@@ -2,6 +2,7 @@ package web
 
 import (
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -39,8 +40,21 @@ func TestEulaAcceptHandler(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// browse the EULA page first, to obtain the session CSRF token the
+	// acceptance form has to send back
+	eulaResp := httptest.NewRecorder()
+	eulaReq := httptest.NewRequest("GET", "/eula", nil)
+	app.webEngine.ServeHTTP(eulaResp, eulaReq)
+
+	token := extractCSRFToken(t, eulaResp.Body.String())
+
 	resp := httptest.NewRecorder()
-	req := httptest.NewRequest("POST", "/accept-eula", nil)
+	req := httptest.NewRequest("POST", "/accept-eula",
+		strings.NewReader("csrf_token="+token))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for _, cookie := range eulaResp.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
 
 	app.webEngine.ServeHTTP(resp, req)
 
@@ -0,0 +1,73 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiHostEventsHandler godoc
+// @Summary Get the chronological event timeline of a host
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} models.ResourceEventList
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/events [get]
+func ApiHostEventsHandler(hostsService services.HostsService, eventsService services.EventsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		events, err := eventsService.GetByResource(models.TagHostResourceType, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, events)
+	}
+}
+
+// ApiClusterEventsHandler godoc
+// @Summary Get the chronological event timeline of a cluster
+// @Produce json
+// @Param cluster_id path string true "Cluster ID"
+// @Success 200 {object} models.ResourceEventList
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{cluster_id}/events [get]
+func ApiClusterEventsHandler(clustersService services.ClustersService, eventsService services.EventsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		clusterID := c.Param("cluster_id")
+
+		cluster, err := clustersService.GetByID(clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if cluster == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		events, err := eventsService.GetByResource(models.TagClusterResourceType, clusterID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, events)
+	}
+}
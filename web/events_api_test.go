@@ -0,0 +1,128 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiHostEventsHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockEventsService := new(services.MockEventsService)
+
+	mockHostsService.On("GetByID", "agent-1").Return(&models.Host{ID: "agent-1"}, nil)
+	mockEventsService.On("GetByResource", models.TagHostResourceType, "agent-1").Return(models.ResourceEventList{
+		{
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "agent-1",
+			Type:         models.ResourceEventTypeHeartbeat,
+			Message:      "Agent heartbeat resumed after an interruption",
+			OccurredAt:   time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.eventsService = mockEventsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/agent-1/events", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[{
+		"resource_type": "hosts",
+		"resource_id": "agent-1",
+		"type": "heartbeat",
+		"message": "Agent heartbeat resumed after an interruption",
+		"occurred_at": "2022-02-01T10:30:00Z"
+	}]`, resp.Body.String())
+
+	mockHostsService.AssertExpectations(t)
+	mockEventsService.AssertExpectations(t)
+}
+
+func TestApiHostEventsHandlerNotFound(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "other").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.eventsService = new(services.MockEventsService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/other/events", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiClusterEventsHandler(t *testing.T) {
+	mockClustersService := new(services.MockClustersService)
+	mockEventsService := new(services.MockEventsService)
+
+	mockClustersService.On("GetByID", "cluster-1").Return(&models.Cluster{ID: "cluster-1"}, nil)
+	mockEventsService.On("GetByResource", models.TagClusterResourceType, "cluster-1").Return(models.ResourceEventList{
+		{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   "cluster-1",
+			Type:         models.ResourceEventTypeCheckRegression,
+			Message:      "Check ABCDEF on host host1 regressed from passing to critical",
+			OccurredAt:   time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.clustersService = mockClustersService
+	deps.eventsService = mockEventsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster-1/events", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"type":"check_regression"`)
+	assert.Contains(t, resp.Body.String(), `"occurred_at":"2022-02-01T10:30:00Z"`)
+
+	mockClustersService.AssertExpectations(t)
+	mockEventsService.AssertExpectations(t)
+}
+
+func TestApiClusterEventsHandlerNotFound(t *testing.T) {
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetByID", "other").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.clustersService = mockClustersService
+	deps.eventsService = new(services.MockEventsService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/other/events", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONFactsGatheringRequest struct {
+	Probe string `json:"probe" binding:"required"`
+}
+
+type JSONFactsOutput struct {
+	Output string `json:"output"`
+	Error  string `json:"error"`
+}
+
+// ApiFactsProbesListHandler godoc
+// @Summary List the predefined facts gathering probes
+// @Produce json
+// @Success 200 {object} []string
+// @Router /facts/probes [get]
+func ApiFactsProbesListHandler(s services.FactsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.GetAvailableProbes())
+	}
+}
+
+// ApiRequestFactsGatheringHandler godoc
+// @Summary Ask an agent to run a predefined troubleshooting probe
+// @Accept json
+// @Produce json
+// @Param id path string true "Host id"
+// @Param Body body JSONFactsGatheringRequest true "Probe to run"
+// @Success 202 {object} models.FactsRequest
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/facts [post]
+func ApiRequestFactsGatheringHandler(hostsService services.HostsService, factsService services.FactsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		var r JSONFactsGatheringRequest
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		request, err := factsService.RequestFactsGathering(id, r.Probe)
+		if err != nil {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusAccepted, request)
+	}
+}
+
+// ApiFactsRequestHandler godoc
+// @Summary Get the state and output of a facts gathering request
+// @Produce json
+// @Param id path int true "Facts request Id"
+// @Success 200 {object} models.FactsRequest
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /facts/{id} [get]
+func ApiFactsRequestHandler(s services.FactsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("id must be a number"))
+			return
+		}
+
+		request, err := s.GetFactsRequestByID(id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if request == nil {
+			_ = c.Error(NotFoundError("could not find facts request"))
+			return
+		}
+
+		c.JSON(http.StatusOK, request)
+	}
+}
+
+// ApiGetAgentFactsRequestsHandler godoc
+// @Summary Get the probes an agent has to run
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} []models.FactsRequest
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/facts [get]
+func ApiGetAgentFactsRequestsHandler(s services.FactsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requests, err := s.GetPendingFactsRequests(c.Param("id"))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, requests)
+	}
+}
+
+// ApiCreateAgentFactsOutputHandler godoc
+// @Summary Store the output of a probe executed by an agent
+// @Accept json
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Param fact_id path int true "Facts request Id"
+// @Param Body body JSONFactsOutput true "Probe output"
+// @Success 202 {object} JSONFactsOutput
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /agents/{id}/facts/{fact_id} [post]
+func ApiCreateAgentFactsOutputHandler(s services.FactsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		factId, err := strconv.ParseInt(c.Param("fact_id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("fact_id must be a number"))
+			return
+		}
+
+		var r JSONFactsOutput
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = s.StoreFactsOutput(factId, r.Output, r.Error)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, &r)
+	}
+}
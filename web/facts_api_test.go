@@ -0,0 +1,161 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiRequestFactsGatheringHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "agent1").Return(&models.Host{ID: "agent1"}, nil)
+
+	mockFactsService := new(services.MockFactsService)
+	mockFactsService.On("RequestFactsGathering", "agent1", "corosync-config").Return(&models.FactsRequest{
+		ID:      1,
+		AgentID: "agent1",
+		Probe:   "corosync-config",
+		Command: "cat /etc/corosync/corosync.conf",
+		Status:  models.ExecutionStatusRequested,
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.factsService = mockFactsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"probe": "corosync-config"}`)
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/hosts/agent1/facts", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+
+	mockFactsService.AssertExpectations(t)
+}
+
+func TestApiRequestFactsGatheringHandlerUnknownHost(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "other").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{"probe": "corosync-config"}`)
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/hosts/other/facts", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiFactsRequestHandler(t *testing.T) {
+	mockFactsService := new(services.MockFactsService)
+	mockFactsService.On("GetFactsRequestByID", int64(1)).Return(&models.FactsRequest{
+		ID:      1,
+		AgentID: "agent1",
+		Probe:   "crm-status",
+		Command: "crm_mon -1r",
+		Status:  models.ExecutionStatusCompleted,
+		Output:  "Cluster Summary",
+	}, nil)
+	mockFactsService.On("GetFactsRequestByID", int64(2)).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.factsService = mockFactsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/facts/1", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Cluster Summary")
+
+	// 404 scenario
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/facts/2", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiFactsProbesListHandler(t *testing.T) {
+	mockFactsService := new(services.MockFactsService)
+	mockFactsService.On("GetAvailableProbes").Return([]string{"corosync-config", "crm-status"})
+
+	deps := setupTestDependencies()
+	deps.factsService = mockFactsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/facts/probes", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `["corosync-config", "crm-status"]`, resp.Body.String())
+}
+
+func TestApiAgentFactsHandlers(t *testing.T) {
+	mockFactsService := new(services.MockFactsService)
+	mockFactsService.On("GetPendingFactsRequests", "agent1").Return([]*models.FactsRequest{
+		{
+			ID:      1,
+			AgentID: "agent1",
+			Probe:   "sbd-config",
+			Command: "cat /etc/sysconfig/sbd",
+			Status:  models.ExecutionStatusRunning,
+		},
+	}, nil)
+	mockFactsService.On("StoreFactsOutput", int64(1), "SBD_DEVICE=/dev/sda", "").Return(nil)
+
+	deps := setupTestDependencies()
+	deps.factsService = mockFactsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/agent1/facts", nil)
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), "cat /etc/sysconfig/sbd")
+
+	body := []byte(`{"output": "SBD_DEVICE=/dev/sda", "error": ""}`)
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/agents/agent1/facts/1", bytes.NewBuffer(body))
+	app.collectorEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+	mockFactsService.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiDeleteHostHandler godoc
+// @Summary Remove a host and every record that belongs to it
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id} [delete]
+func ApiDeleteHostHandler(hostsService services.HostsService, gcService services.GarbageCollectionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		if err := gcService.DeleteHost(id); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ApiGarbageCollectionReportHandler godoc
+// @Summary Report the orphaned records the garbage collection would remove, without removing anything
+// @Produce json
+// @Success 200 {object} models.GarbageCollectionReport
+// @Failure 500 {object} map[string]string
+// @Router /internal/gc [get]
+func ApiGarbageCollectionReportHandler(gcService services.GarbageCollectionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := gcService.Report()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// ApiGarbageCollectionRunHandler godoc
+// @Summary Remove the orphaned records without waiting for the periodic collection
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /internal/gc [post]
+func ApiGarbageCollectionRunHandler(gcService services.GarbageCollectionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		collected, err := gcService.Collect()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"collected": collected,
+		})
+	}
+}
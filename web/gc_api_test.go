@@ -0,0 +1,112 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiDeleteHost(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "1").Return(hostListFixture()[0], nil)
+
+	mockGcService := new(services.MockGarbageCollectionService)
+	mockGcService.On("DeleteHost", "1").Return(nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.gcService = mockGcService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/hosts/1", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	mockGcService.AssertExpectations(t)
+}
+
+func TestApiDeleteHost404(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", mock.Anything).Return(nil, nil)
+
+	mockGcService := new(services.MockGarbageCollectionService)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.gcService = mockGcService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/hosts/not_there", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	mockGcService.AssertNotCalled(t, "DeleteHost", mock.Anything)
+}
+
+func TestApiGarbageCollectionReport(t *testing.T) {
+	mockGcService := new(services.MockGarbageCollectionService)
+	mockGcService.On("Report").Return(&models.GarbageCollectionReport{
+		Orphans: []*models.OrphanedRecords{
+			{Table: "host_heartbeats", Count: 2},
+		},
+		Total: 2,
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.gcService = mockGcService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/internal/gc", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{
+		"orphans": [{"table": "host_heartbeats", "count": 2}],
+		"total": 2
+	}`, resp.Body.String())
+}
+
+func TestApiGarbageCollectionRun(t *testing.T) {
+	mockGcService := new(services.MockGarbageCollectionService)
+	mockGcService.On("Collect").Return(int64(3), nil)
+
+	deps := setupTestDependencies()
+	deps.gcService = mockGcService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/internal/gc", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"collected": 3}`, resp.Body.String())
+	mockGcService.AssertExpectations(t)
+}
@@ -0,0 +1,230 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewGraphQLSchema builds the query schema served at /api/graphql. It exposes
+// the same read models as the REST API, so integrators can fetch hosts,
+// clusters, SAP systems and check results in a single request
+func NewGraphQLSchema(
+	hostsService services.HostsService,
+	clustersService services.ClustersService,
+	sapSystemsService services.SAPSystemsService,
+	checksService services.ChecksService,
+) (graphql.Schema, error) {
+	aggregatedChecksType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "AggregatedChecks",
+		Fields: graphql.Fields{
+			"passingCount":  &graphql.Field{Type: graphql.Int},
+			"warningCount":  &graphql.Field{Type: graphql.Int},
+			"criticalCount": &graphql.Field{Type: graphql.Int},
+			"health": &graphql.Field{
+				Type: graphql.String,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*models.AggregatedCheckData).String(), nil
+				},
+			},
+		},
+	})
+
+	hostType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Host",
+		Fields: graphql.Fields{
+			"id":            &graphql.Field{Type: graphql.String},
+			"name":          &graphql.Field{Type: graphql.String},
+			"health":        &graphql.Field{Type: graphql.String},
+			"ipAddresses":   &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"cloudProvider": &graphql.Field{Type: graphql.String},
+			"clusterId":     &graphql.Field{Type: graphql.String},
+			"clusterName":   &graphql.Field{Type: graphql.String},
+			"agentVersion":  &graphql.Field{Type: graphql.String},
+			"tags":          &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+
+	clusterType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Cluster",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.String},
+			"name":            &graphql.Field{Type: graphql.String},
+			"clusterType":     &graphql.Field{Type: graphql.String},
+			"sid":             &graphql.Field{Type: graphql.String},
+			"health":          &graphql.Field{Type: graphql.String},
+			"resourcesNumber": &graphql.Field{Type: graphql.Int},
+			"hostsNumber":     &graphql.Field{Type: graphql.Int},
+			"tags":            &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"checksResults": &graphql.Field{
+				Type: aggregatedChecksType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return checksService.GetAggregatedChecksResultByCluster(p.Source.(*models.Cluster).ID)
+				},
+			},
+		},
+	})
+
+	sapSystemInstanceType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SAPSystemInstance",
+		Fields: graphql.Fields{
+			"type":           &graphql.Field{Type: graphql.String},
+			"sid":            &graphql.Field{Type: graphql.String},
+			"features":       &graphql.Field{Type: graphql.String},
+			"instanceNumber": &graphql.Field{Type: graphql.String},
+			"status":         &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	sapSystemType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SAPSystem",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.String},
+			"sid":       &graphql.Field{Type: graphql.String},
+			"type":      &graphql.Field{Type: graphql.String},
+			"dbName":    &graphql.Field{Type: graphql.String},
+			"dbHost":    &graphql.Field{Type: graphql.String},
+			"health":    &graphql.Field{Type: graphql.String},
+			"tags":      &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"instances": &graphql.Field{Type: graphql.NewList(sapSystemInstanceType)},
+		},
+	})
+
+	listFilterArgs := graphql.FieldConfigArgument{
+		"sids":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+		"tags":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+		"health": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+	}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"hosts": &graphql.Field{
+				Type: graphql.NewList(hostType),
+				Args: listFilterArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return hostsService.GetAll(&services.HostsFilter{
+						SIDs:      stringListArg(p, "sids"),
+						Tags:      stringListArg(p, "tags"),
+						Health:    stringListArg(p, "health"),
+						ScopeTags: scopeTags(p),
+					}, nil)
+				},
+			},
+			"host": &graphql.Field{
+				Type: hostType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return hostsService.GetByID(p.Args["id"].(string))
+				},
+			},
+			"clusters": &graphql.Field{
+				Type: graphql.NewList(clusterType),
+				Args: listFilterArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return clustersService.GetAll(&services.ClustersFilter{
+						SIDs:      stringListArg(p, "sids"),
+						Tags:      stringListArg(p, "tags"),
+						Health:    stringListArg(p, "health"),
+						ScopeTags: scopeTags(p),
+					}, nil)
+				},
+			},
+			"cluster": &graphql.Field{
+				Type: clusterType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return clustersService.GetByID(p.Args["id"].(string))
+				},
+			},
+			"applications": &graphql.Field{
+				Type: graphql.NewList(sapSystemType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return sapSystemsService.GetAllApplications(&services.SAPSystemFilter{
+						ScopeTags: scopeTags(p),
+					}, nil)
+				},
+			},
+			"databases": &graphql.Field{
+				Type: graphql.NewList(sapSystemType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return sapSystemsService.GetAllDatabases(&services.SAPSystemFilter{
+						ScopeTags: scopeTags(p),
+					}, nil)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
+
+// scopeTags returns the tag visibility scope resolved by the
+// TagScopeMiddleware: the gin context is passed down as the resolution
+// context, see ApiGraphQLHandler
+func scopeTags(p graphql.ResolveParams) []string {
+	if p.Context == nil {
+		return nil
+	}
+	scope, _ := p.Context.Value(tagScopeContextKey).([]string)
+
+	return scope
+}
+
+func stringListArg(p graphql.ResolveParams, name string) []string {
+	rawList, ok := p.Args[name].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := []string{}
+	for _, raw := range rawList {
+		if value, ok := raw.(string); ok {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// ApiGraphQLHandler godoc
+// @Summary Run a GraphQL query against the landscape
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /graphql [post]
+func ApiGraphQLHandler(schema graphql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var request graphQLRequest
+		err := c.BindJSON(&request)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  request.Query,
+			OperationName:  request.OperationName,
+			VariableValues: request.Variables,
+			Context:        c,
+		})
+
+		c.JSON(http.StatusOK, result)
+	}
+}
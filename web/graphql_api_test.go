@@ -0,0 +1,100 @@
+package web
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiGraphQLHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetAll", &services.HostsFilter{}, (*services.Page)(nil)).Return(models.HostList{
+		{
+			ID:   "host1",
+			Name: "netweaver01",
+			Tags: []string{"prod"},
+		},
+	}, nil)
+
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetByID", "cluster1").Return(&models.Cluster{
+		ID:   "cluster1",
+		Name: "hana_cluster",
+	}, nil)
+
+	mockChecksService := new(services.MockChecksService)
+	mockChecksService.On("GetAggregatedChecksResultByCluster", "cluster1").Return(
+		&models.AggregatedCheckData{CriticalCount: 1}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.clustersService = mockClustersService
+	deps.checksService = mockChecksService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := `{"query": "{ hosts { id name tags } cluster(id: \"cluster1\") { name checksResults { criticalCount health } } }"}`
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"data": {
+			"hosts": [
+				{
+					"id": "host1",
+					"name": "netweaver01",
+					"tags": ["prod"]
+				}
+			],
+			"cluster": {
+				"name": "hana_cluster",
+				"checksResults": {
+					"criticalCount": 1,
+					"health": "critical"
+				}
+			}
+		}
+	}`, resp.Body.String())
+
+	mockHostsService.AssertExpectations(t)
+	mockClustersService.AssertExpectations(t)
+	mockChecksService.AssertExpectations(t)
+}
+
+func TestApiGraphQLHandlerQueryError(t *testing.T) {
+	deps := setupTestDependencies()
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/graphql", strings.NewReader(`{"query": "{ nope }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	// malformed queries come back as GraphQL errors, not transport errors
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"errors"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/graphql", strings.NewReader("not json"))
+	req.Header.Set("Content-Type", "application/json")
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
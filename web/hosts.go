@@ -3,9 +3,11 @@ package web
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/trento-project/trento/version"
 	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
@@ -30,9 +32,10 @@ func NewHostListHandler(hostsService services.HostsService) gin.HandlerFunc {
 		query := c.Request.URL.Query()
 
 		hostsFilter := &services.HostsFilter{
-			SIDs:   query["sids"],
-			Health: query["health"],
-			Tags:   query["tags"],
+			SIDs:      query["sids"],
+			Health:    query["health"],
+			Tags:      query["tags"],
+			ScopeTags: TagScopeFromContext(c),
 		}
 
 		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -89,11 +92,25 @@ func NewHostListHandler(hostsService services.HostsService) gin.HandlerFunc {
 	}
 }
 
+type JSONHeartbeat struct {
+	AgentVersion       string    `json:"agent_version"`
+	EnabledDiscoveries []string  `json:"enabled_discoveries"`
+	AgentTime          time.Time `json:"agent_time"`
+}
+
 func ApiHostHeartbeatHandler(hostService services.HostsService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		agentID := c.Param("id")
 
-		err := hostService.Heartbeat(agentID)
+		// the heartbeat body is optional, older agents send an empty one
+		var heartbeat JSONHeartbeat
+		_ = c.ShouldBindJSON(&heartbeat)
+
+		err := hostService.Heartbeat(agentID, &services.HeartbeatData{
+			AgentVersion:       heartbeat.AgentVersion,
+			EnabledDiscoveries: heartbeat.EnabledDiscoveries,
+			AgentTime:          heartbeat.AgentTime,
+		})
 		if err != nil {
 			_ = c.Error(err)
 			return
@@ -103,7 +120,307 @@ func ApiHostHeartbeatHandler(hostService services.HostsService) gin.HandlerFunc
 	}
 }
 
-func NewHostHandler(hostsService services.HostsService, subsService services.SubscriptionsService, monitoringURL string) gin.HandlerFunc {
+type JSONOutdatedAgent struct {
+	AgentID       string `json:"agent_id"`
+	Hostname      string `json:"hostname"`
+	AgentVersion  string `json:"agent_version"`
+	ServerVersion string `json:"server_version"`
+}
+
+// ApiOutdatedAgentsHandler godoc
+// @Summary Get the agents running a version different from the server one
+// @Produce json
+// @Success 200 {object} []JSONOutdatedAgent
+// @Failure 500 {object} map[string]string
+// @Router /agents/outdated [get]
+func ApiOutdatedAgentsHandler(hostsService services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		hosts, err := hostsService.GetOutdatedAgents()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		outdatedAgents := make([]*JSONOutdatedAgent, 0)
+		for _, host := range hosts {
+			outdatedAgents = append(outdatedAgents, &JSONOutdatedAgent{
+				AgentID:       host.ID,
+				Hostname:      host.Name,
+				AgentVersion:  host.AgentVersion,
+				ServerVersion: version.Version,
+			})
+		}
+
+		c.JSON(http.StatusOK, outdatedAgents)
+	}
+}
+
+// ApiHostExportersStatusHandler godoc
+// @Summary Get the health of the Prometheus exporters running on a host
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /hosts/{id}/exporters [get]
+func ApiHostExportersStatusHandler(hostsService services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		exportersState, err := hostsService.GetExportersState(host.Name)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, exportersState)
+	}
+}
+
+type JSONPackageComparison struct {
+	Package        string            `json:"package"`
+	MinimumVersion string            `json:"minimum_version,omitempty"`
+	Versions       map[string]string `json:"versions"`
+	OutdatedHosts  []string          `json:"outdated_hosts"`
+}
+
+// ApiHostsPackagesHandler godoc
+// @Summary Compare the HA/SAP package versions across all the hosts and against the known-good minimums
+// @Produce json
+// @Success 200 {object} []JSONPackageComparison
+// @Failure 500 {object} map[string]string
+// @Router /hosts/packages [get]
+func ApiHostsPackagesHandler(hostsService services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		matrix, err := hostsService.GetPackagesMatrix()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		packages := make([]*JSONPackageComparison, 0)
+		for _, comparison := range matrix.Packages {
+			packages = append(packages, &JSONPackageComparison{
+				Package:        comparison.Package,
+				MinimumVersion: comparison.MinimumVersion,
+				Versions:       comparison.Versions,
+				OutdatedHosts:  comparison.OutdatedHosts,
+			})
+		}
+
+		c.JSON(http.StatusOK, packages)
+	}
+}
+
+type JSONSettingsCompliance struct {
+	HostID    string                  `json:"host_id"`
+	Compliant bool                    `json:"compliant"`
+	Settings  []JSONSettingCompliance `json:"settings"`
+}
+
+type JSONSettingCompliance struct {
+	Name          string `json:"name"`
+	ExpectedValue string `json:"expected_value"`
+	ActualValue   string `json:"actual_value"`
+	SAPNote       string `json:"sap_note"`
+	Compliant     bool   `json:"compliant"`
+}
+
+// ApiHostComplianceHandler godoc
+// @Summary Evaluate the kernel and OS settings of a host against the SAP notes recommendations
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} JSONSettingsCompliance
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/compliance [get]
+func ApiHostComplianceHandler(hostsService services.HostsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		compliance, err := hostsService.GetSettingsComplianceByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if compliance == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		jsonCompliance := &JSONSettingsCompliance{
+			HostID:    compliance.HostID,
+			Compliant: compliance.Compliant,
+			Settings:  []JSONSettingCompliance{},
+		}
+
+		for _, setting := range compliance.Settings {
+			jsonCompliance.Settings = append(jsonCompliance.Settings, JSONSettingCompliance{
+				Name:          setting.Name,
+				ExpectedValue: setting.ExpectedValue,
+				ActualValue:   setting.ActualValue,
+				SAPNote:       setting.SAPNote,
+				Compliant:     setting.Compliant,
+			})
+		}
+
+		c.JSON(http.StatusOK, jsonCompliance)
+	}
+}
+
+type JSONHostDetails struct {
+	AgentID              string                   `json:"agent_id"`
+	Hostname             string                   `json:"hostname"`
+	Health               string                   `json:"health"`
+	IPAddresses          []string                 `json:"ip_addresses"`
+	CloudProvider        string                   `json:"cloud_provider"`
+	CloudData            interface{}              `json:"cloud_data,omitempty"`
+	ClusterID            string                   `json:"cluster_id,omitempty"`
+	ClusterName          string                   `json:"cluster_name,omitempty"`
+	ClusterType          string                   `json:"cluster_type,omitempty"`
+	OSVersion            string                   `json:"os_version,omitempty"`
+	AgentVersion         string                   `json:"agent_version"`
+	AgentVersionMismatch bool                     `json:"agent_version_mismatch"`
+	LastHeartbeat        *time.Time               `json:"last_heartbeat,omitempty"`
+	Subscriptions        []JSONHostSubscription   `json:"subscriptions"`
+	SAPInstances         []JSONHostSAPInstance    `json:"sap_instances"`
+	Discoveries          []JSONDiscoveryFreshness `json:"discoveries"`
+	Tags                 []string                 `json:"tags,omitempty"`
+}
+
+type JSONDiscoveryFreshness struct {
+	DiscoveryType string    `json:"discovery_type"`
+	LastUpdated   time.Time `json:"last_updated"`
+	Stale         bool      `json:"stale"`
+}
+
+type JSONHostSubscription struct {
+	Identifier         string `json:"identifier"`
+	Version            string `json:"version"`
+	Arch               string `json:"arch"`
+	Type               string `json:"type,omitempty"`
+	Status             string `json:"status,omitempty"`
+	StartsAt           string `json:"starts_at,omitempty"`
+	ExpiresAt          string `json:"expires_at,omitempty"`
+	SubscriptionStatus string `json:"subscription_status,omitempty"`
+}
+
+type JSONHostSAPInstance struct {
+	ID             string `json:"id"`
+	SID            string `json:"sid"`
+	Type           string `json:"type"`
+	InstanceNumber string `json:"instance_number"`
+	Features       string `json:"features"`
+	Status         string `json:"status"`
+}
+
+// ApiHostByIdHandler godoc
+// @Summary Retrieve the full discovered inventory of a host
+// @Produce json
+// @Param id path string true "Agent ID"
+// @Success 200 {object} JSONHostDetails
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id} [get]
+func ApiHostByIdHandler(hostsService services.HostsService, subsService services.SubscriptionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		subscriptions, err := subsService.GetHostSubscriptions(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		freshness, err := hostsService.GetDiscoveriesFreshness(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		hostDetails := &JSONHostDetails{
+			AgentID:              host.ID,
+			Hostname:             host.Name,
+			Health:               host.Health,
+			IPAddresses:          host.IPAddresses,
+			CloudProvider:        host.CloudProvider,
+			CloudData:            host.CloudData,
+			ClusterID:            host.ClusterID,
+			ClusterName:          host.ClusterName,
+			ClusterType:          host.ClusterType,
+			AgentVersion:         host.AgentVersion,
+			AgentVersionMismatch: host.AgentVersionMismatch,
+			LastHeartbeat:        host.LastHeartbeat,
+			Subscriptions:        []JSONHostSubscription{},
+			SAPInstances:         []JSONHostSAPInstance{},
+			Discoveries:          []JSONDiscoveryFreshness{},
+			Tags:                 host.Tags,
+		}
+
+		for _, f := range freshness {
+			hostDetails.Discoveries = append(hostDetails.Discoveries, JSONDiscoveryFreshness{
+				DiscoveryType: f.DiscoveryType,
+				LastUpdated:   f.LastUpdated,
+				Stale:         f.Stale,
+			})
+		}
+
+		for _, subscription := range subscriptions {
+			// the base product subscription carries the OS version of the host
+			if subscription.ID == "SLES_SAP" || subscription.ID == "SLES" {
+				hostDetails.OSVersion = subscription.Version
+			}
+
+			hostDetails.Subscriptions = append(hostDetails.Subscriptions, JSONHostSubscription{
+				Identifier:         subscription.ID,
+				Version:            subscription.Version,
+				Arch:               subscription.Arch,
+				Type:               subscription.Type,
+				Status:             subscription.Status,
+				StartsAt:           subscription.StartsAt,
+				ExpiresAt:          subscription.ExpiresAt,
+				SubscriptionStatus: subscription.SubscriptionStatus,
+			})
+		}
+
+		for _, sapSystem := range host.SAPSystems {
+			for _, instance := range sapSystem.Instances {
+				hostDetails.SAPInstances = append(hostDetails.SAPInstances, JSONHostSAPInstance{
+					ID:             sapSystem.ID,
+					SID:            instance.SID,
+					Type:           instance.Type,
+					InstanceNumber: instance.InstanceNumber,
+					Features:       instance.Features,
+					Status:         instance.Status,
+				})
+			}
+		}
+
+		c.JSON(http.StatusOK, hostDetails)
+	}
+}
+
+func NewHostHandler(hostsService services.HostsService, subsService services.SubscriptionsService,
+	alertsService services.AlertsService, metricsProvider MetricsProvider) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		id := c.Param("id")
 
@@ -125,10 +442,19 @@ func NewHostHandler(hostsService services.HostsService, subsService services.Sub
 
 		jobsState, _ := hostsService.GetExportersState(host.Name)
 
+		metricAlerts, err := alertsService.GetFiringByResource(models.TagHostResourceType, host.ID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
 		c.HTML(http.StatusOK, "host.html.tmpl", gin.H{
 			"Host":           &host,
 			"Subscriptions":  subs,
-			"MonitoringURL":  monitoringURL,
+			"MetricsEnabled": metricsProvider.Enabled(),
+			"MetricAlerts":   metricAlerts,
+			"CPUPanelURL":    metricsProvider.HostPanelURL(host.ID, HostPanelCPU),
+			"MemoryPanelURL": metricsProvider.HostPanelURL(host.ID, HostPanelMemory),
 			"ExportersState": jobsState,
 		})
 	}
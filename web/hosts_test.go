@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -135,7 +136,7 @@ func TestApiHostHeartbeat(t *testing.T) {
 	agentID := "agent_id"
 
 	mockHostsService := new(services.MockHostsService)
-	mockHostsService.On("Heartbeat", agentID).Return(nil)
+	mockHostsService.On("Heartbeat", agentID, mock.Anything).Return(nil)
 
 	deps := setupTestDependencies()
 	deps.hostsService = mockHostsService
@@ -154,6 +155,228 @@ func TestApiHostHeartbeat(t *testing.T) {
 	assert.Equal(t, 204, resp.Code)
 }
 
+func TestApiOutdatedAgents(t *testing.T) {
+	outdatedHost := hostListFixture()[0]
+	outdatedHost.AgentVersionMismatch = true
+
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetOutdatedAgents").Return(models.HostList{outdatedHost}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/agents/outdated", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t,
+		`[{"agent_id": "1", "hostname": "host1", "agent_version": "v1", "server_version": ""}]`,
+		resp.Body.String())
+}
+
+func TestApiHostsPackages(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetPackagesMatrix").Return(&models.PackagesMatrix{
+		Packages: []*models.PackageComparison{
+			{
+				Package:        "pacemaker",
+				MinimumVersion: "2.0.4",
+				Versions: map[string]string{
+					"host1": "2.0.5",
+					"host2": "2.0.1",
+				},
+				OutdatedHosts: []string{"host2"},
+			},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/packages", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t,
+		`[{
+			"package": "pacemaker",
+			"minimum_version": "2.0.4",
+			"versions": {"host1": "2.0.5", "host2": "2.0.1"},
+			"outdated_hosts": ["host2"]
+		}]`,
+		resp.Body.String())
+}
+
+func TestApiHostCompliance(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetSettingsComplianceByID", "1").Return(&models.SettingsCompliance{
+		HostID:    "1",
+		Compliant: false,
+		Settings: []*models.SettingCompliance{
+			{
+				Name:          "thp",
+				ExpectedValue: "never",
+				ActualValue:   "always",
+				SAPNote:       "2684254",
+				Compliant:     false,
+			},
+		},
+	}, nil)
+	mockHostsService.On("GetSettingsComplianceByID", "not_found").Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/1/compliance", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t,
+		`{
+			"host_id": "1",
+			"compliant": false,
+			"settings": [{
+				"name": "thp",
+				"expected_value": "never",
+				"actual_value": "always",
+				"sap_note": "2684254",
+				"compliant": false
+			}]
+		}`,
+		resp.Body.String())
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts/not_found/compliance", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
+func TestApiHostExportersStatus(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "1").Return(hostListFixture()[0], nil)
+	mockHostsService.On("GetExportersState", "host1").Return(map[string]string{
+		"Node Exporter": models.HostHealthPassing,
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/1/exporters", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"Node Exporter": "passing"}`, resp.Body.String())
+}
+
+func TestApiHostByIdHandler(t *testing.T) {
+	subscriptionsMocks := new(services.MockSubscriptionsService)
+	mockHostsService := new(services.MockHostsService)
+
+	host := hostListFixture()[0]
+	host.SAPSystems[0].Instances = []*models.SAPSystemInstance{
+		{
+			SID:            "PRD",
+			Type:           "database",
+			InstanceNumber: "00",
+			Features:       "HDB|HDB_WORKER",
+			Status:         "SAPControl-GREEN",
+		},
+	}
+
+	subscriptionsMocks.On("GetHostSubscriptions", "1").Return([]*models.SlesSubscription{
+		{
+			ID:      "SLES_SAP",
+			Version: "15.2",
+			Arch:    "x86_64",
+			Status:  "Registered",
+		},
+	}, nil)
+	mockHostsService.On("GetByID", "1").Return(host, nil)
+	mockHostsService.On("GetDiscoveriesFreshness", "1").Return([]*models.DiscoveryFreshness{
+		{
+			DiscoveryType: "ha_cluster_discovery",
+			LastUpdated:   time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+			Stale:         true,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.subscriptionsService = subscriptionsMocks
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/1", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	responseBody := resp.Body.String()
+
+	assert.Contains(t, responseBody, `"agent_id":"1"`)
+	assert.Contains(t, responseBody, `"discovery_type":"ha_cluster_discovery"`)
+	assert.Contains(t, responseBody, `"stale":true`)
+	assert.Contains(t, responseBody, `"hostname":"host1"`)
+	assert.Contains(t, responseBody, `"os_version":"15.2"`)
+	assert.Contains(t, responseBody, `"identifier":"SLES_SAP"`)
+	assert.Contains(t, responseBody, `"instance_number":"00"`)
+	assert.Contains(t, responseBody, `"resource_group":"carbonara-resourcegroup"`)
+}
+
+func TestApiHostByIdHandler404(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", mock.Anything).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/not_there", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
+
 func TestHostHandler(t *testing.T) {
 	subscriptionsMocks := new(services.MockSubscriptionsService)
 	mockHostsService := new(services.MockHostsService)
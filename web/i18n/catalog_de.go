@@ -0,0 +1,24 @@
+package i18n
+
+// germanCatalog translates the user-facing messages of the web package,
+// keyed by the English source text
+var germanCatalog = map[string]string{
+	"at must be a RFC3339 timestamp":                   "at muss ein RFC3339-Zeitstempel sein",
+	"cluster not found":                                "Cluster nicht gefunden",
+	"could not find a configuration snapshot":          "es wurde kein Konfigurationsabbild gefunden",
+	"could not find cluster":                           "Cluster wurde nicht gefunden",
+	"could not find execution":                         "Ausführung wurde nicht gefunden",
+	"could not find facts request":                     "Faktenanfrage wurde nicht gefunden",
+	"could not find host":                              "Host wurde nicht gefunden",
+	"could not find sap system":                        "SAP-System wurde nicht gefunden",
+	"could not find system":                            "System wurde nicht gefunden",
+	"invalid timezone":                                 "ungültige Zeitzone",
+	"no checks results found for the given executions": "keine Prüfergebnisse für die angegebenen Ausführungen gefunden",
+	"not enough configuration snapshots to compare":    "nicht genügend Konfigurationsabbilder zum Vergleichen",
+	"problems parsing JSON":                            "Probleme beim Einlesen von JSON",
+	"the EULA acceptance cannot be revoked":            "die Zustimmung zur EULA kann nicht widerrufen werden",
+	"the q parameter is required":                      "der Parameter q ist erforderlich",
+	"unable to parse JSON body":                        "JSON-Inhalt konnte nicht eingelesen werden",
+	"unknown exporter type":                            "unbekannter Exporter-Typ",
+	"within_days must be a positive number":            "within_days muss eine positive Zahl sein",
+}
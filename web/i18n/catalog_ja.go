@@ -0,0 +1,24 @@
+package i18n
+
+// japaneseCatalog translates the user-facing messages of the web package,
+// keyed by the English source text
+var japaneseCatalog = map[string]string{
+	"at must be a RFC3339 timestamp":                   "atはRFC3339形式のタイムスタンプである必要があります",
+	"cluster not found":                                "クラスタが見つかりません",
+	"could not find a configuration snapshot":          "構成スナップショットが見つかりません",
+	"could not find cluster":                           "クラスタが見つかりません",
+	"could not find execution":                         "実行が見つかりません",
+	"could not find facts request":                     "ファクト要求が見つかりません",
+	"could not find host":                              "ホストが見つかりません",
+	"could not find sap system":                        "SAPシステムが見つかりません",
+	"could not find system":                            "システムが見つかりません",
+	"invalid timezone":                                 "無効なタイムゾーンです",
+	"no checks results found for the given executions": "指定された実行のチェック結果が見つかりません",
+	"not enough configuration snapshots to compare":    "比較するための構成スナップショットが不足しています",
+	"problems parsing JSON":                            "JSONの解析に問題があります",
+	"the EULA acceptance cannot be revoked":            "EULAへの同意は取り消せません",
+	"the q parameter is required":                      "qパラメータは必須です",
+	"unable to parse JSON body":                        "JSONボディを解析できません",
+	"unknown exporter type":                            "不明なエクスポータの種類です",
+	"within_days must be a positive number":            "within_daysは正の数である必要があります",
+}
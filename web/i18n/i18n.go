@@ -0,0 +1,63 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLocale is the locale used when negotiation does not find a better match
+const DefaultLocale = "en"
+
+// SupportedLocales are the locales a catalog exists for.
+// English is the source language of the catalogs, so it needs no catalog itself.
+var SupportedLocales = []string{"en", "de", "ja"}
+
+// catalogs hold the translated strings per locale, keyed by the English
+// source text, so that strings missing a translation gracefully fall back
+var catalogs = map[string]map[string]string{
+	"de": germanCatalog,
+	"ja": japaneseCatalog,
+}
+
+// T translates a message into the given locale, formatting the args, if any,
+// printf-style. Unknown locales and untranslated messages return the English text.
+func T(locale string, message string, args ...interface{}) string {
+	if catalog, found := catalogs[locale]; found {
+		if translated, found := catalog[message]; found {
+			message = translated
+		}
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// IsSupported returns whether a catalog exists for the given locale
+func IsSupported(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NegotiateLocale picks the best supported locale from an Accept-Language
+// header. Only the primary language subtag is matched: "de-DE" negotiates to
+// "de". The entries are assumed to be ordered by preference, as browsers do.
+func NegotiateLocale(acceptLanguage string) string {
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		language := strings.TrimSpace(strings.SplitN(entry, ";", 2)[0])
+		language = strings.SplitN(language, "-", 2)[0]
+
+		if IsSupported(language) {
+			return language
+		}
+	}
+
+	return DefaultLocale
+}
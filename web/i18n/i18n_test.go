@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "could not find host", T("en", "could not find host"))
+	assert.Equal(t, "Host wurde nicht gefunden", T("de", "could not find host"))
+	assert.Equal(t, "ホストが見つかりません", T("ja", "could not find host"))
+
+	// untranslated messages and unknown locales fall back to English
+	assert.Equal(t, "some untranslated message", T("de", "some untranslated message"))
+	assert.Equal(t, "could not find host", T("fr", "could not find host"))
+}
+
+func TestTWithArgs(t *testing.T) {
+	assert.Equal(t, "found 3 hosts", T("en", "found %d hosts", 3))
+}
+
+func TestIsSupported(t *testing.T) {
+	assert.True(t, IsSupported("en"))
+	assert.True(t, IsSupported("de"))
+	assert.True(t, IsSupported("ja"))
+	assert.False(t, IsSupported("fr"))
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	assert.Equal(t, "de", NegotiateLocale("de-DE,de;q=0.9,en;q=0.8"))
+	assert.Equal(t, "ja", NegotiateLocale("ja"))
+	assert.Equal(t, "en", NegotiateLocale("fr-FR,fr;q=0.9"))
+	assert.Equal(t, "en", NegotiateLocale(""))
+}
@@ -0,0 +1,34 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiIncidentsListHandler godoc
+// @Summary List the recorded incidents
+// @Produce json
+// @Param resource_id query []string false "Filter by resource id"
+// @Param open query bool false "Only return incidents that are still open"
+// @Success 200 {object} models.IncidentList
+// @Failure 500 {object} map[string]string
+// @Router /incidents [get]
+func ApiIncidentsListHandler(incidentsService services.IncidentsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		filter := &services.IncidentsFilter{
+			ResourceID: c.QueryArray("resource_id"),
+			OpenOnly:   c.Query("open") == "true",
+		}
+
+		incidents, err := incidentsService.GetAll(filter)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, incidents)
+	}
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiIncidentsListHandler(t *testing.T) {
+	closedAt := time.Date(2022, 2, 1, 11, 0, 0, 0, time.UTC)
+
+	mockIncidentsService := new(services.MockIncidentsService)
+	mockIncidentsService.On("GetAll", &services.IncidentsFilter{ResourceID: []string{}}).
+		Return(models.IncidentList{
+			{
+				ID:           1,
+				ResourceType: models.TagClusterResourceType,
+				ResourceID:   "cluster_id",
+				OpenedAt:     time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC),
+				ClosedAt:     &closedAt,
+			},
+		}, nil)
+	mockIncidentsService.On("GetAll", &services.IncidentsFilter{
+		ResourceID: []string{"cluster_id"},
+		OpenOnly:   true,
+	}).
+		Return(models.IncidentList{}, nil)
+
+	deps := setupTestDependencies()
+	deps.incidentsService = mockIncidentsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/incidents", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"resource_id":"cluster_id"`)
+	assert.Contains(t, resp.Body.String(), `"closed_at":"2022-02-01T11:00:00Z"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/incidents?resource_id=cluster_id&open=true", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "[]", resp.Body.String())
+
+	mockIncidentsService.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONLandscapePositionRequest struct {
+	// Position along the transport route, lower comes first
+	Position int `json:"position"`
+}
+
+// ApiLandscapesListHandler godoc
+// @Summary List the landscapes, grouping the resources by SID along the transport route
+// @Produce json
+// @Success 200 {object} models.LandscapeList
+// @Failure 500 {object} map[string]string
+// @Router /landscapes [get]
+func ApiLandscapesListHandler(landscapesService services.LandscapesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		landscapes, err := landscapesService.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, landscapes)
+	}
+}
+
+// ApiLandscapeGetHandler godoc
+// @Summary Get the full stack of a SID - SAP systems, databases, clusters and hosts
+// @Produce json
+// @Param sid path string true "SID"
+// @Success 200 {object} models.Landscape
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /landscapes/{sid} [get]
+func ApiLandscapeGetHandler(landscapesService services.LandscapesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sid := c.Param("sid")
+
+		landscape, err := landscapesService.GetBySID(sid)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if landscape == nil {
+			_ = c.Error(NotFoundError("could not find landscape"))
+			return
+		}
+
+		c.JSON(http.StatusOK, landscape)
+	}
+}
+
+// ApiLandscapeSetPositionHandler godoc
+// @Summary Set the transport-route position of a SID
+// @Accept json
+// @Produce json
+// @Param sid path string true "SID"
+// @Param Body body JSONLandscapePositionRequest true "The position to store"
+// @Success 200 {object} JSONLandscapePositionRequest
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /landscapes/{sid}/position [put]
+func ApiLandscapeSetPositionHandler(landscapesService services.LandscapesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sid := c.Param("sid")
+
+		var r JSONLandscapePositionRequest
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if err := landscapesService.SetPosition(sid, r.Position); err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &r)
+	}
+}
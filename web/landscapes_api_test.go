@@ -0,0 +1,94 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func landscapesTestApp(t *testing.T, mockLandscapesService *services.MockLandscapesService) *App {
+	deps := setupTestDependencies()
+	deps.landscapesService = mockLandscapesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func TestApiLandscapesListHandler(t *testing.T) {
+	mockLandscapesService := new(services.MockLandscapesService)
+	mockLandscapesService.On("GetAll").Return(models.LandscapeList{
+		{SID: "PRD", Position: 1},
+		{SID: "HA1", Position: 2},
+	}, nil)
+
+	app := landscapesTestApp(t, mockLandscapesService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/landscapes", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"sid":"PRD"`)
+	assert.Contains(t, resp.Body.String(), `"sid":"HA1"`)
+
+	mockLandscapesService.AssertExpectations(t)
+}
+
+func TestApiLandscapeGetHandler(t *testing.T) {
+	mockLandscapesService := new(services.MockLandscapesService)
+	mockLandscapesService.On("GetBySID", "HA1").Return(&models.Landscape{
+		SID:        "HA1",
+		SAPSystems: models.SAPSystemList{{ID: "sap_system_1", SID: "HA1"}},
+	}, nil)
+	mockLandscapesService.On("GetBySID", "GHO").Return(nil, nil)
+
+	app := landscapesTestApp(t, mockLandscapesService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/landscapes/HA1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"sid":"HA1"`)
+	assert.Contains(t, resp.Body.String(), `"sap_system_1"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/landscapes/GHO", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockLandscapesService.AssertExpectations(t)
+}
+
+func TestApiLandscapeSetPositionHandler(t *testing.T) {
+	mockLandscapesService := new(services.MockLandscapesService)
+	mockLandscapesService.On("SetPosition", "HA1", 2).Return(nil)
+
+	app := landscapesTestApp(t, mockLandscapesService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/landscapes/HA1/position",
+		bytes.NewBufferString(`{"position": 2}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/api/landscapes/HA1/position",
+		bytes.NewBufferString(`not a json`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockLandscapesService.AssertExpectations(t)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"path/filepath"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/version"
@@ -154,9 +155,10 @@ func (r *LayoutRender) addFileFromFS(templatesFS fs.FS, file string) {
 		"sum": func(a int, b int) int {
 			return a + b
 		},
-		"markdown": markdownToHTML,
-		"split":    strings.Split,
-		"script":   script,
+		"markdown":  markdownToHTML,
+		"split":     strings.Split,
+		"script":    script,
+		"localtime": localtime,
 	})
 	patterns := append([]string{r.root, file}, r.blocks...)
 	tmpl = template.Must(tmpl.ParseFS(templatesFS, patterns...))
@@ -164,6 +166,17 @@ func (r *LayoutRender) addFileFromFS(templatesFS fs.FS, file string) {
 	r.addTemplate(name, tmpl)
 }
 
+// localtime formats a timestamp converted to the given IANA timezone,
+// falling back to UTC when the timezone is empty or unknown
+func localtime(t time.Time, timezone string) string {
+	location, err := time.LoadLocation(timezone)
+	if timezone == "" || err != nil {
+		location = time.UTC
+	}
+
+	return t.In(location).Format("Jan 02, 2006 15:04:05 MST")
+}
+
 func script(filename string) template.HTML {
 	scriptTag := fmt.Sprintf("<script src=\"/static/frontend/assets/js/%s\"></script>", filename)
 	return template.HTML(scriptTag)
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"fmt"
+	"net/url"
+)
+
+const (
+	MetricsProviderGrafana    = "grafana"
+	MetricsProviderPrometheus = "prometheus"
+	MetricsProviderDisabled   = "disabled"
+)
+
+// The panels embedded in the host details view
+const (
+	HostPanelCPU = iota
+	HostPanelMemory
+)
+
+// MetricsProvider abstracts where the metrics panels of the host details view
+// come from, so that sites which cannot embed Grafana can still get metrics
+type MetricsProvider interface {
+	// Enabled tells whether the metrics panels should be rendered at all
+	Enabled() bool
+	// HostPanelURL returns the URL to embed for the given host and panel
+	HostPanelURL(hostID string, panel int) string
+}
+
+// NewMetricsProvider builds the MetricsProvider configured by the
+// metrics-provider option, defaulting to Grafana
+func NewMetricsProvider(provider string, grafanaURL string, prometheusURL string) MetricsProvider {
+	switch provider {
+	case MetricsProviderPrometheus:
+		return &prometheusMetricsProvider{baseURL: prometheusURL}
+	case MetricsProviderDisabled:
+		return &disabledMetricsProvider{}
+	default:
+		return &grafanaMetricsProvider{baseURL: grafanaURL}
+	}
+}
+
+type grafanaMetricsProvider struct {
+	baseURL string
+}
+
+func (p *grafanaMetricsProvider) Enabled() bool {
+	return true
+}
+
+func (p *grafanaMetricsProvider) HostPanelURL(hostID string, panel int) string {
+	panelID := 77
+	if panel == HostPanelMemory {
+		panelID = 78
+	}
+
+	return fmt.Sprintf(
+		"%s/d-solo/rYdddlPWj/node-exporter-full?orgId=1&refresh=1m&theme=light&panelId=%d&var-agentID=%s",
+		p.baseURL, panelID, hostID)
+}
+
+type prometheusMetricsProvider struct {
+	baseURL string
+}
+
+func (p *prometheusMetricsProvider) Enabled() bool {
+	return true
+}
+
+func (p *prometheusMetricsProvider) HostPanelURL(hostID string, panel int) string {
+	expr := fmt.Sprintf(
+		`100 - (avg(rate(node_cpu_seconds_total{mode="idle",agentID="%s"}[5m])) * 100)`, hostID)
+	if panel == HostPanelMemory {
+		expr = fmt.Sprintf(
+			`node_memory_MemTotal_bytes{agentID="%s"} - node_memory_MemAvailable_bytes{agentID="%s"}`,
+			hostID, hostID)
+	}
+
+	return fmt.Sprintf("%s/graph?g0.expr=%s&g0.tab=0&g0.range_input=1h", p.baseURL, url.QueryEscape(expr))
+}
+
+type disabledMetricsProvider struct{}
+
+func (p *disabledMetricsProvider) Enabled() bool {
+	return false
+}
+
+func (p *disabledMetricsProvider) HostPanelURL(hostID string, panel int) string {
+	return ""
+}
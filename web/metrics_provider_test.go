@@ -0,0 +1,39 @@
+package web
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricsProviderGrafana(t *testing.T) {
+	provider := NewMetricsProvider(MetricsProviderGrafana, "http://grafana:3000", "http://prometheus:9090")
+
+	assert.True(t, provider.Enabled())
+	assert.Equal(t,
+		"http://grafana:3000/d-solo/rYdddlPWj/node-exporter-full?orgId=1&refresh=1m&theme=light&panelId=77&var-agentID=some-id",
+		provider.HostPanelURL("some-id", HostPanelCPU))
+	assert.Contains(t, provider.HostPanelURL("some-id", HostPanelMemory), "panelId=78")
+}
+
+func TestNewMetricsProviderPrometheus(t *testing.T) {
+	provider := NewMetricsProvider(MetricsProviderPrometheus, "http://grafana:3000", "http://prometheus:9090")
+
+	assert.True(t, provider.Enabled())
+	assert.Contains(t, provider.HostPanelURL("some-id", HostPanelCPU), "http://prometheus:9090/graph?g0.expr=")
+	assert.Contains(t, provider.HostPanelURL("some-id", HostPanelMemory), "node_memory_MemTotal_bytes")
+}
+
+func TestNewMetricsProviderDisabled(t *testing.T) {
+	provider := NewMetricsProvider(MetricsProviderDisabled, "http://grafana:3000", "http://prometheus:9090")
+
+	assert.False(t, provider.Enabled())
+	assert.Empty(t, provider.HostPanelURL("some-id", HostPanelCPU))
+}
+
+func TestNewMetricsProviderDefaultsToGrafana(t *testing.T) {
+	provider := NewMetricsProvider("", "http://grafana:3000", "http://prometheus:9090")
+
+	assert.True(t, provider.Enabled())
+	assert.Contains(t, provider.HostPanelURL("some-id", HostPanelCPU), "http://grafana:3000/d-solo")
+}
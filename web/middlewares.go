@@ -1,13 +1,23 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
 
+	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/i18n"
 	"github.com/trento-project/trento/web/services"
 )
 
+const localeContextKey = "locale"
+const localeSessionKey = "locale"
+
+const tagScopeContextKey = "tag_scope"
+const usernameSessionKey = "username"
+const usernameHeader = "X-Trento-User"
+
 func EulaMiddleware(premiumDetection services.PremiumDetectionService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requiresEulaAcceptance, err := premiumDetection.RequiresEulaAcceptance()
@@ -28,3 +38,92 @@ func EulaMiddleware(premiumDetection services.PremiumDetectionService) gin.Handl
 		c.Next()
 	}
 }
+
+// LocaleMiddleware resolves the locale of the request: the "locale" query
+// parameter (persisted in the session) wins, then the session, then the
+// Accept-Language header
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+
+		locale := c.Query("locale")
+		if locale != "" && i18n.IsSupported(locale) {
+			session.Set(localeSessionKey, locale)
+			_ = session.Save()
+		} else if sessionLocale, ok := session.Get(localeSessionKey).(string); ok {
+			locale = sessionLocale
+		} else {
+			locale = i18n.NegotiateLocale(c.GetHeader("Accept-Language"))
+		}
+
+		c.Set(localeContextKey, locale)
+		c.Next()
+	}
+}
+
+// LocaleFromContext returns the locale resolved by the LocaleMiddleware
+func LocaleFromContext(c *gin.Context) string {
+	if locale, ok := c.Get(localeContextKey); ok {
+		return locale.(string)
+	}
+
+	return i18n.DefaultLocale
+}
+
+// FeatureFlagMiddleware rejects the requests to routes belonging to a
+// subsystem whose feature flag is disabled for the installation
+func FeatureFlagMiddleware(featureFlags services.FeatureFlagsService, feature string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !featureFlags.IsEnabled(feature) {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{
+				"error": fmt.Sprintf("the %s feature is not enabled", feature),
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// TagScopeMiddleware resolves the account a request belongs to and stores its
+// tag visibility scope in the context, so the list handlers can enforce it on
+// the service-layer queries. The account comes from the session when present;
+// until a login flow exists, an authenticating reverse proxy can supply it
+// with the X-Trento-User header
+func TagScopeMiddleware(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username, _ := sessions.Default(c).Get(usernameSessionKey).(string)
+		if username == "" {
+			username = c.GetHeader(usernameHeader)
+		}
+		if username == "" {
+			c.Next()
+			return
+		}
+
+		user, err := usersService.GetByUsername(username)
+		if err != nil {
+			log.Errorf("Could not resolve the tag scope of %s: %s", username, err)
+			c.Next()
+			return
+		}
+		if user == nil {
+			c.Next()
+			return
+		}
+
+		if len(user.TagScope) > 0 {
+			c.Set(tagScopeContextKey, user.TagScope)
+		}
+		c.Next()
+	}
+}
+
+// TagScopeFromContext returns the tag visibility scope resolved by the
+// TagScopeMiddleware; empty means unrestricted
+func TagScopeFromContext(c *gin.Context) []string {
+	if scope, ok := c.Get(tagScopeContextKey); ok {
+		return scope.([]string)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
 
@@ -49,6 +50,63 @@ func TestEulaMiddlewareLettingThrough(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), "homepage-component")
 }
 
+func TestLocaleMiddlewareTranslatesErrors(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "missinghost").Return(nil, nil)
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/missinghost", nil)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Language", "de-DE,de;q=0.9,en;q=0.8")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.Contains(t, resp.Body.String(), "Host wurde nicht gefunden")
+}
+
+func TestLocaleMiddlewarePersistsQueryLocale(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "missinghost").Return(nil, nil)
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts/missinghost?locale=ja", nil)
+	req.Header.Set("Accept", "application/json")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.Contains(t, resp.Body.String(), "ホストが見つかりません")
+
+	// the locale choice is persisted in the session cookie
+	cookies := resp.Result().Cookies()
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/hosts/missinghost", nil)
+	req.Header.Set("Accept", "application/json")
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.Contains(t, resp.Body.String(), "ホストが見つかりません")
+}
+
 func TestEulaMiddlewareError(t *testing.T) {
 	mockedPremiumDetection := new(services.MockPremiumDetectionService)
 	mockedPremiumDetection.On("RequiresEulaAcceptance").Return(false, errors.New("EULA error"))
@@ -67,3 +125,62 @@ func TestEulaMiddlewareError(t *testing.T) {
 
 	assert.Equal(t, 500, resp.Code)
 }
+
+func TestTagScopeMiddleware(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("GetByUsername", "operator").Return(&models.User{
+		Username: "operator",
+		TagScope: []string{"env=qa"},
+	}, nil)
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetAll", &services.ClustersFilter{
+		Name:      []string{},
+		SIDs:      []string{},
+		ScopeTags: []string{"env=qa"},
+	}, (*services.Page)(nil)).Return(models.ClusterList{}, nil)
+
+	deps := setupTestDependencies()
+	deps.usersService = mockUsersService
+	deps.clustersService = mockClustersService
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters", nil)
+	req.Header.Set("X-Trento-User", "operator")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockClustersService.AssertExpectations(t)
+}
+
+func TestTagScopeMiddlewareUnknownAccount(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	// the users service maps a missing account to (nil, nil), see GetByUsername
+	mockUsersService.On("GetByUsername", "ghost").Return(nil, nil)
+	mockClustersService := new(services.MockClustersService)
+	mockClustersService.On("GetAll", (*services.ClustersFilter)(nil), (*services.Page)(nil)).
+		Return(models.ClusterList{}, nil)
+
+	deps := setupTestDependencies()
+	deps.usersService = mockUsersService
+	deps.clustersService = mockClustersService
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters", nil)
+	req.Header.Set("X-Trento-User", "ghost")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockClustersService.AssertExpectations(t)
+}
@@ -0,0 +1,8 @@
+package models
+
+// AgentChecks is the set of checks an agent has to execute locally as part
+// of a pending execution. An ExecutionID of 0 means nothing is pending.
+type AgentChecks struct {
+	ExecutionID int64         `json:"execution_id"`
+	Checks      ChecksCatalog `json:"checks"`
+}
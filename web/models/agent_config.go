@@ -0,0 +1,18 @@
+package models
+
+// AgentConfig is the server-driven configuration an agent should apply,
+// overriding its local defaults
+type AgentConfig struct {
+	AgentID string
+	// DiscoveryPeriods holds the loop period in seconds for each discovery
+	DiscoveryPeriods   map[string]int
+	EnabledDiscoveries []string
+	CollectorHost      string
+	CollectorPort      int
+	// AutoUpdateDisabled opts the host out of the fleet-wide agent auto-update
+	AutoUpdateDisabled bool
+	// DesiredAgentVersion and UpdateRepository carry the fleet-wide update
+	// advertisement; they are served to the agent, not stored per host
+	DesiredAgentVersion string
+	UpdateRepository    string
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+const (
+	AgentErrorTypeDiscovery  = "discovery"
+	AgentErrorTypePermission = "permission"
+	AgentErrorTypeExporter   = "exporter"
+)
+
+// AgentError is a self-diagnosed problem an agent reported to the server,
+// e.g. a failed discovery loop, a permission issue or an exporter crash
+type AgentError struct {
+	Type       string    `json:"type"`
+	Message    string    `json:"message"`
+	ReportedAt time.Time `json:"reported_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// AgentSummary aggregates the fleet-wide status of a single agent:
+// the version it reported last, when it was last heard from and how
+// much discovery data it has pushed to the collector
+type AgentSummary struct {
+	AgentID            string     `json:"agent_id"`
+	Hostname           string     `json:"hostname"`
+	AgentVersion       string     `json:"agent_version"`
+	VersionMismatch    bool       `json:"version_mismatch"`
+	LastContact        *time.Time `json:"last_contact,omitempty"`
+	ClockSkew          int64      `json:"clock_skew"`
+	EnabledDiscoveries []string   `json:"enabled_discoveries"`
+	EventsCount        int64      `json:"events_count"`
+	PayloadBytes       int64      `json:"payload_bytes"`
+	ErrorsCount        int64      `json:"errors_count"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// AgentUpdateSettings is the fleet-wide agent update advertisement: the agent
+// version the server wants running and the package repository to install it from
+type AgentUpdateSettings struct {
+	DesiredAgentVersion string
+	RepositoryURL       string
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+type Alert struct {
+	Fingerprint  string    `json:"fingerprint"`
+	Status       string    `json:"status"`
+	Name         string    `json:"name"`
+	Severity     string    `json:"severity"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Summary      string    `json:"summary"`
+	StartsAt     time.Time `json:"starts_at"`
+}
+
+type AlertList []*Alert
@@ -0,0 +1,37 @@
+package models
+
+const (
+	// AlertRuleConditionClusterHealth fires while a cluster health equals
+	// the rule value
+	AlertRuleConditionClusterHealth = "cluster_health"
+	// AlertRuleConditionHostHealth fires while a host health equals the
+	// rule value
+	AlertRuleConditionHostHealth = "host_health"
+	// AlertRuleConditionOnlineHostsBelow fires while a cluster has fewer
+	// than threshold hosts in passing health
+	AlertRuleConditionOnlineHostsBelow = "online_hosts_below"
+	// AlertRuleConditionChecksFailing fires while a cluster has critical
+	// check results
+	AlertRuleConditionChecksFailing = "checks_failing"
+)
+
+// AlertRule is a user-defined alert condition, continuously evaluated
+// against the projected data and routed to the configured alert channels
+type AlertRule struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Condition string `json:"condition"`
+	// Value is the health the rule matches on, for the health conditions
+	Value string `json:"value"`
+	// Threshold is the minimum number of online hosts, for online_hosts_below
+	Threshold int `json:"threshold"`
+	// ResourceID restricts the rule to one resource; empty matches all
+	ResourceID string `json:"resource_id"`
+	// Tags restricts the rule to the resources carrying any of these tags
+	Tags     []string `json:"tags"`
+	Severity string   `json:"severity"`
+	// ForMinutes is how long the condition must hold before the alert fires
+	ForMinutes int `json:"for_minutes"`
+}
+
+type AlertRuleList []*AlertRule
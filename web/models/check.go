@@ -4,6 +4,12 @@ import (
 	"sort"
 )
 
+const (
+	CheckProviderAzure  = "azure"
+	CheckProviderAws    = "aws"
+	CheckProviderOnPrem = "on-prem"
+)
+
 // List is used instead of a map as it guarantees order
 type ChecksCatalog []*Check
 
@@ -16,9 +22,43 @@ type Check struct {
 	Implementation string `json:"implementation,omitempty" mapstructure:"implementation,omitempty"`
 	Labels         string `json:"labels,omitempty" mapstructure:"labels,omitempty"`
 	Premium        bool   `json:"premium" mapstructure:"premium"`
-	Selected       bool   `json:"selected,omitempty" mapstructure:"selected,omitempty"`
-	Result         string `json:"result,omitempty" mapstructure:"result,omitempty"`
-	Msg            string `json:"msg,omitempty" mapstructure:"msg,omitempty"`
+	// Provider restricts the check to one platform (azure/aws/on-prem);
+	// checks without a provider apply everywhere
+	Provider string   `json:"provider,omitempty" mapstructure:"provider,omitempty"`
+	Tags     []string `json:"tags,omitempty" mapstructure:"tags,omitempty"`
+	Selected bool     `json:"selected,omitempty" mapstructure:"selected,omitempty"`
+	Result   string   `json:"result,omitempty" mapstructure:"result,omitempty"`
+	Msg      string   `json:"msg,omitempty" mapstructure:"msg,omitempty"`
+	// Translations carries the per-locale variants of the texts operators
+	// read, keyed by locale
+	Translations map[string]*CheckTranslation `json:"translations,omitempty" mapstructure:"translations,omitempty"`
+	// RemediationHTML is the remediation markdown rendered as sanitized
+	// HTML; it is only populated on the API responses, never stored
+	RemediationHTML string `json:"remediation_html,omitempty" mapstructure:"remediation_html,omitempty"`
+}
+
+// CheckTranslation is a per-locale variant of the check texts; empty
+// fields fall back to the English source text
+type CheckTranslation struct {
+	Description string `json:"description,omitempty" mapstructure:"description,omitempty"`
+	Remediation string `json:"remediation,omitempty" mapstructure:"remediation,omitempty"`
+}
+
+// Localize returns a copy of the check with the text variants of the given
+// locale applied, when available
+func (c *Check) Localize(locale string) *Check {
+	localized := *c
+
+	if translation, found := c.Translations[locale]; found {
+		if translation.Description != "" {
+			localized.Description = translation.Description
+		}
+		if translation.Remediation != "" {
+			localized.Remediation = translation.Remediation
+		}
+	}
+
+	return &localized
 }
 
 type GroupedChecks struct {
@@ -1,5 +1,9 @@
 package models
 
+import (
+	"sort"
+)
+
 const (
 	CheckPassing   string = "passing"
 	CheckWarning   string = "warning"
@@ -12,6 +16,8 @@ type ChecksResult struct {
 	ID     string                   `json:"-"`
 	Hosts  map[string]*HostState    `json:"hosts,omitempty"`
 	Checks map[string]*ChecksByHost `json:"checks,omitempty"`
+	// Durations stores how long each check took on the runner side, in seconds
+	Durations map[string]float64 `json:"durations,omitempty"`
 }
 
 // Simplifed stuct consumed by the frontend
@@ -74,6 +80,63 @@ func (c *ChecksResult) GetAggregatedChecksResultByCluster() *AggregatedCheckData
 	return aCheckData
 }
 
+// CheckStateChange describes a check whose result changed on a host between
+// two executions
+type CheckStateChange struct {
+	CheckID  string `json:"check_id"`
+	Host     string `json:"host"`
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+type ChecksResultsDiff struct {
+	FromID  int64               `json:"from"`
+	ToID    int64               `json:"to"`
+	Changes []*CheckStateChange `json:"changes"`
+}
+
+// Diff returns the checks that changed state between the receiver execution
+// and the given more recent one
+func (c *ChecksResult) Diff(current *ChecksResult) []*CheckStateChange {
+	changes := []*CheckStateChange{}
+
+	for checkId, checkByHost := range current.Checks {
+		for host, check := range checkByHost.Hosts {
+			previousResult := CheckUndefined
+			if previousCheck, ok := c.Checks[checkId]; ok {
+				if previousHost, ok := previousCheck.Hosts[host]; ok {
+					previousResult = previousHost.Result
+				}
+			}
+
+			if previousResult == check.Result {
+				continue
+			}
+
+			changes = append(changes, &CheckStateChange{
+				CheckID:  checkId,
+				Host:     host,
+				Previous: previousResult,
+				Current:  check.Result,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].CheckID != changes[j].CheckID {
+			return changes[i].CheckID < changes[j].CheckID
+		}
+		return changes[i].Host < changes[j].Host
+	})
+
+	return changes
+}
+
+// IsRegression returns true when a previously passing check stopped passing
+func (c *CheckStateChange) IsRegression() bool {
+	return c.Previous == CheckPassing && (c.Current == CheckWarning || c.Current == CheckCritical)
+}
+
 func (a *AggregatedCheckData) String() string {
 	if a.CriticalCount > 0 {
 		return CheckCritical
@@ -0,0 +1,24 @@
+package models
+
+// CheckSelectionTemplate is an admin defined check selection that is
+// applied automatically to newly discovered clusters matching its
+// criteria; empty criteria act as wildcards
+type CheckSelectionTemplate struct {
+	ID             int64    `json:"id"`
+	Name           string   `json:"name"`
+	Provider       string   `json:"provider,omitempty"`
+	ClusterType    string   `json:"cluster_type,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	SelectedChecks []string `json:"selected_checks"`
+}
+
+// CheckTemplateDivergence reports a cluster whose current check selection
+// differs from the template it matches
+type CheckTemplateDivergence struct {
+	ClusterID        string   `json:"cluster_id"`
+	ClusterName      string   `json:"cluster_name"`
+	TemplateID       int64    `json:"template_id"`
+	TemplateName     string   `json:"template_name"`
+	MissingChecks    []string `json:"missing_checks,omitempty"`
+	AdditionalChecks []string `json:"additional_checks,omitempty"`
+}
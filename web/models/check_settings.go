@@ -14,3 +14,12 @@ type ConnectionSettings struct {
 	Node string `gorm:"primaryKey"`
 	User string
 }
+
+// ConnectionStatus is the outcome of probing a node with the connection
+// settings used by the runner
+type ConnectionStatus struct {
+	Node      string `json:"node"`
+	User      string `json:"user"`
+	Reachable bool   `json:"reachable"`
+	Msg       string `json:"msg"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+const (
+	ExecutionStatusRequested = "requested"
+	ExecutionStatusRunning   = "running"
+	ExecutionStatusCompleted = "completed"
+	ExecutionStatusFailed    = "failed"
+
+	ExecutionActorRunner = "runner"
+)
+
+// ChecksExecution is a single check run for a cluster, either requested
+// on-demand through the API or recorded by the runner on its regular cycle
+type ChecksExecution struct {
+	ID          int64              `json:"id"`
+	ClusterID   string             `json:"cluster_id"`
+	Status      string             `json:"status"`
+	Actor       string             `json:"actor"`
+	StartedAt   *time.Time         `json:"started_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty"`
+	Durations   map[string]float64 `json:"durations,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
@@ -5,6 +5,8 @@ import "time"
 const (
 	ClusterTypeHANAScaleUp  = "HANA scale-up"
 	ClusterTypeHANAScaleOut = "HANA scale-out"
+	ClusterTypeASCSERS      = "ASCS/ERS"
+	ClusterTypeGeneric      = "Generic"
 	ClusterTypeUnknown      = "Unknown"
 	HANAStatusPrimary       = "Primary"
 	HANAStatusSecondary     = "Secondary"
@@ -17,6 +19,10 @@ const (
 	// https://github.com/SUSE/SAPHanaSR/blob/master/ra/SAPHana#L1171
 	HANASrHealthOK = "4"
 	HANASrSyncSOK  = "SOK"
+
+	FencingStatusHealthy      = "healthy"
+	FencingStatusUnhealthy    = "unhealthy"
+	FencingStatusUnconfigured = "unconfigured"
 )
 
 type Cluster struct {
@@ -26,6 +32,10 @@ type Cluster struct {
 	SID             string
 	ResourcesNumber int
 	HostsNumber     int
+	FencingType     string
+	FencingStatus   string
+	SBDWatchdog     string
+	SBDDevices      []*SBDDevice
 	Health          string
 	PassingCount    int
 	WarningCount    int
@@ -50,6 +60,33 @@ type HANAClusterDetails struct {
 	SBDDevices                     []*SBDDevice
 }
 
+type ASCSERSClusterDetails struct {
+	FencingType      string
+	Distributed      bool
+	StoppedResources []*ClusterResource
+	Nodes            []*ASCSERSClusterNode
+}
+
+type ASCSERSClusterNode struct {
+	Name       string
+	Roles      []string
+	VirtualIPs []string
+	Resources  []*ClusterResource
+}
+
+type GenericClusterDetails struct {
+	FencingType      string
+	StoppedResources []*ClusterResource
+	Nodes            []*GenericClusterNode
+}
+
+type GenericClusterNode struct {
+	Name             string
+	Online           bool
+	Standby          bool
+	ResourcesRunning int
+}
+
 type ClusterResource struct {
 	ID        string
 	Type      string
@@ -71,8 +108,11 @@ type HANAClusterNode struct {
 }
 
 type SBDDevice struct {
-	Device string
-	Status string
+	Device          string
+	Status          string
+	UUID            string
+	TimeoutWatchdog int
+	TimeoutMsgwait  int
 }
 
 type ClusterNodes []*HANAClusterNode
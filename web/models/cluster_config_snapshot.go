@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+type ClusterConfigSnapshot struct {
+	ID           int64     `json:"id"`
+	ClusterID    string    `json:"cluster_id"`
+	CibXML       string    `json:"cib_xml"`
+	CorosyncConf string    `json:"corosync_conf"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ClusterConfigDiff struct {
+	From            *ClusterConfigSnapshot `json:"from"`
+	To              *ClusterConfigSnapshot `json:"to"`
+	CibChanged      bool                   `json:"cib_changed"`
+	CorosyncChanged bool                   `json:"corosync_changed"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// ClusterDrift reports the configuration differences between the nodes of
+// a cluster; diverging nodes are a top cause of failed failovers
+type ClusterDrift struct {
+	ClusterID string               `json:"cluster_id"`
+	Drifted   bool                 `json:"drifted"`
+	Fields    []*ClusterDriftField `json:"fields"`
+}
+
+// ClusterDriftField is a single compared fact, with the value each node
+// reported for it
+type ClusterDriftField struct {
+	Name    string            `json:"name"`
+	Values  map[string]string `json:"values"`
+	Drifted bool              `json:"drifted"`
+}
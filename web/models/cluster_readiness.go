@@ -0,0 +1,28 @@
+package models
+
+const (
+	ReadinessFactorSRSync  = "hana_sr_sync"
+	ReadinessFactorFencing = "fencing"
+	ReadinessFactorChecks  = "checks"
+	ReadinessFactorNodes   = "nodes"
+
+	ReadinessHealthPassing  = "passing"
+	ReadinessHealthWarning  = "warning"
+	ReadinessHealthCritical = "critical"
+	ReadinessHealthUnknown  = "unknown"
+)
+
+// ReadinessFactor is a single contribution to the takeover readiness of a
+// HANA cluster
+type ReadinessFactor struct {
+	Name    string `json:"name"`
+	Health  string `json:"health"`
+	Message string `json:"message"`
+}
+
+type ClusterReadiness struct {
+	ClusterID string             `json:"cluster_id"`
+	Score     int                `json:"score"`
+	Ready     bool               `json:"ready"`
+	Factors   []*ReadinessFactor `json:"factors"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// ClusterRebuild pairs the stale entry a rebuilt Pacemaker cluster left
+// behind with the new cluster its nodes re-registered under
+type ClusterRebuild struct {
+	OldClusterID   string   `json:"old_cluster_id"`
+	OldClusterName string   `json:"old_cluster_name"`
+	NewClusterID   string   `json:"new_cluster_id"`
+	NewClusterName string   `json:"new_cluster_name"`
+	SID            string   `json:"sid"`
+	Nodes          []string `json:"nodes"`
+}
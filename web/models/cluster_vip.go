@@ -0,0 +1,9 @@
+package models
+
+type ClusterVIP struct {
+	ClusterID  string `json:"cluster_id"`
+	ResourceID string `json:"resource_id"`
+	Address    string `json:"address"`
+	Node       string `json:"node"`
+	Status     string `json:"status"`
+}
@@ -13,3 +13,18 @@ type HostConnection struct {
 }
 
 type ClustersSettings []*ClusterSettings
+
+// ClustersSettingsBulkUpdate applies the same settings to all the clusters
+// matching the filter; empty filter criteria act as wildcards. Only the
+// non-empty settings are updated.
+type ClustersSettingsBulkUpdate struct {
+	Filter         ClustersSettingsBulkFilter `json:"filter"`
+	SelectedChecks []string                   `json:"selected_checks,omitempty"`
+	ConnectionUser string                     `json:"connection_user,omitempty"`
+}
+
+type ClustersSettingsBulkFilter struct {
+	IDs          []string `json:"ids,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	ClusterTypes []string `json:"cluster_types,omitempty"`
+}
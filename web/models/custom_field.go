@@ -0,0 +1,16 @@
+package models
+
+const (
+	CustomFieldTypeString = "string"
+	CustomFieldTypeNumber = "number"
+)
+
+// CustomFieldDefinition declares a typed custom field (e.g. owner, cost
+// center, SLA tier) that can be set on every resource of a type
+type CustomFieldDefinition struct {
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+}
+
+type CustomFieldDefinitionList []*CustomFieldDefinition
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// DiscoveryFreshness tells when a discovery type was last updated and whether
+// the stored data is old enough to be considered stale
+type DiscoveryFreshness struct {
+	DiscoveryType string    `json:"discovery_type"`
+	LastUpdated   time.Time `json:"last_updated"`
+	Stale         bool      `json:"stale"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// FactsRequest asks an agent to run one of the predefined troubleshooting
+// probes and report back its output
+type FactsRequest struct {
+	ID        int64     `json:"id"`
+	AgentID   string    `json:"agent_id"`
+	Probe     string    `json:"probe"`
+	Command   string    `json:"command"`
+	Status    string    `json:"status"`
+	Output    string    `json:"output,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
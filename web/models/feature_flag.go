@@ -0,0 +1,11 @@
+package models
+
+const (
+	FeatureAlerting       = "alerting"
+	FeatureEmbeddedRunner = "embedded-runner"
+)
+
+type FeatureFlag struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
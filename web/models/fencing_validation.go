@@ -0,0 +1,10 @@
+package models
+
+type FencingValidation struct {
+	ClusterID string
+	Agent     string
+	// Node is the cluster node the dry-run was executed on
+	Node  string
+	Valid bool
+	Msg   string
+}
@@ -0,0 +1,13 @@
+package models
+
+// GarbageCollectionReport is the dry-run outcome of the orphaned data
+// collection: what would be removed, grouped by table
+type GarbageCollectionReport struct {
+	Orphans []*OrphanedRecords `json:"orphans"`
+	Total   int64              `json:"total"`
+}
+
+type OrphanedRecords struct {
+	Table string `json:"table"`
+	Count int64  `json:"count"`
+}
@@ -9,6 +9,20 @@ const (
 
 type HealthSummary []SAPSystemHealthSummary
 
+type LandscapeHealth struct {
+	Health string                  `json:"health"`
+	Layers []*LandscapeHealthLayer `json:"layers"`
+}
+
+type LandscapeHealthLayer struct {
+	Name          string `json:"name"`
+	Health        string `json:"health"`
+	PassingCount  int    `json:"passing_count"`
+	WarningCount  int    `json:"warning_count"`
+	CriticalCount int    `json:"critical_count"`
+	UnknownCount  int    `json:"unknown_count"`
+}
+
 type SAPSystemHealthSummary struct {
 	ID              string `json:"id"`
 	SID             string `json:"sid"`
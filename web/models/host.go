@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/trento-project/trento/internal/cloud"
 )
 
@@ -16,18 +18,36 @@ const (
 )
 
 type Host struct {
-	ID            string
-	Name          string
-	Health        string
-	IPAddresses   []string
-	CloudProvider string
-	ClusterID     string
-	ClusterName   string
-	ClusterType   string
-	SAPSystems    []*SAPSystem
-	AgentVersion  string
-	Tags          []string
-	CloudData     interface{}
+	ID                   string
+	Name                 string
+	Health               string
+	IPAddresses          []string
+	NetworkInterfaces    []*NetworkInterface
+	DefaultGateways      []string
+	CloudProvider        string
+	OSVersion            string
+	KernelVersion        string
+	ClusterID            string
+	ClusterName          string
+	ClusterType          string
+	SAPSystems           []*SAPSystem
+	AgentVersion         string
+	AgentVersionMismatch bool
+	LastHeartbeat        *time.Time
+	Tags                 []string
+	CloudData            interface{}
+	ExportersStatus      map[string]string
+	InstalledPackages    map[string]string
+	SystemSettings       map[string]string
+}
+
+type NetworkInterface struct {
+	Name       string
+	MACAddress string
+	MTU        int
+	Addresses  []string
+	BondMaster string
+	VlanID     int
 }
 
 type AzureCloudData struct {
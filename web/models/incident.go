@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// Incident is a period of time during which a resource was in critical
+// health. Incidents are opened and closed automatically by the health
+// projection, so their duration can feed MTTR and availability reports
+type Incident struct {
+	ID           int64      `json:"id"`
+	ResourceType string     `json:"resource_type"`
+	ResourceID   string     `json:"resource_id"`
+	OpenedAt     time.Time  `json:"opened_at"`
+	ClosedAt     *time.Time `json:"closed_at"`
+}
+
+type IncidentList []*Incident
+
+// Open tells whether the incident is still ongoing
+func (i *Incident) Open() bool {
+	return i.ClosedAt == nil
+}
+
+// Duration returns how long the incident lasted; for open incidents it is
+// the time elapsed since it was opened
+func (i *Incident) Duration() time.Duration {
+	if i.ClosedAt == nil {
+		return time.Since(i.OpenedAt)
+	}
+
+	return i.ClosedAt.Sub(i.OpenedAt)
+}
@@ -0,0 +1,17 @@
+package models
+
+// Landscape groups everything belonging to one SID - the SAP systems, the
+// attached databases, the clusters and the hosts running them - so a single
+// lookup returns the full stack of a product
+type Landscape struct {
+	SID string `json:"sid"`
+	// Position orders the landscapes along the transport route (e.g. DEV
+	// before QAS before PRD); lower positions come first
+	Position   int           `json:"position"`
+	SAPSystems SAPSystemList `json:"sap_systems"`
+	Databases  SAPSystemList `json:"databases"`
+	Clusters   ClusterList   `json:"clusters"`
+	Hosts      HostList      `json:"hosts"`
+}
+
+type LandscapeList []*Landscape
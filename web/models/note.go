@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Note is a free-text annotation attached to a resource, keeping the
+// operational context ("SR disabled for client copy until Friday") next to
+// the discovered data
+type Note struct {
+	ID           int64     `json:"id"`
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Author       string    `json:"author"`
+	Content      string    `json:"content"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type NoteList []*Note
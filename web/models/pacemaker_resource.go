@@ -0,0 +1,12 @@
+package models
+
+type PacemakerResource struct {
+	ID                 string `json:"id"`
+	ClusterID          string `json:"cluster_id"`
+	Type               string `json:"type"`
+	Role               string `json:"role"`
+	Status             string `json:"status"`
+	Node               string `json:"node"`
+	FailCount          int    `json:"fail_count"`
+	MigrationThreshold int    `json:"migration_threshold"`
+}
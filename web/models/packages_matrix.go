@@ -0,0 +1,15 @@
+package models
+
+type PackagesMatrix struct {
+	Packages []*PackageComparison
+}
+
+type PackageComparison struct {
+	Package        string
+	MinimumVersion string
+	// Versions maps the hostname of each host reporting the package to the
+	// installed version
+	Versions map[string]string
+	// OutdatedHosts lists the hosts running a version older than MinimumVersion
+	OutdatedHosts []string
+}
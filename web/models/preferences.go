@@ -0,0 +1,21 @@
+package models
+
+const DefaultItemsPerPage = 10
+const DefaultTimezone = "UTC"
+
+type Preferences struct {
+	DefaultFilters map[string][]string `json:"default_filters"`
+	Columns        map[string][]string `json:"columns"`
+	ItemsPerPage   int                 `json:"items_per_page"`
+	// Timezone is an IANA timezone name the timestamps are converted to for display
+	Timezone string `json:"timezone"`
+}
+
+func NewDefaultPreferences() *Preferences {
+	return &Preferences{
+		DefaultFilters: make(map[string][]string),
+		Columns:        make(map[string][]string),
+		ItemsPerPage:   DefaultItemsPerPage,
+		Timezone:       DefaultTimezone,
+	}
+}
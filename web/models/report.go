@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type Report struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ReportList []*Report
+
+// FailingCheck is a critical check result enriched with the catalog
+// metadata, so that the report can show what to do about it
+type FailingCheck struct {
+	ClusterID   string
+	ClusterName string
+	CheckID     string
+	Host        string
+	Description string
+	Remediation string
+}
+
+// ReportData is the landscape snapshot rendered into a report
+type ReportData struct {
+	GeneratedAt       time.Time
+	HostsCount        int
+	ClustersCount     int
+	ApplicationsCount int
+	DatabasesCount    int
+	HealthSummary     HealthSummary
+	FailingChecks     []*FailingCheck
+	PremiumData       *PremiumData
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+const (
+	ResourceEventTypeHeartbeat       = "heartbeat"
+	ResourceEventTypeHealth          = "health"
+	ResourceEventTypeCheckRegression = "check_regression"
+	ResourceEventTypeDiscovery       = "discovery"
+	ResourceEventTypeFailover        = "failover"
+	ResourceEventTypeTag             = "tag"
+	ResourceEventTypeNote            = "note"
+)
+
+type ResourceEvent struct {
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	Type         string    `json:"type"`
+	Message      string    `json:"message"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+type ResourceEventList []*ResourceEvent
@@ -0,0 +1,6 @@
+package models
+
+type RetentionPolicy struct {
+	Table         string `json:"table"`
+	RetentionDays uint   `json:"retention_days"`
+}
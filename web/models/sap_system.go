@@ -45,10 +45,69 @@ type SAPSystemInstance struct {
 	ClusterType             string
 	HostID                  string
 	Hostname                string
+	Processes               []*SAPSystemInstanceProcess
+}
+
+type SAPSystemInstanceProcess struct {
+	Name        string
+	Description string
+	Dispstatus  string
+	Textstatus  string
+	Starttime   string
+	Elapsedtime string
+	Pid         int
 }
 
 type SAPSystemList []*SAPSystem
 
+const (
+	TopologyNodeTypeSAPSystem = "sapsystem"
+	TopologyNodeTypeDatabase  = "database"
+	TopologyNodeTypeHost      = "host"
+	TopologyNodeTypeCluster   = "cluster"
+
+	TopologyEdgeTypeRunsOn    = "runs_on"
+	TopologyEdgeTypePartOf    = "part_of"
+	TopologyEdgeTypeDependsOn = "depends_on"
+)
+
+type SAPSystemTopology struct {
+	Nodes []*TopologyNode `json:"nodes"`
+	Edges []*TopologyEdge `json:"edges"`
+}
+
+type TopologyNode struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type TopologyEdge struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+	Type   string `json:"type"`
+}
+
+// AddNode appends a node to the topology, skipping already present ones
+func (t *SAPSystemTopology) AddNode(node *TopologyNode) {
+	for _, n := range t.Nodes {
+		if n.ID == node.ID && n.Type == node.Type {
+			return
+		}
+	}
+	t.Nodes = append(t.Nodes, node)
+}
+
+// AddEdge appends an edge to the topology, skipping already present ones
+func (t *SAPSystemTopology) AddEdge(edge *TopologyEdge) {
+	for _, e := range t.Edges {
+		if e.Source == edge.Source && e.Target == edge.Target && e.Type == edge.Type {
+			return
+		}
+	}
+	t.Edges = append(t.Edges, edge)
+}
+
 func (s SAPSystem) GetAllInstances() []*SAPSystemInstance {
 	instances := s.Instances
 
@@ -59,6 +118,19 @@ func (s SAPSystem) GetAllInstances() []*SAPSystemInstance {
 	return instances
 }
 
+func (p SAPSystemInstanceProcess) Health() string {
+	switch p.Dispstatus {
+	case string(sapcontrol.STATECOLOR_RED):
+		return SAPSystemHealthCritical
+	case string(sapcontrol.STATECOLOR_YELLOW):
+		return SAPSystemHealthWarning
+	case string(sapcontrol.STATECOLOR_GREEN):
+		return SAPSystemHealthPassing
+	default:
+		return SAPSystemHealthUnknown
+	}
+}
+
 func (s SAPSystemInstance) Health() string {
 	switch s.Status {
 	case string(sapcontrol.STATECOLOR_RED):
@@ -0,0 +1,17 @@
+package models
+
+const (
+	SearchResultTypeHost      = "host"
+	SearchResultTypeCluster   = "cluster"
+	SearchResultTypeSAPSystem = "sapsystem"
+	SearchResultTypeDatabase  = "database"
+)
+
+type SearchResult struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	SID  string `json:"sid,omitempty"`
+}
+
+type SearchResultList []*SearchResult
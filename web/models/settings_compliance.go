@@ -0,0 +1,16 @@
+package models
+
+type SettingsCompliance struct {
+	HostID    string
+	Compliant bool
+	Settings  []*SettingCompliance
+}
+
+type SettingCompliance struct {
+	Name          string
+	ExpectedValue string
+	ActualValue   string
+	// SAPNote is the number of the SAP note recommending the expected value
+	SAPNote   string
+	Compliant bool
+}
@@ -0,0 +1,23 @@
+package models
+
+const (
+	SetupStepAdmin        = "admin"
+	SetupStepEula         = "eula"
+	SetupStepGrafana      = "grafana"
+	SetupStepTelemetry    = "telemetry"
+	SetupStepCollectorTLS = "collector_tls"
+)
+
+// SetupStatus is the state of the first-boot setup wizard
+type SetupStatus struct {
+	Complete bool `json:"complete"`
+	// NextStep is the first step still to be completed, empty when the
+	// setup is done
+	NextStep string       `json:"next_step,omitempty"`
+	Steps    []*SetupStep `json:"steps"`
+}
+
+type SetupStep struct {
+	Name     string `json:"name"`
+	Complete bool   `json:"complete"`
+}
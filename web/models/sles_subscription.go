@@ -1,5 +1,19 @@
 package models
 
+import (
+	"time"
+)
+
+const (
+	SubscriptionValidityValid    = "valid"
+	SubscriptionValidityExpiring = "expiring"
+	SubscriptionValidityExpired  = "expired"
+	SubscriptionValidityUnknown  = "unknown"
+)
+
+// subscriptionDatesLayout is the timestamp format used by SUSEConnect
+const subscriptionDatesLayout = "2006-01-02 15:04:05 MST"
+
 type SlesSubscription struct {
 	ID                 string
 	Version            string
@@ -15,3 +29,34 @@ type PremiumData struct {
 	IsPremium     bool
 	Sles4SapCount int
 }
+
+// Validity classifies the subscription against its expiration date
+func (s *SlesSubscription) Validity(expiringWithin time.Duration) string {
+	if s.ExpiresAt == "" {
+		return SubscriptionValidityUnknown
+	}
+
+	expiresAt, err := time.Parse(subscriptionDatesLayout, s.ExpiresAt)
+	if err != nil {
+		return SubscriptionValidityUnknown
+	}
+
+	now := time.Now()
+	switch {
+	case expiresAt.Before(now):
+		return SubscriptionValidityExpired
+	case expiresAt.Before(now.Add(expiringWithin)):
+		return SubscriptionValidityExpiring
+	default:
+		return SubscriptionValidityValid
+	}
+}
+
+// ExpiringSubscription associates an expired or soon-to-expire subscription
+// with the host where it was discovered
+type ExpiringSubscription struct {
+	AgentID      string
+	Hostname     string
+	Validity     string
+	Subscription *SlesSubscription
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// TrashEntry is a deleted resource that can still be restored
+type TrashEntry struct {
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	DeletedAt    time.Time `json:"deleted_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// User is a local console account, for sites without an external identity
+// provider. The password hash never leaves the service layer.
+type User struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+	Admin    bool   `json:"admin"`
+	// PasswordChangeRequired forces a password rotation on the next login
+	PasswordChangeRequired bool `json:"password_change_required"`
+	// TagScope restricts the resources the account can see to the ones
+	// carrying at least one of these tags. Empty means unrestricted.
+	TagScope  []string  `json:"tag_scope"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type UserList []*User
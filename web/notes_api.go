@@ -0,0 +1,166 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONNote struct {
+	Author  string `json:"author"`
+	Content string `json:"content" binding:"required"`
+}
+
+// ApiResourceNotesHandler returns the notes attached to one resource; the
+// resource type is fixed by the route it is mounted on, like the tags
+// handlers
+func ApiResourceNotesHandler(resourceType string, notesService services.NotesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		notes, err := notesService.GetByResource(resourceType, resourceID(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, notes)
+	}
+}
+
+// ApiHostCreateNoteHandler godoc
+// @Summary Attach a note to a host
+// @Accept json
+// @Produce json
+// @Param id path string true "Host id"
+// @Param Body body JSONNote true "The note to attach"
+// @Success 201 {object} models.Note
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /hosts/{id}/notes [post]
+func ApiHostCreateNoteHandler(hostsService services.HostsService, notesService services.NotesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		host, err := hostsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if host == nil {
+			_ = c.Error(NotFoundError("could not find host"))
+			return
+		}
+
+		createNote(c, notesService, models.TagHostResourceType, id)
+	}
+}
+
+// ApiClusterCreateNoteHandler godoc
+// @Summary Attach a note to a cluster
+// @Accept json
+// @Produce json
+// @Param id path string true "Cluster id"
+// @Param Body body JSONNote true "The note to attach"
+// @Success 201 {object} models.Note
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /clusters/{id}/notes [post]
+func ApiClusterCreateNoteHandler(clustersService services.ClustersService, notesService services.NotesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		cluster, err := clustersService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if cluster == nil {
+			_ = c.Error(NotFoundError("could not find cluster"))
+			return
+		}
+
+		createNote(c, notesService, models.TagClusterResourceType, id)
+	}
+}
+
+// ApiSAPSystemCreateNoteHandler godoc
+// @Summary Attach a note to a SAP system
+// @Accept json
+// @Produce json
+// @Param id path string true "SAPSystem id"
+// @Param Body body JSONNote true "The note to attach"
+// @Success 201 {object} models.Note
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sapsystems/{id}/notes [post]
+func ApiSAPSystemCreateNoteHandler(sapSystemsService services.SAPSystemsService, notesService services.NotesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+
+		system, err := sapSystemsService.GetByID(id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if system == nil {
+			_ = c.Error(NotFoundError("could not find system"))
+			return
+		}
+
+		createNote(c, notesService, models.TagSAPSystemResourceType, id)
+	}
+}
+
+// ApiNoteDeleteHandler godoc
+// @Summary Delete a note
+// @Produce json
+// @Param id path int true "Note id"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Router /notes/{id} [delete]
+func ApiNoteDeleteHandler(notesService services.NotesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid note id"))
+			return
+		}
+
+		err = notesService.Delete(id)
+		if errors.Is(err, services.ErrNoteNotFound) {
+			_ = c.Error(NotFoundError("could not find note"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func createNote(c *gin.Context, notesService services.NotesService, resourceType string, resourceID string) {
+	var r JSONNote
+
+	if err := c.BindJSON(&r); err != nil {
+		_ = c.Error(BadRequestError("unable to parse JSON body"))
+		return
+	}
+
+	note, err := notesService.Create(resourceType, resourceID, r.Author, r.Content)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, note)
+}
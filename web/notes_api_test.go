@@ -0,0 +1,132 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiHostCreateNoteHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetByID", "1").Return(&models.Host{ID: "1"}, nil)
+	mockHostsService.On("GetByID", "ghost").Return(nil, nil)
+
+	mockNotesService := new(services.MockNotesService)
+	mockNotesService.On("Create", models.TagHostResourceType, "1", "admin", "under maintenance").
+		Return(&models.Note{
+			ID:           1,
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "1",
+			Author:       "admin",
+			Content:      "under maintenance",
+			CreatedAt:    time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.notesService = mockNotesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/hosts/1/notes",
+		bytes.NewBufferString(`{"author": "admin", "content": "under maintenance"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"content":"under maintenance"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/hosts/ghost/notes",
+		bytes.NewBufferString(`{"author": "admin", "content": "under maintenance"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/api/hosts/1/notes",
+		bytes.NewBufferString(`{"author": "admin"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockHostsService.AssertExpectations(t)
+	mockNotesService.AssertExpectations(t)
+}
+
+func TestApiResourceNotesHandler(t *testing.T) {
+	mockNotesService := new(services.MockNotesService)
+	mockNotesService.On("GetByResource", models.TagClusterResourceType, "cluster_id").
+		Return(models.NoteList{
+			{
+				ID:           1,
+				ResourceType: models.TagClusterResourceType,
+				ResourceID:   "cluster_id",
+				Author:       "admin",
+				Content:      "SR disabled for client copy until Friday",
+				CreatedAt:    time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.notesService = mockNotesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/clusters/cluster_id/notes", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"author":"admin"`)
+
+	mockNotesService.AssertExpectations(t)
+}
+
+func TestApiNoteDeleteHandler(t *testing.T) {
+	mockNotesService := new(services.MockNotesService)
+	mockNotesService.On("Delete", int64(1)).Return(nil)
+	mockNotesService.On("Delete", int64(2)).Return(services.ErrNoteNotFound)
+
+	deps := setupTestDependencies()
+	deps.notesService = mockNotesService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/notes/1", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/notes/2", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/notes/not_a_number", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockNotesService.AssertExpectations(t)
+}
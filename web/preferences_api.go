@@ -0,0 +1,85 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+const preferencesSubjectKey = "preferences_subject"
+
+// preferencesSubject returns the identifier the preferences are stored under.
+// Until we get real user accounts, each browser session gets its own subject
+func preferencesSubject(c *gin.Context) string {
+	session := sessions.Default(c)
+
+	if subject, ok := session.Get(preferencesSubjectKey).(string); ok {
+		return subject
+	}
+
+	subject := uuid.New().String()
+	session.Set(preferencesSubjectKey, subject)
+	session.Save()
+
+	return subject
+}
+
+// ApiGetPreferencesHandler godoc
+// @Summary Get the current user preferences
+// @Produce json
+// @Success 200 {object} models.Preferences
+// @Failure 500 {object} map[string]string
+// @Router /preferences [get]
+func ApiGetPreferencesHandler(s services.PreferencesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preferences, err := s.GetPreferences(preferencesSubject(c))
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, preferences)
+	}
+}
+
+// ApiUpdatePreferencesHandler godoc
+// @Summary Update the current user preferences
+// @Accept json
+// @Produce json
+// @Param Body body models.Preferences true "User preferences"
+// @Success 200 {object} models.Preferences
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /preferences [put]
+func ApiUpdatePreferencesHandler(s services.PreferencesService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var preferences models.Preferences
+
+		err := c.BindJSON(&preferences)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if preferences.Timezone != "" {
+			if _, err := time.LoadLocation(preferences.Timezone); err != nil {
+				_ = c.Error(BadRequestError("invalid timezone"))
+				return
+			}
+		}
+
+		err = s.SetPreferences(preferencesSubject(c), &preferences)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, &preferences)
+	}
+}
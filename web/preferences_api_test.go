@@ -0,0 +1,122 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiGetPreferencesHandler(t *testing.T) {
+	preferences := &models.Preferences{
+		DefaultFilters: map[string][]string{
+			"hosts_health": {"passing"},
+		},
+		Columns:      map[string][]string{},
+		ItemsPerPage: 25,
+	}
+
+	mockPreferencesService := new(services.MockPreferencesService)
+	mockPreferencesService.On("GetPreferences", mock.AnythingOfType("string")).Return(preferences, nil)
+
+	deps := setupTestDependencies()
+	deps.preferencesService = mockPreferencesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/preferences", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(preferences)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, expectedBody, resp.Body.Bytes())
+}
+
+func TestApiUpdatePreferencesHandler(t *testing.T) {
+	preferences := &models.Preferences{
+		DefaultFilters: map[string][]string{},
+		Columns: map[string][]string{
+			"hosts": {"name", "health"},
+		},
+		ItemsPerPage: 50,
+	}
+
+	mockPreferencesService := new(services.MockPreferencesService)
+	mockPreferencesService.On("SetPreferences", mock.AnythingOfType("string"), preferences).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.preferencesService = mockPreferencesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(preferences)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/preferences", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockPreferencesService.AssertExpectations(t)
+}
+
+func TestApiUpdatePreferencesHandlerTimezone(t *testing.T) {
+	preferences := &models.Preferences{
+		DefaultFilters: map[string][]string{},
+		Columns:        map[string][]string{},
+		ItemsPerPage:   10,
+		Timezone:       "Europe/Berlin",
+	}
+
+	mockPreferencesService := new(services.MockPreferencesService)
+	mockPreferencesService.On("SetPreferences", mock.AnythingOfType("string"), preferences).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.preferencesService = mockPreferencesService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(preferences)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/preferences", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockPreferencesService.AssertExpectations(t)
+}
+
+func TestApiUpdatePreferencesHandlerInvalidTimezone(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/preferences", bytes.NewBufferString(`{"timezone":"Mars/Olympus_Mons"}`))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+	assert.Contains(t, resp.Body.String(), "invalid timezone")
+}
@@ -2,10 +2,12 @@ package web
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/mitchellh/mapstructure"
 
+	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
 
@@ -16,15 +18,94 @@ type Targets struct {
 	Labels  map[string]string `json:"labels,omitempty"`
 }
 
+type JSONAlertmanagerWebhook struct {
+	Alerts []*JSONAlertmanagerAlert `json:"alerts" binding:"required"`
+}
+
+type JSONAlertmanagerAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+// toModel correlates an Alertmanager alert to a Trento resource by its labels
+func (a *JSONAlertmanagerAlert) toModel() *models.Alert {
+	alert := &models.Alert{
+		Fingerprint: a.Fingerprint,
+		Status:      a.Status,
+		Name:        a.Labels["alertname"],
+		Severity:    a.Labels["severity"],
+		Summary:     a.Annotations["summary"],
+		StartsAt:    a.StartsAt,
+	}
+
+	if agentID, ok := a.Labels["agentID"]; ok {
+		alert.ResourceType = models.TagHostResourceType
+		alert.ResourceID = agentID
+	} else if clusterID, ok := a.Labels["cluster_id"]; ok {
+		alert.ResourceType = models.TagClusterResourceType
+		alert.ResourceID = clusterID
+	}
+
+	return alert
+}
+
+// ApiAlertmanagerWebhookHandler godoc
+// @Summary Receive Prometheus Alertmanager webhook notifications
+// @Accept json
+// @Produce json
+// @Param Body body JSONAlertmanagerWebhook true "Alertmanager webhook payload"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /prometheus/alerts [post]
+func ApiAlertmanagerWebhookHandler(s services.AlertsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var webhook JSONAlertmanagerWebhook
+
+		err := c.BindJSON(&webhook)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		var alerts models.AlertList
+		for _, alert := range webhook.Alerts {
+			alerts = append(alerts, alert.toModel())
+		}
+
+		err = s.StoreAlerts(alerts)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusAccepted, gin.H{"alerts": "accepted"})
+	}
+}
+
 // ApiGetPrometheusHttpSdTargets godoc
 // @Summary Get prometheus HTTP SD targets
 // @Produce json
+// @Param type query string false "Filter by exporter type (node_exporter, hanadb_exporter, ha_cluster_exporter)"
 // @Success 200 {object} TargetsList
+// @Failure 400 {object} map[string]string
 // @Error 500
 // @Router /prometheus/targets [get]
 func ApiGetPrometheusHttpSdTargets(s services.PrometheusService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		targetsList, err := s.GetHttpSDTargets()
+		exporterType := c.Query("type")
+
+		switch exporterType {
+		case "", services.NodeExporterType, services.HANADBExporterType, services.HAClusterExporterType:
+		default:
+			_ = c.Error(BadRequestError("unknown exporter type"))
+			return
+		}
+
+		targetsList, err := s.GetHttpSDTargets(exporterType)
 		if err != nil {
 			c.Error(err)
 			return
@@ -1,9 +1,11 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -27,7 +29,7 @@ func TestGetHttpSDTargets(t *testing.T) {
 		},
 	}
 	mockPrometheusService := new(services.MockPrometheusService)
-	mockPrometheusService.On("GetHttpSDTargets").Return(targets, nil)
+	mockPrometheusService.On("GetHttpSDTargets", "").Return(targets, nil)
 
 	deps := setupTestDependencies()
 	deps.prometheusService = mockPrometheusService
@@ -57,3 +59,107 @@ func TestGetHttpSDTargets(t *testing.T) {
 	assert.JSONEq(t, string(expectedBody), resp.Body.String())
 	assert.Equal(t, 200, resp.Code)
 }
+
+func TestGetHttpSDTargetsFiltered(t *testing.T) {
+	targets := models.PrometheusTargetsList{
+		&models.PrometheusTargets{
+			Targets: []string{"192.168.1.1:9664"},
+			Labels:  map[string]string{"hostname": "host1"},
+		},
+	}
+	mockPrometheusService := new(services.MockPrometheusService)
+	mockPrometheusService.On("GetHttpSDTargets", "ha_cluster_exporter").Return(targets, nil)
+
+	deps := setupTestDependencies()
+	deps.prometheusService = mockPrometheusService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/prometheus/targets?type=ha_cluster_exporter", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockPrometheusService.AssertExpectations(t)
+}
+
+func TestGetHttpSDTargetsUnknownType(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.prometheusService = new(services.MockPrometheusService)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/prometheus/targets?type=some_exporter", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiAlertmanagerWebhookHandler(t *testing.T) {
+	startsAt, _ := time.Parse(time.RFC3339, "2022-02-01T10:00:00Z")
+
+	expectedAlerts := models.AlertList{
+		{
+			Fingerprint:  "fingerprint-1",
+			Status:       models.AlertStatusFiring,
+			Name:         "HighCPULoad",
+			Severity:     "warning",
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "agent-1",
+			Summary:      "CPU load is high",
+			StartsAt:     startsAt,
+		},
+		{
+			Fingerprint:  "fingerprint-2",
+			Status:       models.AlertStatusResolved,
+			Name:         "ClusterDown",
+			Severity:     "critical",
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   "cluster-1",
+			StartsAt:     startsAt,
+		},
+	}
+
+	mockAlertsService := new(services.MockAlertsService)
+	mockAlertsService.On("StoreAlerts", expectedAlerts).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.alertsService = mockAlertsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := []byte(`{
+		"alerts": [
+			{
+				"status": "firing",
+				"labels": {"alertname": "HighCPULoad", "severity": "warning", "agentID": "agent-1"},
+				"annotations": {"summary": "CPU load is high"},
+				"startsAt": "2022-02-01T10:00:00Z",
+				"fingerprint": "fingerprint-1"
+			},
+			{
+				"status": "resolved",
+				"labels": {"alertname": "ClusterDown", "severity": "critical", "cluster_id": "cluster-1"},
+				"startsAt": "2022-02-01T10:00:00Z",
+				"fingerprint": "fingerprint-2"
+			}
+		]
+	}`)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/prometheus/alerts", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 202, resp.Code)
+	mockAlertsService.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiCreateReportHandler godoc
+// @Summary Generate a landscape report and store it for download
+// @Produce json
+// @Success 201 {object} models.Report
+// @Failure 500 {object} map[string]string
+// @Router /reports [post]
+func ApiCreateReportHandler(s services.ReportsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report, err := s.GenerateReport()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, report)
+	}
+}
+
+// ApiReportsListHandler godoc
+// @Summary List the generated landscape reports
+// @Produce json
+// @Success 200 {object} models.ReportList
+// @Failure 500 {object} map[string]string
+// @Router /reports [get]
+func ApiReportsListHandler(s services.ReportsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reports, err := s.GetAll()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, reports)
+	}
+}
+
+// ApiReportDownloadHandler godoc
+// @Summary Download a generated landscape report as HTML
+// @Produce html
+// @Param id path int true "Report Id"
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /reports/{id}/download [get]
+func ApiReportDownloadHandler(s services.ReportsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+		if err != nil {
+			_ = c.Error(BadRequestError("id must be a number"))
+			return
+		}
+
+		content, err := s.GetContentByID(id)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		if content == nil {
+			_ = c.Error(NotFoundError("could not find report"))
+			return
+		}
+
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=trento_report_%d.html", id))
+		c.Data(http.StatusOK, "text/html; charset=utf-8", content)
+	}
+}
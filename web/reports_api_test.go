@@ -0,0 +1,114 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiCreateReportHandler(t *testing.T) {
+	mockReportsService := new(services.MockReportsService)
+	mockReportsService.On("GenerateReport").Return(&models.Report{
+		ID:        1,
+		Name:      "Landscape report 2022-02-01 10:30",
+		CreatedAt: time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.reportsService = mockReportsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/reports", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+	assert.JSONEq(t, `{
+		"id": 1,
+		"name": "Landscape report 2022-02-01 10:30",
+		"created_at": "2022-02-01T10:30:00Z"
+	}`, resp.Body.String())
+
+	mockReportsService.AssertExpectations(t)
+}
+
+func TestApiReportsListHandler(t *testing.T) {
+	mockReportsService := new(services.MockReportsService)
+	mockReportsService.On("GetAll").Return(models.ReportList{
+		{
+			ID:        2,
+			Name:      "Landscape report 2022-02-08 10:30",
+			CreatedAt: time.Date(2022, 2, 8, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			ID:        1,
+			Name:      "Landscape report 2022-02-01 10:30",
+			CreatedAt: time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.reportsService = mockReportsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/reports", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":2`)
+	assert.Contains(t, resp.Body.String(), `"id":1`)
+
+	mockReportsService.AssertExpectations(t)
+}
+
+func TestApiReportDownloadHandler(t *testing.T) {
+	mockReportsService := new(services.MockReportsService)
+	mockReportsService.On("GetContentByID", int64(1)).Return([]byte("<html>report</html>"), nil)
+	mockReportsService.On("GetContentByID", int64(2)).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.reportsService = mockReportsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/reports/1/download", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, "<html>report</html>", resp.Body.String())
+	assert.Contains(t, resp.Header().Get("Content-Disposition"), "trento_report_1.html")
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports/2/download", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/reports/not_a_number/download", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockReportsService.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONLogLevel struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// ApiUpdateLogLevelHandler godoc
+// @Summary Change the log level of the running server, without a restart
+// @Accept json
+// @Produce json
+// @Param Body body JSONLogLevel true "Log level (error, warn, info, debug)"
+// @Success 200 {object} JSONLogLevel
+// @Failure 400 {object} map[string]string
+// @Router /internal/loglevel [put]
+func ApiUpdateLogLevelHandler(s services.RuntimeSettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var logLevel JSONLogLevel
+
+		err := c.BindJSON(&logLevel)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = s.SetLogLevel(logLevel.Level)
+		if err != nil {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+
+		c.JSON(http.StatusOK, &JSONLogLevel{Level: s.GetLogLevel()})
+	}
+}
+
+// ApiRuntimeSettingsHandler godoc
+// @Summary Get the current runtime settings of the running server
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /internal/runtime [get]
+func ApiRuntimeSettingsHandler(s services.RuntimeSettingsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, s.GetAll())
+	}
+}
@@ -0,0 +1,79 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/web/services"
+)
+
+type RuntimeSettingsApiTestCase struct {
+	suite.Suite
+	initialLogLevel log.Level
+	config          *Config
+	deps            Dependencies
+}
+
+func TestRuntimeSettingsApiTestCase(t *testing.T) {
+	suite.Run(t, new(RuntimeSettingsApiTestCase))
+}
+
+func (suite *RuntimeSettingsApiTestCase) SetupTest() {
+	suite.initialLogLevel = log.GetLevel()
+	suite.config = setupTestConfig()
+	suite.deps = setupTestDependencies()
+	suite.deps.runtimeSettingsService = services.NewRuntimeSettingsService()
+}
+
+func (suite *RuntimeSettingsApiTestCase) TearDownTest() {
+	log.SetLevel(suite.initialLogLevel)
+}
+
+func (suite *RuntimeSettingsApiTestCase) Test_UpdateLogLevel() {
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/internal/loglevel", bytes.NewBufferString(`{"level":"debug"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.JSONEq(`{"level":"debug"}`, resp.Body.String())
+	suite.Equal(log.DebugLevel, log.GetLevel())
+}
+
+func (suite *RuntimeSettingsApiTestCase) Test_UpdateLogLevel_Invalid() {
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/internal/loglevel", bytes.NewBufferString(`{"level":"verbose"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(400, resp.Code)
+	suite.Equal(suite.initialLogLevel, log.GetLevel())
+}
+
+func (suite *RuntimeSettingsApiTestCase) Test_RuntimeSettings() {
+	suite.deps.runtimeSettingsService.Set("some-flag", "enabled")
+
+	app, err := NewAppWithDeps(suite.config, suite.deps)
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/internal/runtime", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	suite.Equal(200, resp.Code)
+	suite.Contains(resp.Body.String(), `"some-flag":"enabled"`)
+	suite.Contains(resp.Body.String(), `"log-level"`)
+}
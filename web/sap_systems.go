@@ -14,8 +14,9 @@ func NewSAPSystemListHandler(sapSystemsService services.SAPSystemsService) gin.H
 		query := c.Request.URL.Query()
 
 		tagsFilter := &services.SAPSystemFilter{
-			Tags: query["tags"],
-			SIDs: query["sids"],
+			Tags:      query["tags"],
+			SIDs:      query["sids"],
+			ScopeTags: TagScopeFromContext(c),
 		}
 
 		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -74,8 +75,9 @@ func NewHANADatabaseListHandler(sapSystemsService services.SAPSystemsService) gi
 		query := c.Request.URL.Query()
 
 		tagsFilter := &services.SAPSystemFilter{
-			Tags: query["tags"],
-			SIDs: query["sids"],
+			Tags:      query["tags"],
+			SIDs:      query["sids"],
+			ScopeTags: TagScopeFromContext(c),
 		}
 
 		pageNumber, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -2,21 +2,86 @@ package web
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/trento-project/trento/web/services"
 )
 
+// ApiSAPSystemByIdHandler godoc
+// @Summary Retrieve a SAP System by ID
+// @Accept json
+// @Produce json
+// @Param id path string true "SAP System ID"
+// @Success 200 {object} models.SAPSystem
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sapsystems/{id} [get]
+func ApiSAPSystemByIdHandler(sapSystemsService services.SAPSystemsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sapSystemID := c.Param("id")
+
+		sapSystem, err := sapSystemsService.GetByID(sapSystemID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if sapSystem == nil {
+			_ = c.Error(NotFoundError("could not find sap system"))
+			return
+		}
+
+		c.JSON(http.StatusOK, sapSystem)
+	}
+}
+
+// ApiSAPSystemTopologyHandler godoc
+// @Summary Retrieve the topology graph of a SAP System
+// @Accept json
+// @Produce json
+// @Param id path string true "SAP System ID"
+// @Success 200 {object} models.SAPSystemTopology
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /sapsystems/{id}/topology [get]
+func ApiSAPSystemTopologyHandler(sapSystemsService services.SAPSystemsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sapSystemID := c.Param("id")
+
+		topology, err := sapSystemsService.GetTopologyByID(sapSystemID)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if topology == nil {
+			_ = c.Error(NotFoundError("could not find sap system"))
+			return
+		}
+
+		c.JSON(http.StatusOK, topology)
+	}
+}
+
 // ApiSAPSystemsHealthSummaryHandler godoc
 // @Summary Retrieve SAP Systems Health Summary
 // @Accept json
 // @Produce json
+// @Param unknown_as_critical query bool false "Treat unknown healths as critical"
 // @Success 200 {object} models.HealthSummary
+// @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /sapsystems/health [get]
 func ApiSAPSystemsHealthSummaryHandler(healthSummaryService services.HealthSummaryService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		healthSummary, err := healthSummaryService.GetHealthSummary()
+		unknownAsCritical, err := strconv.ParseBool(c.DefaultQuery("unknown_as_critical", "false"))
+		if err != nil {
+			_ = c.Error(BadRequestError("invalid unknown_as_critical value"))
+			return
+		}
+
+		healthSummary, err := healthSummaryService.GetHealthSummary(unknownAsCritical)
 		if err != nil {
 			c.Error(err)
 			return
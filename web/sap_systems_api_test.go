@@ -0,0 +1,136 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiSAPSystemByIdHandler(t *testing.T) {
+	sapSystemsService := new(services.MockSAPSystemsService)
+
+	sapSystemsService.On("GetByID", "sap_system_id").Return(&models.SAPSystem{
+		ID:   "sap_system_id",
+		SID:  "PRD",
+		Type: models.SAPSystemTypeDatabase,
+		Instances: []*models.SAPSystemInstance{
+			{
+				InstanceNumber: "00",
+				SAPHostname:    "vmhana01",
+				Status:         "SAPControl-GREEN",
+				Processes: []*models.SAPSystemInstanceProcess{
+					{
+						Name:        "hdbindexserver",
+						Description: "HDB Indexserver-PRD",
+						Dispstatus:  "SAPControl-YELLOW",
+						Textstatus:  "Initializing",
+						Starttime:   "2021 09 28 15:53:06",
+						Pid:         16619,
+					},
+				},
+			},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.sapSystemsService = sapSystemsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/sapsystems/sap_system_id", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	responseBody := resp.Body.String()
+
+	assert.Contains(t, responseBody, `"SID":"PRD"`)
+	assert.Contains(t, responseBody, `"Name":"hdbindexserver"`)
+	assert.Contains(t, responseBody, `"Dispstatus":"SAPControl-YELLOW"`)
+}
+
+func TestApiSAPSystemsHealthSummaryHandler(t *testing.T) {
+	healthSummaryService := new(services.MockHealthSummaryService)
+
+	healthSummaryService.On("GetHealthSummary", false).Return(models.HealthSummary{
+		{
+			ID:              "application_id",
+			SID:             "HA1",
+			SAPSystemHealth: models.HealthSummaryHealthPassing,
+			ClustersHealth:  models.HealthSummaryHealthUnknown,
+			DatabaseHealth:  models.HealthSummaryHealthPassing,
+			HostsHealth:     models.HealthSummaryHealthPassing,
+		},
+	}, nil)
+	healthSummaryService.On("GetHealthSummary", true).Return(models.HealthSummary{
+		{
+			ID:              "application_id",
+			SID:             "HA1",
+			SAPSystemHealth: models.HealthSummaryHealthPassing,
+			ClustersHealth:  models.HealthSummaryHealthCritical,
+			DatabaseHealth:  models.HealthSummaryHealthPassing,
+			HostsHealth:     models.HealthSummaryHealthPassing,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.healthSummaryService = healthSummaryService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/sapsystems/health", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"clusters_health":"unknown"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/sapsystems/health?unknown_as_critical=true", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"clusters_health":"critical"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/sapsystems/health?unknown_as_critical=maybe", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	healthSummaryService.AssertExpectations(t)
+}
+
+func TestApiSAPSystemByIdHandler404(t *testing.T) {
+	sapSystemsService := new(services.MockSAPSystemsService)
+	sapSystemsService.On("GetByID", mock.Anything).Return(nil, nil)
+
+	deps := setupTestDependencies()
+	deps.sapSystemsService = sapSystemsService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/sapsystems/not_there", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
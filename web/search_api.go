@@ -0,0 +1,40 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiSearchHandler godoc
+// @Summary Search hosts, clusters and SAP systems by any identifier
+// @Produce json
+// @Param q query string true "Search query"
+// @Success 200 {object} models.SearchResultList
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /search [get]
+func ApiSearchHandler(s services.SearchService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := c.Query("q")
+		if query == "" {
+			_ = c.Error(BadRequestError("the q parameter is required"))
+			return
+		}
+
+		results, err := s.Search(query)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if results == nil {
+			results = models.SearchResultList{}
+		}
+
+		c.JSON(http.StatusOK, results)
+	}
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiSearchHandler(t *testing.T) {
+	results := models.SearchResultList{
+		{
+			Type: models.SearchResultTypeHost,
+			ID:   "779cdd70-e9e2-58ca-b18a-bf3eb3f71244",
+			Name: "netweaver01",
+		},
+		{
+			Type: models.SearchResultTypeCluster,
+			ID:   "47d1190ffb4f781974c8356d7f863b03",
+			Name: "hana_cluster",
+			SID:  "PRD",
+		},
+	}
+
+	mockSearchService := new(services.MockSearchService)
+	mockSearchService.On("Search", "netweaver").Return(results, nil)
+
+	deps := setupTestDependencies()
+	deps.searchService = mockSearchService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/search?q=netweaver", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal(results)
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, expectedBody, resp.Body.Bytes())
+}
+
+func TestApiSearchHandlerMissingQuery(t *testing.T) {
+	mockSearchService := new(services.MockSearchService)
+
+	deps := setupTestDependencies()
+	deps.searchService = mockSearchService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/search", nil)
+	req.Header.Set("Accept", "application/json")
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
@@ -0,0 +1,178 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=AgentChecksService --inpackage --filename=agent_checks_mock.go
+
+// AgentChecksService implements the agent-executed checks mode: instead of
+// the server connecting to the nodes over SSH, the agents pull the selected
+// checks over the collector channel, run them locally with root privileges
+// and push back their partial results. Once every agent of the cluster has
+// reported, the partials are assembled into a regular checks result.
+type AgentChecksService interface {
+	GetPendingChecks(agentID string) (*models.AgentChecks, error)
+	StoreAgentChecksResults(agentID string, executionID int64, results map[string]*models.Check) error
+}
+
+type agentChecksService struct {
+	db            *gorm.DB
+	checksService ChecksService
+}
+
+func NewAgentChecksService(db *gorm.DB, checksService ChecksService) *agentChecksService {
+	return &agentChecksService{
+		db:            db,
+		checksService: checksService,
+	}
+}
+
+func (s *agentChecksService) GetPendingChecks(agentID string) (*models.AgentChecks, error) {
+	agentChecks := &models.AgentChecks{
+		Checks: models.ChecksCatalog{},
+	}
+
+	var host entities.Host
+	err := s.db.Where("agent_id", agentID).First(&host).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return agentChecks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if host.ClusterID == "" {
+		return agentChecks, nil
+	}
+
+	var execution entities.ChecksExecution
+	err = s.db.
+		Where("cluster_id", host.ClusterID).
+		Where("status IN ?", []string{models.ExecutionStatusRequested, models.ExecutionStatusRunning}).
+		Last(&execution).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return agentChecks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if execution.Status == models.ExecutionStatusRequested {
+		if err := s.checksService.StartChecksExecution(execution.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	selectedChecks, err := s.checksService.GetSelectedChecksById(host.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	catalogByID := make(map[string]*models.Check)
+	for _, check := range catalog {
+		catalogByID[check.ID] = check
+	}
+
+	agentChecks.ExecutionID = execution.ID
+	for _, checkId := range selectedChecks.SelectedChecks {
+		if check, ok := catalogByID[checkId]; ok {
+			agentChecks.Checks = append(agentChecks.Checks, check)
+		}
+	}
+
+	return agentChecks, nil
+}
+
+func (s *agentChecksService) StoreAgentChecksResults(agentID string, executionID int64, results map[string]*models.Check) error {
+	var host entities.Host
+	if err := s.db.Where("agent_id", agentID).First(&host).Error; err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(results)
+	if err != nil {
+		return err
+	}
+
+	partial := entities.AgentChecksResult{
+		ExecutionID: executionID,
+		AgentID:     agentID,
+		ClusterID:   host.ClusterID,
+		HostName:    host.Name,
+		Payload:     payload,
+	}
+	err = s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&partial).Error
+	if err != nil {
+		return err
+	}
+
+	return s.assembleChecksResult(executionID, host.ClusterID)
+}
+
+// assembleChecksResult builds a regular checks result out of the partial
+// agent reports, once every host of the cluster has reported
+func (s *agentChecksService) assembleChecksResult(executionID int64, clusterID string) error {
+	var hostsCount int64
+	err := s.db.Model(&entities.Host{}).
+		Where("cluster_id", clusterID).
+		Count(&hostsCount).
+		Error
+	if err != nil {
+		return err
+	}
+
+	var partials []entities.AgentChecksResult
+	err = s.db.Where("execution_id", executionID).Find(&partials).Error
+	if err != nil {
+		return err
+	}
+
+	if int64(len(partials)) < hostsCount {
+		return nil
+	}
+
+	checksResult := &models.ChecksResult{
+		ID:     clusterID,
+		Hosts:  make(map[string]*models.HostState),
+		Checks: make(map[string]*models.ChecksByHost),
+	}
+
+	for _, partial := range partials {
+		var results map[string]*models.Check
+		if err := json.Unmarshal(partial.Payload, &results); err != nil {
+			return err
+		}
+
+		checksResult.Hosts[partial.HostName] = &models.HostState{Reachable: true}
+
+		for checkId, result := range results {
+			if _, ok := checksResult.Checks[checkId]; !ok {
+				checksResult.Checks[checkId] = &models.ChecksByHost{
+					Hosts: make(map[string]*models.Check),
+				}
+			}
+			checksResult.Checks[checkId].Hosts[partial.HostName] = result
+		}
+	}
+
+	if err := s.checksService.CreateChecksResult(checksResult); err != nil {
+		return err
+	}
+
+	return s.db.Where("execution_id", executionID).Delete(&entities.AgentChecksResult{}).Error
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AgentChecksServiceTestSuite struct {
+	suite.Suite
+	db                 *gorm.DB
+	tx                 *gorm.DB
+	checksService      ChecksService
+	agentChecksService *agentChecksService
+}
+
+func TestAgentChecksServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentChecksServiceTestSuite))
+}
+
+func (suite *AgentChecksServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.AgentChecksResult{}, entities.Host{}, entities.ResourceEvent{},
+	)
+
+	check1payload := `{"id":"check1","name":"name1","group":"group1","implementation":"test -f /etc/check1"}`
+	suite.db.Create(&entities.Check{
+		ID:      "check1",
+		Payload: datatypes.JSON([]byte(check1payload)),
+	})
+
+	suite.db.Create(&models.SelectedChecks{
+		ID:             "group1",
+		SelectedChecks: []string{"check1"},
+	})
+
+	hosts := []entities.Host{
+		{
+			AgentID:   "agent1",
+			Name:      "host1",
+			ClusterID: "group1",
+		},
+		{
+			AgentID:   "agent2",
+			Name:      "host2",
+			ClusterID: "group1",
+		},
+	}
+	err := suite.db.Create(&hosts).Error
+	suite.NoError(err)
+}
+
+func (suite *AgentChecksServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.AgentChecksResult{}, entities.Host{}, entities.ResourceEvent{},
+	)
+}
+
+func (suite *AgentChecksServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+
+	premiumDetection := new(MockPremiumDetectionService)
+	premiumDetection.On("IsPremiumActive").Return(false, nil)
+
+	suite.checksService = NewChecksService(suite.tx, premiumDetection, NewAlertsService(suite.tx, nil), NewEventsService(suite.tx, nil), NewSettingsService(suite.tx), NewTrashService(suite.tx))
+	suite.agentChecksService = NewAgentChecksService(suite.tx, suite.checksService)
+}
+
+func (suite *AgentChecksServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *AgentChecksServiceTestSuite) TestAgentChecksService_GetPendingChecks() {
+	// nothing is pending yet
+	agentChecks, err := suite.agentChecksService.GetPendingChecks("agent1")
+	suite.NoError(err)
+	suite.Equal(int64(0), agentChecks.ExecutionID)
+	suite.Empty(agentChecks.Checks)
+
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	agentChecks, err = suite.agentChecksService.GetPendingChecks("agent1")
+	suite.NoError(err)
+	suite.Equal(execution.ID, agentChecks.ExecutionID)
+	suite.Len(agentChecks.Checks, 1)
+	suite.Equal("check1", agentChecks.Checks[0].ID)
+	suite.Equal("test -f /etc/check1", agentChecks.Checks[0].Implementation)
+
+	// the first pickup moves the execution to the running state
+	running, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusRunning, running.Status)
+}
+
+func (suite *AgentChecksServiceTestSuite) TestAgentChecksService_GetPendingChecksUnknownAgent() {
+	agentChecks, err := suite.agentChecksService.GetPendingChecks("other")
+
+	suite.NoError(err)
+	suite.Equal(int64(0), agentChecks.ExecutionID)
+	suite.Empty(agentChecks.Checks)
+}
+
+func (suite *AgentChecksServiceTestSuite) TestAgentChecksService_StoreAgentChecksResults() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	err = suite.agentChecksService.StoreAgentChecksResults("agent1", execution.ID, map[string]*models.Check{
+		"check1": {Result: models.CheckPassing},
+	})
+	suite.NoError(err)
+
+	// only one of the two agents has reported, no result is assembled yet
+	_, err = suite.checksService.GetChecksResultByCluster("group1")
+	suite.EqualError(err, gorm.ErrRecordNotFound.Error())
+
+	err = suite.agentChecksService.StoreAgentChecksResults("agent2", execution.ID, map[string]*models.Check{
+		"check1": {Result: models.CheckCritical, Msg: "check failed"},
+	})
+	suite.NoError(err)
+
+	result, err := suite.checksService.GetChecksResultByCluster("group1")
+	suite.NoError(err)
+	suite.True(result.Hosts["host1"].Reachable)
+	suite.True(result.Hosts["host2"].Reachable)
+	suite.Equal(models.CheckPassing, result.Checks["check1"].Hosts["host1"].Result)
+	suite.Equal(models.CheckCritical, result.Checks["check1"].Hosts["host2"].Result)
+	suite.Equal("check failed", result.Checks["check1"].Hosts["host2"].Msg)
+
+	completed, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusCompleted, completed.Status)
+
+	// the assembled partials are not kept around
+	var partialsCount int64
+	suite.tx.Model(&entities.AgentChecksResult{}).Count(&partialsCount)
+	suite.Equal(int64(0), partialsCount)
+}
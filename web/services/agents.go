@@ -0,0 +1,249 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=AgentsService --inpackage --filename=agents_mock.go
+type AgentsService interface {
+	GetConfig(agentID string) (*models.AgentConfig, error)
+	UpdateConfig(config *models.AgentConfig) error
+	GetUpdateSettings() (*models.AgentUpdateSettings, error)
+	SetUpdateSettings(updateSettings *models.AgentUpdateSettings) error
+	GetSummary() ([]*models.AgentSummary, error)
+	StoreErrors(agentID string, agentErrors []*models.AgentError) error
+	GetErrors(agentID string) ([]*models.AgentError, error)
+}
+
+type agentsService struct {
+	db *gorm.DB
+}
+
+func NewAgentsService(db *gorm.DB) *agentsService {
+	return &agentsService{db}
+}
+
+// GetConfig returns the server-driven configuration of an agent.
+// An empty configuration is returned when no overrides are stored,
+// meaning the agent should keep using its local defaults.
+func (s *agentsService) GetConfig(agentID string) (*models.AgentConfig, error) {
+	var config entities.AgentConfig
+
+	err := s.db.Where("agent_id = ?", agentID).First(&config).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	config.AgentID = agentID
+
+	model := config.ToModel()
+
+	// the fleet-wide update advertisement is served along with the per-host
+	// configuration, unless the host opted out of auto-updates
+	if !config.AutoUpdateDisabled {
+		updateSettings, err := s.GetUpdateSettings()
+		if err != nil {
+			return nil, err
+		}
+		model.DesiredAgentVersion = updateSettings.DesiredAgentVersion
+		model.UpdateRepository = updateSettings.RepositoryURL
+	}
+
+	return model, nil
+}
+
+func (s *agentsService) UpdateConfig(config *models.AgentConfig) error {
+	jsonDiscoveryPeriods, err := json.Marshal(config.DiscoveryPeriods)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&entities.AgentConfig{
+		AgentID:            config.AgentID,
+		DiscoveryPeriods:   (datatypes.JSON)(jsonDiscoveryPeriods),
+		EnabledDiscoveries: config.EnabledDiscoveries,
+		CollectorHost:      config.CollectorHost,
+		CollectorPort:      config.CollectorPort,
+		AutoUpdateDisabled: config.AutoUpdateDisabled,
+	}).Error
+}
+
+// GetUpdateSettings returns the fleet-wide agent update advertisement; both
+// fields are empty when no update was ever advertised
+func (s *agentsService) GetUpdateSettings() (*models.AgentUpdateSettings, error) {
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return &models.AgentUpdateSettings{
+		DesiredAgentVersion: settings.DesiredAgentVersion,
+		RepositoryURL:       settings.AgentUpdateRepository,
+	}, nil
+}
+
+// SetUpdateSettings stores the agent version and package repository to
+// advertise to the whole fleet; empty values stop advertising any update
+func (s *agentsService) SetUpdateSettings(updateSettings *models.AgentUpdateSettings) error {
+	var settings entities.Settings
+	s.db.First(&settings)
+	settings.DesiredAgentVersion = updateSettings.DesiredAgentVersion
+	settings.AgentUpdateRepository = updateSettings.RepositoryURL
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"desired_agent_version", "agent_update_repository"}),
+	}).Create(&settings).Error
+}
+
+type agentEventsAggregate struct {
+	AgentID      string
+	EventsCount  int64
+	PayloadBytes int64
+}
+
+// GetSummary returns one entry per registered host, combining the data
+// reported through the heartbeat with the volume of discovery events
+// the agent has published to the collector
+func (s *agentsService) GetSummary() ([]*models.AgentSummary, error) {
+	var hosts []*entities.Host
+	err := s.db.Preload("Heartbeat").Order("name").Find(&hosts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var aggregates []*agentEventsAggregate
+	err = s.db.
+		Model(&datapipeline.DataCollectedEvent{}).
+		Select("agent_id, COUNT(*) AS events_count, SUM(LENGTH(payload::text)) AS payload_bytes").
+		Group("agent_id").
+		Scan(&aggregates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	aggregatesByAgent := make(map[string]*agentEventsAggregate)
+	for _, aggregate := range aggregates {
+		aggregatesByAgent[aggregate.AgentID] = aggregate
+	}
+
+	var errorsCounts []*agentErrorsCount
+	err = s.db.
+		Model(&entities.AgentError{}).
+		Select("agent_id, COUNT(*) AS errors_count").
+		Group("agent_id").
+		Scan(&errorsCounts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	errorsCountByAgent := make(map[string]int64)
+	for _, errorsCount := range errorsCounts {
+		errorsCountByAgent[errorsCount.AgentID] = errorsCount.ErrorsCount
+	}
+
+	summary := make([]*models.AgentSummary, 0)
+	for _, host := range hosts {
+		agentSummary := &models.AgentSummary{
+			AgentID:         host.AgentID,
+			Hostname:        host.Name,
+			AgentVersion:    host.AgentVersion,
+			VersionMismatch: isOutdatedAgent(host.Heartbeat),
+		}
+
+		if host.Heartbeat != nil {
+			lastContact := host.Heartbeat.UpdatedAt
+			// older agents do not advertise their version in the heartbeat
+			if host.Heartbeat.AgentVersion != "" {
+				agentSummary.AgentVersion = host.Heartbeat.AgentVersion
+			}
+			agentSummary.LastContact = &lastContact
+			agentSummary.ClockSkew = host.Heartbeat.ClockSkew
+			agentSummary.EnabledDiscoveries = host.Heartbeat.EnabledDiscoveries
+		}
+
+		if aggregate, ok := aggregatesByAgent[host.AgentID]; ok {
+			agentSummary.EventsCount = aggregate.EventsCount
+			agentSummary.PayloadBytes = aggregate.PayloadBytes
+		}
+		agentSummary.ErrorsCount = errorsCountByAgent[host.AgentID]
+
+		summary = append(summary, agentSummary)
+	}
+
+	return summary, nil
+}
+
+type agentErrorsCount struct {
+	AgentID     string
+	ErrorsCount int64
+}
+
+// agentErrorsHistorySize is the maximum number of errors kept per agent
+const agentErrorsHistorySize = 100
+
+// StoreErrors appends the errors an agent reported about itself,
+// keeping only the most recent ones per agent
+func (s *agentsService) StoreErrors(agentID string, agentErrors []*models.AgentError) error {
+	if len(agentErrors) == 0 {
+		return nil
+	}
+
+	entityErrors := make([]*entities.AgentError, 0, len(agentErrors))
+	for _, agentError := range agentErrors {
+		entityErrors = append(entityErrors, &entities.AgentError{
+			AgentID:    agentID,
+			Type:       agentError.Type,
+			Message:    agentError.Message,
+			ReportedAt: agentError.ReportedAt,
+		})
+	}
+
+	err := s.db.Create(&entityErrors).Error
+	if err != nil {
+		return err
+	}
+
+	// cap the history so a misbehaving agent cannot grow the table unbounded
+	return s.db.
+		Where("agent_id = ?", agentID).
+		Where("id NOT IN (?)", s.db.
+			Model(&entities.AgentError{}).
+			Select("id").
+			Where("agent_id = ?", agentID).
+			Order("reported_at DESC").
+			Limit(agentErrorsHistorySize)).
+		Delete(&entities.AgentError{}).Error
+}
+
+// GetErrors returns the stored errors of an agent, most recent first
+func (s *agentsService) GetErrors(agentID string) ([]*models.AgentError, error) {
+	var entityErrors []*entities.AgentError
+
+	err := s.db.
+		Where("agent_id = ?", agentID).
+		Order("reported_at DESC").
+		Find(&entityErrors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	agentErrors := make([]*models.AgentError, 0)
+	for _, entityError := range entityErrors {
+		agentErrors = append(agentErrors, entityError.ToModel())
+	}
+
+	return agentErrors, nil
+}
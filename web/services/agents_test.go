@@ -0,0 +1,227 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AgentsServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	tx            *gorm.DB
+	agentsService AgentsService
+}
+
+func TestAgentsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AgentsServiceTestSuite))
+}
+
+func (suite *AgentsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.AgentConfig{}, entities.AgentError{}, entities.Host{}, entities.HostHeartbeat{}, entities.Settings{}, datapipeline.DataCollectedEvent{})
+}
+
+func (suite *AgentsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.AgentConfig{}, entities.AgentError{}, entities.Host{}, entities.HostHeartbeat{}, entities.Settings{}, datapipeline.DataCollectedEvent{})
+}
+
+func (suite *AgentsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.agentsService = NewAgentsService(suite.tx)
+}
+
+func (suite *AgentsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_GetSummary() {
+	heartbeatTime := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	err := suite.tx.Create([]*entities.Host{
+		{AgentID: "agent-1", Name: "vmhana01", AgentVersion: "1.0.0"},
+		{AgentID: "agent-2", Name: "vmhana02"},
+	}).Error
+	suite.NoError(err)
+
+	err = suite.tx.Create(&entities.HostHeartbeat{
+		AgentID:            "agent-1",
+		AgentVersion:       "1.2.0",
+		EnabledDiscoveries: []string{"cluster_discovery"},
+		ClockSkew:          150,
+		UpdatedAt:          heartbeatTime,
+	}).Error
+	suite.NoError(err)
+
+	err = suite.tx.Create([]*datapipeline.DataCollectedEvent{
+		{AgentID: "agent-1", DiscoveryType: datapipeline.ClusterDiscovery, Payload: datatypes.JSON([]byte(`{"key":"value"}`))},
+		{AgentID: "agent-1", DiscoveryType: datapipeline.HostDiscovery, Payload: datatypes.JSON([]byte(`{}`))},
+	}).Error
+	suite.NoError(err)
+
+	err = suite.tx.Create(&entities.AgentError{
+		AgentID:    "agent-1",
+		Type:       models.AgentErrorTypeDiscovery,
+		Message:    "cluster_discovery failed",
+		ReportedAt: heartbeatTime,
+	}).Error
+	suite.NoError(err)
+
+	summary, err := suite.agentsService.GetSummary()
+	suite.NoError(err)
+	suite.Len(summary, 2)
+
+	suite.Equal("agent-1", summary[0].AgentID)
+	suite.Equal("vmhana01", summary[0].Hostname)
+	suite.Equal("1.2.0", summary[0].AgentVersion)
+	suite.WithinDuration(heartbeatTime, *summary[0].LastContact, time.Second)
+	suite.Equal(int64(150), summary[0].ClockSkew)
+	suite.Equal([]string{"cluster_discovery"}, summary[0].EnabledDiscoveries)
+	suite.Equal(int64(2), summary[0].EventsCount)
+	suite.NotZero(summary[0].PayloadBytes)
+	suite.Equal(int64(1), summary[0].ErrorsCount)
+
+	// a host without heartbeat and collected events is still listed
+	suite.Equal("agent-2", summary[1].AgentID)
+	suite.Nil(summary[1].LastContact)
+	suite.Equal(int64(0), summary[1].EventsCount)
+	suite.Equal(int64(0), summary[1].ErrorsCount)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_StoreAndGetErrors() {
+	firstReport := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+	secondReport := firstReport.Add(5 * time.Minute)
+
+	err := suite.agentsService.StoreErrors("agent-1", []*models.AgentError{
+		{Type: models.AgentErrorTypeDiscovery, Message: "cluster_discovery failed", ReportedAt: firstReport},
+		{Type: models.AgentErrorTypeExporter, Message: "node_exporter crashed", ReportedAt: secondReport},
+	})
+	suite.NoError(err)
+
+	agentErrors, err := suite.agentsService.GetErrors("agent-1")
+	suite.NoError(err)
+
+	// the most recent error comes first
+	suite.Len(agentErrors, 2)
+	suite.Equal(models.AgentErrorTypeExporter, agentErrors[0].Type)
+	suite.Equal("node_exporter crashed", agentErrors[0].Message)
+	suite.WithinDuration(secondReport, agentErrors[0].ReportedAt, time.Second)
+	suite.Equal(models.AgentErrorTypeDiscovery, agentErrors[1].Type)
+	suite.Equal("cluster_discovery failed", agentErrors[1].Message)
+	suite.WithinDuration(firstReport, agentErrors[1].ReportedAt, time.Second)
+
+	// errors of other agents are not returned
+	agentErrors, err = suite.agentsService.GetErrors("agent-2")
+	suite.NoError(err)
+	suite.Empty(agentErrors)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_StoreErrorsCapsHistory() {
+	reportedAt := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+
+	agentErrors := make([]*models.AgentError, 0)
+	for i := 0; i < agentErrorsHistorySize+10; i++ {
+		agentErrors = append(agentErrors, &models.AgentError{
+			Type:       models.AgentErrorTypeDiscovery,
+			Message:    "cluster_discovery failed",
+			ReportedAt: reportedAt.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	err := suite.agentsService.StoreErrors("agent-1", agentErrors)
+	suite.NoError(err)
+
+	storedErrors, err := suite.agentsService.GetErrors("agent-1")
+	suite.NoError(err)
+	suite.Len(storedErrors, agentErrorsHistorySize)
+
+	// the oldest entries are the ones dropped
+	suite.WithinDuration(agentErrors[len(agentErrors)-1].ReportedAt, storedErrors[0].ReportedAt, time.Second)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_GetConfigDefaults() {
+	config, err := suite.agentsService.GetConfig("agent-1")
+	suite.NoError(err)
+
+	suite.Equal(&models.AgentConfig{AgentID: "agent-1"}, config)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_UpdateConfig() {
+	expectedConfig := &models.AgentConfig{
+		AgentID: "agent-1",
+		DiscoveryPeriods: map[string]int{
+			"cluster_discovery":    30,
+			"sap_system_discovery": 300,
+		},
+		EnabledDiscoveries: []string{"cluster_discovery", "sap_system_discovery"},
+		CollectorHost:      "collector.example.com",
+		CollectorPort:      8443,
+	}
+
+	err := suite.agentsService.UpdateConfig(expectedConfig)
+	suite.NoError(err)
+
+	config, err := suite.agentsService.GetConfig("agent-1")
+	suite.NoError(err)
+	suite.Equal(expectedConfig, config)
+
+	// updating again overwrites the stored configuration
+	expectedConfig.CollectorPort = 9443
+	err = suite.agentsService.UpdateConfig(expectedConfig)
+	suite.NoError(err)
+
+	config, err = suite.agentsService.GetConfig("agent-1")
+	suite.NoError(err)
+	suite.Equal(9443, config.CollectorPort)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_UpdateSettings() {
+	updateSettings, err := suite.agentsService.GetUpdateSettings()
+	suite.NoError(err)
+	suite.Equal(&models.AgentUpdateSettings{}, updateSettings)
+
+	err = suite.agentsService.SetUpdateSettings(&models.AgentUpdateSettings{
+		DesiredAgentVersion: "0.9.0",
+		RepositoryURL:       "https://updates.example.com/trento",
+	})
+	suite.NoError(err)
+
+	updateSettings, err = suite.agentsService.GetUpdateSettings()
+	suite.NoError(err)
+	suite.Equal("0.9.0", updateSettings.DesiredAgentVersion)
+	suite.Equal("https://updates.example.com/trento", updateSettings.RepositoryURL)
+}
+
+func (suite *AgentsServiceTestSuite) TestAgentsService_GetConfigAdvertisesUpdates() {
+	err := suite.agentsService.SetUpdateSettings(&models.AgentUpdateSettings{
+		DesiredAgentVersion: "0.9.0",
+		RepositoryURL:       "https://updates.example.com/trento",
+	})
+	suite.NoError(err)
+
+	config, err := suite.agentsService.GetConfig("agent-1")
+	suite.NoError(err)
+	suite.Equal("0.9.0", config.DesiredAgentVersion)
+	suite.Equal("https://updates.example.com/trento", config.UpdateRepository)
+
+	// hosts that opted out of auto-updates do not get the advertisement
+	err = suite.agentsService.UpdateConfig(&models.AgentConfig{
+		AgentID:            "agent-1",
+		AutoUpdateDisabled: true,
+	})
+	suite.NoError(err)
+
+	config, err = suite.agentsService.GetConfig("agent-1")
+	suite.NoError(err)
+	suite.True(config.AutoUpdateDisabled)
+	suite.Empty(config.DesiredAgentVersion)
+	suite.Empty(config.UpdateRepository)
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=AlertChannel --inpackage --filename=alert_channel_mock.go
+
+// AlertChannel forwards firing alerts to an external notification system
+// and resolves them there when the underlying condition clears
+type AlertChannel interface {
+	Name() string
+	Trigger(alert *models.Alert) error
+	Resolve(alert *models.Alert) error
+}
+
+// ChannelRoute binds a channel to the alert severities it should receive.
+// An empty severities list routes every alert to the channel
+type ChannelRoute struct {
+	Channel    AlertChannel
+	Severities []string
+	// GroupWindow suppresses further notifications for the same resource
+	// within the window, assuming they share the same root cause
+	GroupWindow time.Duration
+	// EscalateAfter holds the notifications back until the alert stayed
+	// firing this long, so the channel only sees what nobody resolved
+	EscalateAfter time.Duration
+}
+
+func (r *ChannelRoute) matches(alert *models.Alert) bool {
+	if len(r.Severities) == 0 {
+		return true
+	}
+
+	return internal.Contains(r.Severities, alert.Severity)
+}
+
+// AlertRouter fans the stored alerts out to the configured channels,
+// following the per-severity routing rules. Repeated notifications of an
+// alert that is already firing on a channel are suppressed until it
+// resolves
+type AlertRouter struct {
+	routes []ChannelRoute
+
+	mu sync.Mutex
+	// firing tracks the alerts that have not resolved yet, by fingerprint
+	firing map[string]*firingAlert
+	// delivered tracks what each route already notified, by fingerprint
+	delivered []map[string]bool
+	// lastGrouped tracks when each route last notified about a resource
+	lastGrouped []map[string]time.Time
+}
+
+type firingAlert struct {
+	alert *models.Alert
+	since time.Time
+}
+
+func NewAlertRouter(routes ...ChannelRoute) *AlertRouter {
+	delivered := make([]map[string]bool, len(routes))
+	lastGrouped := make([]map[string]time.Time, len(routes))
+	for i := range routes {
+		delivered[i] = make(map[string]bool)
+		lastGrouped[i] = make(map[string]time.Time)
+	}
+
+	return &AlertRouter{
+		routes:      routes,
+		firing:      make(map[string]*firingAlert),
+		delivered:   delivered,
+		lastGrouped: lastGrouped,
+	}
+}
+
+func (r *AlertRouter) Dispatch(alert *models.Alert) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+
+	if alert.Status == models.AlertStatusResolved {
+		delete(r.firing, alert.Fingerprint)
+
+		for i, route := range r.routes {
+			if !route.matches(alert) || !r.delivered[i][alert.Fingerprint] {
+				continue
+			}
+			delete(r.delivered[i], alert.Fingerprint)
+			// an unreachable channel must not block the others
+			if err := route.Channel.Resolve(alert); err != nil {
+				log.Warnf("Could not resolve the %s alert on the %s channel: %s",
+					alert.Name, route.Channel.Name(), err)
+			}
+		}
+
+		return
+	}
+
+	if _, ok := r.firing[alert.Fingerprint]; !ok {
+		r.firing[alert.Fingerprint] = &firingAlert{alert: alert, since: now}
+	}
+
+	for i, route := range r.routes {
+		if !route.matches(alert) || route.EscalateAfter > 0 {
+			continue
+		}
+		r.deliver(i, route, alert, now)
+	}
+}
+
+// CheckEscalations delivers the alerts that are still firing to the
+// escalation channels whose delay elapsed. It is meant to be called
+// periodically
+func (r *AlertRouter) CheckEscalations() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	for _, firing := range r.firing {
+		for i, route := range r.routes {
+			if route.EscalateAfter == 0 || !route.matches(firing.alert) {
+				continue
+			}
+			if now.Sub(firing.since) < route.EscalateAfter {
+				continue
+			}
+			r.deliver(i, route, firing.alert, now)
+		}
+	}
+}
+
+func (r *AlertRouter) deliver(i int, route ChannelRoute, alert *models.Alert, now time.Time) {
+	// the channel already knows about this alert, suppress the duplicate
+	if r.delivered[i][alert.Fingerprint] {
+		return
+	}
+	r.delivered[i][alert.Fingerprint] = true
+
+	if route.GroupWindow > 0 && alert.ResourceID != "" {
+		groupKey := alert.ResourceType + "/" + alert.ResourceID
+		if last, ok := r.lastGrouped[i][groupKey]; ok && now.Sub(last) < route.GroupWindow {
+			// grouped with the recent notification about the same resource
+			return
+		}
+		r.lastGrouped[i][groupKey] = now
+	}
+
+	if err := route.Channel.Trigger(alert); err != nil {
+		log.Warnf("Could not deliver the %s alert to the %s channel: %s",
+			alert.Name, route.Channel.Name(), err)
+	}
+}
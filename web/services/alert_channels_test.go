@@ -0,0 +1,127 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+func firingTestAlert(fingerprint string, resourceID string) *models.Alert {
+	return &models.Alert{
+		Fingerprint:  fingerprint,
+		Status:       models.AlertStatusFiring,
+		Name:         "ClusterDown",
+		Severity:     "critical",
+		ResourceType: models.TagClusterResourceType,
+		ResourceID:   resourceID,
+	}
+}
+
+func TestAlertRouter_SuppressesDuplicates(t *testing.T) {
+	channel := new(MockAlertChannel)
+	channel.On("Name").Return("channel").Maybe()
+
+	router := NewAlertRouter(ChannelRoute{Channel: channel})
+
+	alert := firingTestAlert("fingerprint-1", "cluster-1")
+	channel.On("Trigger", alert).Return(nil).Once()
+
+	// the repeated notifications of a still-firing alert are suppressed
+	router.Dispatch(alert)
+	router.Dispatch(alert)
+	router.Dispatch(alert)
+
+	resolved := *alert
+	resolved.Status = models.AlertStatusResolved
+	channel.On("Resolve", &resolved).Return(nil).Once()
+
+	router.Dispatch(&resolved)
+
+	// after resolving, a new occurrence notifies again
+	channel.On("Trigger", alert).Return(nil).Once()
+	router.Dispatch(alert)
+
+	channel.AssertExpectations(t)
+}
+
+func TestAlertRouter_GroupsByResource(t *testing.T) {
+	channel := new(MockAlertChannel)
+	channel.On("Name").Return("channel").Maybe()
+
+	router := NewAlertRouter(ChannelRoute{Channel: channel, GroupWindow: 5 * time.Minute})
+
+	first := firingTestAlert("fingerprint-1", "cluster-1")
+	channel.On("Trigger", first).Return(nil).Once()
+
+	router.Dispatch(first)
+	// a different alert on the same cluster within the window rides along
+	// with the first notification
+	router.Dispatch(firingTestAlert("fingerprint-2", "cluster-1"))
+
+	// another resource is notified separately
+	other := firingTestAlert("fingerprint-3", "cluster-2")
+	channel.On("Trigger", other).Return(nil).Once()
+	router.Dispatch(other)
+
+	channel.AssertExpectations(t)
+	channel.AssertNumberOfCalls(t, "Trigger", 2)
+}
+
+func TestAlertRouter_Escalation(t *testing.T) {
+	firstResponder := new(MockAlertChannel)
+	firstResponder.On("Name").Return("first").Maybe()
+	escalation := new(MockAlertChannel)
+	escalation.On("Name").Return("escalation").Maybe()
+
+	router := NewAlertRouter(
+		ChannelRoute{Channel: firstResponder},
+		ChannelRoute{Channel: escalation, EscalateAfter: 15 * time.Minute},
+	)
+
+	alert := firingTestAlert("fingerprint-1", "cluster-1")
+	firstResponder.On("Trigger", alert).Return(nil).Once()
+
+	// the escalation channel is not notified right away
+	router.Dispatch(alert)
+	router.CheckEscalations()
+	escalation.AssertNotCalled(t, "Trigger", alert)
+
+	// once the alert stayed firing past the delay, it escalates
+	router.firing["fingerprint-1"].since = time.Now().Add(-20 * time.Minute)
+	escalation.On("Trigger", alert).Return(nil).Once()
+	router.CheckEscalations()
+	router.CheckEscalations()
+
+	// the resolution reaches both channels
+	resolved := *alert
+	resolved.Status = models.AlertStatusResolved
+	firstResponder.On("Resolve", &resolved).Return(nil).Once()
+	escalation.On("Resolve", &resolved).Return(nil).Once()
+	router.Dispatch(&resolved)
+
+	firstResponder.AssertExpectations(t)
+	escalation.AssertExpectations(t)
+}
+
+func TestAlertRouter_EscalationSkippedWhenResolvedInTime(t *testing.T) {
+	escalation := new(MockAlertChannel)
+	escalation.On("Name").Return("escalation").Maybe()
+
+	router := NewAlertRouter(
+		ChannelRoute{Channel: escalation, EscalateAfter: 15 * time.Minute},
+	)
+
+	alert := firingTestAlert("fingerprint-1", "cluster-1")
+	router.Dispatch(alert)
+
+	resolved := *alert
+	resolved.Status = models.AlertStatusResolved
+	router.Dispatch(&resolved)
+
+	router.CheckEscalations()
+
+	// resolved before the delay elapsed: the channel never hears about it
+	escalation.AssertNotCalled(t, "Trigger", alert)
+	escalation.AssertNotCalled(t, "Resolve", &resolved)
+}
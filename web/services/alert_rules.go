@@ -0,0 +1,313 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var (
+	ErrAlertRuleNotFound         = errors.New("the alert rule does not exist")
+	ErrInvalidAlertRuleCondition = errors.New("invalid alert rule condition")
+	ErrInvalidAlertRuleThreshold = errors.New("the online_hosts_below condition requires a positive threshold")
+)
+
+var alertRuleConditions = []string{
+	models.AlertRuleConditionClusterHealth,
+	models.AlertRuleConditionHostHealth,
+	models.AlertRuleConditionOnlineHostsBelow,
+	models.AlertRuleConditionChecksFailing,
+}
+
+//go:generate mockery --name=AlertRulesService --inpackage --filename=alert_rules_mock.go
+
+// AlertRulesService stores the user-defined alert conditions and evaluates
+// them against the projected data. The resulting alerts go through the
+// regular alerts pipeline, so they show up on the resources and reach the
+// configured notification channels like the Alertmanager ones
+type AlertRulesService interface {
+	GetAll() (models.AlertRuleList, error)
+	Create(rule *models.AlertRule) (*models.AlertRule, error)
+	Delete(id int64) error
+	Evaluate() error
+}
+
+type alertRulesService struct {
+	db              *gorm.DB
+	hostsService    HostsService
+	clustersService ClustersService
+	alertsService   AlertsService
+	// pendingSince tracks when a rule condition started to hold, keyed by
+	// alert fingerprint. Only the evaluator goroutine touches it
+	pendingSince map[string]time.Time
+}
+
+func NewAlertRulesService(db *gorm.DB, hostsService HostsService,
+	clustersService ClustersService, alertsService AlertsService) *alertRulesService {
+	return &alertRulesService{
+		db:              db,
+		hostsService:    hostsService,
+		clustersService: clustersService,
+		alertsService:   alertsService,
+		pendingSince:    make(map[string]time.Time),
+	}
+}
+
+func (s *alertRulesService) GetAll() (models.AlertRuleList, error) {
+	var ruleEntities []entities.AlertRule
+
+	if err := s.db.Order("id").Find(&ruleEntities).Error; err != nil {
+		return nil, err
+	}
+
+	rules := make(models.AlertRuleList, 0, len(ruleEntities))
+	for _, ruleEntity := range ruleEntities {
+		rules = append(rules, ruleEntity.ToModel())
+	}
+
+	return rules, nil
+}
+
+func (s *alertRulesService) Create(rule *models.AlertRule) (*models.AlertRule, error) {
+	if !internal.Contains(alertRuleConditions, rule.Condition) {
+		return nil, ErrInvalidAlertRuleCondition
+	}
+	if rule.Condition == models.AlertRuleConditionOnlineHostsBelow && rule.Threshold <= 0 {
+		return nil, ErrInvalidAlertRuleThreshold
+	}
+
+	severity := rule.Severity
+	if severity == "" {
+		severity = "critical"
+	}
+	value := rule.Value
+	if value == "" {
+		value = "critical"
+	}
+
+	ruleEntity := entities.AlertRule{
+		Name:       rule.Name,
+		Condition:  rule.Condition,
+		Value:      value,
+		Threshold:  rule.Threshold,
+		ResourceID: rule.ResourceID,
+		Tags:       rule.Tags,
+		Severity:   severity,
+		ForMinutes: rule.ForMinutes,
+	}
+
+	if err := s.db.Create(&ruleEntity).Error; err != nil {
+		return nil, err
+	}
+
+	return ruleEntity.ToModel(), nil
+}
+
+func (s *alertRulesService) Delete(id int64) error {
+	result := s.db.Delete(&entities.AlertRule{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlertRuleNotFound
+	}
+
+	return nil
+}
+
+// Evaluate checks every rule against the current data, firing the alerts
+// whose condition held long enough and resolving the ones that cleared
+func (s *alertRulesService) Evaluate() error {
+	rules, err := s.GetAll()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		candidates, err := s.evaluateRule(rule)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		minDuration := time.Duration(rule.ForMinutes) * time.Minute
+
+		var alerts models.AlertList
+		firing := make(map[string]bool)
+		for _, candidate := range candidates {
+			since, pending := s.pendingSince[candidate.Fingerprint]
+			if !pending {
+				since = now
+				s.pendingSince[candidate.Fingerprint] = now
+			}
+			if now.Sub(since) < minDuration {
+				continue
+			}
+			firing[candidate.Fingerprint] = true
+			candidate.StartsAt = since
+			alerts = append(alerts, candidate)
+		}
+
+		s.forgetCleared(rule, candidates)
+
+		resolved, err := s.resolveCleared(rule, firing)
+		if err != nil {
+			return err
+		}
+		alerts = append(alerts, resolved...)
+
+		if len(alerts) == 0 {
+			continue
+		}
+		if err := s.alertsService.StoreAlerts(alerts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *alertRulesService) evaluateRule(rule *models.AlertRule) ([]*models.Alert, error) {
+	var alerts []*models.Alert
+
+	if rule.Condition == models.AlertRuleConditionHostHealth {
+		hosts, err := s.hostsService.GetAll(&HostsFilter{Tags: rule.Tags}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, host := range hosts {
+			if rule.ResourceID != "" && host.ID != rule.ResourceID {
+				continue
+			}
+			if host.Health != rule.Value {
+				continue
+			}
+			alerts = append(alerts, ruleAlert(rule, models.TagHostResourceType, host.ID,
+				fmt.Sprintf("Host %s health is %s", host.Name, host.Health)))
+		}
+
+		return alerts, nil
+	}
+
+	clusters, err := s.clustersService.GetAll(&ClustersFilter{Tags: rule.Tags}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var onlineHosts map[string]int
+	if rule.Condition == models.AlertRuleConditionOnlineHostsBelow {
+		onlineHosts, err = s.countOnlineHostsByCluster()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, cluster := range clusters {
+		if rule.ResourceID != "" && cluster.ID != rule.ResourceID {
+			continue
+		}
+
+		switch rule.Condition {
+		case models.AlertRuleConditionClusterHealth:
+			if cluster.Health == rule.Value {
+				alerts = append(alerts, ruleAlert(rule, models.TagClusterResourceType, cluster.ID,
+					fmt.Sprintf("Cluster %s health is %s", cluster.Name, cluster.Health)))
+			}
+		case models.AlertRuleConditionChecksFailing:
+			if cluster.CriticalCount > 0 {
+				alerts = append(alerts, ruleAlert(rule, models.TagClusterResourceType, cluster.ID,
+					fmt.Sprintf("Cluster %s has %d critical check results", cluster.Name, cluster.CriticalCount)))
+			}
+		case models.AlertRuleConditionOnlineHostsBelow:
+			if online := onlineHosts[cluster.ID]; online < rule.Threshold {
+				alerts = append(alerts, ruleAlert(rule, models.TagClusterResourceType, cluster.ID,
+					fmt.Sprintf("Cluster %s has only %d online hosts", cluster.Name, online)))
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+func (s *alertRulesService) countOnlineHostsByCluster() (map[string]int, error) {
+	hosts, err := s.hostsService.GetAll(&HostsFilter{}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	onlineHosts := make(map[string]int)
+	for _, host := range hosts {
+		if host.ClusterID == "" || host.Health != models.HostHealthPassing {
+			continue
+		}
+		onlineHosts[host.ClusterID]++
+	}
+
+	return onlineHosts, nil
+}
+
+// forgetCleared drops the pending timers of the rule conditions that no
+// longer hold, so they restart from scratch the next time they show up
+func (s *alertRulesService) forgetCleared(rule *models.AlertRule, candidates []*models.Alert) {
+	current := make(map[string]bool)
+	for _, candidate := range candidates {
+		current[candidate.Fingerprint] = true
+	}
+
+	for fingerprint := range s.pendingSince {
+		if strings.HasPrefix(fingerprint, ruleFingerprintPrefix(rule)) && !current[fingerprint] {
+			delete(s.pendingSince, fingerprint)
+		}
+	}
+}
+
+// resolveCleared turns the stored firing alerts of the rule that are not
+// firing anymore into resolutions, closing them on the channels too
+func (s *alertRulesService) resolveCleared(rule *models.AlertRule, firing map[string]bool) (models.AlertList, error) {
+	var alertEntities []entities.Alert
+
+	err := s.db.
+		Where("fingerprint LIKE ?", ruleFingerprintPrefix(rule)+"%").
+		Where("status = ?", models.AlertStatusFiring).
+		Find(&alertEntities).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved models.AlertList
+	for _, alertEntity := range alertEntities {
+		if firing[alertEntity.Fingerprint] {
+			continue
+		}
+		alert := alertEntity.ToModel()
+		alert.Status = models.AlertStatusResolved
+		resolved = append(resolved, alert)
+	}
+
+	return resolved, nil
+}
+
+func ruleFingerprintPrefix(rule *models.AlertRule) string {
+	return fmt.Sprintf("rule-%d-", rule.ID)
+}
+
+func ruleAlert(rule *models.AlertRule, resourceType string, resourceID string, summary string) *models.Alert {
+	return &models.Alert{
+		Fingerprint:  fmt.Sprintf("%s%s", ruleFingerprintPrefix(rule), resourceID),
+		Status:       models.AlertStatusFiring,
+		Name:         rule.Name,
+		Severity:     rule.Severity,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Summary:      summary,
+	}
+}
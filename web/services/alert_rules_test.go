@@ -0,0 +1,203 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type AlertRulesServiceTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	tx                  *gorm.DB
+	mockHostsService    *MockHostsService
+	mockClustersService *MockClustersService
+	alertRulesService   *alertRulesService
+}
+
+func TestAlertRulesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AlertRulesServiceTestSuite))
+}
+
+func (suite *AlertRulesServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.AlertRule{}, entities.Alert{})
+}
+
+func (suite *AlertRulesServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.AlertRule{}, entities.Alert{})
+}
+
+func (suite *AlertRulesServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.mockHostsService = new(MockHostsService)
+	suite.mockClustersService = new(MockClustersService)
+	suite.alertRulesService = NewAlertRulesService(suite.tx,
+		suite.mockHostsService, suite.mockClustersService, NewAlertsService(suite.tx, nil))
+}
+
+func (suite *AlertRulesServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Create() {
+	rule, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "cluster down",
+		Condition: models.AlertRuleConditionClusterHealth,
+	})
+	suite.NoError(err)
+	suite.NotZero(rule.ID)
+	// the severity and the matched health default to critical
+	suite.Equal("critical", rule.Severity)
+	suite.Equal("critical", rule.Value)
+
+	rules, err := suite.alertRulesService.GetAll()
+	suite.NoError(err)
+	suite.Equal(1, len(rules))
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Create_Invalid() {
+	_, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "bad",
+		Condition: "cluster_exploded",
+	})
+	suite.ErrorIs(err, ErrInvalidAlertRuleCondition)
+
+	_, err = suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "bad",
+		Condition: models.AlertRuleConditionOnlineHostsBelow,
+	})
+	suite.ErrorIs(err, ErrInvalidAlertRuleThreshold)
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Delete() {
+	rule, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "cluster down",
+		Condition: models.AlertRuleConditionClusterHealth,
+	})
+	suite.NoError(err)
+
+	suite.NoError(suite.alertRulesService.Delete(rule.ID))
+	suite.ErrorIs(suite.alertRulesService.Delete(rule.ID), ErrAlertRuleNotFound)
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Evaluate_ClusterHealth() {
+	rule, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "cluster down",
+		Condition: models.AlertRuleConditionClusterHealth,
+	})
+	suite.NoError(err)
+
+	suite.mockClustersService.On("GetAll", &ClustersFilter{}, (*Page)(nil)).Return(
+		models.ClusterList{
+			{ID: "cluster-1", Name: "hana_cluster", Health: "critical"},
+			{ID: "cluster-2", Name: "netweaver_cluster", Health: "passing"},
+		}, nil)
+
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	var alerts []entities.Alert
+	suite.tx.Find(&alerts)
+	suite.Len(alerts, 1)
+	suite.Equal(fmt.Sprintf("rule-%d-cluster-1", rule.ID), alerts[0].Fingerprint)
+	suite.Equal(models.AlertStatusFiring, alerts[0].Status)
+	suite.Equal("cluster down", alerts[0].Name)
+	suite.Equal("Cluster hana_cluster health is critical", alerts[0].Summary)
+
+	// once the cluster recovers, the alert is resolved
+	recoveredClustersService := new(MockClustersService)
+	recoveredClustersService.On("GetAll", &ClustersFilter{}, (*Page)(nil)).Return(
+		models.ClusterList{
+			{ID: "cluster-1", Name: "hana_cluster", Health: "passing"},
+		}, nil)
+	suite.alertRulesService.clustersService = recoveredClustersService
+
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	suite.tx.Find(&alerts)
+	suite.Len(alerts, 1)
+	suite.Equal(models.AlertStatusResolved, alerts[0].Status)
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Evaluate_OnlineHostsBelow() {
+	_, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "quorum at risk",
+		Condition: models.AlertRuleConditionOnlineHostsBelow,
+		Threshold: 2,
+	})
+	suite.NoError(err)
+
+	suite.mockClustersService.On("GetAll", &ClustersFilter{}, (*Page)(nil)).Return(
+		models.ClusterList{
+			{ID: "cluster-1", Name: "hana_cluster"},
+		}, nil)
+	suite.mockHostsService.On("GetAll", &HostsFilter{}, (*Page)(nil)).Return(
+		models.HostList{
+			{ID: "host-1", ClusterID: "cluster-1", Health: models.HostHealthPassing},
+			{ID: "host-2", ClusterID: "cluster-1", Health: models.HostHealthCritical},
+		}, nil)
+
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	var alerts []entities.Alert
+	suite.tx.Find(&alerts)
+	suite.Len(alerts, 1)
+	suite.Equal("Cluster hana_cluster has only 1 online hosts", alerts[0].Summary)
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Evaluate_HostHealth() {
+	_, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:      "host unreachable",
+		Condition: models.AlertRuleConditionHostHealth,
+		Tags:      []string{"prod"},
+	})
+	suite.NoError(err)
+
+	suite.mockHostsService.On("GetAll", &HostsFilter{Tags: []string{"prod"}}, (*Page)(nil)).Return(
+		models.HostList{
+			{ID: "host-1", Name: "netweaver01", Health: models.HostHealthCritical},
+		}, nil)
+
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	var alerts []entities.Alert
+	suite.tx.Find(&alerts)
+	suite.Len(alerts, 1)
+	suite.Equal("Host netweaver01 health is critical", alerts[0].Summary)
+}
+
+func (suite *AlertRulesServiceTestSuite) TestAlertRulesService_Evaluate_ForMinutes() {
+	rule, err := suite.alertRulesService.Create(&models.AlertRule{
+		Name:       "cluster down for a while",
+		Condition:  models.AlertRuleConditionClusterHealth,
+		ForMinutes: 5,
+	})
+	suite.NoError(err)
+
+	suite.mockClustersService.On("GetAll", &ClustersFilter{}, (*Page)(nil)).Return(
+		models.ClusterList{
+			{ID: "cluster-1", Name: "hana_cluster", Health: "critical"},
+		}, nil)
+
+	// the condition has to hold for 5 minutes before the alert fires
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	var alerts []entities.Alert
+	suite.tx.Find(&alerts)
+	suite.Empty(alerts)
+
+	suite.alertRulesService.pendingSince[fmt.Sprintf("rule-%d-cluster-1", rule.ID)] = time.Now().Add(-10 * time.Minute)
+
+	suite.NoError(suite.alertRulesService.Evaluate())
+
+	suite.tx.Find(&alerts)
+	suite.Len(alerts, 1)
+	suite.Equal(models.AlertStatusFiring, alerts[0].Status)
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=AlertsService --inpackage --filename=alerts_mock.go
+
+type AlertsService interface {
+	StoreAlerts(alerts models.AlertList) error
+	GetFiringByResource(resourceType string, resourceID string) (models.AlertList, error)
+}
+
+type alertsService struct {
+	db     *gorm.DB
+	router *AlertRouter
+}
+
+// NewAlertsService creates a new alerts service. The router is optional:
+// when nil, the alerts are only stored locally and not forwarded to any
+// notification channel
+func NewAlertsService(db *gorm.DB, router *AlertRouter) *alertsService {
+	return &alertsService{db: db, router: router}
+}
+
+func (s *alertsService) StoreAlerts(alerts models.AlertList) error {
+	var alertEntities []entities.Alert
+	for _, alert := range alerts {
+		alertEntities = append(alertEntities, entities.Alert{
+			Fingerprint:  alert.Fingerprint,
+			Status:       alert.Status,
+			Name:         alert.Name,
+			Severity:     alert.Severity,
+			ResourceType: alert.ResourceType,
+			ResourceID:   alert.ResourceID,
+			Summary:      alert.Summary,
+			StartsAt:     alert.StartsAt,
+		})
+	}
+
+	if alertEntities == nil {
+		return nil
+	}
+
+	err := s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&alertEntities).Error
+	if err != nil {
+		return err
+	}
+
+	if s.router != nil {
+		for _, alert := range alerts {
+			s.router.Dispatch(alert)
+		}
+	}
+
+	return nil
+}
+
+func (s *alertsService) GetFiringByResource(resourceType string, resourceID string) (models.AlertList, error) {
+	var alertEntities []entities.Alert
+
+	err := s.db.
+		Where("status = ?", models.AlertStatusFiring).
+		Where("resource_type = ?", resourceType).
+		Where("resource_id = ?", resourceID).
+		Order("starts_at").
+		Find(&alertEntities).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var alerts models.AlertList
+	for _, alertEntity := range alertEntities {
+		alerts = append(alerts, alertEntity.ToModel())
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,170 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type AlertsServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	tx            *gorm.DB
+	alertsService AlertsService
+}
+
+func TestAlertsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AlertsServiceTestSuite))
+}
+
+func (suite *AlertsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Alert{})
+}
+
+func (suite *AlertsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Alert{})
+}
+
+func (suite *AlertsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.alertsService = NewAlertsService(suite.tx, nil)
+}
+
+func (suite *AlertsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *AlertsServiceTestSuite) TestAlertsService_StoreAlerts() {
+	alerts := models.AlertList{
+		{
+			Fingerprint:  "fingerprint-1",
+			Status:       models.AlertStatusFiring,
+			Name:         "HighCPULoad",
+			Severity:     "warning",
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "agent-1",
+			Summary:      "CPU load is high",
+			StartsAt:     time.Now().Add(-1 * time.Hour),
+		},
+	}
+
+	err := suite.alertsService.StoreAlerts(alerts)
+	suite.NoError(err)
+
+	firingAlerts, err := suite.alertsService.GetFiringByResource(models.TagHostResourceType, "agent-1")
+	suite.NoError(err)
+
+	suite.Equal(1, len(firingAlerts))
+	suite.Equal("HighCPULoad", firingAlerts[0].Name)
+}
+
+func (suite *AlertsServiceTestSuite) TestAlertsService_ResolvedAlertsAreNotFiring() {
+	alerts := models.AlertList{
+		{
+			Fingerprint:  "fingerprint-1",
+			Status:       models.AlertStatusFiring,
+			Name:         "HighCPULoad",
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "agent-1",
+		},
+	}
+
+	err := suite.alertsService.StoreAlerts(alerts)
+	suite.NoError(err)
+
+	// the same alert gets resolved
+	alerts[0].Status = models.AlertStatusResolved
+	err = suite.alertsService.StoreAlerts(alerts)
+	suite.NoError(err)
+
+	firingAlerts, err := suite.alertsService.GetFiringByResource(models.TagHostResourceType, "agent-1")
+	suite.NoError(err)
+
+	suite.Equal(0, len(firingAlerts))
+}
+
+func (suite *AlertsServiceTestSuite) TestAlertsService_StoreAlerts_RoutesToChannels() {
+	criticalChannel := new(MockAlertChannel)
+	criticalChannel.On("Name").Return("critical-only").Maybe()
+	catchAllChannel := new(MockAlertChannel)
+	catchAllChannel.On("Name").Return("catch-all").Maybe()
+
+	router := NewAlertRouter(
+		ChannelRoute{Channel: criticalChannel, Severities: []string{"critical"}},
+		ChannelRoute{Channel: catchAllChannel},
+	)
+	alertsService := NewAlertsService(suite.tx, router)
+
+	firing := &models.Alert{
+		Fingerprint: "fingerprint-1",
+		Status:      models.AlertStatusFiring,
+		Name:        "ClusterDown",
+		Severity:    "critical",
+	}
+	criticalChannel.On("Trigger", firing).Return(nil)
+	catchAllChannel.On("Trigger", firing).Return(nil)
+
+	// a warning only reaches the catch-all channel
+	warning := &models.Alert{
+		Fingerprint: "fingerprint-2",
+		Status:      models.AlertStatusFiring,
+		Name:        "HighCPULoad",
+		Severity:    "warning",
+	}
+	catchAllChannel.On("Trigger", warning).Return(nil)
+
+	err := alertsService.StoreAlerts(models.AlertList{firing, warning})
+	suite.NoError(err)
+
+	// the resolution is forwarded so the external incidents close automatically
+	resolved := &models.Alert{
+		Fingerprint: "fingerprint-1",
+		Status:      models.AlertStatusResolved,
+		Name:        "ClusterDown",
+		Severity:    "critical",
+	}
+	criticalChannel.On("Resolve", resolved).Return(nil)
+	catchAllChannel.On("Resolve", resolved).Return(nil)
+
+	err = alertsService.StoreAlerts(models.AlertList{resolved})
+	suite.NoError(err)
+
+	criticalChannel.AssertExpectations(suite.T())
+	catchAllChannel.AssertExpectations(suite.T())
+	criticalChannel.AssertNotCalled(suite.T(), "Trigger", warning)
+}
+
+func (suite *AlertsServiceTestSuite) TestAlertsService_GetFiringByResourceFiltersByResource() {
+	alerts := models.AlertList{
+		{
+			Fingerprint:  "fingerprint-1",
+			Status:       models.AlertStatusFiring,
+			Name:         "HighCPULoad",
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   "agent-1",
+		},
+		{
+			Fingerprint:  "fingerprint-2",
+			Status:       models.AlertStatusFiring,
+			Name:         "ClusterDown",
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   "cluster-1",
+		},
+	}
+
+	err := suite.alertsService.StoreAlerts(alerts)
+	suite.NoError(err)
+
+	firingAlerts, err := suite.alertsService.GetFiringByResource(models.TagClusterResourceType, "cluster-1")
+	suite.NoError(err)
+
+	suite.Equal(1, len(firingAlerts))
+	suite.Equal("ClusterDown", firingAlerts[0].Name)
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// ListCache is an in-memory cache for the expensive read-model list queries.
+// Entries are kept until the cache gets invalidated by a new projected event
+type ListCache struct {
+	mu      sync.RWMutex
+	entries map[string]interface{}
+	// generation is bumped by Clear, so a value computed against the
+	// pre-invalidation read model is never stored after the invalidation
+	generation uint64
+}
+
+func NewListCache() *ListCache {
+	return &ListCache{
+		entries: make(map[string]interface{}),
+	}
+}
+
+// GetOrCompute returns the cached value for the given key, computing and
+// storing it if the key is not cached yet
+func (c *ListCache) GetOrCompute(key string, compute func() (interface{}, error)) (interface{}, error) {
+	c.mu.RLock()
+	value, hit := c.entries[key]
+	generation := c.generation
+	c.mu.RUnlock()
+	if hit {
+		return value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	// only store what was computed against the current generation: a Clear
+	// that ran in the meantime means the value may predate the event that
+	// invalidated the cache
+	if c.generation == generation {
+		c.entries[key] = value
+	}
+	c.mu.Unlock()
+
+	return value, nil
+}
+
+// Clear removes all the cached entries
+func (c *ListCache) Clear() {
+	c.mu.Lock()
+	c.entries = make(map[string]interface{})
+	c.generation++
+	c.mu.Unlock()
+}
+
+// listCacheKey builds a cache key from a prefix and the query parameters,
+// so that different filters and pages get their own entry
+func listCacheKey(prefix string, parts ...interface{}) string {
+	key := prefix
+	for _, part := range parts {
+		data, _ := json.Marshal(part)
+		key = fmt.Sprintf("%s:%s", key, data)
+	}
+
+	return key
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCacheGetOrCompute(t *testing.T) {
+	cache := NewListCache()
+	computations := 0
+
+	compute := func() (interface{}, error) {
+		computations++
+		return "value", nil
+	}
+
+	value, err := cache.GetOrCompute("key", compute)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+
+	value, err = cache.GetOrCompute("key", compute)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.Equal(t, 1, computations)
+}
+
+func TestListCacheGetOrComputeError(t *testing.T) {
+	cache := NewListCache()
+
+	_, err := cache.GetOrCompute("key", func() (interface{}, error) {
+		return nil, errors.New("kaboom")
+	})
+	assert.EqualError(t, err, "kaboom")
+
+	// errors must not be cached
+	value, err := cache.GetOrCompute("key", func() (interface{}, error) {
+		return "value", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+}
+
+func TestListCacheClear(t *testing.T) {
+	cache := NewListCache()
+	computations := 0
+
+	compute := func() (interface{}, error) {
+		computations++
+		return "value", nil
+	}
+
+	cache.GetOrCompute("key", compute)
+	cache.Clear()
+	cache.GetOrCompute("key", compute)
+
+	assert.Equal(t, 2, computations)
+}
+
+func TestListCacheClearDuringCompute(t *testing.T) {
+	cache := NewListCache()
+	computations := 0
+
+	// a Clear racing with an in-flight computation invalidates its result:
+	// the stale value must not be stored
+	cache.GetOrCompute("key", func() (interface{}, error) {
+		cache.Clear()
+		return "stale", nil
+	})
+
+	cache.GetOrCompute("key", func() (interface{}, error) {
+		computations++
+		return "fresh", nil
+	})
+
+	assert.Equal(t, 1, computations)
+}
+
+func TestListCacheKey(t *testing.T) {
+	filter := &HostsFilter{SIDs: []string{"PRD"}}
+
+	key := listCacheKey("hosts", filter, nil)
+	sameKey := listCacheKey("hosts", &HostsFilter{SIDs: []string{"PRD"}}, nil)
+	otherKey := listCacheKey("hosts", &HostsFilter{SIDs: []string{"QAS"}}, nil)
+
+	assert.Equal(t, key, sameKey)
+	assert.NotEqual(t, key, otherKey)
+}
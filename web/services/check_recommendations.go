@@ -0,0 +1,111 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/cloud"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// GetRecommendedChecksByID suggests the catalog checks applicable to a
+// cluster based on its discovered platform: the cloud provider of its
+// hosts, the fencing type and the HANA scenario. A check is recommended
+// when its provider matches the cluster one and all its tags are traits
+// the cluster exhibits; untagged checks apply to every cluster.
+// It returns nil when the cluster is not found.
+func (s *clustersService) GetRecommendedChecksByID(clusterID string) (models.ChecksCatalog, error) {
+	var cluster entities.Cluster
+
+	err := s.db.
+		Preload("Hosts").
+		Where("id = ?", clusterID).
+		First(&cluster).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	provider := clusterProvider(&cluster)
+	traits := clusterTraits(&cluster)
+
+	recommended := models.ChecksCatalog{}
+	for _, check := range catalog {
+		if check.Provider != "" && check.Provider != provider {
+			continue
+		}
+
+		applicable := true
+		for _, tag := range check.Tags {
+			if !internal.Contains(traits, tag) {
+				applicable = false
+				break
+			}
+		}
+		if applicable {
+			recommended = append(recommended, check)
+		}
+	}
+
+	return recommended, nil
+}
+
+// clusterProvider maps the cloud provider discovered on the cluster hosts
+// to the catalog provider names
+func clusterProvider(cluster *entities.Cluster) string {
+	for _, host := range cluster.Hosts {
+		switch host.CloudProvider {
+		case cloud.Azure:
+			return models.CheckProviderAzure
+		case cloud.Aws:
+			return models.CheckProviderAws
+		}
+	}
+
+	return models.CheckProviderOnPrem
+}
+
+// clusterTraits derives the platform traits the catalog tags are matched
+// against from the discovered cluster facts
+func clusterTraits(cluster *entities.Cluster) []string {
+	var traits []string
+
+	switch cluster.ClusterType {
+	case models.ClusterTypeHANAScaleUp:
+		traits = append(traits, "hana", "hana-scale-up")
+	case models.ClusterTypeHANAScaleOut:
+		traits = append(traits, "hana", "hana-scale-out")
+	case models.ClusterTypeASCSERS:
+		traits = append(traits, "ascs-ers")
+	}
+
+	if cluster.FencingType != "" {
+		traits = append(traits, "fencing")
+		if strings.Contains(cluster.FencingType, "sbd") {
+			traits = append(traits, "sbd")
+		}
+	}
+
+	var details entities.HANAClusterDetails
+	if err := json.Unmarshal(cluster.Details, &details); err == nil {
+		if details.SystemReplicationMode != "" {
+			traits = append(traits, "system-replication")
+		}
+	}
+
+	return traits
+}
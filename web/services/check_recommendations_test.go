@@ -0,0 +1,54 @@
+package services
+
+import (
+	"encoding/json"
+
+	"github.com/trento-project/trento/internal/cloud"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetRecommendedChecksByID() {
+	details, _ := json.Marshal(&entities.HANAClusterDetails{
+		SystemReplicationMode: "sync",
+	})
+
+	suite.tx.Create(&entities.Cluster{
+		ID:          "azure-cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		FencingType: "external/sbd",
+		Hosts: []*entities.Host{
+			{
+				AgentID:       "azure-host",
+				ClusterID:     "azure-cluster",
+				Name:          "azure-host",
+				CloudProvider: cloud.Azure,
+			},
+		},
+		Details: details,
+	})
+
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		&models.Check{ID: "GENERIC"},
+		&models.Check{ID: "AZURE_SBD", Provider: models.CheckProviderAzure, Tags: []string{"sbd"}},
+		&models.Check{ID: "AZURE_SCALE_OUT", Provider: models.CheckProviderAzure, Tags: []string{"hana-scale-out"}},
+		&models.Check{ID: "AWS_ONLY", Provider: models.CheckProviderAws},
+		&models.Check{ID: "HANA_SR", Tags: []string{"hana", "system-replication"}},
+	}, nil)
+
+	recommended, err := suite.clustersService.GetRecommendedChecksByID("azure-cluster")
+
+	suite.NoError(err)
+	var ids []string
+	for _, check := range recommended {
+		ids = append(ids, check.ID)
+	}
+	// the scale-out and aws specific checks do not apply to an azure
+	// scale-up cluster
+	suite.ElementsMatch([]string{"GENERIC", "AZURE_SBD", "HANA_SR"}, ids)
+
+	recommended, err = suite.clustersService.GetRecommendedChecksByID("not_found")
+	suite.NoError(err)
+	suite.Nil(recommended)
+}
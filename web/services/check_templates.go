@@ -0,0 +1,229 @@
+package services
+
+import (
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=CheckTemplatesService --inpackage --filename=check_templates_mock.go
+
+// ErrCheckTemplateNotFound is returned when the referenced check
+// selection template does not exist
+var ErrCheckTemplateNotFound = errors.New("check selection template not found")
+
+type CheckTemplatesService interface {
+	GetAll() ([]*models.CheckSelectionTemplate, error)
+	Create(template *models.CheckSelectionTemplate) error
+	Delete(id int64) error
+	// Apply selects the template checks on the matching clusters that do
+	// not have a check selection yet
+	Apply() error
+	GetDivergences() ([]*models.CheckTemplateDivergence, error)
+}
+
+type checkTemplatesService struct {
+	db            *gorm.DB
+	checksService ChecksService
+}
+
+func NewCheckTemplatesService(db *gorm.DB, checksService ChecksService) *checkTemplatesService {
+	return &checkTemplatesService{
+		db:            db,
+		checksService: checksService,
+	}
+}
+
+func (s *checkTemplatesService) GetAll() ([]*models.CheckSelectionTemplate, error) {
+	var templates []entities.CheckSelectionTemplate
+
+	err := s.db.Order("name").Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	templateModels := []*models.CheckSelectionTemplate{}
+	for _, template := range templates {
+		templateModels = append(templateModels, template.ToModel())
+	}
+
+	return templateModels, nil
+}
+
+func (s *checkTemplatesService) Create(template *models.CheckSelectionTemplate) error {
+	templateEntity := entities.CheckSelectionTemplate{
+		Name:           template.Name,
+		Provider:       template.Provider,
+		ClusterType:    template.ClusterType,
+		Tags:           template.Tags,
+		SelectedChecks: template.SelectedChecks,
+	}
+
+	err := s.db.Create(&templateEntity).Error
+	if err != nil {
+		return err
+	}
+
+	template.ID = templateEntity.ID
+
+	return nil
+}
+
+func (s *checkTemplatesService) Delete(id int64) error {
+	result := s.db.Where("id = ?", id).Delete(&entities.CheckSelectionTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrCheckTemplateNotFound
+	}
+
+	return nil
+}
+
+func (s *checkTemplatesService) Apply() error {
+	templates, clusters, err := s.loadTemplatesAndClusters()
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		template := matchTemplate(templates, cluster)
+		if template == nil {
+			continue
+		}
+
+		hasSelection, err := s.hasCheckSelection(cluster.ID)
+		if err != nil {
+			return err
+		}
+		if hasSelection {
+			continue
+		}
+
+		err = s.checksService.CreateSelectedChecks(cluster.ID, template.SelectedChecks)
+		if err != nil {
+			return err
+		}
+		log.Infof("Applied check selection template %s to cluster %s", template.Name, cluster.ID)
+	}
+
+	return nil
+}
+
+func (s *checkTemplatesService) GetDivergences() ([]*models.CheckTemplateDivergence, error) {
+	templates, clusters, err := s.loadTemplatesAndClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	divergences := []*models.CheckTemplateDivergence{}
+	for _, cluster := range clusters {
+		template := matchTemplate(templates, cluster)
+		if template == nil {
+			continue
+		}
+
+		selectedChecks, err := s.checksService.GetSelectedChecksById(cluster.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var missing, additional []string
+		for _, check := range template.SelectedChecks {
+			if !internal.Contains(selectedChecks.SelectedChecks, check) {
+				missing = append(missing, check)
+			}
+		}
+		for _, check := range selectedChecks.SelectedChecks {
+			if !internal.Contains(template.SelectedChecks, check) {
+				additional = append(additional, check)
+			}
+		}
+
+		if len(missing) == 0 && len(additional) == 0 {
+			continue
+		}
+
+		divergences = append(divergences, &models.CheckTemplateDivergence{
+			ClusterID:        cluster.ID,
+			ClusterName:      cluster.Name,
+			TemplateID:       template.ID,
+			TemplateName:     template.Name,
+			MissingChecks:    missing,
+			AdditionalChecks: additional,
+		})
+	}
+
+	return divergences, nil
+}
+
+func (s *checkTemplatesService) loadTemplatesAndClusters() ([]*models.CheckSelectionTemplate, []*entities.Cluster, error) {
+	var templates []entities.CheckSelectionTemplate
+	err := s.db.Order("id").Find(&templates).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templateModels := make([]*models.CheckSelectionTemplate, 0, len(templates))
+	for _, template := range templates {
+		templateModels = append(templateModels, template.ToModel())
+	}
+
+	var clusters []*entities.Cluster
+	err = s.db.Preload("Hosts").Preload("Tags").Find(&clusters).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return templateModels, clusters, nil
+}
+
+func (s *checkTemplatesService) hasCheckSelection(clusterID string) (bool, error) {
+	var count int64
+
+	err := s.db.Model(&models.SelectedChecks{}).Where("id = ?", clusterID).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// matchTemplate returns the first template whose criteria the cluster
+// satisfies, in creation order
+func matchTemplate(templates []*models.CheckSelectionTemplate, cluster *entities.Cluster) *models.CheckSelectionTemplate {
+	var tags []string
+	for _, tag := range cluster.Tags {
+		tags = append(tags, tag.Value)
+	}
+	provider := clusterProvider(cluster)
+
+	for _, template := range templates {
+		if template.Provider != "" && template.Provider != provider {
+			continue
+		}
+		if template.ClusterType != "" && template.ClusterType != cluster.ClusterType {
+			continue
+		}
+
+		matches := true
+		for _, tag := range template.Tags {
+			if !internal.Contains(tags, tag) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return template
+		}
+	}
+
+	return nil
+}
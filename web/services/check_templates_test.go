@@ -0,0 +1,168 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/cloud"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type CheckTemplatesServiceTestSuite struct {
+	suite.Suite
+	db                    *gorm.DB
+	tx                    *gorm.DB
+	checksService         *MockChecksService
+	checkTemplatesService *checkTemplatesService
+}
+
+func TestCheckTemplatesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CheckTemplatesServiceTestSuite))
+}
+
+func (suite *CheckTemplatesServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.CheckSelectionTemplate{}, entities.Cluster{}, entities.Host{},
+		models.Tag{}, models.SelectedChecks{},
+	)
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.CheckSelectionTemplate{}, entities.Cluster{}, entities.Host{},
+		models.Tag{}, models.SelectedChecks{},
+	)
+}
+
+func (suite *CheckTemplatesServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.checksService = new(MockChecksService)
+	suite.checkTemplatesService = NewCheckTemplatesService(suite.tx, suite.checksService)
+
+	suite.tx.Create(&entities.Cluster{
+		ID:          "azure-cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Hosts: []*entities.Host{
+			{
+				AgentID:       "azure-host",
+				ClusterID:     "azure-cluster",
+				Name:          "azure-host",
+				CloudProvider: cloud.Azure,
+			},
+		},
+	})
+	suite.tx.Create(&entities.Cluster{
+		ID:          "onprem-cluster",
+		Name:        "ascs_cluster",
+		ClusterType: models.ClusterTypeASCSERS,
+	})
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TestCheckTemplatesService_CreateAndGetAll() {
+	template := &models.CheckSelectionTemplate{
+		Name:           "Azure HANA scale-up production",
+		Provider:       models.CheckProviderAzure,
+		ClusterType:    models.ClusterTypeHANAScaleUp,
+		Tags:           []string{"prod"},
+		SelectedChecks: []string{"ABCDEF", "123456"},
+	}
+
+	err := suite.checkTemplatesService.Create(template)
+	suite.NoError(err)
+	suite.NotZero(template.ID)
+
+	templates, err := suite.checkTemplatesService.GetAll()
+	suite.NoError(err)
+	suite.Len(templates, 1)
+	suite.Equal(template, templates[0])
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TestCheckTemplatesService_Delete() {
+	template := &models.CheckSelectionTemplate{
+		Name:           "to be deleted",
+		SelectedChecks: []string{"ABCDEF"},
+	}
+	suite.NoError(suite.checkTemplatesService.Create(template))
+
+	suite.NoError(suite.checkTemplatesService.Delete(template.ID))
+
+	err := suite.checkTemplatesService.Delete(template.ID)
+	suite.ErrorIs(err, ErrCheckTemplateNotFound)
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TestCheckTemplatesService_Apply() {
+	suite.NoError(suite.checkTemplatesService.Create(&models.CheckSelectionTemplate{
+		Name:           "azure scale-up",
+		Provider:       models.CheckProviderAzure,
+		ClusterType:    models.ClusterTypeHANAScaleUp,
+		SelectedChecks: []string{"ABCDEF", "123456"},
+	}))
+
+	suite.checksService.On(
+		"CreateSelectedChecks", "azure-cluster", []string{"ABCDEF", "123456"},
+	).Return(nil).Once()
+
+	suite.NoError(suite.checkTemplatesService.Apply())
+
+	// the on-prem cluster does not match the template criteria
+	suite.checksService.AssertNotCalled(
+		suite.T(), "CreateSelectedChecks", "onprem-cluster", []string{"ABCDEF", "123456"})
+
+	// a cluster that already has a selection is left alone
+	suite.tx.Create(&models.SelectedChecks{
+		ID:             "azure-cluster",
+		SelectedChecks: []string{"ABCDEF"},
+	})
+
+	suite.NoError(suite.checkTemplatesService.Apply())
+
+	suite.checksService.AssertNumberOfCalls(suite.T(), "CreateSelectedChecks", 1)
+	suite.checksService.AssertExpectations(suite.T())
+}
+
+func (suite *CheckTemplatesServiceTestSuite) TestCheckTemplatesService_GetDivergences() {
+	suite.NoError(suite.checkTemplatesService.Create(&models.CheckSelectionTemplate{
+		Name:           "azure scale-up",
+		Provider:       models.CheckProviderAzure,
+		SelectedChecks: []string{"ABCDEF", "123456"},
+	}))
+
+	suite.checksService.On("GetSelectedChecksById", "azure-cluster").Return(
+		models.SelectedChecks{
+			ID:             "azure-cluster",
+			SelectedChecks: []string{"ABCDEF", "FEDCBA"},
+		}, nil,
+	)
+
+	divergences, err := suite.checkTemplatesService.GetDivergences()
+
+	suite.NoError(err)
+	suite.Len(divergences, 1)
+	suite.Equal("azure-cluster", divergences[0].ClusterID)
+	suite.Equal("azure scale-up", divergences[0].TemplateName)
+	suite.Equal([]string{"123456"}, divergences[0].MissingChecks)
+	suite.Equal([]string{"FEDCBA"}, divergences[0].AdditionalChecks)
+
+	// a cluster whose selection matches its template does not show up
+	suite.checksService.ExpectedCalls = nil
+	suite.checksService.On("GetSelectedChecksById", "azure-cluster").Return(
+		models.SelectedChecks{
+			ID:             "azure-cluster",
+			SelectedChecks: []string{"123456", "ABCDEF"},
+		}, nil,
+	)
+
+	divergences, err = suite.checkTemplatesService.GetDivergences()
+	suite.NoError(err)
+	suite.Empty(divergences)
+}
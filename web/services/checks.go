@@ -1,13 +1,17 @@
 package services
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -29,9 +33,17 @@ type ChecksService interface {
 	CreateChecksResult(checksResult *models.ChecksResult) error
 	GetLastExecutionByGroup() ([]*models.ChecksResult, error)
 	GetChecksResultByCluster(clusterId string) (*models.ChecksResult, error)
+	GetChecksResultsDiff(clusterId string, fromId, toId int64) (*models.ChecksResultsDiff, error)
 	GetChecksResultAndMetadataByCluster(clusterId string) (*models.ChecksResultAsList, error)
+	GetChecksResultAndMetadataByHost(clusterId, host string) (models.ChecksCatalog, error)
 	GetAggregatedChecksResultByHost(clusterId string) (map[string]*models.AggregatedCheckData, error)
 	GetAggregatedChecksResultByCluster(clusterId string) (*models.AggregatedCheckData, error)
+	// Checks execution services
+	RequestChecksExecution(clusterId, actor string) (*models.ChecksExecution, error)
+	StartChecksExecution(id int64) error
+	FailChecksExecution(id int64) error
+	GetChecksExecutionByID(id int64) (*models.ChecksExecution, error)
+	GetChecksExecutionsByCluster(clusterId string) ([]*models.ChecksExecution, error)
 	// Selected checks services
 	GetSelectedChecksById(id string) (models.SelectedChecks, error)
 	CreateSelectedChecks(id string, selectedChecksList []string) error
@@ -44,12 +56,22 @@ type ChecksService interface {
 type checksService struct {
 	db                      *gorm.DB
 	premiumDetectionService PremiumDetectionService
+	alertsService           AlertsService
+	eventsService           EventsService
+	settingsService         SettingsService
+	trashService            TrashService
 }
 
-func NewChecksService(db *gorm.DB, premiumDetectionService PremiumDetectionService) *checksService {
+func NewChecksService(db *gorm.DB, premiumDetectionService PremiumDetectionService,
+	alertsService AlertsService, eventsService EventsService,
+	settingsService SettingsService, trashService TrashService) *checksService {
 	return &checksService{
 		db:                      db,
 		premiumDetectionService: premiumDetectionService,
+		alertsService:           alertsService,
+		eventsService:           eventsService,
+		settingsService:         settingsService,
+		trashService:            trashService,
 	}
 }
 
@@ -144,6 +166,11 @@ Checks result services
 */
 
 func (c *checksService) CreateChecksResult(checksResult *models.ChecksResult) error {
+	previousResult, err := c.GetChecksResultByCluster(checksResult.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
 	jsonData, err := json.Marshal(&checksResult)
 	if err != nil {
 		return err
@@ -167,7 +194,135 @@ func (c *checksService) CreateChecksResult(checksResult *models.ChecksResult) er
 		return err
 	}
 
-	return nil
+	if previousResult != nil {
+		if err := c.emitRegressionAlerts(checksResult.ID, previousResult, checksResult); err != nil {
+			return err
+		}
+	}
+
+	return c.completeChecksExecutions(checksResult.ID, checksResult.Durations)
+}
+
+// completeChecksExecutions marks the pending executions of the cluster as
+// completed, as the runner just delivered a fresh result. If none is pending,
+// a completed execution is recorded anyway, so that regular runner cycles are
+// tracked like the on-demand ones.
+func (c *checksService) completeChecksExecutions(clusterId string, durations map[string]float64) error {
+	var durationsJson datatypes.JSON
+	if durations != nil {
+		jsonData, err := json.Marshal(durations)
+		if err != nil {
+			return err
+		}
+		durationsJson = jsonData
+	}
+
+	now := time.Now()
+	result := c.db.Model(&entities.ChecksExecution{}).
+		Where("cluster_id", clusterId).
+		Where("status IN ?", []string{models.ExecutionStatusRequested, models.ExecutionStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":       models.ExecutionStatusCompleted,
+			"completed_at": &now,
+			"durations":    durationsJson,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	execution := entities.ChecksExecution{
+		ClusterID:   clusterId,
+		Status:      models.ExecutionStatusCompleted,
+		Actor:       models.ExecutionActorRunner,
+		CompletedAt: &now,
+		Durations:   durationsJson,
+	}
+
+	return c.db.Create(&execution).Error
+}
+
+// emitRegressionAlerts feeds the alerting subsystem with a firing alert for
+// every check that was passing in the previous execution and is not anymore,
+// and records each regression in the cluster timeline
+func (c *checksService) emitRegressionAlerts(clusterId string, previousResult, currentResult *models.ChecksResult) error {
+	var alerts models.AlertList
+
+	for _, change := range previousResult.Diff(currentResult) {
+		if !change.IsRegression() {
+			continue
+		}
+
+		fingerprint := fmt.Sprintf("%x",
+			sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", clusterId, change.CheckID, change.Host))))
+
+		summary := fmt.Sprintf("Check %s on host %s regressed from %s to %s",
+			change.CheckID, change.Host, change.Previous, change.Current)
+
+		alerts = append(alerts, &models.Alert{
+			Fingerprint:  fingerprint,
+			Status:       models.AlertStatusFiring,
+			Name:         "check_regression",
+			Severity:     change.Current,
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterId,
+			Summary:      summary,
+			StartsAt:     time.Now(),
+		})
+
+		err := c.eventsService.StoreEvent(&models.ResourceEvent{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   clusterId,
+			Type:         models.ResourceEventTypeCheckRegression,
+			Message:      summary,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.alertsService.StoreAlerts(alerts)
+}
+
+func (c *checksService) GetChecksResultsDiff(clusterId string, fromId, toId int64) (*models.ChecksResultsDiff, error) {
+	var toEntity entities.ChecksResult
+	toQuery := c.db.Where("group_id", clusterId)
+	if toId != 0 {
+		toQuery = toQuery.Where("id", toId)
+	}
+	if err := toQuery.Last(&toEntity).Error; err != nil {
+		return nil, err
+	}
+
+	var fromEntity entities.ChecksResult
+	fromQuery := c.db.Where("group_id", clusterId)
+	if fromId != 0 {
+		fromQuery = fromQuery.Where("id", fromId)
+	} else {
+		fromQuery = fromQuery.Where("id < ?", toEntity.ID)
+	}
+	if err := fromQuery.Last(&fromEntity).Error; err != nil {
+		return nil, err
+	}
+
+	fromResult, err := fromEntity.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	toResult, err := toEntity.ToModel()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ChecksResultsDiff{
+		FromID:  fromEntity.ID,
+		ToID:    toEntity.ID,
+		Changes: fromResult.Diff(toResult),
+	}, nil
 }
 
 func (c *checksService) GetLastExecutionByGroup() ([]*models.ChecksResult, error) {
@@ -233,6 +388,45 @@ func (c *checksService) GetChecksResultAndMetadataByCluster(clusterId string) (*
 	return resultSet, nil
 }
 
+// GetChecksResultAndMetadataByHost returns the latest checks results of a
+// single host of the cluster, enriched with the catalog metadata
+func (c *checksService) GetChecksResultAndMetadataByHost(clusterId, host string) (models.ChecksCatalog, error) {
+	cResultByCluster, err := c.GetChecksResultByCluster(clusterId)
+	if err != nil {
+		return nil, err
+	}
+
+	checkList, err := c.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	hostResults := models.ChecksCatalog{}
+
+	for _, checkMeta := range checkList {
+		checkByHost, ok := cResultByCluster.Checks[checkMeta.ID]
+		if !ok {
+			continue
+		}
+
+		hostCheck, ok := checkByHost.Hosts[host]
+		if !ok {
+			continue
+		}
+
+		hostResults = append(hostResults, &models.Check{
+			ID:          checkMeta.ID,
+			Name:        checkMeta.Name,
+			Group:       checkMeta.Group,
+			Description: checkMeta.Description,
+			Result:      hostCheck.Result,
+			Msg:         hostCheck.Msg,
+		})
+	}
+
+	return hostResults, nil
+}
+
 func (c *checksService) GetAggregatedChecksResultByHost(clusterId string) (map[string]*models.AggregatedCheckData, error) {
 	cResultByCluster, err := c.GetChecksResultByCluster(clusterId)
 	if err != nil {
@@ -251,6 +445,102 @@ func (c *checksService) GetAggregatedChecksResultByCluster(clusterId string) (*m
 	return cResultByCluster.GetAggregatedChecksResultByCluster(), nil
 }
 
+/*
+Checks execution services
+*/
+
+// RequestChecksExecution enqueues an immediate check run for the cluster.
+// If one is already pending it is returned instead of piling up a new one.
+func (c *checksService) RequestChecksExecution(clusterId, actor string) (*models.ChecksExecution, error) {
+	var execution entities.ChecksExecution
+
+	err := c.db.
+		Where("cluster_id", clusterId).
+		Where("status IN ?", []string{models.ExecutionStatusRequested, models.ExecutionStatusRunning}).
+		Last(&execution).
+		Error
+	if err == nil {
+		return execution.ToModel(), nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	execution = entities.ChecksExecution{
+		ClusterID: clusterId,
+		Status:    models.ExecutionStatusRequested,
+		Actor:     actor,
+	}
+	if err := c.db.Create(&execution).Error; err != nil {
+		return nil, err
+	}
+
+	return execution.ToModel(), nil
+}
+
+// StartChecksExecution moves a requested execution to the running state,
+// recording when the runner picked it up
+func (c *checksService) StartChecksExecution(id int64) error {
+	now := time.Now()
+
+	return c.db.Model(&entities.ChecksExecution{}).
+		Where("id", id).
+		Updates(map[string]interface{}{
+			"status":     models.ExecutionStatusRunning,
+			"started_at": &now,
+		}).
+		Error
+}
+
+// FailChecksExecution marks an execution the runner could not complete
+func (c *checksService) FailChecksExecution(id int64) error {
+	now := time.Now()
+
+	return c.db.Model(&entities.ChecksExecution{}).
+		Where("id", id).
+		Updates(map[string]interface{}{
+			"status":       models.ExecutionStatusFailed,
+			"completed_at": &now,
+		}).
+		Error
+}
+
+func (c *checksService) GetChecksExecutionByID(id int64) (*models.ChecksExecution, error) {
+	var execution entities.ChecksExecution
+
+	err := c.db.Where("id", id).First(&execution).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return execution.ToModel(), nil
+}
+
+// GetChecksExecutionsByCluster returns the executions of a cluster, the most
+// recent one first
+func (c *checksService) GetChecksExecutionsByCluster(clusterId string) ([]*models.ChecksExecution, error) {
+	var executions []entities.ChecksExecution
+
+	err := c.db.
+		Where("cluster_id", clusterId).
+		Order("id desc").
+		Find(&executions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	executionModels := []*models.ChecksExecution{}
+	for _, execution := range executions {
+		executionModels = append(executionModels, execution.ToModel())
+	}
+
+	return executionModels, nil
+}
+
 /*
 Selected checks services
 */
@@ -298,6 +588,20 @@ func (c *checksService) CreateSelectedChecks(id string, selectedChecksList []str
 		SelectedChecks: selectedChecksList,
 	}
 
+	// overwriting a checks selection destroys the curated one, so snapshot
+	// the previous selection into the trash before replacing it
+	var previous models.SelectedChecks
+	err := c.db.Where("id = ?", id).First(&previous).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if err == nil {
+		err = c.trashService.Store(TrashResourceTypeCheckSettings, id, &previous)
+		if err != nil {
+			return err
+		}
+	}
+
 	result := c.db.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(&selectedChecks)
@@ -313,8 +617,11 @@ func (c *checksService) GetConnectionSettingsByNode(node string) (models.Connect
 	var connUser models.ConnectionSettings
 
 	result := c.db.Where("node", node).First(&connUser)
+	if result.Error != nil {
+		return connUser, result.Error
+	}
 
-	return connUser, result.Error
+	return c.openConnectionSettings(connUser)
 }
 
 func (c *checksService) GetConnectionSettingsById(id string) (map[string]models.ConnectionSettings, error) {
@@ -328,17 +635,31 @@ func (c *checksService) GetConnectionSettingsById(id string) (map[string]models.
 
 	connUsersMap := make(map[string]models.ConnectionSettings)
 	for _, user := range connUsersList {
-		connUsersMap[user.Node] = user
+		openedUser, err := c.openConnectionSettings(user)
+		if err != nil {
+			return nil, err
+		}
+		connUsersMap[user.Node] = openedUser
 	}
 
 	return connUsersMap, nil
 }
 
 func (c *checksService) CreateConnectionSettings(id, node, user string) error {
+	sealer, err := c.getSealer()
+	if err != nil {
+		return err
+	}
+
+	sealedUser, err := sealer.Seal(user)
+	if err != nil {
+		return err
+	}
+
 	connUser := models.ConnectionSettings{
 		ID:   id,
 		Node: node,
-		User: user,
+		User: sealedUser,
 	}
 
 	result := c.db.Clauses(clause.OnConflict{
@@ -347,3 +668,34 @@ func (c *checksService) CreateConnectionSettings(id, node, user string) error {
 
 	return result.Error
 }
+
+// openConnectionSettings decrypts the sensitive fields of a stored connection
+// settings row. Rows persisted before encryption at rest was introduced are
+// passed through as they are.
+func (c *checksService) openConnectionSettings(connUser models.ConnectionSettings) (models.ConnectionSettings, error) {
+	if !secrets.IsSealed(connUser.User) {
+		return connUser, nil
+	}
+
+	sealer, err := c.getSealer()
+	if err != nil {
+		return connUser, err
+	}
+
+	user, err := sealer.Open(connUser.User)
+	if err != nil {
+		return connUser, err
+	}
+	connUser.User = user
+
+	return connUser, nil
+}
+
+func (c *checksService) getSealer() (*secrets.Sealer, error) {
+	encryptionKey, err := c.settingsService.GetEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return secrets.NewSealer(encryptionKey), nil
+}
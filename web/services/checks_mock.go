@@ -82,6 +82,20 @@ func (_m *MockChecksService) CreateSelectedChecks(id string, selectedChecksList
 	return r0
 }
 
+// FailChecksExecution provides a mock function with given fields: id
+func (_m *MockChecksService) FailChecksExecution(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAggregatedChecksResultByCluster provides a mock function with given fields: clusterId
 func (_m *MockChecksService) GetAggregatedChecksResultByCluster(clusterId string) (*models.AggregatedCheckData, error) {
 	ret := _m.Called(clusterId)
@@ -174,6 +188,52 @@ func (_m *MockChecksService) GetChecksCatalogByGroup() (models.GroupedCheckList,
 	return r0, r1
 }
 
+// GetChecksExecutionByID provides a mock function with given fields: id
+func (_m *MockChecksService) GetChecksExecutionByID(id int64) (*models.ChecksExecution, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.ChecksExecution
+	if rf, ok := ret.Get(0).(func(int64) *models.ChecksExecution); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecksExecutionsByCluster provides a mock function with given fields: clusterId
+func (_m *MockChecksService) GetChecksExecutionsByCluster(clusterId string) ([]*models.ChecksExecution, error) {
+	ret := _m.Called(clusterId)
+
+	var r0 []*models.ChecksExecution
+	if rf, ok := ret.Get(0).(func(string) []*models.ChecksExecution); ok {
+		r0 = rf(clusterId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ChecksExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChecksResultAndMetadataByCluster provides a mock function with given fields: clusterId
 func (_m *MockChecksService) GetChecksResultAndMetadataByCluster(clusterId string) (*models.ChecksResultAsList, error) {
 	ret := _m.Called(clusterId)
@@ -197,6 +257,29 @@ func (_m *MockChecksService) GetChecksResultAndMetadataByCluster(clusterId strin
 	return r0, r1
 }
 
+// GetChecksResultAndMetadataByHost provides a mock function with given fields: clusterId, host
+func (_m *MockChecksService) GetChecksResultAndMetadataByHost(clusterId string, host string) (models.ChecksCatalog, error) {
+	ret := _m.Called(clusterId, host)
+
+	var r0 models.ChecksCatalog
+	if rf, ok := ret.Get(0).(func(string, string) models.ChecksCatalog); ok {
+		r0 = rf(clusterId, host)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.ChecksCatalog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(clusterId, host)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetChecksResultByCluster provides a mock function with given fields: clusterId
 func (_m *MockChecksService) GetChecksResultByCluster(clusterId string) (*models.ChecksResult, error) {
 	ret := _m.Called(clusterId)
@@ -220,6 +303,29 @@ func (_m *MockChecksService) GetChecksResultByCluster(clusterId string) (*models
 	return r0, r1
 }
 
+// GetChecksResultsDiff provides a mock function with given fields: clusterId, fromId, toId
+func (_m *MockChecksService) GetChecksResultsDiff(clusterId string, fromId int64, toId int64) (*models.ChecksResultsDiff, error) {
+	ret := _m.Called(clusterId, fromId, toId)
+
+	var r0 *models.ChecksResultsDiff
+	if rf, ok := ret.Get(0).(func(string, int64, int64) *models.ChecksResultsDiff); ok {
+		r0 = rf(clusterId, fromId, toId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksResultsDiff)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64, int64) error); ok {
+		r1 = rf(clusterId, fromId, toId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConnectionSettingsById provides a mock function with given fields: id
 func (_m *MockChecksService) GetConnectionSettingsById(id string) (map[string]models.ConnectionSettings, error) {
 	ret := _m.Called(id)
@@ -307,3 +413,40 @@ func (_m *MockChecksService) GetSelectedChecksById(id string) (models.SelectedCh
 
 	return r0, r1
 }
+
+// RequestChecksExecution provides a mock function with given fields: clusterId, actor
+func (_m *MockChecksService) RequestChecksExecution(clusterId string, actor string) (*models.ChecksExecution, error) {
+	ret := _m.Called(clusterId, actor)
+
+	var r0 *models.ChecksExecution
+	if rf, ok := ret.Get(0).(func(string, string) *models.ChecksExecution); ok {
+		r0 = rf(clusterId, actor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ChecksExecution)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(clusterId, actor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartChecksExecution provides a mock function with given fields: id
+func (_m *MockChecksService) StartChecksExecution(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
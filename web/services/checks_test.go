@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/test/helpers"
 
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 )
@@ -69,7 +70,9 @@ func (suite *ChecksServiceTestSuite) SetupSuite() {
 
 	suite.db.AutoMigrate(
 		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
-		models.ConnectionSettings{}, entities.HealthState{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.ResourceEvent{}, entities.Settings{},
+		entities.TrashEntry{},
 	)
 	loadChecksCatalogFixtures(suite.db)
 	loadChecksResultFixtures(suite.db)
@@ -80,13 +83,15 @@ func (suite *ChecksServiceTestSuite) SetupSuite() {
 func (suite *ChecksServiceTestSuite) TearDownSuite() {
 	suite.db.Migrator().DropTable(
 		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
-		models.ConnectionSettings{}, entities.HealthState{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.ResourceEvent{}, entities.Settings{},
+		entities.TrashEntry{},
 	)
 }
 
 func (suite *ChecksServiceTestSuite) SetupTest() {
 	suite.tx = suite.db.Begin()
-	suite.checksService = NewChecksService(suite.tx, suite.premiumDetection)
+	suite.checksService = NewChecksService(suite.tx, suite.premiumDetection, NewAlertsService(suite.tx, nil), NewEventsService(suite.tx, nil), NewSettingsService(suite.tx), NewTrashService(suite.tx))
 }
 
 func (suite *ChecksServiceTestSuite) TearDownTest() {
@@ -535,6 +540,12 @@ func (suite *ChecksServiceTestSuite) TestChecksService_CreateSelectedChecks() {
 
 	suite.NoError(err)
 	suite.Equal(expectedValue, selectedChecks)
+
+	// the overwritten selection was snapshotted into the trash
+	var entry entities.TrashEntry
+	suite.NoError(suite.tx.Where("resource_type", TrashResourceTypeCheckSettings).First(&entry).Error)
+	suite.Equal("group4", entry.ResourceID)
+	suite.JSONEq(`{"ID": "group4", "SelectedChecks": ["FEDCBA", "ABCDEF"]}`, string(entry.Payload))
 }
 
 func (suite *ChecksServiceTestSuite) TestChecksService_GetConnectionSettingsByNode() {
@@ -582,21 +593,271 @@ func (suite *ChecksServiceTestSuite) TestChecksService_GetConnectionSettingsById
 
 func (suite *ChecksServiceTestSuite) TestChecksService_CreateConnectionSettings() {
 	err := suite.checksService.CreateConnectionSettings("group4", "node4", "user4")
+	suite.NoError(err)
 
+	// The user is sealed at rest
 	var data models.ConnectionSettings
-
 	suite.tx.Where("id", "group4").First(&data)
-	expectedValue := models.ConnectionSettings{ID: "group4", Node: "node4", User: "user4"}
+	suite.True(secrets.IsSealed(data.User))
+	suite.NotContains(data.User, "user4")
 
+	// And transparently opened when read back
+	settings, err := suite.checksService.GetConnectionSettingsByNode("node4")
 	suite.NoError(err)
-	suite.Equal(expectedValue, data)
+	suite.Equal(models.ConnectionSettings{ID: "group4", Node: "node4", User: "user4"}, settings)
 
 	// Check if an update works
 	err = suite.checksService.CreateConnectionSettings("group4", "node4", "user5")
+	suite.NoError(err)
 
-	suite.tx.Where("id", "group4").First(&data)
-	expectedValue = models.ConnectionSettings{ID: "group4", Node: "node4", User: "user5"}
+	settings, err = suite.checksService.GetConnectionSettingsByNode("node4")
+	suite.NoError(err)
+	suite.Equal(models.ConnectionSettings{ID: "group4", Node: "node4", User: "user5"}, settings)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_CreateChecksResultEmitsRegressionAlerts() {
+	results := &models.ChecksResult{
+		ID: "group1",
+		Hosts: map[string]*models.HostState{
+			"host1": &models.HostState{
+				Reachable: true,
+			},
+			"host2": &models.HostState{
+				Reachable: true,
+			},
+		},
+		Checks: map[string]*models.ChecksByHost{
+			"check1": &models.ChecksByHost{
+				Hosts: map[string]*models.Check{
+					"host1": &models.Check{
+						Result: models.CheckCritical,
+					},
+					"host2": &models.Check{
+						Result: models.CheckPassing,
+					},
+				},
+			},
+		},
+	}
+
+	err := suite.checksService.CreateChecksResult(results)
+	suite.NoError(err)
+
+	var alerts []entities.Alert
+	err = suite.tx.Order("summary").Find(&alerts).Error
+	suite.NoError(err)
+	suite.Len(alerts, 1)
+	suite.Equal("check_regression", alerts[0].Name)
+	suite.Equal(models.AlertStatusFiring, alerts[0].Status)
+	suite.Equal(models.CheckCritical, alerts[0].Severity)
+	suite.Equal(models.TagClusterResourceType, alerts[0].ResourceType)
+	suite.Equal("group1", alerts[0].ResourceID)
+	suite.Equal("Check check1 on host host1 regressed from passing to critical", alerts[0].Summary)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultsDiff() {
+	var executions []entities.ChecksResult
+	err := suite.tx.Where("group_id", "group1").Order("id").Find(&executions).Error
+	suite.NoError(err)
+	suite.Len(executions, 2)
+
+	diff, err := suite.checksService.GetChecksResultsDiff("group1", 0, 0)
+	suite.NoError(err)
+
+	suite.Equal(executions[0].ID, diff.FromID)
+	suite.Equal(executions[1].ID, diff.ToID)
+	suite.Equal([]*models.CheckStateChange{
+		{
+			CheckID:  "check1",
+			Host:     "host1",
+			Previous: models.CheckCritical,
+			Current:  models.CheckPassing,
+		},
+		{
+			CheckID:  "check1",
+			Host:     "host2",
+			Previous: models.CheckCritical,
+			Current:  models.CheckPassing,
+		},
+		{
+			CheckID:  "check2",
+			Host:     "host1",
+			Previous: models.CheckCritical,
+			Current:  models.CheckWarning,
+		},
+	}, diff.Changes)
+
+	// explicit execution ids
+	diff, err = suite.checksService.GetChecksResultsDiff("group1", executions[0].ID, executions[1].ID)
+	suite.NoError(err)
+	suite.Len(diff.Changes, 3)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultsDiffNotFound() {
+	_, err := suite.checksService.GetChecksResultsDiff("other", 0, 0)
+
+	suite.EqualError(err, "record not found")
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultAndMetadataByHost() {
+	results, err := suite.checksService.GetChecksResultAndMetadataByHost("group1", "host1")
+
+	expectedResults := models.ChecksCatalog{
+		&models.Check{
+			ID:          "check1",
+			Name:        "name1",
+			Group:       "group1",
+			Description: "description1",
+			Result:      models.CheckPassing,
+		},
+		&models.Check{
+			ID:          "check2",
+			Name:        "name2",
+			Group:       "group1",
+			Description: "description2",
+			Result:      models.CheckWarning,
+		},
+	}
+
+	suite.NoError(err)
+	suite.Equal(expectedResults, results)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksResultAndMetadataByHostUnknownHost() {
+	results, err := suite.checksService.GetChecksResultAndMetadataByHost("group1", "other")
+
+	suite.NoError(err)
+	suite.Empty(results)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_RequestChecksExecution() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+
+	suite.NoError(err)
+	suite.NotZero(execution.ID)
+	suite.Equal("group1", execution.ClusterID)
+	suite.Equal(models.ExecutionStatusRequested, execution.Status)
+
+	// requesting again while one is pending does not enqueue a new execution
+	pending, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+	suite.Equal(execution.ID, pending.ID)
+
+	byId, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(execution.ID, byId.ID)
+	suite.Equal(models.ExecutionStatusRequested, byId.Status)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_RequestChecksExecutionCompleted() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	results := &models.ChecksResult{
+		ID: "group1",
+		Checks: map[string]*models.ChecksByHost{
+			"check1": {
+				Hosts: map[string]*models.Check{
+					"host1": {
+						Result: models.CheckPassing,
+					},
+				},
+			},
+		},
+	}
+	err = suite.checksService.CreateChecksResult(results)
+	suite.NoError(err)
+
+	byId, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusCompleted, byId.Status)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksExecutionByIDNotFound() {
+	execution, err := suite.checksService.GetChecksExecutionByID(12345)
+
+	suite.NoError(err)
+	suite.Nil(execution)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_ChecksExecutionLifecycle() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+	suite.Equal("api", execution.Actor)
+	suite.Nil(execution.StartedAt)
+	suite.Nil(execution.CompletedAt)
+
+	err = suite.checksService.StartChecksExecution(execution.ID)
+	suite.NoError(err)
+
+	running, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusRunning, running.Status)
+	suite.NotNil(running.StartedAt)
+
+	results := &models.ChecksResult{
+		ID: "group1",
+		Checks: map[string]*models.ChecksByHost{
+			"check1": {
+				Hosts: map[string]*models.Check{
+					"host1": {
+						Result: models.CheckPassing,
+					},
+				},
+			},
+		},
+		Durations: map[string]float64{"check1": 1.5},
+	}
+	err = suite.checksService.CreateChecksResult(results)
+	suite.NoError(err)
+
+	completed, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusCompleted, completed.Status)
+	suite.NotNil(completed.CompletedAt)
+	suite.Equal(map[string]float64{"check1": 1.5}, completed.Durations)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_FailChecksExecution() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	err = suite.checksService.FailChecksExecution(execution.ID)
+	suite.NoError(err)
+
+	failed, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusFailed, failed.Status)
+	suite.NotNil(failed.CompletedAt)
+}
+
+func (suite *ChecksServiceTestSuite) TestChecksService_GetChecksExecutionsByCluster() {
+	_, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	results := &models.ChecksResult{
+		ID: "group2",
+		Checks: map[string]*models.ChecksByHost{
+			"check1": {
+				Hosts: map[string]*models.Check{
+					"host3": {
+						Result: models.CheckPassing,
+					},
+				},
+			},
+		},
+	}
+	// a result without a pending execution records a runner-triggered one
+	err = suite.checksService.CreateChecksResult(results)
+	suite.NoError(err)
+
+	executions, err := suite.checksService.GetChecksExecutionsByCluster("group2")
+	suite.NoError(err)
+	suite.Len(executions, 1)
+	suite.Equal(models.ExecutionActorRunner, executions[0].Actor)
+	suite.Equal(models.ExecutionStatusCompleted, executions[0].Status)
 
+	executions, err = suite.checksService.GetChecksExecutionsByCluster("group3")
 	suite.NoError(err)
-	suite.Equal(expectedValue, data)
+	suite.Empty(executions)
 }
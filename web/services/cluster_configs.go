@@ -0,0 +1,92 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=ClusterConfigsService --inpackage --filename=cluster_configs_mock.go
+
+// ClusterConfigsService gives access to the raw Pacemaker configuration
+// snapshots stored by the data pipeline
+type ClusterConfigsService interface {
+	GetConfigSnapshot(clusterID string, at *time.Time) (*models.ClusterConfigSnapshot, error)
+	GetConfigSnapshotsDiff(clusterID string, fromID, toID int64) (*models.ClusterConfigDiff, error)
+}
+
+type clusterConfigsService struct {
+	db *gorm.DB
+}
+
+func NewClusterConfigsService(db *gorm.DB) *clusterConfigsService {
+	return &clusterConfigsService{db}
+}
+
+// GetConfigSnapshot returns the latest configuration snapshot of a cluster,
+// or the one that was current at the given time
+func (s *clusterConfigsService) GetConfigSnapshot(clusterID string, at *time.Time) (*models.ClusterConfigSnapshot, error) {
+	var snapshot entities.ClusterConfigSnapshot
+
+	query := s.db.Where("cluster_id", clusterID)
+	if at != nil {
+		query = query.Where("created_at <= ?", *at)
+	}
+
+	err := query.Last(&snapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot.ToModel(), nil
+}
+
+// GetConfigSnapshotsDiff compares two configuration snapshots of a cluster.
+// A zero toID selects the latest snapshot, a zero fromID the one right before it
+func (s *clusterConfigsService) GetConfigSnapshotsDiff(clusterID string, fromID, toID int64) (*models.ClusterConfigDiff, error) {
+	var toSnapshot entities.ClusterConfigSnapshot
+
+	toQuery := s.db.Where("cluster_id", clusterID)
+	if toID != 0 {
+		toQuery = toQuery.Where("id", toID)
+	}
+
+	err := toQuery.Last(&toSnapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fromSnapshot entities.ClusterConfigSnapshot
+
+	fromQuery := s.db.Where("cluster_id", clusterID)
+	if fromID != 0 {
+		fromQuery = fromQuery.Where("id", fromID)
+	} else {
+		fromQuery = fromQuery.Where("id < ?", toSnapshot.ID)
+	}
+
+	err = fromQuery.Last(&fromSnapshot).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ClusterConfigDiff{
+		From:            fromSnapshot.ToModel(),
+		To:              toSnapshot.ToModel(),
+		CibChanged:      fromSnapshot.CibXML != toSnapshot.CibXML,
+		CorosyncChanged: fromSnapshot.CorosyncConf != toSnapshot.CorosyncConf,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+type ClusterConfigsServiceTestSuite struct {
+	suite.Suite
+	db                    *gorm.DB
+	tx                    *gorm.DB
+	clusterConfigsService *clusterConfigsService
+}
+
+func TestClusterConfigsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterConfigsServiceTestSuite))
+}
+
+func (suite *ClusterConfigsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.ClusterConfigSnapshot{})
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.ClusterConfigSnapshot{})
+}
+
+func (suite *ClusterConfigsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.clusterConfigsService = NewClusterConfigsService(suite.tx)
+
+	suite.tx.Create(&[]entities.ClusterConfigSnapshot{
+		{
+			ID:           1,
+			ClusterID:    "cluster1",
+			CibXML:       "<cib epoch=\"1\"/>",
+			CorosyncConf: "totem {\n  version: 2\n}",
+			CreatedAt:    time.Date(2022, 1, 10, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           2,
+			ClusterID:    "cluster1",
+			CibXML:       "<cib epoch=\"2\"/>",
+			CorosyncConf: "totem {\n  version: 2\n}",
+			CreatedAt:    time.Date(2022, 1, 12, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:           3,
+			ClusterID:    "cluster2",
+			CibXML:       "<cib epoch=\"7\"/>",
+			CorosyncConf: "",
+			CreatedAt:    time.Date(2022, 1, 11, 10, 0, 0, 0, time.UTC),
+		},
+	})
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshot() {
+	snapshot, err := suite.clusterConfigsService.GetConfigSnapshot("cluster1", nil)
+
+	suite.NoError(err)
+	suite.Equal(int64(2), snapshot.ID)
+	suite.Equal("cluster1", snapshot.ClusterID)
+	suite.Equal("<cib epoch=\"2\"/>", snapshot.CibXML)
+	suite.Equal("totem {\n  version: 2\n}", snapshot.CorosyncConf)
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshotAt() {
+	at := time.Date(2022, 1, 11, 10, 0, 0, 0, time.UTC)
+
+	snapshot, err := suite.clusterConfigsService.GetConfigSnapshot("cluster1", &at)
+
+	suite.NoError(err)
+	suite.Equal(int64(1), snapshot.ID)
+	suite.Equal("<cib epoch=\"1\"/>", snapshot.CibXML)
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshotNotFound() {
+	snapshot, err := suite.clusterConfigsService.GetConfigSnapshot("other", nil)
+
+	suite.NoError(err)
+	suite.Nil(snapshot)
+
+	// before the first discovery
+	at := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	snapshot, err = suite.clusterConfigsService.GetConfigSnapshot("cluster1", &at)
+
+	suite.NoError(err)
+	suite.Nil(snapshot)
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshotsDiff() {
+	diff, err := suite.clusterConfigsService.GetConfigSnapshotsDiff("cluster1", 0, 0)
+
+	suite.NoError(err)
+	suite.Equal(int64(1), diff.From.ID)
+	suite.Equal(int64(2), diff.To.ID)
+	suite.True(diff.CibChanged)
+	suite.False(diff.CorosyncChanged)
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshotsDiffExplicitIds() {
+	diff, err := suite.clusterConfigsService.GetConfigSnapshotsDiff("cluster1", 2, 2)
+
+	suite.NoError(err)
+	suite.Equal(int64(2), diff.From.ID)
+	suite.Equal(int64(2), diff.To.ID)
+	suite.False(diff.CibChanged)
+	suite.False(diff.CorosyncChanged)
+}
+
+func (suite *ClusterConfigsServiceTestSuite) TestClusterConfigsService_GetConfigSnapshotsDiffNotEnoughSnapshots() {
+	// cluster2 only has one snapshot, there is nothing to compare it against
+	diff, err := suite.clusterConfigsService.GetConfigSnapshotsDiff("cluster2", 0, 0)
+
+	suite.NoError(err)
+	suite.Nil(diff)
+
+	diff, err = suite.clusterConfigsService.GetConfigSnapshotsDiff("other", 0, 0)
+
+	suite.NoError(err)
+	suite.Nil(diff)
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=ClusterIdentityService --inpackage --filename=cluster_identity_mock.go
+
+// ErrMergeSourceStillRegistered is returned when a merge is requested from a
+// cluster that still has hosts attached: it is not a leftover of a rebuild
+var ErrMergeSourceStillRegistered = errors.New("the cluster to merge from still has registered hosts")
+
+// ErrMergeClusterNotFound is returned when either side of a merge does not exist
+var ErrMergeClusterNotFound = errors.New("cluster not found")
+
+// ClusterIdentityService keeps the identity of a cluster stable across
+// rebuilds: recreating a Pacemaker cluster from scratch gives it a new ID, so
+// the same machines reappear as a brand new cluster while the old entry
+// lingers with all the curated tags, check settings and history attached
+type ClusterIdentityService interface {
+	// FindRebuilds detects the clusters that lost all their hosts to another
+	// cluster with the very same node set, which is what a rebuild looks like
+	FindRebuilds() ([]*models.ClusterRebuild, error)
+	// Merge moves the tags, check settings and history of the old cluster
+	// onto the new one and removes the old entry
+	Merge(oldClusterID, newClusterID string) error
+}
+
+type clusterIdentityService struct {
+	db *gorm.DB
+}
+
+func NewClusterIdentityService(db *gorm.DB) *clusterIdentityService {
+	return &clusterIdentityService{db: db}
+}
+
+func (s *clusterIdentityService) FindRebuilds() ([]*models.ClusterRebuild, error) {
+	var clusters []*entities.Cluster
+
+	err := s.db.Preload("Hosts").Find(&clusters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rebuilds := []*models.ClusterRebuild{}
+	for _, oldCluster := range clusters {
+		// a rebuilt cluster leaves its old entry behind with no hosts
+		// attached, as they all re-registered under the new ID
+		if len(oldCluster.Hosts) > 0 {
+			continue
+		}
+
+		oldNodes := clusterNodeNames(oldCluster)
+		if len(oldNodes) == 0 {
+			continue
+		}
+
+		for _, newCluster := range clusters {
+			if newCluster.ID == oldCluster.ID || len(newCluster.Hosts) == 0 {
+				continue
+			}
+
+			var newNodes []string
+			for _, host := range newCluster.Hosts {
+				newNodes = append(newNodes, host.Name)
+			}
+			sort.Strings(newNodes)
+
+			if !sameNodeSet(oldNodes, newNodes) {
+				continue
+			}
+
+			rebuilds = append(rebuilds, &models.ClusterRebuild{
+				OldClusterID:   oldCluster.ID,
+				OldClusterName: oldCluster.Name,
+				NewClusterID:   newCluster.ID,
+				NewClusterName: newCluster.Name,
+				SID:            oldCluster.SID,
+				Nodes:          oldNodes,
+			})
+		}
+	}
+
+	sort.Slice(rebuilds, func(i, j int) bool {
+		return rebuilds[i].OldClusterID < rebuilds[j].OldClusterID
+	})
+
+	return rebuilds, nil
+}
+
+func (s *clusterIdentityService) Merge(oldClusterID, newClusterID string) error {
+	var oldCluster entities.Cluster
+	err := s.db.Preload("Hosts").Where("id = ?", oldClusterID).First(&oldCluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMergeClusterNotFound
+		}
+		return err
+	}
+
+	if len(oldCluster.Hosts) > 0 {
+		return ErrMergeSourceStillRegistered
+	}
+
+	var newCluster entities.Cluster
+	err = s.db.Where("id = ?", newClusterID).First(&newCluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrMergeClusterNotFound
+		}
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := mergeTags(tx, oldClusterID, newClusterID); err != nil {
+			return err
+		}
+
+		if err := mergeCustomFields(tx, oldClusterID, newClusterID); err != nil {
+			return err
+		}
+
+		if err := mergeCheckSettings(tx, oldClusterID, newClusterID); err != nil {
+			return err
+		}
+
+		// the checks history, CIB snapshots, notes and events of the old
+		// cluster continue under the new identity
+		err := tx.Model(&entities.ChecksResult{}).
+			Where("group_id = ?", oldClusterID).
+			Update("group_id", newClusterID).Error
+		if err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&entities.ChecksExecution{},
+			&entities.AgentChecksResult{},
+			&entities.ClusterConfigSnapshot{},
+		} {
+			err := tx.Model(model).
+				Where("cluster_id = ?", oldClusterID).
+				Update("cluster_id", newClusterID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{
+			&entities.Note{},
+			&entities.ResourceEvent{},
+		} {
+			err := tx.Model(model).
+				Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, oldClusterID).
+				Update("resource_id", newClusterID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.Where("id = ?", oldClusterID).Delete(&entities.HealthState{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", oldClusterID).Delete(&entities.Cluster{}).Error
+	})
+}
+
+// mergeTags moves the tags of the old cluster onto the new one, dropping the
+// ones the new cluster already carries
+func mergeTags(tx *gorm.DB, oldClusterID, newClusterID string) error {
+	var tags []*models.Tag
+	err := tx.
+		Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, oldClusterID).
+		Find(&tags).Error
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Tag{
+			Value:        tag.Value,
+			ResourceID:   newClusterID,
+			ResourceType: models.TagClusterResourceType,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.
+		Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, oldClusterID).
+		Delete(&models.Tag{}).Error
+}
+
+// mergeCustomFields moves the custom field values of the old cluster onto the
+// new one. On a name clash the value of the new cluster wins, being the more
+// recently curated one.
+func mergeCustomFields(tx *gorm.DB, oldClusterID, newClusterID string) error {
+	var fields []*entities.CustomFieldValue
+	err := tx.
+		Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, oldClusterID).
+		Find(&fields).Error
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entities.CustomFieldValue{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   newClusterID,
+			Name:         field.Name,
+			Value:        field.Value,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.
+		Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, oldClusterID).
+		Delete(&entities.CustomFieldValue{}).Error
+}
+
+// mergeCheckSettings re-keys the selected checks and connection settings of
+// the old cluster, unless the new cluster has already been configured
+func mergeCheckSettings(tx *gorm.DB, oldClusterID, newClusterID string) error {
+	var count int64
+	err := tx.Model(&models.SelectedChecks{}).Where("id = ?", newClusterID).Count(&count).Error
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		err = tx.Model(&models.SelectedChecks{}).
+			Where("id = ?", oldClusterID).
+			Update("id", newClusterID).Error
+	} else {
+		err = tx.Where("id = ?", oldClusterID).Delete(&models.SelectedChecks{}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	var connectionSettings []*models.ConnectionSettings
+	err = tx.Where("id = ?", oldClusterID).Find(&connectionSettings).Error
+	if err != nil {
+		return err
+	}
+
+	for _, settings := range connectionSettings {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.ConnectionSettings{
+			ID:   newClusterID,
+			Node: settings.Node,
+			User: settings.User,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Where("id = ?", oldClusterID).Delete(&models.ConnectionSettings{}).Error
+}
+
+// clusterNodeNames extracts the sorted node names from the discovered cluster
+// details, which keep them even after the hosts re-registered elsewhere
+func clusterNodeNames(cluster *entities.Cluster) []string {
+	if cluster.Details == nil {
+		return nil
+	}
+
+	var details struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	}
+
+	if err := json.Unmarshal(cluster.Details, &details); err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, node := range details.Nodes {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func sameNodeSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type ClusterIdentityServiceTestSuite struct {
+	suite.Suite
+	db              *gorm.DB
+	tx              *gorm.DB
+	identityService *clusterIdentityService
+}
+
+func TestClusterIdentityServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ClusterIdentityServiceTestSuite))
+}
+
+func (suite *ClusterIdentityServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.Cluster{}, entities.Host{}, entities.HealthState{},
+		models.Tag{}, entities.CustomFieldValue{},
+		models.SelectedChecks{}, models.ConnectionSettings{},
+		entities.ChecksResult{}, entities.ChecksExecution{}, entities.AgentChecksResult{},
+		entities.ClusterConfigSnapshot{}, entities.Note{}, entities.ResourceEvent{},
+	)
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.Cluster{}, entities.Host{}, entities.HealthState{},
+		models.Tag{}, entities.CustomFieldValue{},
+		models.SelectedChecks{}, models.ConnectionSettings{},
+		entities.ChecksResult{}, entities.ChecksExecution{}, entities.AgentChecksResult{},
+		entities.ClusterConfigSnapshot{}, entities.Note{}, entities.ResourceEvent{},
+	)
+}
+
+func (suite *ClusterIdentityServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.identityService = NewClusterIdentityService(suite.tx)
+
+	// the stale entry a rebuild left behind: no hosts attached anymore, but
+	// the discovered details still carry the node names
+	suite.tx.Create(&entities.Cluster{
+		ID:          "old-cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		SID:         "PRD",
+		Details:     datatypes.JSON([]byte(`{"nodes":[{"name":"node1"},{"name":"node2"}]}`)),
+	})
+
+	// the same node set re-registered under a new ID
+	suite.tx.Create(&entities.Cluster{
+		ID:          "new-cluster",
+		Name:        "hana_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		SID:         "PRD",
+		Details:     datatypes.JSON([]byte(`{"nodes":[{"name":"node1"},{"name":"node2"}]}`)),
+	})
+	suite.tx.Create(&entities.Host{AgentID: "agent-1", Name: "node1", ClusterID: "new-cluster"})
+	suite.tx.Create(&entities.Host{AgentID: "agent-2", Name: "node2", ClusterID: "new-cluster"})
+
+	// an unrelated cluster with a different node set
+	suite.tx.Create(&entities.Cluster{
+		ID:          "other-cluster",
+		Name:        "other_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		SID:         "QAS",
+		Details:     datatypes.JSON([]byte(`{"nodes":[{"name":"node3"}]}`)),
+	})
+	suite.tx.Create(&entities.Host{AgentID: "agent-3", Name: "node3", ClusterID: "other-cluster"})
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TestClusterIdentityService_FindRebuilds() {
+	rebuilds, err := suite.identityService.FindRebuilds()
+	suite.NoError(err)
+
+	suite.Equal([]*models.ClusterRebuild{
+		{
+			OldClusterID:   "old-cluster",
+			OldClusterName: "hana_cluster",
+			NewClusterID:   "new-cluster",
+			NewClusterName: "hana_cluster",
+			SID:            "PRD",
+			Nodes:          []string{"node1", "node2"},
+		},
+	}, rebuilds)
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TestClusterIdentityService_Merge() {
+	suite.tx.Create(&models.Tag{Value: "env:prod", ResourceID: "old-cluster", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&models.Tag{Value: "critical", ResourceID: "old-cluster", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&models.Tag{Value: "critical", ResourceID: "new-cluster", ResourceType: models.TagClusterResourceType})
+	suite.tx.Create(&entities.CustomFieldValue{
+		ResourceType: models.TagClusterResourceType, ResourceID: "old-cluster", Name: "owner", Value: "basis-team",
+	})
+	suite.tx.Create(&models.SelectedChecks{ID: "old-cluster", SelectedChecks: pq.StringArray{"ABCDEF"}})
+	suite.tx.Create(&models.ConnectionSettings{ID: "old-cluster", Node: "node1", User: "cloudadmin"})
+	suite.tx.Create(&entities.ChecksResult{GroupID: "old-cluster", Payload: []byte("{}")})
+	suite.tx.Create(&entities.ChecksExecution{ClusterID: "old-cluster", Status: "completed"})
+	suite.tx.Create(&entities.ClusterConfigSnapshot{ClusterID: "old-cluster", CibXML: "<cib/>"})
+	suite.tx.Create(&entities.Note{ResourceType: models.TagClusterResourceType, ResourceID: "old-cluster", Content: "a note"})
+	suite.tx.Create(&entities.HealthState{ID: "old-cluster", Health: "passing"})
+
+	err := suite.identityService.Merge("old-cluster", "new-cluster")
+	suite.NoError(err)
+
+	var count int64
+	suite.tx.Model(&entities.Cluster{}).Where("id", "old-cluster").Count(&count)
+	suite.EqualValues(0, count)
+	suite.tx.Model(&entities.HealthState{}).Where("id", "old-cluster").Count(&count)
+	suite.EqualValues(0, count)
+
+	var tags []string
+	suite.tx.Model(&models.Tag{}).
+		Where("resource_type = ? AND resource_id = ?", models.TagClusterResourceType, "new-cluster").
+		Order("value").
+		Pluck("value", &tags)
+	suite.Equal([]string{"critical", "env:prod"}, tags)
+
+	var field entities.CustomFieldValue
+	suite.NoError(suite.tx.Where("resource_id", "new-cluster").First(&field).Error)
+	suite.Equal("basis-team", field.Value)
+
+	var selectedChecks models.SelectedChecks
+	suite.NoError(suite.tx.Where("id", "new-cluster").First(&selectedChecks).Error)
+	suite.Equal(pq.StringArray{"ABCDEF"}, selectedChecks.SelectedChecks)
+
+	var connectionSettings models.ConnectionSettings
+	suite.NoError(suite.tx.Where("id", "new-cluster").First(&connectionSettings).Error)
+	suite.Equal("cloudadmin", connectionSettings.User)
+
+	suite.tx.Model(&entities.ChecksResult{}).Where("group_id", "new-cluster").Count(&count)
+	suite.EqualValues(1, count)
+	suite.tx.Model(&entities.ChecksExecution{}).Where("cluster_id", "new-cluster").Count(&count)
+	suite.EqualValues(1, count)
+	suite.tx.Model(&entities.ClusterConfigSnapshot{}).Where("cluster_id", "new-cluster").Count(&count)
+	suite.EqualValues(1, count)
+	suite.tx.Model(&entities.Note{}).Where("resource_id", "new-cluster").Count(&count)
+	suite.EqualValues(1, count)
+
+	// nothing keyed by the old ID survives
+	suite.tx.Model(&models.Tag{}).Where("resource_id", "old-cluster").Count(&count)
+	suite.EqualValues(0, count)
+	suite.tx.Model(&models.SelectedChecks{}).Where("id", "old-cluster").Count(&count)
+	suite.EqualValues(0, count)
+	suite.tx.Model(&models.ConnectionSettings{}).Where("id", "old-cluster").Count(&count)
+	suite.EqualValues(0, count)
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TestClusterIdentityService_MergeKeepsConfiguredChecks() {
+	suite.tx.Create(&models.SelectedChecks{ID: "old-cluster", SelectedChecks: pq.StringArray{"ABCDEF"}})
+	suite.tx.Create(&models.SelectedChecks{ID: "new-cluster", SelectedChecks: pq.StringArray{"123456"}})
+
+	err := suite.identityService.Merge("old-cluster", "new-cluster")
+	suite.NoError(err)
+
+	// the checks already selected on the new cluster win over the old ones
+	var selectedChecks models.SelectedChecks
+	suite.NoError(suite.tx.Where("id", "new-cluster").First(&selectedChecks).Error)
+	suite.Equal(pq.StringArray{"123456"}, selectedChecks.SelectedChecks)
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TestClusterIdentityService_MergeStillRegistered() {
+	err := suite.identityService.Merge("other-cluster", "new-cluster")
+	suite.EqualError(err, ErrMergeSourceStillRegistered.Error())
+}
+
+func (suite *ClusterIdentityServiceTestSuite) TestClusterIdentityService_MergeNotFound() {
+	err := suite.identityService.Merge("old-cluster", "not-there")
+	suite.EqualError(err, ErrMergeClusterNotFound.Error())
+}
@@ -3,13 +3,17 @@ package services
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/internal"
 	"github.com/trento-project/trento/internal/cloud"
+	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 //go:generate mockery --name=ClustersService --inpackage --filename=clusters_mock.go
@@ -24,8 +28,19 @@ type ClustersService interface {
 	GetAllTags() ([]string, error)
 	GetAllClustersSettings() (models.ClustersSettings, error)
 	GetClusterSettingsByID(id string) (*models.ClusterSettings, error)
+	GetResourcesByClusterID(clusterID string) ([]*models.PacemakerResource, error)
+	GetVIPsByClusterID(clusterID string) ([]*models.ClusterVIP, error)
+	GetFreshnessByClusterID(clusterID string) (*models.DiscoveryFreshness, error)
+	GetReadinessByClusterID(clusterID string) (*models.ClusterReadiness, error)
+	GetRecommendedChecksByID(clusterID string) (models.ChecksCatalog, error)
+	BulkUpdateSettings(update *models.ClustersSettingsBulkUpdate) ([]string, error)
+	GetDriftByClusterID(clusterID string) (*models.ClusterDrift, error)
 }
 
+// ErrClusterNotHANA is returned when takeover readiness is requested for a
+// cluster that does not run a HANA workload
+var ErrClusterNotHANA = errors.New("cluster is not running a HANA workload")
+
 type ClustersFilter struct {
 	ID          []string
 	Name        []string
@@ -33,21 +48,40 @@ type ClustersFilter struct {
 	SIDs        []string
 	Tags        []string
 	Health      []string
+	// ScopeTags is set by the authorization layer to enforce the per-account
+	// visibility, see HostsFilter
+	ScopeTags []string
+	// CustomFields matches the clusters whose custom fields carry all the
+	// given name/value pairs
+	CustomFields map[string]string
 }
 
 type clustersService struct {
 	db            *gorm.DB
 	checksService ChecksService
+	cache         *ListCache
 }
 
-func NewClustersService(db *gorm.DB, checksService ChecksService) *clustersService {
+func NewClustersService(db *gorm.DB, checksService ChecksService, cache *ListCache) *clustersService {
 	return &clustersService{
 		db:            db,
 		checksService: checksService,
+		cache:         cache,
 	}
 }
 
 func (s *clustersService) GetAll(filter *ClustersFilter, page *Page) (models.ClusterList, error) {
+	value, err := s.cache.GetOrCompute(listCacheKey("clusters", filter, page), func() (interface{}, error) {
+		return s.getAll(filter, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(models.ClusterList), nil
+}
+
+func (s *clustersService) getAll(filter *ClustersFilter, page *Page) (models.ClusterList, error) {
 	var clusters []entities.Cluster
 
 	db := s.db.Preload("Health").Preload("Tags").Scopes(Paginate(page))
@@ -77,6 +111,23 @@ func (s *clustersService) GetAll(filter *ClustersFilter, page *Page) (models.Clu
 			)
 		}
 
+		if len(filter.ScopeTags) > 0 {
+			db = db.Where("id IN (?)", s.db.Model(&models.Tag{}).
+				Select("resource_id").
+				Where("resource_type = ?", models.TagClusterResourceType).
+				Where("value IN ?", filter.ScopeTags),
+			)
+		}
+
+		for name, value := range filter.CustomFields {
+			db = db.Where("id IN (?)", s.db.Model(&entities.CustomFieldValue{}).
+				Select("resource_id").
+				Where("resource_type = ?", models.TagClusterResourceType).
+				Where("name = ?", name).
+				Where("value = ?", value),
+			)
+		}
+
 		if len(filter.Health) > 0 {
 			db = db.Where("id IN (?)", s.db.Model(&entities.HealthState{}).
 				Select("id").
@@ -129,6 +180,26 @@ func (s *clustersService) GetByID(clusterID string) (*models.Cluster, error) {
 		s.enrichClusterNodes(detail.Nodes, cluster.ID, cluster.Hosts)
 		s.enrichCluster(clusterModel)
 		clusterModel.Details = detail
+	case models.ClusterTypeASCSERS:
+		var clusterDetailASCSERS entities.ASCSERSClusterDetails
+
+		err := json.Unmarshal(cluster.Details, &clusterDetailASCSERS)
+		if err != nil {
+			return nil, err
+		}
+
+		s.enrichCluster(clusterModel)
+		clusterModel.Details = clusterDetailASCSERS.ToModel()
+	case models.ClusterTypeGeneric:
+		var clusterDetailGeneric entities.GenericClusterDetails
+
+		err := json.Unmarshal(cluster.Details, &clusterDetailGeneric)
+		if err != nil {
+			return nil, err
+		}
+
+		s.enrichCluster(clusterModel)
+		clusterModel.Details = clusterDetailGeneric.ToModel()
 	default:
 		clusterModel.Details = nil
 	}
@@ -136,6 +207,227 @@ func (s *clustersService) GetByID(clusterID string) (*models.Cluster, error) {
 	return clusterModel, nil
 }
 
+func (s *clustersService) GetResourcesByClusterID(clusterID string) ([]*models.PacemakerResource, error) {
+	var resources []entities.PacemakerResource
+
+	err := s.db.
+		Where("cluster_id", clusterID).
+		Order("id").
+		Find(&resources).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	resourceModels := []*models.PacemakerResource{}
+	for _, resource := range resources {
+		resourceModels = append(resourceModels, resource.ToModel())
+	}
+
+	return resourceModels, nil
+}
+
+func (s *clustersService) GetVIPsByClusterID(clusterID string) ([]*models.ClusterVIP, error) {
+	var vips []entities.ClusterVIP
+
+	err := s.db.
+		Where("cluster_id", clusterID).
+		Order("resource_id").
+		Find(&vips).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	vipModels := []*models.ClusterVIP{}
+	for _, vip := range vips {
+		vipModels = append(vipModels, vip.ToModel())
+	}
+
+	return vipModels, nil
+}
+
+// GetFreshnessByClusterID tells when the cluster discovery last updated a
+// cluster and whether that data is stale by now
+func (s *clustersService) GetFreshnessByClusterID(clusterID string) (*models.DiscoveryFreshness, error) {
+	var cluster entities.Cluster
+
+	err := s.db.
+		Where("id = ?", clusterID).First(&cluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &models.DiscoveryFreshness{
+		DiscoveryType: datapipeline.ClusterDiscovery,
+		LastUpdated:   cluster.UpdatedAt,
+		Stale:         timeSince(cluster.UpdatedAt) > StaleDiscoveryTreshold,
+	}, nil
+}
+
+// GetReadinessByClusterID scores how prepared a HANA cluster is for a
+// takeover, combining system replication, fencing, checks and node status
+// into a single number with the contributing factors listed
+func (s *clustersService) GetReadinessByClusterID(clusterID string) (*models.ClusterReadiness, error) {
+	var cluster entities.Cluster
+
+	err := s.db.
+		Where("id = ?", clusterID).First(&cluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if cluster.ClusterType != models.ClusterTypeHANAScaleUp && cluster.ClusterType != models.ClusterTypeHANAScaleOut {
+		return nil, ErrClusterNotHANA
+	}
+
+	var details entities.HANAClusterDetails
+	err = json.Unmarshal(cluster.Details, &details)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregatedChecks, err := s.checksService.GetAggregatedChecksResultByCluster(clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	factors := []*models.ReadinessFactor{
+		computeSRSyncFactor(&details),
+		computeFencingFactor(&cluster),
+		computeChecksFactor(aggregatedChecks),
+		computeNodesFactor(&details),
+	}
+
+	score := computeReadinessScore(factors)
+
+	return &models.ClusterReadiness{
+		ClusterID: clusterID,
+		Score:     score,
+		Ready:     score == 100,
+		Factors:   factors,
+	}, nil
+}
+
+func computeSRSyncFactor(details *entities.HANAClusterDetails) *models.ReadinessFactor {
+	factor := &models.ReadinessFactor{Name: models.ReadinessFactorSRSync}
+
+	switch {
+	case details.SRHealthState == models.HANASrHealthOK && details.SecondarySyncState == models.HANASrSyncSOK:
+		factor.Health = models.ReadinessHealthPassing
+		factor.Message = "System replication is in sync"
+	case details.SRHealthState == "" || details.SecondarySyncState == "":
+		factor.Health = models.ReadinessHealthUnknown
+		factor.Message = "System replication state is unknown"
+	default:
+		factor.Health = models.ReadinessHealthCritical
+		factor.Message = fmt.Sprintf("System replication is not in sync: secondary sync state is %s",
+			details.SecondarySyncState)
+	}
+
+	return factor
+}
+
+func computeFencingFactor(cluster *entities.Cluster) *models.ReadinessFactor {
+	factor := &models.ReadinessFactor{Name: models.ReadinessFactorFencing}
+
+	switch cluster.FencingStatus {
+	case models.FencingStatusHealthy:
+		factor.Health = models.ReadinessHealthPassing
+		factor.Message = fmt.Sprintf("%s fencing is healthy", cluster.FencingType)
+	case models.FencingStatusUnhealthy:
+		factor.Health = models.ReadinessHealthCritical
+		factor.Message = fmt.Sprintf("%s fencing is unhealthy", cluster.FencingType)
+	case models.FencingStatusUnconfigured:
+		factor.Health = models.ReadinessHealthCritical
+		factor.Message = "Fencing is not configured"
+	default:
+		factor.Health = models.ReadinessHealthUnknown
+		factor.Message = "Fencing state is unknown"
+	}
+
+	return factor
+}
+
+func computeChecksFactor(aggregatedChecks *models.AggregatedCheckData) *models.ReadinessFactor {
+	factor := &models.ReadinessFactor{Name: models.ReadinessFactorChecks}
+
+	switch aggregatedChecks.String() {
+	case models.CheckPassing:
+		factor.Health = models.ReadinessHealthPassing
+		factor.Message = "All configured checks are passing"
+	case models.CheckWarning:
+		factor.Health = models.ReadinessHealthWarning
+		factor.Message = fmt.Sprintf("%d checks are in warning state", aggregatedChecks.WarningCount)
+	case models.CheckCritical:
+		factor.Health = models.ReadinessHealthCritical
+		factor.Message = fmt.Sprintf("%d checks are in critical state", aggregatedChecks.CriticalCount)
+	default:
+		factor.Health = models.ReadinessHealthUnknown
+		factor.Message = "No check results available"
+	}
+
+	return factor
+}
+
+func computeNodesFactor(details *entities.HANAClusterDetails) *models.ReadinessFactor {
+	factor := &models.ReadinessFactor{Name: models.ReadinessFactorNodes}
+
+	if len(details.Nodes) == 0 {
+		factor.Health = models.ReadinessHealthUnknown
+		factor.Message = "No cluster nodes discovered"
+		return factor
+	}
+
+	var primaryNode, secondaryNode string
+	for _, node := range details.Nodes {
+		switch node.HANAStatus {
+		case models.HANAStatusFailed:
+			factor.Health = models.ReadinessHealthCritical
+			factor.Message = fmt.Sprintf("HANA on node %s is in Failed status", node.Name)
+			return factor
+		case models.HANAStatusPrimary:
+			primaryNode = node.Name
+		case models.HANAStatusSecondary:
+			secondaryNode = node.Name
+		}
+	}
+
+	if primaryNode != "" && secondaryNode != "" {
+		factor.Health = models.ReadinessHealthPassing
+		factor.Message = fmt.Sprintf("Primary running on node %s, secondary registered on node %s",
+			primaryNode, secondaryNode)
+		return factor
+	}
+
+	factor.Health = models.ReadinessHealthWarning
+	factor.Message = "No healthy primary/secondary pair registered"
+	return factor
+}
+
+// computeReadinessScore averages the factors into a 0-100 score: passing
+// factors count fully, warnings half, critical and unknown ones not at all
+func computeReadinessScore(factors []*models.ReadinessFactor) int {
+	var score float64
+
+	for _, factor := range factors {
+		switch factor.Health {
+		case models.ReadinessHealthPassing:
+			score += 1
+		case models.ReadinessHealthWarning:
+			score += 0.5
+		}
+	}
+
+	return int(score / float64(len(factors)) * 100)
+}
+
 func (s *clustersService) GetCount() (int, error) {
 	var count int64
 	err := s.db.Model(&entities.Cluster{}).Count(&count).Error
@@ -357,3 +649,89 @@ func (s *clustersService) enrichClusterNodes(nodes []*models.HANAClusterNode, cl
 		}
 	}
 }
+
+// BulkUpdateSettings applies the given selected checks and connection user
+// to all the clusters matching the filter, in a single transaction. It
+// returns the IDs of the updated clusters.
+func (s *clustersService) BulkUpdateSettings(update *models.ClustersSettingsBulkUpdate) ([]string, error) {
+	var clusters []*entities.Cluster
+
+	err := s.db.Preload("Hosts").Preload("Tags").Find(&clusters).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []*entities.Cluster
+	for _, cluster := range clusters {
+		if matchesBulkFilter(&update.Filter, cluster) {
+			matched = append(matched, cluster)
+		}
+	}
+
+	updatedIDs := []string{}
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, cluster := range matched {
+			if update.SelectedChecks != nil {
+				selectedChecks := models.SelectedChecks{
+					ID:             cluster.ID,
+					SelectedChecks: update.SelectedChecks,
+				}
+				err := tx.Clauses(clause.OnConflict{
+					UpdateAll: true,
+				}).Create(&selectedChecks).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if update.ConnectionUser != "" {
+				for _, host := range cluster.Hosts {
+					connectionSettings := models.ConnectionSettings{
+						ID:   cluster.ID,
+						Node: host.Name,
+						User: update.ConnectionUser,
+					}
+					err := tx.Clauses(clause.OnConflict{
+						UpdateAll: true,
+					}).Create(&connectionSettings).Error
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			updatedIDs = append(updatedIDs, cluster.ID)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedIDs, nil
+}
+
+func matchesBulkFilter(filter *models.ClustersSettingsBulkFilter, cluster *entities.Cluster) bool {
+	if len(filter.IDs) > 0 && !internal.Contains(filter.IDs, cluster.ID) {
+		return false
+	}
+
+	if len(filter.ClusterTypes) > 0 && !internal.Contains(filter.ClusterTypes, cluster.ClusterType) {
+		return false
+	}
+
+	if len(filter.Tags) > 0 {
+		var tags []string
+		for _, tag := range cluster.Tags {
+			tags = append(tags, tag.Value)
+		}
+		for _, tag := range filter.Tags {
+			if !internal.Contains(tags, tag) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
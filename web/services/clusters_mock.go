@@ -12,6 +12,29 @@ type MockClustersService struct {
 	mock.Mock
 }
 
+// BulkUpdateSettings provides a mock function with given fields: update
+func (_m *MockClustersService) BulkUpdateSettings(update *models.ClustersSettingsBulkUpdate) ([]string, error) {
+	ret := _m.Called(update)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(*models.ClustersSettingsBulkUpdate) []string); ok {
+		r0 = rf(update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.ClustersSettingsBulkUpdate) error); ok {
+		r1 = rf(update)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: _a0, _a1
 func (_m *MockClustersService) GetAll(_a0 *ClustersFilter, _a1 *Page) (models.ClusterList, error) {
 	ret := _m.Called(_a0, _a1)
@@ -216,3 +239,141 @@ func (_m *MockClustersService) GetCount() (int, error) {
 
 	return r0, r1
 }
+
+// GetDriftByClusterID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetDriftByClusterID(clusterID string) (*models.ClusterDrift, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 *models.ClusterDrift
+	if rf, ok := ret.Get(0).(func(string) *models.ClusterDrift); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClusterDrift)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFreshnessByClusterID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetFreshnessByClusterID(clusterID string) (*models.DiscoveryFreshness, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 *models.DiscoveryFreshness
+	if rf, ok := ret.Get(0).(func(string) *models.DiscoveryFreshness); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DiscoveryFreshness)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReadinessByClusterID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetReadinessByClusterID(clusterID string) (*models.ClusterReadiness, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 *models.ClusterReadiness
+	if rf, ok := ret.Get(0).(func(string) *models.ClusterReadiness); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ClusterReadiness)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRecommendedChecksByID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetRecommendedChecksByID(clusterID string) (models.ChecksCatalog, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 models.ChecksCatalog
+	if rf, ok := ret.Get(0).(func(string) models.ChecksCatalog); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.ChecksCatalog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResourcesByClusterID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetResourcesByClusterID(clusterID string) ([]*models.PacemakerResource, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 []*models.PacemakerResource
+	if rf, ok := ret.Get(0).(func(string) []*models.PacemakerResource); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PacemakerResource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVIPsByClusterID provides a mock function with given fields: clusterID
+func (_m *MockClustersService) GetVIPsByClusterID(clusterID string) ([]*models.ClusterVIP, error) {
+	ret := _m.Called(clusterID)
+
+	var r0 []*models.ClusterVIP
+	if rf, ok := ret.Get(0).(func(string) []*models.ClusterVIP); ok {
+		r0 = rf(clusterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ClusterVIP)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(clusterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -3,10 +3,12 @@ package services
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
@@ -30,6 +32,7 @@ func (suite *ClustersServiceTestSuite) SetupSuite() {
 	suite.db.AutoMigrate(
 		entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{},
 		models.ConnectionSettings{}, entities.ChecksResult{}, entities.HealthState{},
+		entities.PacemakerResource{}, entities.ClusterVIP{},
 	)
 	loadClustersFixtures(suite.db)
 }
@@ -38,13 +41,14 @@ func (suite *ClustersServiceTestSuite) TearDownSuite() {
 	suite.db.Migrator().DropTable(
 		entities.Cluster{}, entities.Host{}, models.Tag{}, models.SelectedChecks{},
 		models.ConnectionSettings{}, entities.ChecksResult{}, entities.HealthState{},
+		entities.PacemakerResource{}, entities.ClusterVIP{},
 	)
 }
 
 func (suite *ClustersServiceTestSuite) SetupTest() {
 	suite.tx = suite.db.Begin()
 	suite.checksService = new(MockChecksService)
-	suite.clustersService = NewClustersService(suite.tx, suite.checksService)
+	suite.clustersService = NewClustersService(suite.tx, suite.checksService, NewListCache())
 }
 
 func (suite *ClustersServiceTestSuite) TearDownTest() {
@@ -270,6 +274,24 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAll_Filter() {
 	suite.Equal(clusters[0].ID, "1")
 	suite.Equal([]string{"tag1"}, clusters[0].Tags)
 }
+func (suite *ClustersServiceTestSuite) TestClustersService_GetAll_ScopeTags() {
+	suite.checksService.On("GetAggregatedChecksResultByCluster", "1").Return(
+		&models.AggregatedCheckData{PassingCount: 1}, nil)
+
+	clusters, _ := suite.clustersService.GetAll(&ClustersFilter{
+		ScopeTags: []string{"tag1"},
+	}, nil)
+
+	suite.Equal(1, len(clusters))
+	suite.Equal("1", clusters[0].ID)
+
+	clusters, _ = suite.clustersService.GetAll(&ClustersFilter{
+		ScopeTags: []string{"some-other-tag"},
+	}, nil)
+
+	suite.Empty(clusters)
+}
+
 func (suite *ClustersServiceTestSuite) TestClustersService_GetByID() {
 	suite.checksService.On("GetAggregatedChecksResultByCluster", "1").Return(&models.AggregatedCheckData{PassingCount: 1}, nil)
 	suite.checksService.On("GetAggregatedChecksResultByHost", "1").Return(map[string]*models.AggregatedCheckData{
@@ -331,8 +353,8 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetAllClustersSetting
 	mockPremiumDetection := new(MockPremiumDetectionService)
 
 	tx := suite.tx.Raw("TRUNCATE TABLE clusters")
-	checksService := NewChecksService(tx, mockPremiumDetection)
-	suite.clustersService = NewClustersService(tx, checksService)
+	checksService := NewChecksService(tx, mockPremiumDetection, NewAlertsService(tx, nil), NewEventsService(tx, nil), NewSettingsService(tx), NewTrashService(tx))
+	suite.clustersService = NewClustersService(tx, checksService, NewListCache())
 
 	clustersSettings, err := suite.clustersService.GetAllClustersSettings()
 	suite.NoError(err)
@@ -451,3 +473,199 @@ func (suite *ClustersServiceTestSuite) TestClustersService_GetClusterSettings_No
 	suite.NoError(err)
 	suite.Nil(clusterSettings)
 }
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetResourcesByClusterID() {
+	suite.tx.Create(&[]entities.PacemakerResource{
+		{
+			ID:                 "rsc_SAPHana_PRD_HDB00",
+			ClusterID:          "1",
+			Node:               "host1",
+			Type:               "ocf::suse:SAPHana",
+			Role:               "Master",
+			Status:             "active",
+			FailCount:          2,
+			MigrationThreshold: 5000,
+		},
+		{
+			ID:        "stonith-sbd",
+			ClusterID: "1",
+			Node:      "host2",
+			Type:      "stonith:external/sbd",
+			Role:      "Started",
+			Status:    "active",
+		},
+		{
+			ID:        "rsc_ip_QAS_HDB00",
+			ClusterID: "2",
+			Node:      "host3",
+			Type:      "ocf::heartbeat:IPaddr2",
+			Role:      "Started",
+			Status:    "active",
+		},
+	})
+
+	resources, err := suite.clustersService.GetResourcesByClusterID("1")
+
+	suite.NoError(err)
+	suite.Equal([]*models.PacemakerResource{
+		{
+			ID:                 "rsc_SAPHana_PRD_HDB00",
+			ClusterID:          "1",
+			Node:               "host1",
+			Type:               "ocf::suse:SAPHana",
+			Role:               "Master",
+			Status:             "active",
+			FailCount:          2,
+			MigrationThreshold: 5000,
+		},
+		{
+			ID:        "stonith-sbd",
+			ClusterID: "1",
+			Node:      "host2",
+			Type:      "stonith:external/sbd",
+			Role:      "Started",
+			Status:    "active",
+		},
+	}, resources)
+
+	resources, err = suite.clustersService.GetResourcesByClusterID("not_found")
+
+	suite.NoError(err)
+	suite.Empty(resources)
+}
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetVIPsByClusterID() {
+	suite.tx.Create(&[]entities.ClusterVIP{
+		{
+			ClusterID:  "1",
+			ResourceID: "rsc_ip_PRD_HDB00",
+			Address:    "10.74.1.12",
+			Node:       "host1",
+			Status:     "active",
+		},
+		{
+			ClusterID:  "2",
+			ResourceID: "rsc_ip_QAS_HDB00",
+			Address:    "10.74.1.13",
+			Node:       "host3",
+			Status:     "active",
+		},
+	})
+
+	vips, err := suite.clustersService.GetVIPsByClusterID("1")
+
+	suite.NoError(err)
+	suite.Equal([]*models.ClusterVIP{
+		{
+			ClusterID:  "1",
+			ResourceID: "rsc_ip_PRD_HDB00",
+			Address:    "10.74.1.12",
+			Node:       "host1",
+			Status:     "active",
+		},
+	}, vips)
+
+	vips, err = suite.clustersService.GetVIPsByClusterID("not_found")
+
+	suite.NoError(err)
+	suite.Empty(vips)
+}
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetFreshnessByClusterID() {
+	timeSince = time.Since
+
+	freshness, err := suite.clustersService.GetFreshnessByClusterID("1")
+
+	suite.NoError(err)
+	suite.Equal(datapipeline.ClusterDiscovery, freshness.DiscoveryType)
+	suite.False(freshness.LastUpdated.IsZero())
+	// the fixtures were just created, so the data is not stale yet
+	suite.False(freshness.Stale)
+
+	freshness, err = suite.clustersService.GetFreshnessByClusterID("not_found")
+
+	suite.NoError(err)
+	suite.Nil(freshness)
+}
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetReadinessByClusterID() {
+	suite.checksService.On("GetAggregatedChecksResultByCluster", "1").Return(&models.AggregatedCheckData{PassingCount: 1}, nil)
+
+	readiness, err := suite.clustersService.GetReadinessByClusterID("1")
+
+	suite.NoError(err)
+	suite.Equal("1", readiness.ClusterID)
+	// checks are passing, the single discovered node gives a warning,
+	// replication and fencing states are unknown
+	suite.Equal(37, readiness.Score)
+	suite.False(readiness.Ready)
+	suite.Equal([]*models.ReadinessFactor{
+		{
+			Name:    models.ReadinessFactorSRSync,
+			Health:  models.ReadinessHealthUnknown,
+			Message: "System replication state is unknown",
+		},
+		{
+			Name:    models.ReadinessFactorFencing,
+			Health:  models.ReadinessHealthUnknown,
+			Message: "Fencing state is unknown",
+		},
+		{
+			Name:    models.ReadinessFactorChecks,
+			Health:  models.ReadinessHealthPassing,
+			Message: "All configured checks are passing",
+		},
+		{
+			Name:    models.ReadinessFactorNodes,
+			Health:  models.ReadinessHealthWarning,
+			Message: "No healthy primary/secondary pair registered",
+		},
+	}, readiness.Factors)
+
+	_, err = suite.clustersService.GetReadinessByClusterID("3")
+	suite.ErrorIs(err, ErrClusterNotHANA)
+
+	readiness, err = suite.clustersService.GetReadinessByClusterID("not_found")
+	suite.NoError(err)
+	suite.Nil(readiness)
+}
+
+func (suite *ClustersServiceTestSuite) TestClustersService_BulkUpdateSettings() {
+	updatedIDs, err := suite.clustersService.BulkUpdateSettings(&models.ClustersSettingsBulkUpdate{
+		Filter: models.ClustersSettingsBulkFilter{
+			Tags: []string{"tag1"},
+		},
+		SelectedChecks: []string{"ABCDEF", "123456"},
+		ConnectionUser: "cloudadmin",
+	})
+
+	suite.NoError(err)
+	suite.Equal([]string{"1"}, updatedIDs)
+
+	var selectedChecks models.SelectedChecks
+	suite.NoError(suite.tx.First(&selectedChecks, "id = ?", "1").Error)
+	suite.Equal(pq.StringArray{"ABCDEF", "123456"}, selectedChecks.SelectedChecks)
+
+	var connectionSettings models.ConnectionSettings
+	suite.NoError(suite.tx.First(&connectionSettings, "id = ? AND node = ?", "1", "host1").Error)
+	suite.Equal("cloudadmin", connectionSettings.User)
+
+	// the other clusters are left alone
+	var count int64
+	suite.tx.Model(&models.SelectedChecks{}).Count(&count)
+	suite.Equal(int64(1), count)
+
+	// a second update overwrites the existing settings
+	updatedIDs, err = suite.clustersService.BulkUpdateSettings(&models.ClustersSettingsBulkUpdate{
+		Filter: models.ClustersSettingsBulkFilter{
+			IDs: []string{"1"},
+		},
+		SelectedChecks: []string{"FEDCBA"},
+	})
+
+	suite.NoError(err)
+	suite.Equal([]string{"1"}, updatedIDs)
+
+	suite.NoError(suite.tx.First(&selectedChecks, "id = ?", "1").Error)
+	suite.Equal(pq.StringArray{"FEDCBA"}, selectedChecks.SelectedChecks)
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/contracts"
 	"github.com/trento-project/trento/web/datapipeline"
 	"gorm.io/gorm"
 )
@@ -8,6 +10,9 @@ import (
 //go:generate mockery --name=CollectorService --inpackage --filename=collector_mock.go
 type CollectorService interface {
 	StoreEvent(dataCollected *datapipeline.DataCollectedEvent) error
+	StoreMalformedEvent(malformedEvent *datapipeline.MalformedEvent) error
+	CompactEvents() (int64, error)
+	RebuildProjection(name string) (int64, error)
 }
 
 type collectorService struct {
@@ -20,10 +25,66 @@ func NewCollectorService(db *gorm.DB, projectorsChannel chan *datapipeline.DataC
 }
 
 func (c *collectorService) StoreEvent(collectedData *datapipeline.DataCollectedEvent) error {
+	// old agents do not send a schema version
+	if collectedData.SchemaVersion == 0 {
+		collectedData.SchemaVersion = 1
+	}
+	if collectedData.SchemaVersion > contracts.Version {
+		return datapipeline.ErrUnsupportedSchemaVersion
+	}
+
+	// fast-path rejection before doing any database work; the non-blocking
+	// send below is what actually guarantees the backpressure
+	if cap(c.projectorsChannel) > 0 && len(c.projectorsChannel) == cap(c.projectorsChannel) {
+		return datapipeline.ErrEventsQueueFull
+	}
+
+	if collectedData.EventID != "" {
+		var count int64
+		err := c.db.Model(&datapipeline.DataCollectedEvent{}).
+			Where("event_id = ?", collectedData.EventID).
+			Count(&count).
+			Error
+		if err != nil {
+			return err
+		}
+
+		if count > 0 {
+			log.Debugf("Event %s was already stored, skipping duplicate submission", collectedData.EventID)
+			return nil
+		}
+	}
+
 	if err := c.db.Create(collectedData).Error; err != nil {
 		return err
 	}
-	c.projectorsChannel <- collectedData
+
+	select {
+	case c.projectorsChannel <- collectedData:
+	default:
+		// the queue filled up between the saturation check and here: undo the
+		// store, so that the retried submission is not dropped as a duplicate
+		if err := c.db.Delete(collectedData).Error; err != nil {
+			log.Warnf("could not undo the store of rejected event %d: %s", collectedData.ID, err)
+		}
+		return datapipeline.ErrEventsQueueFull
+	}
 
 	return nil
 }
+
+func (c *collectorService) StoreMalformedEvent(malformedEvent *datapipeline.MalformedEvent) error {
+	return c.db.Create(malformedEvent).Error
+}
+
+// CompactEvents discards the already-projected historical events,
+// see datapipeline.CompactEvents
+func (c *collectorService) CompactEvents() (int64, error) {
+	return datapipeline.CompactEvents(c.db)
+}
+
+// RebuildProjection truncates a single read model and replays the stored
+// events through the matching projector, see datapipeline.RebuildProjection
+func (c *collectorService) RebuildProjection(name string) (int64, error) {
+	return datapipeline.RebuildProjection(c.db, name)
+}
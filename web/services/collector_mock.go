@@ -12,6 +12,48 @@ type MockCollectorService struct {
 	mock.Mock
 }
 
+// CompactEvents provides a mock function with given fields:
+func (_m *MockCollectorService) CompactEvents() (int64, error) {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RebuildProjection provides a mock function with given fields: name
+func (_m *MockCollectorService) RebuildProjection(name string) (int64, error) {
+	ret := _m.Called(name)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string) int64); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // StoreEvent provides a mock function with given fields: dataCollected
 func (_m *MockCollectorService) StoreEvent(dataCollected *datapipeline.DataCollectedEvent) error {
 	ret := _m.Called(dataCollected)
@@ -25,3 +67,17 @@ func (_m *MockCollectorService) StoreEvent(dataCollected *datapipeline.DataColle
 
 	return r0
 }
+
+// StoreMalformedEvent provides a mock function with given fields: malformedEvent
+func (_m *MockCollectorService) StoreMalformedEvent(malformedEvent *datapipeline.MalformedEvent) error {
+	ret := _m.Called(malformedEvent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*datapipeline.MalformedEvent) error); ok {
+		r0 = rf(malformedEvent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
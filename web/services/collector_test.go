@@ -3,13 +3,47 @@ package services
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/contracts"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
 )
 
+// TestCollectorService_StoreEventQueueFull does not need a database:
+// saturation is checked before the event is stored
+func TestCollectorService_StoreEventQueueFull(t *testing.T) {
+	ch := make(chan *datapipeline.DataCollectedEvent, 1)
+	ch <- &datapipeline.DataCollectedEvent{}
+
+	collectorService := NewCollectorService(nil, ch)
+	err := collectorService.StoreEvent(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: "test_discovery_type",
+		Payload:       []byte("{}"),
+	})
+
+	assert.ErrorIs(t, err, datapipeline.ErrEventsQueueFull)
+}
+
+// TestCollectorService_StoreEventUnsupportedSchemaVersion does not need a
+// database either: the version is checked before the event is stored
+func TestCollectorService_StoreEventUnsupportedSchemaVersion(t *testing.T) {
+	ch := make(chan *datapipeline.DataCollectedEvent, 1)
+
+	collectorService := NewCollectorService(nil, ch)
+	err := collectorService.StoreEvent(&datapipeline.DataCollectedEvent{
+		AgentID:       "agent_id",
+		DiscoveryType: "test_discovery_type",
+		Payload:       []byte("{}"),
+		SchemaVersion: contracts.Version + 1,
+	})
+
+	assert.ErrorIs(t, err, datapipeline.ErrUnsupportedSchemaVersion)
+}
+
 type CollectorServiceTestSuite struct {
 	suite.Suite
 	db               *gorm.DB
@@ -25,7 +59,7 @@ func TestCollectorServiceTestSuite(t *testing.T) {
 func (suite *CollectorServiceTestSuite) SetupSuite() {
 	suite.db = helpers.SetupTestDatabase(suite.T())
 
-	suite.db.AutoMigrate(&datapipeline.DataCollectedEvent{})
+	suite.db.AutoMigrate(&datapipeline.DataCollectedEvent{}, &datapipeline.MalformedEvent{})
 }
 
 func (suite *CollectorServiceTestSuite) TearDownSuite() {
@@ -60,3 +94,44 @@ func (suite *CollectorServiceTestSuite) TestCollectorService_StoreEvent() {
 	suite.EqualValues(eventFromChannel.DiscoveryType, eventFromDB.DiscoveryType)
 	suite.EqualValues(eventFromChannel.Payload, eventFromDB.Payload)
 }
+
+func (suite *CollectorServiceTestSuite) TestCollectorService_StoreEventDropsDuplicates() {
+	storeEvent := func() error {
+		return suite.collectorService.StoreEvent(&datapipeline.DataCollectedEvent{
+			EventID:       "7a9c47d2-7969-428b-9f47-2e1b2dbea9dd",
+			Checksum:      "some-checksum",
+			AgentID:       "agent_id",
+			DiscoveryType: "test_discovery_type",
+			Payload:       []byte("{}"),
+		})
+	}
+
+	suite.NoError(storeEvent())
+	<-suite.ch
+
+	// a retried submission with the same event ID is dropped
+	suite.NoError(storeEvent())
+
+	var count int64
+	suite.tx.Model(&datapipeline.DataCollectedEvent{}).Count(&count)
+	suite.EqualValues(1, count)
+	suite.Empty(suite.ch)
+}
+
+func (suite *CollectorServiceTestSuite) TestCollectorService_StoreMalformedEvent() {
+	err := suite.collectorService.StoreMalformedEvent(&datapipeline.MalformedEvent{
+		AgentID:          "agent_id",
+		DiscoveryType:    "host_discovery",
+		Payload:          []byte(`{"cpu_count": "four"}`),
+		ValidationErrors: []string{"(root): hostname is required"},
+	})
+	suite.NoError(err)
+
+	var malformedEvent datapipeline.MalformedEvent
+	suite.tx.First(&malformedEvent)
+
+	suite.Equal("agent_id", malformedEvent.AgentID)
+	suite.Equal("host_discovery", malformedEvent.DiscoveryType)
+	suite.EqualValues([]string{"(root): hostname is required"}, malformedEvent.ValidationErrors)
+	suite.Empty(suite.ch)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"net"
+	"os"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+const sshProbeTimeout = 5 * time.Second
+
+//go:generate mockery --name=ConnectionsService --inpackage --filename=connections_mock.go
+
+// ConnectionsService probes the cluster nodes with the connection settings
+// used by the runner, so that bad credentials surface before the next
+// scheduled execution fails with them
+type ConnectionsService interface {
+	ValidateConnectionSettings(clusterId string, users map[string]string) (map[string]*models.ConnectionStatus, error)
+}
+
+//go:generate mockery --name=ConnectionProbe --inpackage --filename=connection_probe_mock.go
+
+// ConnectionProbe attempts an actual SSH connection with passwordless sudo
+// against a single node
+type ConnectionProbe interface {
+	Probe(address, user string) error
+}
+
+type connectionsService struct {
+	db    *gorm.DB
+	probe ConnectionProbe
+}
+
+func NewConnectionsService(db *gorm.DB, probe ConnectionProbe) *connectionsService {
+	return &connectionsService{db: db, probe: probe}
+}
+
+func (s *connectionsService) ValidateConnectionSettings(clusterId string, users map[string]string) (map[string]*models.ConnectionStatus, error) {
+	var hosts []*entities.Host
+	err := s.db.
+		Where("cluster_id", clusterId).
+		Order("name").
+		Find(&hosts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*models.ConnectionStatus)
+	for _, host := range hosts {
+		user := users[host.Name]
+		if user == "" {
+			user, err = getDefaultUserName(host)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		status := &models.ConnectionStatus{
+			Node:      host.Name,
+			User:      user,
+			Reachable: true,
+		}
+
+		if err := s.probe.Probe(host.SSHAddress, user); err != nil {
+			status.Reachable = false
+			status.Msg = err.Error()
+		}
+
+		statuses[host.Name] = status
+	}
+
+	return statuses, nil
+}
+
+type sshConnectionProbe struct{}
+
+func NewSSHConnectionProbe() *sshConnectionProbe {
+	return &sshConnectionProbe{}
+}
+
+func (p *sshConnectionProbe) Probe(address, user string) error {
+	// the runner requires passwordless sudo on the nodes
+	return sshRunCommand(address, user, "sudo -n true")
+}
+
+type sshRemoteExecutor struct{}
+
+func NewSSHRemoteExecutor() *sshRemoteExecutor {
+	return &sshRemoteExecutor{}
+}
+
+func (e *sshRemoteExecutor) Execute(address, user, command string) error {
+	return sshRunCommand(address, user, command)
+}
+
+func sshRunCommand(address, user, command string) error {
+	config := &ssh.ClientConfig{
+		User:            user,
+		Auth:            sshAuthMethods(),
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshProbeTimeout,
+	}
+
+	client, err := ssh.Dial("tcp", net.JoinHostPort(address, "22"), config)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	return session.Run(command)
+}
+
+// sshAuthMethods authenticates through the same SSH agent the runner relies
+// on, when one is available
+func sshAuthMethods() []ssh.AuthMethod {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil
+	}
+
+	return []ssh.AuthMethod{ssh.PublicKeysCallback(agent.NewClient(conn).Signers)}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func connectionsFixtures() []entities.Host {
+	return []entities.Host{
+		{
+			AgentID:    "1",
+			Name:       "node1",
+			ClusterID:  "cluster1",
+			SSHAddress: "10.74.1.5",
+		},
+		{
+			AgentID:       "2",
+			Name:          "node2",
+			ClusterID:     "cluster1",
+			SSHAddress:    "10.74.1.10",
+			CloudProvider: "azure",
+			CloudData:     datatypes.JSON(`{"admin_username": "cloudadmin"}`),
+		},
+	}
+}
+
+type ConnectionsServiceTestSuite struct {
+	suite.Suite
+	db                 *gorm.DB
+	tx                 *gorm.DB
+	probe              *MockConnectionProbe
+	connectionsService *connectionsService
+}
+
+func TestConnectionsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ConnectionsServiceTestSuite))
+}
+
+func (suite *ConnectionsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&entities.Host{})
+	hosts := connectionsFixtures()
+	err := suite.db.Create(&hosts).Error
+	suite.NoError(err)
+}
+
+func (suite *ConnectionsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(&entities.Host{})
+}
+
+func (suite *ConnectionsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.probe = new(MockConnectionProbe)
+	suite.connectionsService = NewConnectionsService(suite.tx, suite.probe)
+}
+
+func (suite *ConnectionsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ConnectionsServiceTestSuite) TestConnectionsService_ValidateConnectionSettings() {
+	suite.probe.On("Probe", "10.74.1.5", "myuser").Return(nil)
+	suite.probe.On("Probe", "10.74.1.10", "cloudadmin").Return(errors.New("ssh: handshake failed"))
+
+	statuses, err := suite.connectionsService.ValidateConnectionSettings("cluster1", map[string]string{
+		"node1": "myuser",
+	})
+
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	suite.Equal("node1", statuses["node1"].Node)
+	suite.Equal("myuser", statuses["node1"].User)
+	suite.True(statuses["node1"].Reachable)
+	suite.Empty(statuses["node1"].Msg)
+
+	// node2 has no explicit user, so the cloud default is probed
+	suite.Equal("node2", statuses["node2"].Node)
+	suite.Equal("cloudadmin", statuses["node2"].User)
+	suite.False(statuses["node2"].Reachable)
+	suite.Equal("ssh: handshake failed", statuses["node2"].Msg)
+}
+
+func (suite *ConnectionsServiceTestSuite) TestConnectionsService_ValidateConnectionSettingsUnknownCluster() {
+	statuses, err := suite.connectionsService.ValidateConnectionSettings("other_cluster", nil)
+
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
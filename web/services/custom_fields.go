@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var (
+	ErrCustomFieldAlreadyDefined      = errors.New("the custom field is already defined")
+	ErrCustomFieldNotDefined          = errors.New("the custom field is not defined for this resource type")
+	ErrCustomFieldInvalidType         = errors.New("the custom field type is not supported")
+	ErrCustomFieldInvalidResourceType = errors.New("the resource type does not support custom fields")
+	ErrCustomFieldInvalidValue        = errors.New("the value does not match the custom field type")
+)
+
+// customFieldResourceTypes are the resource types custom fields can be
+// defined on, reusing the tags resource type names
+var customFieldResourceTypes = []string{
+	models.TagHostResourceType,
+	models.TagClusterResourceType,
+	models.TagSAPSystemResourceType,
+	models.TagDatabaseResourceType,
+}
+
+var customFieldTypes = []string{
+	models.CustomFieldTypeString,
+	models.CustomFieldTypeNumber,
+}
+
+//go:generate mockery --name=CustomFieldsService --inpackage --filename=custom_fields_mock.go
+
+// CustomFieldsService manages the typed custom fields (owner, cost center,
+// SLA tier...) that complement tags: the fields available on a resource type
+// are declared once by an administrator, then set per resource
+type CustomFieldsService interface {
+	GetDefinitions(resourceType string) (models.CustomFieldDefinitionList, error)
+	CreateDefinition(resourceType, name, fieldType string) error
+	// DeleteDefinition removes the definition and every stored value of it
+	DeleteDefinition(resourceType, name string) error
+	GetValues(resourceType, resourceID string) (map[string]string, error)
+	SetValue(resourceType, resourceID, name, value string) error
+	DeleteValue(resourceType, resourceID, name string) error
+}
+
+type customFieldsService struct {
+	db *gorm.DB
+}
+
+func NewCustomFieldsService(db *gorm.DB) *customFieldsService {
+	return &customFieldsService{db: db}
+}
+
+func (s *customFieldsService) GetDefinitions(resourceType string) (models.CustomFieldDefinitionList, error) {
+	var definitions []entities.CustomFieldDefinition
+
+	err := s.db.
+		Where("resource_type", resourceType).
+		Order("name").
+		Find(&definitions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	definitionList := models.CustomFieldDefinitionList{}
+	for _, definition := range definitions {
+		definitionList = append(definitionList, &models.CustomFieldDefinition{
+			ResourceType: definition.ResourceType,
+			Name:         definition.Name,
+			Type:         definition.Type,
+		})
+	}
+
+	return definitionList, nil
+}
+
+func (s *customFieldsService) CreateDefinition(resourceType, name, fieldType string) error {
+	if !internal.Contains(customFieldResourceTypes, resourceType) {
+		return ErrCustomFieldInvalidResourceType
+	}
+	if !internal.Contains(customFieldTypes, fieldType) {
+		return ErrCustomFieldInvalidType
+	}
+
+	_, err := s.getDefinition(resourceType, name)
+	if err == nil {
+		return ErrCustomFieldAlreadyDefined
+	}
+	if !errors.Is(err, ErrCustomFieldNotDefined) {
+		return err
+	}
+
+	return s.db.Create(&entities.CustomFieldDefinition{
+		ResourceType: resourceType,
+		Name:         name,
+		Type:         fieldType,
+	}).Error
+}
+
+func (s *customFieldsService) DeleteDefinition(resourceType, name string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Where("resource_type", resourceType).
+			Where("name", name).
+			Delete(&entities.CustomFieldDefinition{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrCustomFieldNotDefined
+		}
+
+		return tx.
+			Where("resource_type", resourceType).
+			Where("name", name).
+			Delete(&entities.CustomFieldValue{}).
+			Error
+	})
+}
+
+func (s *customFieldsService) GetValues(resourceType, resourceID string) (map[string]string, error) {
+	var values []entities.CustomFieldValue
+
+	err := s.db.
+		Where("resource_type", resourceType).
+		Where("resource_id", resourceID).
+		Find(&values).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for _, value := range values {
+		fields[value.Name] = value.Value
+	}
+
+	return fields, nil
+}
+
+func (s *customFieldsService) SetValue(resourceType, resourceID, name, value string) error {
+	definition, err := s.getDefinition(resourceType, name)
+	if err != nil {
+		return err
+	}
+
+	if definition.Type == models.CustomFieldTypeNumber {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return ErrCustomFieldInvalidValue
+		}
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "resource_type"},
+			{Name: "resource_id"},
+			{Name: "name"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"value"}),
+	}).Create(&entities.CustomFieldValue{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Name:         name,
+		Value:        value,
+	}).Error
+}
+
+func (s *customFieldsService) DeleteValue(resourceType, resourceID, name string) error {
+	return s.db.
+		Where("resource_type", resourceType).
+		Where("resource_id", resourceID).
+		Where("name", name).
+		Delete(&entities.CustomFieldValue{}).
+		Error
+}
+
+func (s *customFieldsService) getDefinition(resourceType, name string) (*entities.CustomFieldDefinition, error) {
+	var definition entities.CustomFieldDefinition
+
+	err := s.db.
+		Where("resource_type", resourceType).
+		Where("name", name).
+		First(&definition).
+		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCustomFieldNotDefined
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &definition, nil
+}
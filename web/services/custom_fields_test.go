@@ -0,0 +1,136 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type CustomFieldsServiceTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	tx                  *gorm.DB
+	customFieldsService CustomFieldsService
+}
+
+func TestCustomFieldsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CustomFieldsServiceTestSuite))
+}
+
+func (suite *CustomFieldsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.CustomFieldDefinition{}, entities.CustomFieldValue{})
+}
+
+func (suite *CustomFieldsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.CustomFieldDefinition{}, entities.CustomFieldValue{})
+}
+
+func (suite *CustomFieldsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.customFieldsService = NewCustomFieldsService(suite.tx)
+}
+
+func (suite *CustomFieldsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *CustomFieldsServiceTestSuite) TestCustomFieldsService_Definitions() {
+	err := suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "owner", models.CustomFieldTypeString)
+	suite.NoError(err)
+
+	err = suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "owner", models.CustomFieldTypeString)
+	suite.ErrorIs(err, ErrCustomFieldAlreadyDefined)
+
+	err = suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "sla", "boolean")
+	suite.ErrorIs(err, ErrCustomFieldInvalidType)
+
+	err = suite.customFieldsService.CreateDefinition(
+		"printers", "owner", models.CustomFieldTypeString)
+	suite.ErrorIs(err, ErrCustomFieldInvalidResourceType)
+
+	definitions, err := suite.customFieldsService.GetDefinitions(models.TagHostResourceType)
+	suite.NoError(err)
+	suite.Equal(models.CustomFieldDefinitionList{
+		{
+			ResourceType: models.TagHostResourceType,
+			Name:         "owner",
+			Type:         models.CustomFieldTypeString,
+		},
+	}, definitions)
+}
+
+func (suite *CustomFieldsServiceTestSuite) TestCustomFieldsService_SetValue() {
+	err := suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "owner", models.CustomFieldTypeString)
+	suite.NoError(err)
+	err = suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "cost_center", models.CustomFieldTypeNumber)
+	suite.NoError(err)
+
+	suite.NoError(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "owner", "team-hana"))
+	suite.NoError(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "cost_center", "4242"))
+
+	// values of undefined fields are rejected
+	suite.ErrorIs(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "undefined", "value"), ErrCustomFieldNotDefined)
+
+	// number fields only accept numeric values
+	suite.ErrorIs(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "cost_center", "not-a-number"), ErrCustomFieldInvalidValue)
+
+	// setting an already set field overwrites it
+	suite.NoError(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "owner", "team-netweaver"))
+
+	values, err := suite.customFieldsService.GetValues(models.TagHostResourceType, "1")
+	suite.NoError(err)
+	suite.Equal(map[string]string{
+		"owner":       "team-netweaver",
+		"cost_center": "4242",
+	}, values)
+}
+
+func (suite *CustomFieldsServiceTestSuite) TestCustomFieldsService_DeleteValue() {
+	err := suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "owner", models.CustomFieldTypeString)
+	suite.NoError(err)
+	suite.NoError(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "owner", "team-hana"))
+
+	suite.NoError(suite.customFieldsService.DeleteValue(
+		models.TagHostResourceType, "1", "owner"))
+
+	values, err := suite.customFieldsService.GetValues(models.TagHostResourceType, "1")
+	suite.NoError(err)
+	suite.Empty(values)
+}
+
+func (suite *CustomFieldsServiceTestSuite) TestCustomFieldsService_DeleteDefinition() {
+	err := suite.customFieldsService.CreateDefinition(
+		models.TagHostResourceType, "owner", models.CustomFieldTypeString)
+	suite.NoError(err)
+	suite.NoError(suite.customFieldsService.SetValue(
+		models.TagHostResourceType, "1", "owner", "team-hana"))
+
+	suite.NoError(suite.customFieldsService.DeleteDefinition(
+		models.TagHostResourceType, "owner"))
+
+	// the stored values are deleted together with the definition
+	values, err := suite.customFieldsService.GetValues(models.TagHostResourceType, "1")
+	suite.NoError(err)
+	suite.Empty(values)
+
+	suite.ErrorIs(suite.customFieldsService.DeleteDefinition(
+		models.TagHostResourceType, "owner"), ErrCustomFieldNotDefined)
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// GetDriftByClusterID compares the discovered facts of the cluster nodes
+// and reports the ones where the nodes diverge: OS and kernel versions,
+// agent versions, installed HA/SAP package versions and the checksums of
+// key HA configuration files.
+// It returns nil when the cluster is not found.
+func (s *clustersService) GetDriftByClusterID(clusterID string) (*models.ClusterDrift, error) {
+	var cluster entities.Cluster
+
+	err := s.db.
+		Preload("Hosts").
+		Where("id = ?", clusterID).
+		First(&cluster).
+		Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	fieldValues := make(map[string]map[string]string)
+	record := func(field, node, value string) {
+		if value == "" {
+			return
+		}
+		if fieldValues[field] == nil {
+			fieldValues[field] = make(map[string]string)
+		}
+		fieldValues[field][node] = value
+	}
+
+	for _, host := range cluster.Hosts {
+		record("os_version", host.Name, host.OSVersion)
+		record("kernel_version", host.Name, host.KernelVersion)
+		record("agent_version", host.Name, host.AgentVersion)
+
+		var checksums map[string]string
+		if host.ConfigFilesChecksums != nil {
+			if err := json.Unmarshal(host.ConfigFilesChecksums, &checksums); err == nil {
+				for path, checksum := range checksums {
+					record("config:"+path, host.Name, checksum)
+				}
+			}
+		}
+
+		var packages map[string]string
+		if host.InstalledPackages != nil {
+			if err := json.Unmarshal(host.InstalledPackages, &packages); err == nil {
+				for name, version := range packages {
+					record("package:"+name, host.Name, version)
+				}
+			}
+		}
+	}
+
+	drift := &models.ClusterDrift{
+		ClusterID: clusterID,
+		Fields:    []*models.ClusterDriftField{},
+	}
+
+	var fieldNames []string
+	for name := range fieldValues {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+
+	for _, name := range fieldNames {
+		values := fieldValues[name]
+
+		distinct := make(map[string]struct{})
+		for _, value := range values {
+			distinct[value] = struct{}{}
+		}
+
+		field := &models.ClusterDriftField{
+			Name:    name,
+			Values:  values,
+			Drifted: len(distinct) > 1,
+		}
+		if field.Drifted {
+			drift.Drifted = true
+		}
+
+		drift.Fields = append(drift.Fields, field)
+	}
+
+	return drift, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+func (suite *ClustersServiceTestSuite) TestClustersService_GetDriftByClusterID() {
+	corosyncConf := "/etc/corosync/corosync.conf"
+	checksums1, _ := json.Marshal(map[string]string{corosyncConf: "aaaa"})
+	checksums2, _ := json.Marshal(map[string]string{corosyncConf: "bbbb"})
+
+	suite.tx.Create(&entities.Cluster{
+		ID:          "drifted-cluster",
+		Name:        "drifted_cluster",
+		ClusterType: models.ClusterTypeHANAScaleUp,
+		Hosts: []*entities.Host{
+			{
+				AgentID:              "drift-host-1",
+				ClusterID:            "drifted-cluster",
+				Name:                 "node01",
+				OSVersion:            "15-SP2",
+				KernelVersion:        "5.3.18-24.102-default",
+				AgentVersion:         "1.0.0",
+				ConfigFilesChecksums: datatypes.JSON(checksums1),
+			},
+			{
+				AgentID:              "drift-host-2",
+				ClusterID:            "drifted-cluster",
+				Name:                 "node02",
+				OSVersion:            "15-SP2",
+				KernelVersion:        "5.3.18-24.99-default",
+				AgentVersion:         "1.0.0",
+				ConfigFilesChecksums: datatypes.JSON(checksums2),
+			},
+		},
+	})
+
+	drift, err := suite.clustersService.GetDriftByClusterID("drifted-cluster")
+
+	suite.NoError(err)
+	suite.True(drift.Drifted)
+
+	fields := make(map[string]*models.ClusterDriftField)
+	for _, field := range drift.Fields {
+		fields[field.Name] = field
+	}
+
+	suite.False(fields["os_version"].Drifted)
+	suite.False(fields["agent_version"].Drifted)
+
+	suite.True(fields["kernel_version"].Drifted)
+	suite.Equal(map[string]string{
+		"node01": "5.3.18-24.102-default",
+		"node02": "5.3.18-24.99-default",
+	}, fields["kernel_version"].Values)
+
+	suite.True(fields["config:"+corosyncConf].Drifted)
+
+	// the fixture cluster nodes carry no comparable facts at all
+	drift, err = suite.clustersService.GetDriftByClusterID("1")
+	suite.NoError(err)
+	suite.False(drift.Drifted)
+
+	drift, err = suite.clustersService.GetDriftByClusterID("not_found")
+	suite.NoError(err)
+	suite.Nil(drift)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=EventBusPublisher --inpackage --filename=eventbus_mock.go
+
+// EventBusPublisher forwards the recorded domain events to an external bus,
+// so downstream systems can react to them without polling the REST API
+type EventBusPublisher interface {
+	Publish(event *models.ResourceEvent) error
+}
+
+type natsEventBusPublisher struct {
+	conn          *nats.Conn
+	subjectPrefix string
+}
+
+// NewNATSEventBusPublisher connects to a NATS server and publishes each event
+// to a per-type subject below the given prefix, e.g. trento.events.health
+func NewNATSEventBusPublisher(url, subjectPrefix string) (*natsEventBusPublisher, error) {
+	conn, err := nats.Connect(url,
+		nats.RetryOnFailedConnect(true),
+		nats.MaxReconnects(-1),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &natsEventBusPublisher{
+		conn:          conn,
+		subjectPrefix: subjectPrefix,
+	}, nil
+}
+
+func (p *natsEventBusPublisher) Publish(event *models.ResourceEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return p.conn.Publish(fmt.Sprintf("%s.%s", p.subjectPrefix, event.Type), payload)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=EventsService --inpackage --filename=events_mock.go
+
+// EventsService records notable state transitions of the monitored resources
+// and serves them back as a chronological timeline
+type EventsService interface {
+	StoreEvent(event *models.ResourceEvent) error
+	GetByResource(resourceType string, resourceID string) (models.ResourceEventList, error)
+}
+
+type eventsService struct {
+	db        *gorm.DB
+	publisher EventBusPublisher
+}
+
+// NewEventsService creates a new events service. The publisher is optional:
+// when nil, the events are only recorded locally and not forwarded to an
+// external bus
+func NewEventsService(db *gorm.DB, publisher EventBusPublisher) *eventsService {
+	return &eventsService{db: db, publisher: publisher}
+}
+
+func (s *eventsService) StoreEvent(event *models.ResourceEvent) error {
+	occurredAt := event.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	err := s.db.Create(&entities.ResourceEvent{
+		ResourceType: event.ResourceType,
+		ResourceID:   event.ResourceID,
+		Type:         event.Type,
+		Message:      event.Message,
+		OccurredAt:   occurredAt,
+	}).Error
+	if err != nil {
+		return err
+	}
+
+	if s.publisher != nil {
+		publishedEvent := *event
+		publishedEvent.OccurredAt = occurredAt
+		// an unreachable bus must not lose the local timeline entry
+		if err := s.publisher.Publish(&publishedEvent); err != nil {
+			log.Warnf("Could not publish the %s event to the external bus: %s", event.Type, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *eventsService) GetByResource(resourceType string, resourceID string) (models.ResourceEventList, error) {
+	var eventEntities []entities.ResourceEvent
+
+	err := s.db.
+		Where("resource_type = ?", resourceType).
+		Where("resource_id = ?", resourceID).
+		Order("occurred_at DESC, id DESC").
+		Find(&eventEntities).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(models.ResourceEventList, 0, len(eventEntities))
+	for _, eventEntity := range eventEntities {
+		events = append(events, eventEntity.ToModel())
+	}
+
+	return events, nil
+}
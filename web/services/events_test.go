@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type EventsServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	tx            *gorm.DB
+	eventsService *eventsService
+}
+
+func TestEventsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(EventsServiceTestSuite))
+}
+
+func (suite *EventsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&entities.ResourceEvent{})
+}
+
+func (suite *EventsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(&entities.ResourceEvent{})
+}
+
+func (suite *EventsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.eventsService = NewEventsService(suite.tx, nil)
+}
+
+func (suite *EventsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *EventsServiceTestSuite) TestEventsService_StoreAndGetByResource() {
+	older := time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC)
+	newer := time.Date(2022, 2, 1, 11, 30, 0, 0, time.UTC)
+
+	err := suite.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "agent-1",
+		Type:         models.ResourceEventTypeHeartbeat,
+		Message:      "Agent started sending heartbeats",
+		OccurredAt:   older,
+	})
+	suite.NoError(err)
+
+	err = suite.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "agent-1",
+		Type:         models.ResourceEventTypeTag,
+		Message:      "Tag prod added",
+		OccurredAt:   newer,
+	})
+	suite.NoError(err)
+
+	err = suite.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: models.TagClusterResourceType,
+		ResourceID:   "cluster-1",
+		Type:         models.ResourceEventTypeHealth,
+		Message:      "Health changed from passing to critical",
+	})
+	suite.NoError(err)
+
+	events, err := suite.eventsService.GetByResource(models.TagHostResourceType, "agent-1")
+	suite.NoError(err)
+	suite.Len(events, 2)
+
+	// the timeline comes back newest first
+	suite.Equal(models.ResourceEventTypeTag, events[0].Type)
+	suite.Equal("Tag prod added", events[0].Message)
+	suite.WithinDuration(newer, events[0].OccurredAt, time.Second)
+	suite.Equal(models.ResourceEventTypeHeartbeat, events[1].Type)
+	suite.WithinDuration(older, events[1].OccurredAt, time.Second)
+
+	clusterEvents, err := suite.eventsService.GetByResource(models.TagClusterResourceType, "cluster-1")
+	suite.NoError(err)
+	suite.Len(clusterEvents, 1)
+	// events without an explicit timestamp are stamped on arrival
+	suite.WithinDuration(time.Now(), clusterEvents[0].OccurredAt, 5*time.Second)
+
+	noEvents, err := suite.eventsService.GetByResource(models.TagHostResourceType, "agent-2")
+	suite.NoError(err)
+	suite.Empty(noEvents)
+}
+
+func (suite *EventsServiceTestSuite) TestEventsService_StoreEventPublishes() {
+	publisher := new(MockEventBusPublisher)
+	publisher.On("Publish", mock.MatchedBy(func(event *models.ResourceEvent) bool {
+		return event.Type == models.ResourceEventTypeHealth &&
+			event.Message == "Health changed from passing to critical" &&
+			!event.OccurredAt.IsZero()
+	})).Return(nil)
+	eventsService := NewEventsService(suite.tx, publisher)
+
+	err := eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: models.TagClusterResourceType,
+		ResourceID:   "cluster-1",
+		Type:         models.ResourceEventTypeHealth,
+		Message:      "Health changed from passing to critical",
+	})
+	suite.NoError(err)
+
+	publisher.AssertExpectations(suite.T())
+
+	// a failing publisher must not fail the local write
+	failingPublisher := new(MockEventBusPublisher)
+	failingPublisher.On("Publish", mock.Anything).Return(errors.New("bus unreachable"))
+	eventsService = NewEventsService(suite.tx, failingPublisher)
+
+	err = eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "agent-3",
+		Type:         models.ResourceEventTypeHeartbeat,
+		Message:      "Agent started sending heartbeats",
+	})
+	suite.NoError(err)
+
+	events, err := eventsService.GetByResource(models.TagHostResourceType, "agent-3")
+	suite.NoError(err)
+	suite.Len(events, 1)
+}
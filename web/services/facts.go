@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// factsProbes is the allowlist of commands an agent can be asked to run.
+// Facts gathering is deliberately not an arbitrary shell channel: only these
+// predefined probes can be requested.
+var factsProbes = map[string]string{
+	"corosync-config": "cat /etc/corosync/corosync.conf",
+	"crm-status":      "crm_mon -1r",
+	"saphanasr-state": "SAPHanaSR-showAttr",
+	"sbd-config":      "cat /etc/sysconfig/sbd",
+}
+
+//go:generate mockery --name=FactsService --inpackage --filename=facts_mock.go
+
+// FactsService requests predefined troubleshooting probes from the agents
+// and collects their output
+type FactsService interface {
+	GetAvailableProbes() []string
+	RequestFactsGathering(agentID, probe string) (*models.FactsRequest, error)
+	GetFactsRequestByID(id int64) (*models.FactsRequest, error)
+	GetPendingFactsRequests(agentID string) ([]*models.FactsRequest, error)
+	StoreFactsOutput(id int64, output, gatheringError string) error
+}
+
+type factsService struct {
+	db *gorm.DB
+}
+
+func NewFactsService(db *gorm.DB) *factsService {
+	return &factsService{db}
+}
+
+func (s *factsService) GetAvailableProbes() []string {
+	probes := make([]string, 0, len(factsProbes))
+	for probe := range factsProbes {
+		probes = append(probes, probe)
+	}
+	sort.Strings(probes)
+
+	return probes
+}
+
+func (s *factsService) RequestFactsGathering(agentID, probe string) (*models.FactsRequest, error) {
+	command, ok := factsProbes[probe]
+	if !ok {
+		return nil, fmt.Errorf("unknown facts gathering probe %s", probe)
+	}
+
+	request := entities.FactsRequest{
+		AgentID: agentID,
+		Probe:   probe,
+		Command: command,
+		Status:  models.ExecutionStatusRequested,
+	}
+	if err := s.db.Create(&request).Error; err != nil {
+		return nil, err
+	}
+
+	return request.ToModel(), nil
+}
+
+func (s *factsService) GetFactsRequestByID(id int64) (*models.FactsRequest, error) {
+	var request entities.FactsRequest
+
+	err := s.db.Where("id", id).First(&request).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return request.ToModel(), nil
+}
+
+// GetPendingFactsRequests returns the probes an agent has to run, moving
+// them to the running state so they are only served once
+func (s *factsService) GetPendingFactsRequests(agentID string) ([]*models.FactsRequest, error) {
+	var requests []entities.FactsRequest
+
+	err := s.db.
+		Where("agent_id", agentID).
+		Where("status", models.ExecutionStatusRequested).
+		Order("id").
+		Find(&requests).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	requestModels := []*models.FactsRequest{}
+	for _, request := range requests {
+		err := s.db.Model(&entities.FactsRequest{}).
+			Where("id", request.ID).
+			Update("status", models.ExecutionStatusRunning).
+			Error
+		if err != nil {
+			return nil, err
+		}
+
+		request.Status = models.ExecutionStatusRunning
+		requestModels = append(requestModels, request.ToModel())
+	}
+
+	return requestModels, nil
+}
+
+func (s *factsService) StoreFactsOutput(id int64, output, gatheringError string) error {
+	status := models.ExecutionStatusCompleted
+	if gatheringError != "" {
+		status = models.ExecutionStatusFailed
+		output = gatheringError
+	}
+
+	return s.db.Model(&entities.FactsRequest{}).
+		Where("id", id).
+		Updates(map[string]interface{}{
+			"status": status,
+			"output": output,
+		}).
+		Error
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type FactsServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	factsService *factsService
+}
+
+func TestFactsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FactsServiceTestSuite))
+}
+
+func (suite *FactsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.FactsRequest{})
+}
+
+func (suite *FactsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.FactsRequest{})
+}
+
+func (suite *FactsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.factsService = NewFactsService(suite.tx)
+}
+
+func (suite *FactsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_GetAvailableProbes() {
+	probes := suite.factsService.GetAvailableProbes()
+
+	suite.Equal([]string{"corosync-config", "crm-status", "saphanasr-state", "sbd-config"}, probes)
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_RequestFactsGathering() {
+	request, err := suite.factsService.RequestFactsGathering("agent1", "corosync-config")
+
+	suite.NoError(err)
+	suite.NotZero(request.ID)
+	suite.Equal("agent1", request.AgentID)
+	suite.Equal("corosync-config", request.Probe)
+	suite.Equal("cat /etc/corosync/corosync.conf", request.Command)
+	suite.Equal(models.ExecutionStatusRequested, request.Status)
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_RequestFactsGatheringUnknownProbe() {
+	_, err := suite.factsService.RequestFactsGathering("agent1", "rm -rf /")
+
+	suite.EqualError(err, "unknown facts gathering probe rm -rf /")
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_GetPendingFactsRequests() {
+	request, err := suite.factsService.RequestFactsGathering("agent1", "crm-status")
+	suite.NoError(err)
+
+	pending, err := suite.factsService.GetPendingFactsRequests("agent1")
+	suite.NoError(err)
+	suite.Len(pending, 1)
+	suite.Equal(request.ID, pending[0].ID)
+	suite.Equal("crm_mon -1r", pending[0].Command)
+	suite.Equal(models.ExecutionStatusRunning, pending[0].Status)
+
+	// requests are only served once
+	pending, err = suite.factsService.GetPendingFactsRequests("agent1")
+	suite.NoError(err)
+	suite.Empty(pending)
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_StoreFactsOutput() {
+	request, err := suite.factsService.RequestFactsGathering("agent1", "saphanasr-state")
+	suite.NoError(err)
+
+	err = suite.factsService.StoreFactsOutput(request.ID, "some output", "")
+	suite.NoError(err)
+
+	completed, err := suite.factsService.GetFactsRequestByID(request.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusCompleted, completed.Status)
+	suite.Equal("some output", completed.Output)
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_StoreFactsOutputError() {
+	request, err := suite.factsService.RequestFactsGathering("agent1", "saphanasr-state")
+	suite.NoError(err)
+
+	err = suite.factsService.StoreFactsOutput(request.ID, "", "command not found")
+	suite.NoError(err)
+
+	failed, err := suite.factsService.GetFactsRequestByID(request.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusFailed, failed.Status)
+	suite.Equal("command not found", failed.Output)
+}
+
+func (suite *FactsServiceTestSuite) TestFactsService_GetFactsRequestByIDNotFound() {
+	request, err := suite.factsService.GetFactsRequestByID(12345)
+
+	suite.NoError(err)
+	suite.Nil(request)
+}
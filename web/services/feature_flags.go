@@ -0,0 +1,101 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=FeatureFlagsService --inpackage --filename=feature_flags_mock.go
+
+// defaultFeatureFlags lists the known feature flags and whether they are
+// enabled when the installation has not toggled them explicitly.
+// New experimental subsystems should be registered here as disabled, so that
+// they ship dark and can be enabled per installation without a rebuild.
+var defaultFeatureFlags = map[string]bool{
+	models.FeatureAlerting:       true,
+	models.FeatureEmbeddedRunner: true,
+}
+
+type FeatureFlagsService interface {
+	GetAll() ([]*models.FeatureFlag, error)
+	IsEnabled(name string) bool
+	SetEnabled(name string, enabled bool) error
+}
+
+type featureFlagsService struct {
+	db *gorm.DB
+}
+
+func NewFeatureFlagsService(db *gorm.DB) *featureFlagsService {
+	return &featureFlagsService{db: db}
+}
+
+func (s *featureFlagsService) GetAll() ([]*models.FeatureFlag, error) {
+	var flags []entities.FeatureFlag
+
+	err := s.db.Find(&flags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]bool)
+	for _, flag := range flags {
+		overrides[flag.Name] = flag.Enabled
+	}
+
+	var names []string
+	for name := range defaultFeatureFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	flagModels := []*models.FeatureFlag{}
+	for _, name := range names {
+		enabled, overridden := overrides[name]
+		if !overridden {
+			enabled = defaultFeatureFlags[name]
+		}
+
+		flagModels = append(flagModels, &models.FeatureFlag{
+			Name:    name,
+			Enabled: enabled,
+		})
+	}
+
+	return flagModels, nil
+}
+
+func (s *featureFlagsService) IsEnabled(name string) bool {
+	var flag entities.FeatureFlag
+
+	err := s.db.Where("name", name).First(&flag).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Errorf("could not read the %s feature flag: %s", name, err)
+		}
+		return defaultFeatureFlags[name]
+	}
+
+	return flag.Enabled
+}
+
+func (s *featureFlagsService) SetEnabled(name string, enabled bool) error {
+	if _, found := defaultFeatureFlags[name]; !found {
+		return fmt.Errorf("unknown feature flag: %s", name)
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"enabled", "updated_at"}),
+	}).Create(&entities.FeatureFlag{
+		Name:    name,
+		Enabled: enabled,
+	}).Error
+}
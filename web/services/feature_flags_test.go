@@ -0,0 +1,81 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type FeatureFlagsServiceTestSuite struct {
+	suite.Suite
+	db                  *gorm.DB
+	tx                  *gorm.DB
+	featureFlagsService *featureFlagsService
+}
+
+func TestFeatureFlagsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagsServiceTestSuite))
+}
+
+func (suite *FeatureFlagsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&entities.FeatureFlag{})
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(&entities.FeatureFlag{})
+}
+
+func (suite *FeatureFlagsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.featureFlagsService = NewFeatureFlagsService(suite.tx)
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TestFeatureFlagsService_Defaults() {
+	suite.True(suite.featureFlagsService.IsEnabled(models.FeatureAlerting))
+	suite.True(suite.featureFlagsService.IsEnabled(models.FeatureEmbeddedRunner))
+	suite.False(suite.featureFlagsService.IsEnabled("unknown-feature"))
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TestFeatureFlagsService_SetEnabled() {
+	err := suite.featureFlagsService.SetEnabled(models.FeatureAlerting, false)
+	suite.NoError(err)
+	suite.False(suite.featureFlagsService.IsEnabled(models.FeatureAlerting))
+
+	err = suite.featureFlagsService.SetEnabled(models.FeatureAlerting, true)
+	suite.NoError(err)
+	suite.True(suite.featureFlagsService.IsEnabled(models.FeatureAlerting))
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TestFeatureFlagsService_SetEnabled_Unknown() {
+	err := suite.featureFlagsService.SetEnabled("unknown-feature", true)
+	suite.EqualError(err, "unknown feature flag: unknown-feature")
+}
+
+func (suite *FeatureFlagsServiceTestSuite) TestFeatureFlagsService_GetAll() {
+	err := suite.featureFlagsService.SetEnabled(models.FeatureEmbeddedRunner, false)
+	suite.NoError(err)
+
+	flags, err := suite.featureFlagsService.GetAll()
+	suite.NoError(err)
+
+	suite.Equal([]*models.FeatureFlag{
+		{
+			Name:    models.FeatureAlerting,
+			Enabled: true,
+		},
+		{
+			Name:    models.FeatureEmbeddedRunner,
+			Enabled: false,
+		},
+	}, flags)
+}
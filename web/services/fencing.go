@@ -0,0 +1,151 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// ErrFencingAgentNotSupported is returned when the cluster does not fence
+// through one of the cloud agents whose credentials can be validated
+var ErrFencingAgentNotSupported = errors.New("the cluster does not use a supported cloud fence agent")
+
+//go:generate mockery --name=FencingService --inpackage --filename=fencing_mock.go
+
+// FencingService validates that the credentials configured for the cloud
+// fence agents actually work, instead of waiting for a real failover to
+// expose them
+type FencingService interface {
+	ValidateFencingCredentials(clusterID string) (*models.FencingValidation, error)
+}
+
+// cloudFenceAgents are the stonith agents whose credentials can be validated
+// with a dry-run of their monitor operation
+var cloudFenceAgents = []string{
+	"fence_azure_arm",
+	"fence_aws",
+	"fence_gce",
+}
+
+type fencingService struct {
+	db            *gorm.DB
+	checksService ChecksService
+	executor      RemoteExecutor
+}
+
+func NewFencingService(db *gorm.DB, checksService ChecksService, executor RemoteExecutor) *fencingService {
+	return &fencingService{
+		db:            db,
+		checksService: checksService,
+		executor:      executor,
+	}
+}
+
+// ValidateFencingCredentials runs the monitor operation of the cloud stonith
+// resource on one of the cluster nodes, which contacts the cloud API with the
+// credentials configured in the CIB without fencing anything.
+// It returns nil when the cluster is not found.
+func (s *fencingService) ValidateFencingCredentials(clusterID string) (*models.FencingValidation, error) {
+	var cluster entities.Cluster
+
+	err := s.db.Where("id = ?", clusterID).First(&cluster).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var resource entities.PacemakerResource
+	err = s.db.
+		Where("cluster_id = ?", clusterID).
+		Where("type LIKE ?", "stonith:%").
+		Order("id").
+		First(&resource).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFencingAgentNotSupported
+		}
+		return nil, err
+	}
+
+	agent := strings.TrimPrefix(resource.Type, "stonith:")
+	if !internal.Contains(cloudFenceAgents, agent) {
+		return nil, ErrFencingAgentNotSupported
+	}
+
+	host, err := s.pickNode(clusterID, resource.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.connectionUser(clusterID, host)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &models.FencingValidation{
+		ClusterID: clusterID,
+		Agent:     agent,
+		Node:      host.Name,
+		Valid:     true,
+	}
+
+	// crm_resource --force-check runs the monitor action of the resource
+	// locally with the parameters from the CIB, a credentials dry-run
+	command := fmt.Sprintf("sudo -n crm_resource --force-check -r %s", resource.ID)
+	if err := s.executor.Execute(host.SSHAddress, user, command); err != nil {
+		validation.Valid = false
+		validation.Msg = err.Error()
+	}
+
+	return validation, nil
+}
+
+// pickNode selects the node to run the dry-run on, preferring the one the
+// stonith resource is currently running on
+func (s *fencingService) pickNode(clusterID, resourceNode string) (*entities.Host, error) {
+	var hosts []*entities.Host
+
+	err := s.db.
+		Where("cluster_id", clusterID).
+		Order("name").
+		Find(&hosts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hosts discovered for cluster %s", clusterID)
+	}
+
+	for _, host := range hosts {
+		if host.Name == resourceNode {
+			return host, nil
+		}
+	}
+
+	return hosts[0], nil
+}
+
+// connectionUser resolves the SSH user the same way the runner does: the
+// stored connection settings first, the platform default otherwise
+func (s *fencingService) connectionUser(clusterID string, host *entities.Host) (string, error) {
+	connectionSettings, err := s.checksService.GetConnectionSettingsById(clusterID)
+	if err != nil {
+		return "", err
+	}
+
+	if user := connectionSettings[host.Name].User; user != "" {
+		return user, nil
+	}
+
+	return getDefaultUserName(host)
+}
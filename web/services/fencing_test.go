@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type FencingServiceTestSuite struct {
+	suite.Suite
+	db             *gorm.DB
+	tx             *gorm.DB
+	executor       *MockRemoteExecutor
+	checksService  *MockChecksService
+	fencingService *fencingService
+}
+
+func TestFencingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FencingServiceTestSuite))
+}
+
+func (suite *FencingServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Cluster{}, entities.Host{}, entities.PacemakerResource{})
+
+	suite.db.Create(&entities.Cluster{ID: "azure_cluster", Name: "azure_cluster"})
+	suite.db.Create(&entities.Cluster{ID: "sbd_cluster", Name: "sbd_cluster"})
+
+	suite.db.Create(&entities.Host{
+		AgentID:    "1",
+		Name:       "node01",
+		ClusterID:  "azure_cluster",
+		SSHAddress: "10.74.1.5",
+		CloudData:  []byte(`{"admin_username": "cloudadmin"}`),
+	})
+	suite.db.Create(&entities.Host{
+		AgentID:    "2",
+		Name:       "node02",
+		ClusterID:  "azure_cluster",
+		SSHAddress: "10.74.1.10",
+	})
+
+	suite.db.Create(&entities.PacemakerResource{
+		ID:        "rsc_stonith_azure",
+		ClusterID: "azure_cluster",
+		Node:      "node02",
+		Type:      "stonith:fence_azure_arm",
+	})
+	suite.db.Create(&entities.PacemakerResource{
+		ID:        "stonith-sbd",
+		ClusterID: "sbd_cluster",
+		Node:      "node03",
+		Type:      "stonith:external/sbd",
+	})
+}
+
+func (suite *FencingServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Cluster{}, entities.Host{}, entities.PacemakerResource{})
+}
+
+func (suite *FencingServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.executor = new(MockRemoteExecutor)
+	suite.checksService = new(MockChecksService)
+	suite.fencingService = NewFencingService(suite.tx, suite.checksService, suite.executor)
+}
+
+func (suite *FencingServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *FencingServiceTestSuite) TestFencingService_ValidateFencingCredentials() {
+	suite.checksService.On("GetConnectionSettingsById", "azure_cluster").Return(
+		map[string]models.ConnectionSettings{
+			"node02": {ID: "azure_cluster", Node: "node02", User: "fencer"},
+		}, nil)
+
+	// the dry-run runs on the node currently holding the stonith resource
+	suite.executor.On("Execute", "10.74.1.10", "fencer",
+		"sudo -n crm_resource --force-check -r rsc_stonith_azure").Return(nil)
+
+	validation, err := suite.fencingService.ValidateFencingCredentials("azure_cluster")
+	suite.NoError(err)
+
+	suite.Equal("azure_cluster", validation.ClusterID)
+	suite.Equal("fence_azure_arm", validation.Agent)
+	suite.Equal("node02", validation.Node)
+	suite.True(validation.Valid)
+	suite.executor.AssertExpectations(suite.T())
+}
+
+func (suite *FencingServiceTestSuite) TestFencingService_ValidateFencingCredentials_Broken() {
+	suite.checksService.On("GetConnectionSettingsById", "azure_cluster").Return(
+		map[string]models.ConnectionSettings{
+			"node02": {ID: "azure_cluster", Node: "node02", User: "fencer"},
+		}, nil)
+
+	suite.executor.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("Error performing operation: Permission denied"))
+
+	validation, err := suite.fencingService.ValidateFencingCredentials("azure_cluster")
+	suite.NoError(err)
+
+	suite.False(validation.Valid)
+	suite.Equal("Error performing operation: Permission denied", validation.Msg)
+}
+
+func (suite *FencingServiceTestSuite) TestFencingService_ValidateFencingCredentials_Unsupported() {
+	_, err := suite.fencingService.ValidateFencingCredentials("sbd_cluster")
+	suite.ErrorIs(err, ErrFencingAgentNotSupported)
+}
+
+func (suite *FencingServiceTestSuite) TestFencingService_ValidateFencingCredentials_NotFound() {
+	validation, err := suite.fencingService.ValidateFencingCredentials("not_found")
+	suite.NoError(err)
+	suite.Nil(validation)
+}
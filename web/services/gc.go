@@ -0,0 +1,182 @@
+package services
+
+import (
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+// hostOwnedTable describes a table holding rows keyed by the agent ID of a
+// host: they are removed together with the host and scanned for orphans
+type hostOwnedTable struct {
+	name  string
+	model interface{}
+}
+
+var hostOwnedTables = []hostOwnedTable{
+	{name: "host_heartbeats", model: &entities.HostHeartbeat{}},
+	{name: "host_telemetry", model: &entities.HostTelemetry{}},
+	{name: "sles_subscriptions", model: &entities.SlesSubscription{}},
+	{name: "sap_system_instances", model: &entities.SAPSystemInstance{}},
+	{name: "agent_configs", model: &entities.AgentConfig{}},
+	{name: "agent_errors", model: &entities.AgentError{}},
+}
+
+//go:generate mockery --name=GarbageCollectionService --inpackage --filename=gc_mock.go
+
+type GarbageCollectionService interface {
+	DeleteHost(agentID string) error
+	Report() (*models.GarbageCollectionReport, error)
+	Collect() (int64, error)
+}
+
+type garbageCollectionService struct {
+	db           *gorm.DB
+	trashService TrashService
+}
+
+func NewGarbageCollectionService(db *gorm.DB, trashService TrashService) *garbageCollectionService {
+	return &garbageCollectionService{db: db, trashService: trashService}
+}
+
+// DeleteHost removes a host together with every row that belongs to it:
+// heartbeats, telemetry, subscriptions, SAP instances, agent data, tags and
+// the collected discovery events, all within a single transaction.
+// The host row and its tags are snapshotted into the trash first, so an
+// accidental removal can be restored.
+func (s *garbageCollectionService) DeleteHost(agentID string) error {
+	if err := s.trashHost(agentID); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range hostOwnedTables {
+			if err := tx.Where("agent_id = ?", agentID).Delete(table.model).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, model := range []interface{}{
+			&datapipeline.DataCollectedEvent{},
+			&datapipeline.MalformedEvent{},
+			&datapipeline.Subscription{},
+		} {
+			if err := tx.Where("agent_id = ?", agentID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Where("resource_type = ? AND resource_id = ?", models.TagHostResourceType, agentID).
+			Delete(&models.Tag{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("agent_id = ?", agentID).Delete(&entities.Host{}).Error
+	})
+}
+
+func (s *garbageCollectionService) trashHost(agentID string) error {
+	var host entities.Host
+
+	err := s.db.Where("agent_id = ?", agentID).First(&host).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var tags []*models.Tag
+	err = s.db.
+		Where("resource_type = ? AND resource_id = ?", models.TagHostResourceType, agentID).
+		Find(&tags).Error
+	if err != nil {
+		return err
+	}
+
+	return s.trashService.Store(models.TagHostResourceType, agentID, &hostTrashPayload{
+		Host: host,
+		Tags: tags,
+	})
+}
+
+// Report counts the orphaned rows the collection would remove, without
+// touching anything
+func (s *garbageCollectionService) Report() (*models.GarbageCollectionReport, error) {
+	report := &models.GarbageCollectionReport{
+		Orphans: []*models.OrphanedRecords{},
+	}
+
+	for _, table := range hostOwnedTables {
+		var count int64
+		err := s.orphansQuery(table.model).Count(&count).Error
+		if err != nil {
+			return nil, err
+		}
+		report.Orphans = append(report.Orphans, &models.OrphanedRecords{
+			Table: table.name,
+			Count: count,
+		})
+		report.Total += count
+	}
+
+	var count int64
+	err := s.orphanedTagsQuery().Count(&count).Error
+	if err != nil {
+		return nil, err
+	}
+	report.Orphans = append(report.Orphans, &models.OrphanedRecords{
+		Table: "tags",
+		Count: count,
+	})
+	report.Total += count
+
+	return report, nil
+}
+
+// Collect removes the orphaned rows left behind by projector bugs or partial
+// removals, returning how many were deleted. Rows belonging to a live agent
+// are recreated by its next publication, so a false positive is harmless.
+func (s *garbageCollectionService) Collect() (int64, error) {
+	var collected int64
+
+	for _, table := range hostOwnedTables {
+		result := s.orphansQuery(table.model).Delete(table.model)
+		if result.Error != nil {
+			return collected, result.Error
+		}
+		if result.RowsAffected > 0 {
+			log.Infof("Garbage collected %d orphaned rows from %s.", result.RowsAffected, table.name)
+		}
+		collected += result.RowsAffected
+	}
+
+	result := s.orphanedTagsQuery().Delete(&models.Tag{})
+	if result.Error != nil {
+		return collected, result.Error
+	}
+	if result.RowsAffected > 0 {
+		log.Infof("Garbage collected %d orphaned host tags.", result.RowsAffected)
+	}
+	collected += result.RowsAffected
+
+	return collected, nil
+}
+
+// orphansQuery selects the rows of a host-owned table whose agent ID does not
+// belong to any registered host
+func (s *garbageCollectionService) orphansQuery(model interface{}) *gorm.DB {
+	return s.db.Model(model).
+		Where("agent_id NOT IN (?)", s.db.Model(&entities.Host{}).Select("agent_id"))
+}
+
+func (s *garbageCollectionService) orphanedTagsQuery() *gorm.DB {
+	return s.db.Model(&models.Tag{}).
+		Where("resource_type = ?", models.TagHostResourceType).
+		Where("resource_id NOT IN (?)", s.db.Model(&entities.Host{}).Select("agent_id"))
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/datapipeline"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type GarbageCollectionServiceTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	tx        *gorm.DB
+	gcService *garbageCollectionService
+}
+
+func TestGarbageCollectionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GarbageCollectionServiceTestSuite))
+}
+
+func (suite *GarbageCollectionServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.Host{}, entities.HostHeartbeat{}, entities.HostTelemetry{},
+		entities.SlesSubscription{}, entities.SAPSystemInstance{},
+		entities.AgentConfig{}, entities.AgentError{}, models.Tag{},
+		datapipeline.DataCollectedEvent{}, datapipeline.MalformedEvent{}, datapipeline.Subscription{},
+		entities.TrashEntry{},
+	)
+}
+
+func (suite *GarbageCollectionServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.Host{}, entities.HostHeartbeat{}, entities.HostTelemetry{},
+		entities.SlesSubscription{}, entities.SAPSystemInstance{},
+		entities.AgentConfig{}, entities.AgentError{}, models.Tag{},
+		datapipeline.DataCollectedEvent{}, datapipeline.MalformedEvent{}, datapipeline.Subscription{},
+		entities.TrashEntry{},
+	)
+}
+
+func (suite *GarbageCollectionServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.gcService = NewGarbageCollectionService(suite.tx, NewTrashService(suite.tx))
+
+	// two registered hosts with their owned rows, plus orphaned rows
+	// belonging to an agent no host references anymore
+	for _, agentID := range []string{"agent-1", "agent-2", "orphaned-agent"} {
+		if agentID != "orphaned-agent" {
+			suite.tx.Create(&entities.Host{AgentID: agentID, Name: "host-" + agentID})
+		}
+		suite.tx.Create(&entities.HostHeartbeat{AgentID: agentID})
+		suite.tx.Create(&entities.HostTelemetry{AgentID: agentID})
+		suite.tx.Create(&entities.SlesSubscription{AgentID: agentID, ID: "SLES_SAP-" + agentID})
+		suite.tx.Create(&entities.AgentConfig{AgentID: agentID})
+		suite.tx.Create(&models.Tag{Value: "env:prod", ResourceType: models.TagHostResourceType, ResourceID: agentID})
+		suite.tx.Create(&datapipeline.DataCollectedEvent{AgentID: agentID, DiscoveryType: "host_discovery", Payload: []byte("{}")})
+	}
+}
+
+func (suite *GarbageCollectionServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *GarbageCollectionServiceTestSuite) TestGarbageCollectionService_DeleteHost() {
+	err := suite.gcService.DeleteHost("agent-1")
+	suite.NoError(err)
+
+	var count int64
+	suite.tx.Model(&entities.Host{}).Where("agent_id", "agent-1").Count(&count)
+	suite.EqualValues(0, count)
+
+	for _, model := range []interface{}{
+		&entities.HostHeartbeat{}, &entities.HostTelemetry{}, &entities.SlesSubscription{},
+		&entities.AgentConfig{}, &datapipeline.DataCollectedEvent{},
+	} {
+		suite.tx.Model(model).Where("agent_id", "agent-1").Count(&count)
+		suite.EqualValues(0, count)
+
+		// the rows of the other hosts are untouched
+		suite.tx.Model(model).Where("agent_id", "agent-2").Count(&count)
+		suite.EqualValues(1, count)
+	}
+
+	suite.tx.Model(&models.Tag{}).Where("resource_id", "agent-1").Count(&count)
+	suite.EqualValues(0, count)
+
+	// the host and its tags went to the trash and can be restored
+	suite.tx.Model(&entities.TrashEntry{}).
+		Where("resource_type = ? AND resource_id = ?", models.TagHostResourceType, "agent-1").
+		Count(&count)
+	suite.EqualValues(1, count)
+}
+
+func (suite *GarbageCollectionServiceTestSuite) TestGarbageCollectionService_Report() {
+	report, err := suite.gcService.Report()
+	suite.NoError(err)
+
+	counts := make(map[string]int64)
+	for _, orphans := range report.Orphans {
+		counts[orphans.Table] = orphans.Count
+	}
+
+	suite.EqualValues(1, counts["host_heartbeats"])
+	suite.EqualValues(1, counts["host_telemetry"])
+	suite.EqualValues(1, counts["sles_subscriptions"])
+	suite.EqualValues(1, counts["agent_configs"])
+	suite.EqualValues(1, counts["tags"])
+	suite.EqualValues(0, counts["sap_system_instances"])
+	suite.EqualValues(5, report.Total)
+
+	// the report does not remove anything
+	var count int64
+	suite.tx.Model(&entities.HostHeartbeat{}).Count(&count)
+	suite.EqualValues(3, count)
+}
+
+func (suite *GarbageCollectionServiceTestSuite) TestGarbageCollectionService_Collect() {
+	collected, err := suite.gcService.Collect()
+	suite.NoError(err)
+	suite.EqualValues(5, collected)
+
+	var count int64
+	suite.tx.Model(&entities.HostHeartbeat{}).Where("agent_id", "orphaned-agent").Count(&count)
+	suite.EqualValues(0, count)
+	suite.tx.Model(&models.Tag{}).Where("resource_id", "orphaned-agent").Count(&count)
+	suite.EqualValues(0, count)
+
+	// the rows of the registered hosts survive the collection
+	suite.tx.Model(&entities.HostHeartbeat{}).Count(&count)
+	suite.EqualValues(2, count)
+}
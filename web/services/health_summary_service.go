@@ -6,26 +6,68 @@ import (
 
 //go:generate mockery --name=HealthSummaryService --inpackage --filename=health_summary_service_mock.go
 type HealthSummaryService interface {
-	GetHealthSummary() (models.HealthSummary, error)
+	GetHealthSummary(unknownAsCritical bool) (models.HealthSummary, error)
+	GetLandscapeHealth() (*models.LandscapeHealth, error)
 }
 
 type healthSummaryService struct {
 	sapSystemsService SAPSystemsService
 	hostsService      HostsService
 	clustersService   ClustersService
+	settingsService   SettingsService
+	cache             *ListCache
 }
 
 func NewHealthSummaryService(sapSystemsService SAPSystemsService,
 	clustersService ClustersService,
-	hostsService HostsService) HealthSummaryService {
+	hostsService HostsService,
+	settingsService SettingsService,
+	cache *ListCache) HealthSummaryService {
 	return &healthSummaryService{
 		sapSystemsService: sapSystemsService,
 		clustersService:   clustersService,
 		hostsService:      hostsService,
+		settingsService:   settingsService,
+		cache:             cache,
 	}
 }
 
-func (s *healthSummaryService) GetHealthSummary() (models.HealthSummary, error) {
+func (s *healthSummaryService) GetHealthSummary(unknownAsCritical bool) (models.HealthSummary, error) {
+	value, err := s.cache.GetOrCompute("health_summary", func() (interface{}, error) {
+		return s.getHealthSummary()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	healthSummary := value.(models.HealthSummary)
+	if !unknownAsCritical {
+		return healthSummary, nil
+	}
+
+	// consumers wiring the summary into alerting can opt into pessimistic
+	// propagation, so that stale or missing data does not read as all green
+	pessimisticSummary := make(models.HealthSummary, 0, len(healthSummary))
+	for _, summary := range healthSummary {
+		summary.SAPSystemHealth = unknownToCritical(summary.SAPSystemHealth)
+		summary.DatabaseHealth = unknownToCritical(summary.DatabaseHealth)
+		summary.ClustersHealth = unknownToCritical(summary.ClustersHealth)
+		summary.HostsHealth = unknownToCritical(summary.HostsHealth)
+		pessimisticSummary = append(pessimisticSummary, summary)
+	}
+
+	return pessimisticSummary, nil
+}
+
+func unknownToCritical(health string) string {
+	if health == models.HealthSummaryHealthUnknown {
+		return models.HealthSummaryHealthCritical
+	}
+
+	return health
+}
+
+func (s *healthSummaryService) getHealthSummary() (models.HealthSummary, error) {
 	var healthSummary models.HealthSummary
 
 	sapSystems, err := s.sapSystemsService.GetAllApplications(nil, nil)
@@ -68,6 +110,123 @@ func (s *healthSummaryService) GetHealthSummary() (models.HealthSummary, error)
 	return healthSummary, nil
 }
 
+func (s *healthSummaryService) GetLandscapeHealth() (*models.LandscapeHealth, error) {
+	unknownAsCritical, err := s.settingsService.IsHealthRollupUnknownAsCritical()
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := s.hostsService.GetAll(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := s.clustersService.GetAll(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := s.sapSystemsService.GetAllDatabases(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sapSystems, err := s.sapSystemsService.GetAllApplications(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var hostsHealths, clustersHealths, databasesHealths, sapSystemsHealths []string
+
+	for _, host := range hosts {
+		hostsHealths = append(hostsHealths, host.Health)
+	}
+
+	for _, cluster := range clusters {
+		clustersHealths = append(clustersHealths, cluster.Health)
+	}
+
+	for _, database := range databases {
+		databasesHealths = append(databasesHealths, database.Health)
+	}
+
+	for _, sapSystem := range sapSystems {
+		sapSystemsHealths = append(sapSystemsHealths, sapSystem.Health)
+	}
+
+	layers := []*models.LandscapeHealthLayer{
+		computeLandscapeHealthLayer("hosts", hostsHealths, unknownAsCritical),
+		computeLandscapeHealthLayer("clusters", clustersHealths, unknownAsCritical),
+		computeLandscapeHealthLayer("databases", databasesHealths, unknownAsCritical),
+		computeLandscapeHealthLayer("sapsystems", sapSystemsHealths, unknownAsCritical),
+	}
+
+	return &models.LandscapeHealth{
+		Health: computeLandscapeHealth(layers),
+		Layers: layers,
+	}, nil
+}
+
+// computeLandscapeHealthLayer aggregates the healths of the resources of a single
+// landscape layer, applying the configured propagation rule for unknown healths
+func computeLandscapeHealthLayer(name string, healths []string, unknownAsCritical bool) *models.LandscapeHealthLayer {
+	layer := &models.LandscapeHealthLayer{Name: name}
+
+	for _, health := range healths {
+		switch health {
+		case models.HealthSummaryHealthPassing:
+			layer.PassingCount += 1
+		case models.HealthSummaryHealthWarning:
+			layer.WarningCount += 1
+		case models.HealthSummaryHealthCritical:
+			layer.CriticalCount += 1
+		default:
+			if unknownAsCritical {
+				layer.CriticalCount += 1
+			} else {
+				layer.UnknownCount += 1
+			}
+		}
+	}
+
+	switch {
+	case layer.CriticalCount > 0:
+		layer.Health = models.HealthSummaryHealthCritical
+	case layer.WarningCount > 0:
+		layer.Health = models.HealthSummaryHealthWarning
+	case layer.UnknownCount > 0:
+		layer.Health = models.HealthSummaryHealthUnknown
+	default:
+		layer.Health = models.HealthSummaryHealthPassing
+	}
+
+	return layer
+}
+
+func computeLandscapeHealth(layers []*models.LandscapeHealthLayer) string {
+	health := models.HealthSummaryHealthPassing
+	for _, layer := range layers {
+		health = worstHealth(health, layer.Health)
+	}
+
+	return health
+}
+
+func worstHealth(a, b string) string {
+	severities := map[string]int{
+		models.HealthSummaryHealthPassing:  0,
+		models.HealthSummaryHealthUnknown:  1,
+		models.HealthSummaryHealthWarning:  2,
+		models.HealthSummaryHealthCritical: 3,
+	}
+
+	if severities[b] > severities[a] {
+		return b
+	}
+
+	return a
+}
+
 func computeSAPSystemHealth(sapsystem *models.SAPSystem) string {
 	if sapsystem == nil {
 		return models.HealthSummaryHealthUnknown
@@ -115,6 +274,12 @@ func computeAggregatedClustersHealth(clusters []*models.Cluster) string {
 }
 
 func computeAggregatedHostsHealth(hosts []*models.Host) string {
+	// no hosts projected for the system means the data never arrived or was
+	// pruned: that is unknown, not passing
+	if len(hosts) == 0 {
+		return models.HealthSummaryHealthUnknown
+	}
+
 	var hasWarningHost, hasUnknownHost bool
 
 	for _, h := range hosts {
@@ -12,19 +12,42 @@ type MockHealthSummaryService struct {
 	mock.Mock
 }
 
-// GetHealthSummary provides a mock function with given fields:
-func (_m *MockHealthSummaryService) GetHealthSummary() (models.HealthSummary, error) {
-	ret := _m.Called()
+// GetHealthSummary provides a mock function with given fields: unknownAsCritical
+func (_m *MockHealthSummaryService) GetHealthSummary(unknownAsCritical bool) (models.HealthSummary, error) {
+	ret := _m.Called(unknownAsCritical)
 
 	var r0 models.HealthSummary
-	if rf, ok := ret.Get(0).(func() models.HealthSummary); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(bool) models.HealthSummary); ok {
+		r0 = rf(unknownAsCritical)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(models.HealthSummary)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(unknownAsCritical)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLandscapeHealth provides a mock function with given fields:
+func (_m *MockHealthSummaryService) GetLandscapeHealth() (*models.LandscapeHealth, error) {
+	ret := _m.Called()
+
+	var r0 *models.LandscapeHealth
+	if rf, ok := ret.Get(0).(func() *models.LandscapeHealth); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LandscapeHealth)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func() error); ok {
 		r1 = rf()
@@ -68,8 +68,8 @@ func (suite *HealthSummaryServiceTestSuite) TestGetHealthSummary() {
 			Health: models.HostHealthPassing,
 		}}, nil)
 
-	healthSummaryService := NewHealthSummaryService(sapSystemsService, clustersService, hostsService)
-	healthSummary, _ := healthSummaryService.GetHealthSummary()
+	healthSummaryService := NewHealthSummaryService(sapSystemsService, clustersService, hostsService, new(MockSettingsService), NewListCache())
+	healthSummary, _ := healthSummaryService.GetHealthSummary(false)
 
 	suite.EqualValues(models.HealthSummary{{
 		ID: "application_id", SID: "HA1",
@@ -79,3 +79,129 @@ func (suite *HealthSummaryServiceTestSuite) TestGetHealthSummary() {
 		HostsHealth:     models.HealthSummaryHealthWarning,
 	}}, healthSummary)
 }
+
+func (suite *HealthSummaryServiceTestSuite) TestGetHealthSummary_UnknownAsCritical() {
+	sapSystemsService := new(MockSAPSystemsService)
+	clustersService := new(MockClustersService)
+	hostsService := new(MockHostsService)
+
+	sapSystemsService.On("GetAllApplications", mock.Anything, mock.Anything).Return(models.SAPSystemList{
+		{
+			ID:     "application_id",
+			SID:    "HA1",
+			Type:   models.SAPSystemTypeApplication,
+			Health: models.SAPSystemHealthPassing,
+			Instances: []*models.SAPSystemInstance{
+				{
+					HostID:    "netweaver01",
+					ClusterID: "netweaver_cluster",
+				},
+			},
+		},
+	}, nil)
+
+	// no clusters and no hosts projected: the data is missing, not healthy
+	clustersService.On("GetAll", mock.Anything, mock.Anything).Return(models.ClusterList{}, nil)
+	hostsService.On("GetAll", mock.Anything, mock.Anything).Return(models.HostList{}, nil)
+
+	healthSummaryService := NewHealthSummaryService(sapSystemsService, clustersService, hostsService, new(MockSettingsService), NewListCache())
+
+	healthSummary, err := healthSummaryService.GetHealthSummary(false)
+	suite.NoError(err)
+	suite.EqualValues(models.HealthSummary{{
+		ID: "application_id", SID: "HA1",
+		SAPSystemHealth: models.HealthSummaryHealthPassing,
+		ClustersHealth:  models.HealthSummaryHealthUnknown,
+		DatabaseHealth:  models.HealthSummaryHealthUnknown,
+		HostsHealth:     models.HealthSummaryHealthUnknown,
+	}}, healthSummary)
+
+	healthSummary, err = healthSummaryService.GetHealthSummary(true)
+	suite.NoError(err)
+	suite.EqualValues(models.HealthSummary{{
+		ID: "application_id", SID: "HA1",
+		SAPSystemHealth: models.HealthSummaryHealthPassing,
+		ClustersHealth:  models.HealthSummaryHealthCritical,
+		DatabaseHealth:  models.HealthSummaryHealthCritical,
+		HostsHealth:     models.HealthSummaryHealthCritical,
+	}}, healthSummary)
+}
+
+func (suite *HealthSummaryServiceTestSuite) TestGetLandscapeHealth() {
+	sapSystemsService := new(MockSAPSystemsService)
+	clustersService := new(MockClustersService)
+	hostsService := new(MockHostsService)
+	settingsService := new(MockSettingsService)
+
+	settingsService.On("IsHealthRollupUnknownAsCritical").Return(false, nil)
+
+	hostsService.On("GetAll", mock.Anything, mock.Anything).Return(models.HostList{
+		{ID: "host1", Health: models.HostHealthPassing},
+		{ID: "host2", Health: models.HostHealthWarning},
+		{ID: "host3", Health: models.HostHealthUnknown},
+	}, nil)
+
+	clustersService.On("GetAll", mock.Anything, mock.Anything).Return(models.ClusterList{
+		{ID: "cluster1", Health: models.CheckCritical},
+		{ID: "cluster2", Health: models.CheckPassing},
+	}, nil)
+
+	sapSystemsService.On("GetAllDatabases", mock.Anything, mock.Anything).Return(models.SAPSystemList{
+		{ID: "database1", Health: models.SAPSystemHealthPassing},
+	}, nil)
+
+	sapSystemsService.On("GetAllApplications", mock.Anything, mock.Anything).Return(models.SAPSystemList{
+		{ID: "application1", Health: models.SAPSystemHealthPassing},
+	}, nil)
+
+	healthSummaryService := NewHealthSummaryService(sapSystemsService, clustersService, hostsService, settingsService, NewListCache())
+	landscapeHealth, err := healthSummaryService.GetLandscapeHealth()
+	suite.NoError(err)
+
+	suite.Equal(models.HealthSummaryHealthCritical, landscapeHealth.Health)
+	suite.EqualValues([]*models.LandscapeHealthLayer{
+		{
+			Name:         "hosts",
+			Health:       models.HealthSummaryHealthWarning,
+			PassingCount: 1, WarningCount: 1, UnknownCount: 1,
+		},
+		{
+			Name:         "clusters",
+			Health:       models.HealthSummaryHealthCritical,
+			PassingCount: 1, CriticalCount: 1,
+		},
+		{
+			Name:         "databases",
+			Health:       models.HealthSummaryHealthPassing,
+			PassingCount: 1,
+		},
+		{
+			Name:         "sapsystems",
+			Health:       models.HealthSummaryHealthPassing,
+			PassingCount: 1,
+		},
+	}, landscapeHealth.Layers)
+}
+
+func (suite *HealthSummaryServiceTestSuite) TestGetLandscapeHealth_UnknownAsCritical() {
+	sapSystemsService := new(MockSAPSystemsService)
+	clustersService := new(MockClustersService)
+	hostsService := new(MockHostsService)
+	settingsService := new(MockSettingsService)
+
+	settingsService.On("IsHealthRollupUnknownAsCritical").Return(true, nil)
+
+	hostsService.On("GetAll", mock.Anything, mock.Anything).Return(models.HostList{
+		{ID: "host1", Health: models.HostHealthUnknown},
+	}, nil)
+	clustersService.On("GetAll", mock.Anything, mock.Anything).Return(models.ClusterList{}, nil)
+	sapSystemsService.On("GetAllDatabases", mock.Anything, mock.Anything).Return(models.SAPSystemList{}, nil)
+	sapSystemsService.On("GetAllApplications", mock.Anything, mock.Anything).Return(models.SAPSystemList{}, nil)
+
+	healthSummaryService := NewHealthSummaryService(sapSystemsService, clustersService, hostsService, settingsService, NewListCache())
+	landscapeHealth, err := healthSummaryService.GetLandscapeHealth()
+	suite.NoError(err)
+
+	suite.Equal(models.HealthSummaryHealthCritical, landscapeHealth.Health)
+	suite.Equal(1, landscapeHealth.Layers[0].CriticalCount)
+}
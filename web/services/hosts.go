@@ -9,6 +9,9 @@ import (
 	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/exporters"
+	"github.com/trento-project/trento/version"
+	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
@@ -19,6 +22,11 @@ import (
 
 const HeartbeatTreshold = internal.HeartbeatInterval * 2
 
+// StaleDiscoveryTreshold is the age after which discovered data is considered
+// stale: agents republish unchanged payloads hourly, so missing two refresh
+// cycles means the discovery stopped flowing
+const StaleDiscoveryTreshold = 2 * time.Hour
+
 var timeSince = time.Since
 
 //go:generate mockery --name=HostsService --inpackage --filename=hosts_mock.go
@@ -29,27 +37,59 @@ type HostsService interface {
 	GetCount() (int, error)
 	GetAllSIDs() ([]string, error)
 	GetAllTags() ([]string, error)
-	Heartbeat(agentID string) error
+	Heartbeat(agentID string, data *HeartbeatData) error
+	GetOutdatedAgents() (models.HostList, error)
 	GetExportersState(hostname string) (map[string]string, error)
+	GetDiscoveriesFreshness(agentID string) ([]*models.DiscoveryFreshness, error)
+	GetPackagesMatrix() (*models.PackagesMatrix, error)
+	GetSettingsComplianceByID(id string) (*models.SettingsCompliance, error)
+}
+
+// HeartbeatData carries the optional metadata sent by the agents with each heartbeat
+type HeartbeatData struct {
+	AgentVersion       string
+	EnabledDiscoveries []string
+	AgentTime          time.Time
 }
 
 type HostsFilter struct {
-	ID     []string
-	SIDs   []string
-	Tags   []string
-	Health []string
+	ID          []string
+	SIDs        []string
+	Tags        []string
+	Health      []string
+	IPAddresses []string
+	// ScopeTags restricts the visible hosts to the ones carrying at least one
+	// of these tags. Unlike Tags, it is meant to be set by the authorization
+	// layer from the requesting account, not from the request query
+	ScopeTags []string
+	// CustomFields matches the hosts whose custom fields carry all the
+	// given name/value pairs
+	CustomFields map[string]string
 }
 
 type hostsService struct {
 	db                *gorm.DB
 	prometheusService PrometheusService
+	cache             *ListCache
+	eventsService     EventsService
 }
 
-func NewHostsService(db *gorm.DB, promService PrometheusService) *hostsService {
-	return &hostsService{db, promService}
+func NewHostsService(db *gorm.DB, promService PrometheusService, cache *ListCache, eventsService EventsService) *hostsService {
+	return &hostsService{db, promService, cache, eventsService}
 }
 
 func (s *hostsService) GetAll(filter *HostsFilter, page *Page) (models.HostList, error) {
+	value, err := s.cache.GetOrCompute(listCacheKey("hosts", filter, page), func() (interface{}, error) {
+		return s.getAll(filter, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(models.HostList), nil
+}
+
+func (s *hostsService) getAll(filter *HostsFilter, page *Page) (models.HostList, error) {
 	var hosts []entities.Host
 	var healthFilteredHosts []string
 
@@ -98,9 +138,30 @@ func (s *hostsService) GetAll(filter *HostsFilter, page *Page) (models.HostList,
 			)
 		}
 
+		if len(filter.ScopeTags) > 0 {
+			db = db.Where("agent_id IN (?)", s.db.Model(&models.Tag{}).
+				Select("resource_id").
+				Where("resource_type = ?", models.TagHostResourceType).
+				Where("value IN ?", filter.ScopeTags),
+			)
+		}
+
+		for name, value := range filter.CustomFields {
+			db = db.Where("agent_id IN (?)", s.db.Model(&entities.CustomFieldValue{}).
+				Select("resource_id").
+				Where("resource_type = ?", models.TagHostResourceType).
+				Where("name = ?", name).
+				Where("value = ?", value),
+			)
+		}
+
 		if len(filter.Health) > 0 {
 			db = db.Where("agent_id IN (?)", healthFilteredHosts)
 		}
+
+		if len(filter.IPAddresses) > 0 {
+			db = db.Where("ip_addresses && ?", pq.Array(filter.IPAddresses))
+		}
 	}
 
 	err := db.Order("name").Find(&hosts).Error
@@ -112,6 +173,7 @@ func (s *hostsService) GetAll(filter *HostsFilter, page *Page) (models.HostList,
 	for _, h := range hosts {
 		host := h.ToModel()
 		host.Health = computeHealth(&h)
+		host.AgentVersionMismatch = isOutdatedAgent(h.Heartbeat)
 		hostList = append(hostList, host)
 	}
 
@@ -137,6 +199,10 @@ func (s *hostsService) GetByID(id string) (*models.Host, error) {
 	hostHealth := computeHealth(&host)
 	modeledHost := host.ToModel()
 	modeledHost.Health = hostHealth
+	modeledHost.AgentVersionMismatch = isOutdatedAgent(host.Heartbeat)
+	if host.Heartbeat != nil {
+		modeledHost.LastHeartbeat = &host.Heartbeat.UpdatedAt
+	}
 
 	if modeledHost.CloudProvider == "azure" {
 		var cloudData models.AzureCloudData
@@ -217,17 +283,78 @@ func (s *hostsService) GetAllTags() ([]string, error) {
 	return tags, nil
 }
 
-func (s *hostsService) Heartbeat(agentID string) error {
+func (s *hostsService) Heartbeat(agentID string, data *HeartbeatData) error {
+	var previousHeartbeat entities.HostHeartbeat
+	firstHeartbeat := false
+
+	err := s.db.Where("agent_id = ?", agentID).First(&previousHeartbeat).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		firstHeartbeat = true
+	}
+
 	heartbeat := &entities.HostHeartbeat{
 		AgentID: agentID,
 	}
 
-	return s.db.Clauses(clause.OnConflict{
+	if data != nil {
+		heartbeat.AgentVersion = data.AgentVersion
+		heartbeat.EnabledDiscoveries = data.EnabledDiscoveries
+		if !data.AgentTime.IsZero() {
+			heartbeat.ClockSkew = time.Now().Sub(data.AgentTime).Milliseconds()
+		}
+	}
+
+	err = s.db.Clauses(clause.OnConflict{
 		Columns: []clause.Column{
 			{Name: "agent_id"},
 		},
-		DoUpdates: clause.AssignmentColumns([]string{"updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{
+			"updated_at",
+			"agent_version",
+			"enabled_discoveries",
+			"clock_skew",
+		}),
 	}).Create(heartbeat).Error
+	if err != nil {
+		return err
+	}
+
+	// Record heartbeat transitions in the timeline: the first heartbeat ever
+	// and the ones resuming after the agent was considered gone
+	switch {
+	case firstHeartbeat:
+		err = s.eventsService.StoreEvent(&models.ResourceEvent{
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   agentID,
+			Type:         models.ResourceEventTypeHeartbeat,
+			Message:      "Agent started sending heartbeats",
+		})
+	case timeSince(previousHeartbeat.UpdatedAt) > HeartbeatTreshold:
+		err = s.eventsService.StoreEvent(&models.ResourceEvent{
+			ResourceType: models.TagHostResourceType,
+			ResourceID:   agentID,
+			Type:         models.ResourceEventTypeHeartbeat,
+			Message:      "Agent heartbeat resumed after an interruption",
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	// The heartbeat affects the hosts health, which is part of the cached lists
+	s.cache.Clear()
+
+	return nil
+}
+
+// exporterUnitNames maps the exporter systemd units reported by the agents
+// to the exporter names used by prometheus
+var exporterUnitNames = map[string]string{
+	exporters.NodeExporterUnit:   nodeExporterName,
+	exporters.HANADBExporterUnit: hanaDBExporterName,
 }
 
 func initJobsStates() map[string]string {
@@ -236,8 +363,39 @@ func initJobsStates() map[string]string {
 	return states
 }
 
+// seedJobsStates fills the states with the systemd unit statuses reported by
+// the agent, which get overridden by the prometheus scraping state when available
+func (s *hostsService) seedJobsStates(hostname string, jobsState map[string]string) {
+	var host entities.Host
+
+	err := s.db.Where("name = ?", hostname).First(&host).Error
+	if err != nil || host.ExportersStatus == nil {
+		return
+	}
+
+	var reportedStatus map[string]string
+	if err := json.Unmarshal(host.ExportersStatus, &reportedStatus); err != nil {
+		return
+	}
+
+	for unit, status := range reportedStatus {
+		name, ok := exporterUnitNames[unit]
+		if !ok {
+			continue
+		}
+
+		if status == exporters.StatusActive {
+			jobsState[name] = models.HostHealthPassing
+		} else {
+			jobsState[name] = models.HostHealthCritical
+		}
+	}
+}
+
 func (s *hostsService) GetExportersState(hostname string) (map[string]string, error) {
 	jobsState := initJobsStates()
+	s.seedJobsStates(hostname, jobsState)
+
 	result, err := s.prometheusService.Query(fmt.Sprintf("up{hostname=\"%s\"}", hostname), time.Now())
 	if err != nil {
 		log.Warnf("error querying to prometheus: %s", err)
@@ -268,8 +426,84 @@ func (s *hostsService) GetExportersState(hostname string) (map[string]string, er
 	return jobsState, nil
 }
 
+// GetOutdatedAgents returns the hosts whose agent last reported a version
+// different from the one the server is running
+func (s *hostsService) GetOutdatedAgents() (models.HostList, error) {
+	var hosts []entities.Host
+
+	err := s.db.
+		Order("name").
+		Preload("Heartbeat").
+		Find(&hosts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var hostList models.HostList
+	for _, h := range hosts {
+		if !isOutdatedAgent(h.Heartbeat) {
+			continue
+		}
+
+		host := h.ToModel()
+		host.Health = computeHealth(&h)
+		host.AgentVersion = h.Heartbeat.AgentVersion
+		host.AgentVersionMismatch = true
+		hostList = append(hostList, host)
+	}
+
+	return hostList, nil
+}
+
+// GetDiscoveriesFreshness returns, for each discovery type an agent ever
+// published, when it was last updated and whether that data is stale by now
+func (s *hostsService) GetDiscoveriesFreshness(agentID string) ([]*models.DiscoveryFreshness, error) {
+	var freshness []*models.DiscoveryFreshness
+
+	err := s.db.
+		Model(&datapipeline.DataCollectedEvent{}).
+		Select("discovery_type, MAX(created_at) AS last_updated").
+		Where("agent_id = ?", agentID).
+		Group("discovery_type").
+		Order("discovery_type").
+		Scan(&freshness).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if freshness == nil {
+		freshness = make([]*models.DiscoveryFreshness, 0)
+	}
+
+	for _, f := range freshness {
+		f.Stale = timeSince(f.LastUpdated) > StaleDiscoveryTreshold
+	}
+
+	return freshness, nil
+}
+
+// isOutdatedAgent detects a version skew between an agent and the server,
+// based on the version the agent reported with its last heartbeat
+func isOutdatedAgent(heartbeat *entities.HostHeartbeat) bool {
+	if heartbeat == nil || heartbeat.AgentVersion == "" || version.Version == "" {
+		return false
+	}
+
+	return heartbeat.AgentVersion != version.Version
+}
+
+// computeHealth combines the heartbeat state with the freshness of the
+// discovered data: a host whose agent is alive but whose discoveries stopped
+// flowing is degraded to warning instead of being shown as current
 func computeHealth(host *entities.Host) string {
-	return computeHearbeatHealth(host.Heartbeat)
+	health := computeHearbeatHealth(host.Heartbeat)
+
+	if health == models.HostHealthPassing && timeSince(host.UpdatedAt) > StaleDiscoveryTreshold {
+		return models.HostHealthWarning
+	}
+
+	return health
 }
 
 func computeHearbeatHealth(hearbeat *entities.HostHeartbeat) string {
@@ -148,6 +148,29 @@ func (_m *MockHostsService) GetCount() (int, error) {
 	return r0, r1
 }
 
+// GetDiscoveriesFreshness provides a mock function with given fields: agentID
+func (_m *MockHostsService) GetDiscoveriesFreshness(agentID string) ([]*models.DiscoveryFreshness, error) {
+	ret := _m.Called(agentID)
+
+	var r0 []*models.DiscoveryFreshness
+	if rf, ok := ret.Get(0).(func(string) []*models.DiscoveryFreshness); ok {
+		r0 = rf(agentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DiscoveryFreshness)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(agentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetExportersState provides a mock function with given fields: hostname
 func (_m *MockHostsService) GetExportersState(hostname string) (map[string]string, error) {
 	ret := _m.Called(hostname)
@@ -171,13 +194,82 @@ func (_m *MockHostsService) GetExportersState(hostname string) (map[string]strin
 	return r0, r1
 }
 
-// Heartbeat provides a mock function with given fields: agentID
-func (_m *MockHostsService) Heartbeat(agentID string) error {
-	ret := _m.Called(agentID)
+// GetOutdatedAgents provides a mock function with given fields:
+func (_m *MockHostsService) GetOutdatedAgents() (models.HostList, error) {
+	ret := _m.Called()
+
+	var r0 models.HostList
+	if rf, ok := ret.Get(0).(func() models.HostList); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.HostList)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackagesMatrix provides a mock function with given fields:
+func (_m *MockHostsService) GetPackagesMatrix() (*models.PackagesMatrix, error) {
+	ret := _m.Called()
+
+	var r0 *models.PackagesMatrix
+	if rf, ok := ret.Get(0).(func() *models.PackagesMatrix); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PackagesMatrix)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettingsComplianceByID provides a mock function with given fields: id
+func (_m *MockHostsService) GetSettingsComplianceByID(id string) (*models.SettingsCompliance, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.SettingsCompliance
+	if rf, ok := ret.Get(0).(func(string) *models.SettingsCompliance); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SettingsCompliance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Heartbeat provides a mock function with given fields: agentID, data
+func (_m *MockHostsService) Heartbeat(agentID string, data *HeartbeatData) error {
+	ret := _m.Called(agentID, data)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(agentID)
+	if rf, ok := ret.Get(0).(func(string, *HeartbeatData) error); ok {
+		r0 = rf(agentID, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -8,8 +8,10 @@ import (
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/datapipeline"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 
 	prometheusModel "github.com/prometheus/common/model"
@@ -89,7 +91,7 @@ func TestHostsServiceTestSuite(t *testing.T) {
 func (suite *HostsServiceTestSuite) SetupSuite() {
 	suite.db = helpers.SetupTestDatabase(suite.T())
 
-	suite.db.AutoMigrate(&entities.Host{}, &entities.HostHeartbeat{}, &entities.SAPSystemInstance{}, &models.Tag{})
+	suite.db.AutoMigrate(&entities.Host{}, &entities.HostHeartbeat{}, &entities.SAPSystemInstance{}, &models.Tag{}, &datapipeline.DataCollectedEvent{}, &entities.ResourceEvent{}, &entities.CustomFieldValue{})
 	hosts := hostsFixtures()
 	err := suite.db.Create(&hosts).Error
 	suite.NoError(err)
@@ -99,13 +101,16 @@ func (suite *HostsServiceTestSuite) TearDownSuite() {
 	suite.db.Migrator().DropTable(&entities.Host{},
 		&entities.HostHeartbeat{},
 		&entities.SAPSystemInstance{},
-		&models.Tag{})
+		&models.Tag{},
+		&datapipeline.DataCollectedEvent{},
+		&entities.ResourceEvent{},
+		&entities.CustomFieldValue{})
 }
 
 func (suite *HostsServiceTestSuite) SetupTest() {
 	suite.tx = suite.db.Begin()
 	suite.prometheusService = new(MockPrometheusService)
-	suite.hostsService = NewHostsService(suite.tx, suite.prometheusService)
+	suite.hostsService = NewHostsService(suite.tx, suite.prometheusService, NewListCache(), NewEventsService(suite.tx, nil))
 }
 
 func (suite *HostsServiceTestSuite) TearDownTest() {
@@ -196,6 +201,59 @@ func (suite *HostsServiceTestSuite) TestHostsService_GetAll_Filters() {
 	suite.Equal("1", hosts[0].ID)
 }
 
+func (suite *HostsServiceTestSuite) TestHostsService_GetAll_FilterByIPAddress() {
+	hosts, err := suite.hostsService.GetAll(&HostsFilter{
+		IPAddresses: []string{"10.74.1.10"},
+	}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(hosts))
+	suite.Equal("2", hosts[0].ID)
+
+	hosts, err = suite.hostsService.GetAll(&HostsFilter{
+		IPAddresses: []string{"192.168.1.1"},
+	}, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(hosts))
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetAll_ScopeTags() {
+	hosts, _ := suite.hostsService.GetAll(&HostsFilter{
+		ScopeTags: []string{"tag2"},
+	}, nil)
+	suite.Equal(1, len(hosts))
+	suite.Equal("2", hosts[0].ID)
+
+	// the scope is intersected with the user-chosen tags filter,
+	// it cannot be widened from the request
+	hosts, _ = suite.hostsService.GetAll(&HostsFilter{
+		Tags:      []string{"tag1"},
+		ScopeTags: []string{"tag2"},
+	}, nil)
+	suite.Equal(0, len(hosts))
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetAll_FilterByCustomFields() {
+	suite.tx.Create(&entities.CustomFieldValue{
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "1",
+		Name:         "owner",
+		Value:        "team-hana",
+	})
+
+	hosts, err := suite.hostsService.GetAll(&HostsFilter{
+		CustomFields: map[string]string{"owner": "team-hana"},
+	}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(hosts))
+	suite.Equal("1", hosts[0].ID)
+
+	hosts, err = suite.hostsService.GetAll(&HostsFilter{
+		CustomFields: map[string]string{"owner": "someone-else"},
+	}, nil)
+	suite.NoError(err)
+	suite.Equal(0, len(hosts))
+}
+
 func (suite *HostsServiceTestSuite) TestHostsService_GetByID() {
 	host, _ := suite.hostsService.GetByID("1")
 	suite.Equal("host1", host.Name)
@@ -231,12 +289,59 @@ func (suite *HostsServiceTestSuite) TestHostsService_GetAllSIDs() {
 }
 
 func (suite *HostsServiceTestSuite) TestHostsService_Heartbeat() {
-	err := suite.hostsService.Heartbeat("1")
+	timeSince = time.Since
+
+	err := suite.hostsService.Heartbeat("1", nil)
 	suite.NoError(err)
 
 	var heartbeat entities.HostHeartbeat
 	suite.tx.First(&heartbeat)
 	suite.Equal("1", heartbeat.AgentID)
+
+	// the fixture heartbeat is long expired, so the resumption lands in the timeline
+	var events []entities.ResourceEvent
+	suite.tx.Where("resource_id", "1").Find(&events)
+	suite.Len(events, 1)
+	suite.Equal(models.ResourceEventTypeHeartbeat, events[0].Type)
+	suite.Equal("Agent heartbeat resumed after an interruption", events[0].Message)
+
+	// a heartbeat within the threshold is not a transition
+	timeSince = func(t time.Time) time.Duration { return 0 }
+	err = suite.hostsService.Heartbeat("1", nil)
+	suite.NoError(err)
+
+	suite.tx.Where("resource_id", "1").Find(&events)
+	suite.Len(events, 1)
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_HeartbeatFirstContact() {
+	timeSince = time.Since
+
+	err := suite.hostsService.Heartbeat("never-seen-before", nil)
+	suite.NoError(err)
+
+	var events []entities.ResourceEvent
+	suite.tx.Where("resource_id", "never-seen-before").Find(&events)
+	suite.Len(events, 1)
+	suite.Equal(models.TagHostResourceType, events[0].ResourceType)
+	suite.Equal(models.ResourceEventTypeHeartbeat, events[0].Type)
+	suite.Equal("Agent started sending heartbeats", events[0].Message)
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_HeartbeatWithMetadata() {
+	err := suite.hostsService.Heartbeat("1", &HeartbeatData{
+		AgentVersion:       "rolling1337",
+		EnabledDiscoveries: []string{"host_discovery", "cluster_discovery"},
+		AgentTime:          time.Now().Add(-2 * time.Second),
+	})
+	suite.NoError(err)
+
+	var heartbeat entities.HostHeartbeat
+	suite.tx.First(&heartbeat)
+	suite.Equal("1", heartbeat.AgentID)
+	suite.Equal("rolling1337", heartbeat.AgentVersion)
+	suite.EqualValues(pq.StringArray{"host_discovery", "cluster_discovery"}, heartbeat.EnabledDiscoveries)
+	suite.GreaterOrEqual(heartbeat.ClockSkew, int64(2000))
 }
 
 func (suite *HostsServiceTestSuite) TestHostsService_computeHealth() {
@@ -252,10 +357,52 @@ func (suite *HostsServiceTestSuite) TestHostsService_computeHealth() {
 	}
 	suite.Equal(models.HostHealthCritical, computeHealth(&host))
 
+	// a live agent whose discoveries stopped flowing degrades the host to warning
+	timeSince = func(t time.Time) time.Duration {
+		if t.Equal(host.UpdatedAt) {
+			return StaleDiscoveryTreshold + 1
+		}
+		return time.Duration(0)
+	}
+	suite.Equal(models.HostHealthWarning, computeHealth(&host))
+
+	timeSince = func(_ time.Time) time.Duration {
+		return time.Duration(HeartbeatTreshold + 1)
+	}
+
 	host.Heartbeat = nil
 	suite.Equal(models.HostHealthUnknown, computeHealth(&host))
 }
 
+func (suite *HostsServiceTestSuite) TestHostsService_GetDiscoveriesFreshness() {
+	timeSince = time.Since
+
+	err := suite.tx.Create([]*datapipeline.DataCollectedEvent{
+		{AgentID: "1", DiscoveryType: datapipeline.ClusterDiscovery, CreatedAt: time.Now().Add(-3 * time.Hour), Payload: []byte("{}")},
+		{AgentID: "1", DiscoveryType: datapipeline.ClusterDiscovery, CreatedAt: time.Now().Add(-10 * time.Minute), Payload: []byte("{}")},
+		{AgentID: "1", DiscoveryType: datapipeline.HostDiscovery, CreatedAt: time.Now().Add(-5 * time.Hour), Payload: []byte("{}")},
+		{AgentID: "2", DiscoveryType: datapipeline.HostDiscovery, CreatedAt: time.Now(), Payload: []byte("{}")},
+	}).Error
+	suite.NoError(err)
+
+	freshness, err := suite.hostsService.GetDiscoveriesFreshness("1")
+	suite.NoError(err)
+
+	suite.Len(freshness, 2)
+
+	// only the most recent event of each discovery type counts
+	suite.Equal(datapipeline.ClusterDiscovery, freshness[0].DiscoveryType)
+	suite.False(freshness[0].Stale)
+
+	suite.Equal(datapipeline.HostDiscovery, freshness[1].DiscoveryType)
+	suite.True(freshness[1].Stale)
+
+	// an agent without collected events has no freshness entries
+	freshness, err = suite.hostsService.GetDiscoveriesFreshness("3")
+	suite.NoError(err)
+	suite.Empty(freshness)
+}
+
 func (suite *HostsServiceTestSuite) TestHostsService_GetExportersState() {
 	exporterStates := prometheusModel.Vector{
 		&prometheusModel.Sample{
@@ -300,3 +447,25 @@ func (suite *HostsServiceTestSuite) TestHostsService_GetExportersState_Unknown()
 
 	suite.Equal(expectedStates, states)
 }
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetExportersState_AgentReported() {
+	err := suite.tx.Create(&entities.Host{
+		AgentID: "4",
+		Name:    "host4",
+		ExportersStatus: datatypes.JSON([]byte(
+			`{"prometheus-node_exporter.service": "active", "prometheus-hanadb_exporter.service": "failed"}`)),
+	}).Error
+	suite.NoError(err)
+
+	suite.prometheusService.On("Query", "up{hostname=\"host4\"}", mock.Anything).Return(prometheusModel.Vector{}, nil)
+
+	states, err := suite.hostsService.GetExportersState("host4")
+	suite.NoError(err)
+
+	expectedStates := map[string]string{
+		"Node Exporter":   models.HostHealthPassing,
+		"HANADB Exporter": models.HostHealthCritical,
+	}
+
+	suite.Equal(expectedStates, states)
+}
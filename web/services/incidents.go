@@ -0,0 +1,55 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=IncidentsService --inpackage --filename=incidents_mock.go
+
+// IncidentsService serves the downtime periods recorded by the health
+// projection for MTTR and availability reporting
+type IncidentsService interface {
+	GetAll(filter *IncidentsFilter) (models.IncidentList, error)
+}
+
+type IncidentsFilter struct {
+	ResourceID []string
+	OpenOnly   bool
+}
+
+type incidentsService struct {
+	db *gorm.DB
+}
+
+func NewIncidentsService(db *gorm.DB) *incidentsService {
+	return &incidentsService{db: db}
+}
+
+func (s *incidentsService) GetAll(filter *IncidentsFilter) (models.IncidentList, error) {
+	var incidentEntities []entities.Incident
+
+	db := s.db.Order("opened_at DESC, id DESC")
+
+	if filter != nil {
+		if len(filter.ResourceID) > 0 {
+			db = db.Where("resource_id IN ?", filter.ResourceID)
+		}
+		if filter.OpenOnly {
+			db = db.Where("closed_at IS NULL")
+		}
+	}
+
+	if err := db.Find(&incidentEntities).Error; err != nil {
+		return nil, err
+	}
+
+	incidents := make(models.IncidentList, 0, len(incidentEntities))
+	for _, incidentEntity := range incidentEntities {
+		incidents = append(incidents, incidentEntity.ToModel())
+	}
+
+	return incidents, nil
+}
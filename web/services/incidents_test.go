@@ -0,0 +1,87 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type IncidentsServiceTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	tx               *gorm.DB
+	incidentsService IncidentsService
+}
+
+func TestIncidentsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(IncidentsServiceTestSuite))
+}
+
+func (suite *IncidentsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Incident{})
+}
+
+func (suite *IncidentsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Incident{})
+}
+
+func (suite *IncidentsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.incidentsService = NewIncidentsService(suite.tx)
+
+	closedAt := time.Date(2022, 2, 1, 11, 0, 0, 0, time.UTC)
+	suite.tx.Create(&[]entities.Incident{
+		{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   "cluster_id",
+			OpenedAt:     time.Date(2022, 2, 1, 10, 0, 0, 0, time.UTC),
+			ClosedAt:     &closedAt,
+		},
+		{
+			ResourceType: models.TagClusterResourceType,
+			ResourceID:   "other_cluster",
+			OpenedAt:     time.Date(2022, 2, 2, 9, 0, 0, 0, time.UTC),
+		},
+	})
+}
+
+func (suite *IncidentsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *IncidentsServiceTestSuite) TestIncidentsService_GetAll() {
+	incidents, err := suite.incidentsService.GetAll(nil)
+	suite.NoError(err)
+	suite.Equal(2, len(incidents))
+	// the most recently opened incident comes first
+	suite.Equal("other_cluster", incidents[0].ResourceID)
+	suite.True(incidents[0].Open())
+	suite.Equal("cluster_id", incidents[1].ResourceID)
+	suite.False(incidents[1].Open())
+	suite.Equal(time.Hour, incidents[1].Duration())
+}
+
+func (suite *IncidentsServiceTestSuite) TestIncidentsService_GetAll_FilterByResourceID() {
+	incidents, err := suite.incidentsService.GetAll(&IncidentsFilter{
+		ResourceID: []string{"cluster_id"},
+	})
+	suite.NoError(err)
+	suite.Equal(1, len(incidents))
+	suite.Equal("cluster_id", incidents[0].ResourceID)
+}
+
+func (suite *IncidentsServiceTestSuite) TestIncidentsService_GetAll_OpenOnly() {
+	incidents, err := suite.incidentsService.GetAll(&IncidentsFilter{
+		OpenOnly: true,
+	})
+	suite.NoError(err)
+	suite.Equal(1, len(incidents))
+	suite.Equal("other_cluster", incidents[0].ResourceID)
+}
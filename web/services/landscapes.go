@@ -0,0 +1,174 @@
+package services
+
+import (
+	"sort"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+//go:generate mockery --name=LandscapesService --inpackage --filename=landscapes_mock.go
+
+// LandscapesService groups the discovered resources by SID, so the full stack
+// of a product (SAP systems, databases, clusters and hosts) can be navigated
+// from a single place
+type LandscapesService interface {
+	GetAll() (models.LandscapeList, error)
+	GetBySID(sid string) (*models.Landscape, error)
+	// SetPosition stores where the SID sits along the transport route
+	// (e.g. DEV before QAS before PRD); lower positions come first
+	SetPosition(sid string, position int) error
+}
+
+type landscapesService struct {
+	db                *gorm.DB
+	hostsService      HostsService
+	clustersService   ClustersService
+	sapSystemsService SAPSystemsService
+}
+
+func NewLandscapesService(db *gorm.DB, hostsService HostsService,
+	clustersService ClustersService, sapSystemsService SAPSystemsService) *landscapesService {
+	return &landscapesService{db, hostsService, clustersService, sapSystemsService}
+}
+
+func (s *landscapesService) GetAll() (models.LandscapeList, error) {
+	sids, err := s.getAllSIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := s.getPositions()
+	if err != nil {
+		return nil, err
+	}
+
+	landscapes := models.LandscapeList{}
+	for _, sid := range sids {
+		landscape, err := s.buildLandscape(sid)
+		if err != nil {
+			return nil, err
+		}
+		landscape.Position = positions[sid]
+		landscapes = append(landscapes, landscape)
+	}
+
+	sort.SliceStable(landscapes, func(i, j int) bool {
+		if landscapes[i].Position != landscapes[j].Position {
+			return landscapes[i].Position < landscapes[j].Position
+		}
+		return landscapes[i].SID < landscapes[j].SID
+	})
+
+	return landscapes, nil
+}
+
+func (s *landscapesService) GetBySID(sid string) (*models.Landscape, error) {
+	landscape, err := s.buildLandscape(sid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(landscape.SAPSystems) == 0 && len(landscape.Databases) == 0 &&
+		len(landscape.Clusters) == 0 && len(landscape.Hosts) == 0 {
+		return nil, nil
+	}
+
+	positions, err := s.getPositions()
+	if err != nil {
+		return nil, err
+	}
+	landscape.Position = positions[sid]
+
+	return landscape, nil
+}
+
+func (s *landscapesService) SetPosition(sid string, position int) error {
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "sid"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"position"}),
+	}).Create(&entities.Landscape{
+		SID:      sid,
+		Position: position,
+	}).Error
+}
+
+func (s *landscapesService) buildLandscape(sid string) (*models.Landscape, error) {
+	sapSystems, err := s.sapSystemsService.GetAllApplications(&SAPSystemFilter{SIDs: []string{sid}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	databases, err := s.sapSystemsService.GetAllDatabases(&SAPSystemFilter{SIDs: []string{sid}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters, err := s.clustersService.GetAll(&ClustersFilter{SIDs: []string{sid}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts, err := s.hostsService.GetAll(&HostsFilter{SIDs: []string{sid}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Landscape{
+		SID:        sid,
+		SAPSystems: sapSystems,
+		Databases:  databases,
+		Clusters:   clusters,
+		Hosts:      hosts,
+	}, nil
+}
+
+// getAllSIDs returns the union of the SIDs known by the applications, the
+// databases and the hosts, deduplicated
+func (s *landscapesService) getAllSIDs() ([]string, error) {
+	applicationSIDs, err := s.sapSystemsService.GetAllApplicationsSIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	databaseSIDs, err := s.sapSystemsService.GetAllDatabasesSIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	hostSIDs, err := s.hostsService.GetAllSIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var sids []string
+	for _, sid := range append(append(applicationSIDs, databaseSIDs...), hostSIDs...) {
+		if !internal.Contains(sids, sid) {
+			sids = append(sids, sid)
+		}
+	}
+
+	return sids, nil
+}
+
+func (s *landscapesService) getPositions() (map[string]int, error) {
+	var landscapes []entities.Landscape
+
+	err := s.db.Find(&landscapes).Error
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make(map[string]int)
+	for _, landscape := range landscapes {
+		positions[landscape.SID] = landscape.Position
+	}
+
+	return positions, nil
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type LandscapesServiceTestSuite struct {
+	suite.Suite
+	db                *gorm.DB
+	tx                *gorm.DB
+	hostsService      *MockHostsService
+	clustersService   *MockClustersService
+	sapSystemsService *MockSAPSystemsService
+	landscapesService *landscapesService
+}
+
+func TestLandscapesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LandscapesServiceTestSuite))
+}
+
+func (suite *LandscapesServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Landscape{})
+}
+
+func (suite *LandscapesServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Landscape{})
+}
+
+func (suite *LandscapesServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.hostsService = new(MockHostsService)
+	suite.clustersService = new(MockClustersService)
+	suite.sapSystemsService = new(MockSAPSystemsService)
+	suite.landscapesService = NewLandscapesService(
+		suite.tx, suite.hostsService, suite.clustersService, suite.sapSystemsService)
+}
+
+func (suite *LandscapesServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *LandscapesServiceTestSuite) mockSID(sid string,
+	sapSystems models.SAPSystemList, databases models.SAPSystemList,
+	clusters models.ClusterList, hosts models.HostList) {
+	suite.sapSystemsService.On("GetAllApplications",
+		&SAPSystemFilter{SIDs: []string{sid}}, (*Page)(nil)).Return(sapSystems, nil)
+	suite.sapSystemsService.On("GetAllDatabases",
+		&SAPSystemFilter{SIDs: []string{sid}}, (*Page)(nil)).Return(databases, nil)
+	suite.clustersService.On("GetAll",
+		&ClustersFilter{SIDs: []string{sid}}, (*Page)(nil)).Return(clusters, nil)
+	suite.hostsService.On("GetAll",
+		&HostsFilter{SIDs: []string{sid}}, (*Page)(nil)).Return(hosts, nil)
+}
+
+func (suite *LandscapesServiceTestSuite) TestLandscapesService_GetBySID() {
+	suite.mockSID("HA1",
+		models.SAPSystemList{{ID: "sap_system_1", SID: "HA1"}},
+		models.SAPSystemList{},
+		models.ClusterList{{ID: "cluster_1", SID: "HA1"}},
+		models.HostList{{ID: "1", Name: "host1"}},
+	)
+
+	landscape, err := suite.landscapesService.GetBySID("HA1")
+	suite.NoError(err)
+	suite.Equal("HA1", landscape.SID)
+	suite.Equal("sap_system_1", landscape.SAPSystems[0].ID)
+	suite.Equal("cluster_1", landscape.Clusters[0].ID)
+	suite.Equal("host1", landscape.Hosts[0].Name)
+}
+
+func (suite *LandscapesServiceTestSuite) TestLandscapesService_GetBySID_NotFound() {
+	suite.mockSID("GHO",
+		models.SAPSystemList{}, models.SAPSystemList{},
+		models.ClusterList{}, models.HostList{})
+
+	landscape, err := suite.landscapesService.GetBySID("GHO")
+	suite.NoError(err)
+	suite.Nil(landscape)
+}
+
+func (suite *LandscapesServiceTestSuite) TestLandscapesService_GetAll() {
+	suite.sapSystemsService.On("GetAllApplicationsSIDs").Return([]string{"HA1"}, nil)
+	suite.sapSystemsService.On("GetAllDatabasesSIDs").Return([]string{"PRD"}, nil)
+	suite.hostsService.On("GetAllSIDs").Return([]string{"HA1", "PRD"}, nil)
+
+	suite.mockSID("HA1",
+		models.SAPSystemList{{ID: "sap_system_1", SID: "HA1"}},
+		models.SAPSystemList{},
+		models.ClusterList{}, models.HostList{})
+	suite.mockSID("PRD",
+		models.SAPSystemList{},
+		models.SAPSystemList{{ID: "sap_system_2", SID: "PRD"}},
+		models.ClusterList{}, models.HostList{})
+
+	// PRD is placed before HA1 along the transport route
+	suite.NoError(suite.landscapesService.SetPosition("PRD", 1))
+	suite.NoError(suite.landscapesService.SetPosition("HA1", 2))
+
+	landscapes, err := suite.landscapesService.GetAll()
+	suite.NoError(err)
+	suite.Equal(2, len(landscapes))
+	suite.Equal("PRD", landscapes[0].SID)
+	suite.Equal(1, landscapes[0].Position)
+	suite.Equal("HA1", landscapes[1].SID)
+	suite.Equal(2, landscapes[1].Position)
+}
+
+func (suite *LandscapesServiceTestSuite) TestLandscapesService_SetPosition() {
+	suite.NoError(suite.landscapesService.SetPosition("HA1", 1))
+	suite.NoError(suite.landscapesService.SetPosition("HA1", 3))
+
+	var landscape entities.Landscape
+	suite.tx.First(&landscape)
+	suite.Equal("HA1", landscape.SID)
+	suite.Equal(3, landscape.Position)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var ErrNoteNotFound = errors.New("the note does not exist")
+
+//go:generate mockery --name=NotesService --inpackage --filename=notes_mock.go
+
+// NotesService manages the free-text annotations users attach to resources
+type NotesService interface {
+	GetByResource(resourceType string, resourceID string) (models.NoteList, error)
+	Create(resourceType string, resourceID string, author string, content string) (*models.Note, error)
+	Delete(id int64) error
+}
+
+type notesService struct {
+	db            *gorm.DB
+	eventsService EventsService
+}
+
+func NewNotesService(db *gorm.DB, eventsService EventsService) *notesService {
+	return &notesService{db: db, eventsService: eventsService}
+}
+
+func (s *notesService) GetByResource(resourceType string, resourceID string) (models.NoteList, error) {
+	var notes []entities.Note
+
+	err := s.db.
+		Where("resource_type", resourceType).
+		Where("resource_id", resourceID).
+		Order("created_at DESC, id DESC").
+		Find(&notes).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	noteList := models.NoteList{}
+	for _, note := range notes {
+		noteList = append(noteList, note.ToModel())
+	}
+
+	return noteList, nil
+}
+
+func (s *notesService) Create(resourceType string, resourceID string, author string, content string) (*models.Note, error) {
+	note := entities.Note{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Author:       author,
+		Content:      content,
+	}
+
+	if err := s.db.Create(&note).Error; err != nil {
+		return nil, err
+	}
+
+	err := s.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Type:         models.ResourceEventTypeNote,
+		Message:      fmt.Sprintf("Note added by %s", author),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return note.ToModel(), nil
+}
+
+func (s *notesService) Delete(id int64) error {
+	result := s.db.Delete(&entities.Note{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNoteNotFound
+	}
+
+	return nil
+}
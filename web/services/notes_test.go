@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type NotesServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	notesService NotesService
+}
+
+func TestNotesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(NotesServiceTestSuite))
+}
+
+func (suite *NotesServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Note{}, entities.ResourceEvent{})
+}
+
+func (suite *NotesServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Note{}, entities.ResourceEvent{})
+}
+
+func (suite *NotesServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.notesService = NewNotesService(suite.tx, NewEventsService(suite.tx, nil))
+}
+
+func (suite *NotesServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *NotesServiceTestSuite) TestNotesService_Create() {
+	note, err := suite.notesService.Create(
+		models.TagClusterResourceType, "cluster_id", "admin",
+		"SR disabled for client copy until Friday")
+	suite.NoError(err)
+	suite.Equal("admin", note.Author)
+	suite.NotZero(note.ID)
+	suite.NotZero(note.CreatedAt)
+
+	notes, err := suite.notesService.GetByResource(models.TagClusterResourceType, "cluster_id")
+	suite.NoError(err)
+	suite.Equal(1, len(notes))
+	suite.Equal("SR disabled for client copy until Friday", notes[0].Content)
+
+	// adding a note is recorded in the resource timeline
+	var event entities.ResourceEvent
+	suite.tx.First(&event)
+	suite.Equal(models.ResourceEventTypeNote, event.Type)
+	suite.Equal("cluster_id", event.ResourceID)
+}
+
+func (suite *NotesServiceTestSuite) TestNotesService_GetByResource() {
+	_, err := suite.notesService.Create(
+		models.TagHostResourceType, "host_id", "admin", "first")
+	suite.NoError(err)
+	_, err = suite.notesService.Create(
+		models.TagHostResourceType, "host_id", "operator", "second")
+	suite.NoError(err)
+	_, err = suite.notesService.Create(
+		models.TagHostResourceType, "other_host", "admin", "elsewhere")
+	suite.NoError(err)
+
+	notes, err := suite.notesService.GetByResource(models.TagHostResourceType, "host_id")
+	suite.NoError(err)
+	suite.Equal(2, len(notes))
+	// the newest note comes first
+	suite.Equal("second", notes[0].Content)
+	suite.Equal("first", notes[1].Content)
+}
+
+func (suite *NotesServiceTestSuite) TestNotesService_Delete() {
+	note, err := suite.notesService.Create(
+		models.TagHostResourceType, "host_id", "admin", "to be removed")
+	suite.NoError(err)
+
+	suite.NoError(suite.notesService.Delete(note.ID))
+
+	notes, err := suite.notesService.GetByResource(models.TagHostResourceType, "host_id")
+	suite.NoError(err)
+	suite.Empty(notes)
+
+	suite.ErrorIs(suite.notesService.Delete(note.ID), ErrNoteNotFound)
+}
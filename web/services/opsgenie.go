@@ -0,0 +1,111 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+const OpsgenieAPIURL = "https://api.opsgenie.com"
+
+type opsgenieChannel struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewOpsgenieChannel creates an alert channel pushing to the Opsgenie alert
+// API. The alert fingerprint is used as alias, so Opsgenie deduplicates
+// repeated notifications and the resolution closes the right alert.
+// The base URL selects the region, e.g. https://api.eu.opsgenie.com
+func NewOpsgenieChannel(baseURL string, apiKey string, httpClient *http.Client) *opsgenieChannel {
+	return &opsgenieChannel{
+		baseURL:    baseURL,
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (c *opsgenieChannel) Name() string {
+	return "opsgenie"
+}
+
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Entity      string            `json:"entity,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+func (c *opsgenieChannel) Trigger(alert *models.Alert) error {
+	message := alert.Summary
+	if message == "" {
+		message = alert.Name
+	}
+
+	return c.post("/v2/alerts", &opsgenieAlert{
+		Message:     message,
+		Alias:       alert.Fingerprint,
+		Description: alert.Summary,
+		Priority:    opsgeniePriority(alert.Severity),
+		Entity:      alert.ResourceID,
+		Details: map[string]string{
+			"alertname":     alert.Name,
+			"resource_type": alert.ResourceType,
+			"resource_id":   alert.ResourceID,
+		},
+	})
+}
+
+func (c *opsgenieChannel) Resolve(alert *models.Alert) error {
+	path := fmt.Sprintf("/v2/alerts/%s/close?identifierType=alias", alert.Fingerprint)
+
+	return c.post(path, map[string]string{"source": "trento"})
+}
+
+func (c *opsgenieChannel) post(path string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("GenieKey %s", c.apiKey))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d from the Opsgenie API", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// opsgeniePriority maps the Alertmanager severities to Opsgenie priorities
+func opsgeniePriority(severity string) string {
+	switch severity {
+	case "critical":
+		return "P1"
+	case "error":
+		return "P2"
+	case "warning":
+		return "P3"
+	case "info":
+		return "P5"
+	default:
+		return "P3"
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestOpsgenieChannel_Trigger(t *testing.T) {
+	var receivedPath string
+	var receivedAuth string
+	var received opsgenieAlert
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.Path
+		receivedAuth = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := NewOpsgenieChannel(server.URL, "api-key", server.Client())
+
+	err := channel.Trigger(&models.Alert{
+		Fingerprint:  "fingerprint-1",
+		Status:       models.AlertStatusFiring,
+		Name:         "HighCPULoad",
+		Severity:     "critical",
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "agent-1",
+		Summary:      "CPU load is high",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/v2/alerts", receivedPath)
+	assert.Equal(t, "GenieKey api-key", receivedAuth)
+	assert.Equal(t, "CPU load is high", received.Message)
+	assert.Equal(t, "fingerprint-1", received.Alias)
+	assert.Equal(t, "P1", received.Priority)
+	assert.Equal(t, "agent-1", received.Entity)
+}
+
+func TestOpsgenieChannel_Resolve(t *testing.T) {
+	var receivedURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedURL = r.URL.String()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := NewOpsgenieChannel(server.URL, "api-key", server.Client())
+
+	err := channel.Resolve(&models.Alert{
+		Fingerprint: "fingerprint-1",
+		Status:      models.AlertStatusResolved,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "/v2/alerts/fingerprint-1/close?identifierType=alias", receivedURL)
+}
+
+func TestOpsgenieChannel_UnexpectedStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	channel := NewOpsgenieChannel(server.URL, "wrong-key", server.Client())
+
+	err := channel.Trigger(&models.Alert{Fingerprint: "fingerprint-1", Name: "HighCPULoad"})
+	assert.EqualError(t, err, "unexpected status code 401 from the Opsgenie API")
+}
+
+func TestOpsgeniePriority(t *testing.T) {
+	assert.Equal(t, "P1", opsgeniePriority("critical"))
+	assert.Equal(t, "P2", opsgeniePriority("error"))
+	assert.Equal(t, "P3", opsgeniePriority("warning"))
+	assert.Equal(t, "P5", opsgeniePriority("info"))
+	assert.Equal(t, "P3", opsgeniePriority(""))
+}
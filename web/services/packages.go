@@ -0,0 +1,133 @@
+package services
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// knownGoodPackageMinimums are the minimum versions of the HA/SAP stack
+// packages known to work reliably; hosts running older versions are
+// flagged as outdated in the packages matrix
+var knownGoodPackageMinimums = map[string]string{
+	"pacemaker":       "2.0.4",
+	"corosync":        "2.4.5",
+	"SAPHanaSR":       "0.154",
+	"resource-agents": "4.4.0",
+	"sbd":             "1.4.0",
+}
+
+// GetPackagesMatrix compares the package versions discovered on each host
+// across the whole fleet and against the known-good minimums
+func (s *hostsService) GetPackagesMatrix() (*models.PackagesMatrix, error) {
+	var hosts []entities.Host
+
+	err := s.db.Order("name").Find(&hosts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	packageVersions := make(map[string]map[string]string)
+	record := func(name, host, version string) {
+		if version == "" {
+			return
+		}
+		if packageVersions[name] == nil {
+			packageVersions[name] = make(map[string]string)
+		}
+		packageVersions[name][host] = version
+	}
+
+	for _, host := range hosts {
+		record("kernel", host.Name, host.KernelVersion)
+
+		var packages map[string]string
+		if host.InstalledPackages != nil {
+			if err := json.Unmarshal(host.InstalledPackages, &packages); err == nil {
+				for name, version := range packages {
+					record(name, host.Name, version)
+				}
+			}
+		}
+	}
+
+	var packageNames []string
+	for name := range packageVersions {
+		packageNames = append(packageNames, name)
+	}
+	sort.Strings(packageNames)
+
+	matrix := &models.PackagesMatrix{
+		Packages: []*models.PackageComparison{},
+	}
+
+	for _, name := range packageNames {
+		comparison := &models.PackageComparison{
+			Package:        name,
+			MinimumVersion: knownGoodPackageMinimums[name],
+			Versions:       packageVersions[name],
+			OutdatedHosts:  []string{},
+		}
+
+		if comparison.MinimumVersion != "" {
+			for host, version := range comparison.Versions {
+				if compareVersions(version, comparison.MinimumVersion) < 0 {
+					comparison.OutdatedHosts = append(comparison.OutdatedHosts, host)
+				}
+			}
+			sort.Strings(comparison.OutdatedHosts)
+		}
+
+		matrix.Packages = append(matrix.Packages, comparison)
+	}
+
+	return matrix, nil
+}
+
+// compareVersions compares two package versions segment by segment,
+// numerically where both segments are numbers, returning -1, 0 or 1
+func compareVersions(a, b string) int {
+	splitVersion := func(version string) []string {
+		return strings.FieldsFunc(version, func(r rune) bool {
+			return r == '.' || r == '-' || r == '+' || r == '_'
+		})
+	}
+
+	aSegments := splitVersion(a)
+	bSegments := splitVersion(b)
+
+	for i := 0; i < len(aSegments) && i < len(bSegments); i++ {
+		aNumber, aErr := strconv.Atoi(aSegments[i])
+		bNumber, bErr := strconv.Atoi(bSegments[i])
+
+		if aErr == nil && bErr == nil {
+			if aNumber != bNumber {
+				if aNumber < bNumber {
+					return -1
+				}
+				return 1
+			}
+			continue
+		}
+
+		if aSegments[i] != bSegments[i] {
+			if aSegments[i] < bSegments[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(aSegments) < len(bSegments):
+		return -1
+	case len(aSegments) > len(bSegments):
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/entities"
+)
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, compareVersions("2.0.4", "2.0.4"))
+	assert.Equal(t, -1, compareVersions("2.0.4", "2.0.5"))
+	assert.Equal(t, 1, compareVersions("2.1.0", "2.0.5"))
+	assert.Equal(t, -1, compareVersions("2.0.4", "2.0.10"))
+	assert.Equal(t, -1, compareVersions("2.0", "2.0.4"))
+	assert.Equal(t, 1, compareVersions("2.0.4+20200616", "2.0.4"))
+	assert.Equal(t, -1, compareVersions("1.4.0-rc1", "1.4.0-rc2"))
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetPackagesMatrix() {
+	hosts := []entities.Host{
+		{
+			AgentID:           "uptodate",
+			Name:              "uptodate",
+			KernelVersion:     "5.3.18-24.102-default",
+			InstalledPackages: datatypes.JSON([]byte(`{"pacemaker": "2.0.5", "corosync": "2.4.5"}`)),
+		},
+		{
+			AgentID:           "outdated",
+			Name:              "outdated",
+			KernelVersion:     "5.3.18-24.75-default",
+			InstalledPackages: datatypes.JSON([]byte(`{"pacemaker": "2.0.1", "corosync": "2.4.5"}`)),
+		},
+	}
+	err := suite.tx.Create(&hosts).Error
+	suite.NoError(err)
+
+	matrix, err := suite.hostsService.GetPackagesMatrix()
+	suite.NoError(err)
+
+	packages := make(map[string]int)
+	for i, comparison := range matrix.Packages {
+		packages[comparison.Package] = i
+	}
+	suite.Contains(packages, "corosync")
+	suite.Contains(packages, "pacemaker")
+	suite.Contains(packages, "kernel")
+
+	pacemaker := matrix.Packages[packages["pacemaker"]]
+	suite.Equal("2.0.4", pacemaker.MinimumVersion)
+	suite.Equal(map[string]string{
+		"uptodate": "2.0.5",
+		"outdated": "2.0.1",
+	}, pacemaker.Versions)
+	suite.Equal([]string{"outdated"}, pacemaker.OutdatedHosts)
+
+	corosync := matrix.Packages[packages["corosync"]]
+	suite.Empty(corosync.OutdatedHosts)
+
+	// the kernel has no known-good minimum, so no host is flagged
+	kernel := matrix.Packages[packages["kernel"]]
+	suite.Empty(kernel.MinimumVersion)
+	suite.Empty(kernel.OutdatedHosts)
+}
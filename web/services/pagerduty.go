@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/trento-project/trento/web/models"
+)
+
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+type pagerDutyChannel struct {
+	url        string
+	routingKey string
+	httpClient *http.Client
+}
+
+// NewPagerDutyChannel creates an alert channel pushing to the PagerDuty
+// Events API v2. The alert fingerprint is used as deduplication key, so
+// repeated notifications and the final resolution land on the same
+// PagerDuty incident
+func NewPagerDutyChannel(url string, routingKey string, httpClient *http.Client) *pagerDutyChannel {
+	return &pagerDutyChannel{
+		url:        url,
+		routingKey: routingKey,
+		httpClient: httpClient,
+	}
+}
+
+func (c *pagerDutyChannel) Name() string {
+	return "pagerduty"
+}
+
+func (c *pagerDutyChannel) Trigger(alert *models.Alert) error {
+	return c.sendEvent("trigger", alert)
+}
+
+func (c *pagerDutyChannel) Resolve(alert *models.Alert) error {
+	return c.sendEvent("resolve", alert)
+}
+
+type pagerDutyEvent struct {
+	RoutingKey  string                 `json:"routing_key"`
+	EventAction string                 `json:"event_action"`
+	DedupKey    string                 `json:"dedup_key"`
+	Payload     *pagerDutyEventPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyEventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+func (c *pagerDutyChannel) sendEvent(action string, alert *models.Alert) error {
+	event := pagerDutyEvent{
+		RoutingKey:  c.routingKey,
+		EventAction: action,
+		DedupKey:    alert.Fingerprint,
+	}
+
+	if action == "trigger" {
+		summary := alert.Summary
+		if summary == "" {
+			summary = alert.Name
+		}
+		source := alert.ResourceID
+		if source == "" {
+			source = "trento"
+		}
+
+		event.Payload = &pagerDutyEventPayload{
+			Summary:   summary,
+			Source:    source,
+			Severity:  pagerDutySeverity(alert.Severity),
+			Component: alert.ResourceType,
+			CustomDetails: map[string]string{
+				"alertname":     alert.Name,
+				"resource_type": alert.ResourceType,
+				"resource_id":   alert.ResourceID,
+			},
+		}
+	}
+
+	body, err := json.Marshal(&event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Post(c.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("unexpected status code %d from the PagerDuty events API", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// pagerDutySeverity maps the Alertmanager severities to the values the
+// Events API accepts
+func pagerDutySeverity(severity string) string {
+	switch severity {
+	case "critical", "error", "warning", "info":
+		return severity
+	default:
+		return "error"
+	}
+}
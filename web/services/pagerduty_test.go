@@ -0,0 +1,82 @@
+package services
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+)
+
+func TestPagerDutyChannel_Trigger(t *testing.T) {
+	var received pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := NewPagerDutyChannel(server.URL, "routing-key", server.Client())
+
+	err := channel.Trigger(&models.Alert{
+		Fingerprint:  "fingerprint-1",
+		Status:       models.AlertStatusFiring,
+		Name:         "HighCPULoad",
+		Severity:     "critical",
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "agent-1",
+		Summary:      "CPU load is high",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "routing-key", received.RoutingKey)
+	assert.Equal(t, "trigger", received.EventAction)
+	assert.Equal(t, "fingerprint-1", received.DedupKey)
+	assert.Equal(t, "CPU load is high", received.Payload.Summary)
+	assert.Equal(t, "agent-1", received.Payload.Source)
+	assert.Equal(t, "critical", received.Payload.Severity)
+}
+
+func TestPagerDutyChannel_Resolve(t *testing.T) {
+	var received pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	channel := NewPagerDutyChannel(server.URL, "routing-key", server.Client())
+
+	err := channel.Resolve(&models.Alert{
+		Fingerprint: "fingerprint-1",
+		Status:      models.AlertStatusResolved,
+		Name:        "HighCPULoad",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "resolve", received.EventAction)
+	assert.Equal(t, "fingerprint-1", received.DedupKey)
+	assert.Nil(t, received.Payload)
+}
+
+func TestPagerDutyChannel_UnexpectedStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	channel := NewPagerDutyChannel(server.URL, "routing-key", server.Client())
+
+	err := channel.Trigger(&models.Alert{Fingerprint: "fingerprint-1", Name: "HighCPULoad"})
+	assert.EqualError(t, err, "unexpected status code 400 from the PagerDuty events API")
+}
+
+func TestPagerDutySeverity(t *testing.T) {
+	assert.Equal(t, "critical", pagerDutySeverity("critical"))
+	assert.Equal(t, "warning", pagerDutySeverity("warning"))
+	// severities the events API does not know are reported as errors
+	assert.Equal(t, "error", pagerDutySeverity("page"))
+	assert.Equal(t, "error", pagerDutySeverity(""))
+}
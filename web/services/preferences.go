@@ -0,0 +1,56 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=PreferencesService --inpackage --filename=preferences_mock.go
+
+type PreferencesService interface {
+	GetPreferences(subject string) (*models.Preferences, error)
+	SetPreferences(subject string, preferences *models.Preferences) error
+}
+
+type preferencesService struct {
+	db *gorm.DB
+}
+
+func NewPreferencesService(db *gorm.DB) *preferencesService {
+	return &preferencesService{db: db}
+}
+
+func (s *preferencesService) GetPreferences(subject string) (*models.Preferences, error) {
+	var preferences entities.Preferences
+
+	err := s.db.Where("subject = ?", subject).First(&preferences).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.NewDefaultPreferences(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return preferences.ToModel()
+}
+
+func (s *preferencesService) SetPreferences(subject string, preferences *models.Preferences) error {
+	payload, err := json.Marshal(preferences)
+	if err != nil {
+		return err
+	}
+
+	entity := entities.Preferences{
+		Subject: subject,
+		Payload: payload,
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&entity).Error
+}
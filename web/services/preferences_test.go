@@ -0,0 +1,91 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type PreferencesServiceTestSuite struct {
+	suite.Suite
+	db                 *gorm.DB
+	tx                 *gorm.DB
+	preferencesService PreferencesService
+}
+
+func TestPreferencesServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PreferencesServiceTestSuite))
+}
+
+func (suite *PreferencesServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Preferences{})
+}
+
+func (suite *PreferencesServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Preferences{})
+}
+
+func (suite *PreferencesServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.preferencesService = NewPreferencesService(suite.tx)
+}
+
+func (suite *PreferencesServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *PreferencesServiceTestSuite) TestPreferencesService_GetPreferencesDefaults() {
+	preferences, err := suite.preferencesService.GetPreferences("some-subject")
+	suite.NoError(err)
+
+	suite.Equal(models.DefaultItemsPerPage, preferences.ItemsPerPage)
+	suite.Empty(preferences.DefaultFilters)
+	suite.Empty(preferences.Columns)
+}
+
+func (suite *PreferencesServiceTestSuite) TestPreferencesService_SetPreferences() {
+	preferences := &models.Preferences{
+		DefaultFilters: map[string][]string{
+			"hosts_health": {"passing", "critical"},
+		},
+		Columns:      map[string][]string{},
+		ItemsPerPage: 25,
+	}
+
+	err := suite.preferencesService.SetPreferences("some-subject", preferences)
+	suite.NoError(err)
+
+	storedPreferences, err := suite.preferencesService.GetPreferences("some-subject")
+	suite.NoError(err)
+	suite.Equal(preferences, storedPreferences)
+
+	// other subjects keep getting the defaults
+	otherPreferences, err := suite.preferencesService.GetPreferences("other-subject")
+	suite.NoError(err)
+	suite.Equal(models.DefaultItemsPerPage, otherPreferences.ItemsPerPage)
+}
+
+func (suite *PreferencesServiceTestSuite) TestPreferencesService_UpdatePreferences() {
+	preferences := &models.Preferences{
+		DefaultFilters: map[string][]string{},
+		Columns:        map[string][]string{},
+		ItemsPerPage:   25,
+	}
+
+	err := suite.preferencesService.SetPreferences("some-subject", preferences)
+	suite.NoError(err)
+
+	preferences.ItemsPerPage = 50
+	err = suite.preferencesService.SetPreferences("some-subject", preferences)
+	suite.NoError(err)
+
+	storedPreferences, err := suite.preferencesService.GetPreferences("some-subject")
+	suite.NoError(err)
+	suite.Equal(50, storedPreferences.ItemsPerPage)
+}
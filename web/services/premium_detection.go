@@ -44,6 +44,14 @@ func (premiumDetection *premiumDetectionService) RequiresEulaAcceptance() (bool,
 }
 
 func (premiumDetection *premiumDetectionService) CanPublishTelemetry() (bool, error) {
+	optedOut, err := premiumDetection.settings.IsTelemetryOptedOut()
+	if err != nil {
+		log.Errorf("Unable to determine whether telemetry has been opted out. Error: %s", err)
+		return false, err
+	}
+	if optedOut {
+		return false, nil
+	}
 	if !premiumDetection.isPremiumFlavor() {
 		return false, nil
 	}
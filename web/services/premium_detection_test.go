@@ -83,6 +83,8 @@ func (suite *PremiumDetectionTestSuite) Test_FailsDeterminingEulaAcceptanceRequi
 }
 
 func (suite *PremiumDetectionTestSuite) Test_CannotPublishTelemetryOnCommunityFlavor() {
+	suite.settings.On("IsTelemetryOptedOut").Return(false, nil)
+
 	premiumDetection := NewPremiumDetectionService(
 		community,
 		suite.subscriptions,
@@ -94,7 +96,38 @@ func (suite *PremiumDetectionTestSuite) Test_CannotPublishTelemetryOnCommunityFl
 	suite.False(canPublishTelemetry)
 }
 
+func (suite *PremiumDetectionTestSuite) Test_CannotPublishTelemetryWhenOptedOut() {
+	suite.settings.On("IsTelemetryOptedOut").Return(true, nil)
+
+	premiumDetection := NewPremiumDetectionService(
+		premium,
+		suite.subscriptions,
+		suite.settings,
+	)
+
+	canPublishTelemetry, err := premiumDetection.CanPublishTelemetry()
+	suite.NoError(err)
+	suite.False(canPublishTelemetry)
+	suite.settings.AssertNotCalled(suite.T(), "IsEulaAccepted")
+}
+
+func (suite *PremiumDetectionTestSuite) Test_FailsDeterminingTelemetryOptOut() {
+	suite.settings.On("IsTelemetryOptedOut").Return(false, errors.New("WOOPS"))
+
+	premiumDetection := NewPremiumDetectionService(
+		premium,
+		suite.subscriptions,
+		suite.settings,
+	)
+
+	canPublishTelemetry, err := premiumDetection.CanPublishTelemetry()
+	suite.Error(err, "WOOPS")
+	suite.False(canPublishTelemetry)
+	suite.settings.AssertExpectations(suite.T())
+}
+
 func (suite *PremiumDetectionTestSuite) Test_CanPublishTelemetryOnPremiumFlavor() {
+	suite.settings.On("IsTelemetryOptedOut").Return(false, nil)
 	suite.settings.On("IsEulaAccepted").Return(true, nil)
 
 	premiumDetection := NewPremiumDetectionService(
@@ -110,6 +143,7 @@ func (suite *PremiumDetectionTestSuite) Test_CanPublishTelemetryOnPremiumFlavor(
 }
 
 func (suite *PremiumDetectionTestSuite) Test_CannotPublishTelemetryOnPremiumFlavor() {
+	suite.settings.On("IsTelemetryOptedOut").Return(false, nil)
 	suite.settings.On("IsEulaAccepted").Return(false, nil)
 
 	premiumDetection := NewPremiumDetectionService(
@@ -125,6 +159,7 @@ func (suite *PremiumDetectionTestSuite) Test_CannotPublishTelemetryOnPremiumFlav
 }
 
 func (suite *PremiumDetectionTestSuite) Test_FailsDeterminingTelemetryPublishability() {
+	suite.settings.On("IsTelemetryOptedOut").Return(false, nil)
 	suite.settings.On("IsEulaAccepted").Return(false, errors.New("KABOOM"))
 
 	premiumDetection := NewPremiumDetectionService(
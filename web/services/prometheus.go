@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/internal"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
@@ -17,11 +19,24 @@ import (
 const (
 	nodeExporterPort = 9100
 	nodeExporterName = "Node Exporter"
+
+	hanaDBExporterPort = 9668
+	hanaDBExporterName = "HANADB Exporter"
+
+	haClusterExporterPort = 9664
+	haClusterExporterName = "HA Cluster Exporter"
+)
+
+// The exporter types selectable with the targets endpoint type parameter
+const (
+	NodeExporterType      = "node_exporter"
+	HANADBExporterType    = "hanadb_exporter"
+	HAClusterExporterType = "ha_cluster_exporter"
 )
 
 //go:generate mockery --name=PrometheusService --inpackage --filename=prometheus_mock.go
 type PrometheusService interface {
-	GetHttpSDTargets() (models.PrometheusTargetsList, error)
+	GetHttpSDTargets(exporterType string) (models.PrometheusTargetsList, error)
 	Query(query string, ts time.Time) (prometheusModel.Value, error)
 }
 
@@ -34,30 +49,96 @@ func NewPrometheusService(db *gorm.DB, promApi prometheusInternal.PrometheusAPI)
 	return &prometheusService{db, promApi}
 }
 
-func (p *prometheusService) GetHttpSDTargets() (models.PrometheusTargetsList, error) {
+func (p *prometheusService) GetHttpSDTargets(exporterType string) (models.PrometheusTargetsList, error) {
 	var targetsList models.PrometheusTargetsList
 	var hosts []entities.Host
 
-	err := p.db.Find(&hosts).Error
+	switch exporterType {
+	case "", NodeExporterType, HANADBExporterType, HAClusterExporterType:
+	default:
+		return nil, fmt.Errorf("unknown exporter type %s", exporterType)
+	}
+
+	err := p.db.
+		Preload("Tags").
+		Preload("SAPSystemInstances").
+		Find(&hosts).
+		Error
 	if err != nil {
 		return targetsList, err
 	}
 
 	for _, host := range hosts {
-		targets := &models.PrometheusTargets{
-			Targets: []string{fmt.Sprintf("%s:%d", host.SSHAddress, nodeExporterPort)},
-			Labels: map[string]string{
-				"agentID":       host.AgentID,
-				"hostname":      host.Name,
-				"exporter_name": nodeExporterName,
-			},
+		if exporterType == "" || exporterType == NodeExporterType {
+			targetsList = append(targetsList, makeExporterTargets(
+				&host, NodeExporterType, nodeExporterName, nodeExporterPort))
+		}
+
+		if (exporterType == "" || exporterType == HAClusterExporterType) && host.ClusterID != "" {
+			targetsList = append(targetsList, makeExporterTargets(
+				&host, HAClusterExporterType, haClusterExporterName, haClusterExporterPort))
+		}
+
+		if (exporterType == "" || exporterType == HANADBExporterType) && hostsDatabaseSID(&host) != "" {
+			targetsList = append(targetsList, makeExporterTargets(
+				&host, HANADBExporterType, hanaDBExporterName, hanaDBExporterPort))
 		}
-		targetsList = append(targetsList, targets)
 	}
 
 	return targetsList, nil
 }
 
+// makeExporterTargets builds the target group of a single exporter on a host,
+// labeled so that scrape configs do not need any manual relabeling
+func makeExporterTargets(host *entities.Host, exporterType string, exporterName string, port int) *models.PrometheusTargets {
+	labels := map[string]string{
+		"agentID":       host.AgentID,
+		"hostname":      host.Name,
+		"exporter_name": exporterName,
+		"exporter_type": exporterType,
+	}
+
+	if host.ClusterID != "" {
+		labels["cluster_id"] = host.ClusterID
+		labels["cluster_name"] = host.ClusterName
+	}
+
+	var sids []string
+	for _, instance := range host.SAPSystemInstances {
+		if !internal.Contains(sids, instance.SID) {
+			sids = append(sids, instance.SID)
+		}
+	}
+	if len(sids) > 0 {
+		labels["sid"] = strings.Join(sids, ",")
+	}
+
+	var tags []string
+	for _, tag := range host.Tags {
+		tags = append(tags, tag.Value)
+	}
+	if len(tags) > 0 {
+		labels["tags"] = strings.Join(tags, ",")
+	}
+
+	return &models.PrometheusTargets{
+		Targets: []string{fmt.Sprintf("%s:%d", host.SSHAddress, port)},
+		Labels:  labels,
+	}
+}
+
+// hostsDatabaseSID returns the SID of the HANA database running on the host,
+// if any
+func hostsDatabaseSID(host *entities.Host) string {
+	for _, instance := range host.SAPSystemInstances {
+		if instance.Type == models.SAPSystemTypeDatabase {
+			return instance.SID
+		}
+	}
+
+	return ""
+}
+
 func (p *prometheusService) Query(query string, ts time.Time) (prometheusModel.Value, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -16,13 +16,13 @@ type MockPrometheusService struct {
 	mock.Mock
 }
 
-// GetHttpSDTargets provides a mock function with given fields:
-func (_m *MockPrometheusService) GetHttpSDTargets() (models.PrometheusTargetsList, error) {
-	ret := _m.Called()
+// GetHttpSDTargets provides a mock function with given fields: exporterType
+func (_m *MockPrometheusService) GetHttpSDTargets(exporterType string) (models.PrometheusTargetsList, error) {
+	ret := _m.Called(exporterType)
 
 	var r0 models.PrometheusTargetsList
-	if rf, ok := ret.Get(0).(func() models.PrometheusTargetsList); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string) models.PrometheusTargetsList); ok {
+		r0 = rf(exporterType)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(models.PrometheusTargetsList)
@@ -30,8 +30,8 @@ func (_m *MockPrometheusService) GetHttpSDTargets() (models.PrometheusTargetsLis
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(exporterType)
 	} else {
 		r1 = ret.Error(1)
 	}
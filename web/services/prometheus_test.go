@@ -24,14 +24,32 @@ func targetsFixtures() []entities.Host {
 			SSHAddress: "192.168.1.1",
 		},
 		{
-			AgentID:    "2",
-			Name:       "host2",
-			SSHAddress: "192.168.1.2",
+			AgentID:     "2",
+			Name:        "host2",
+			SSHAddress:  "192.168.1.2",
+			ClusterID:   "cluster_id",
+			ClusterName: "cluster",
 		},
 		{
 			AgentID:    "3",
 			Name:       "host3",
 			SSHAddress: "192.168.1.3",
+			SAPSystemInstances: []*entities.SAPSystemInstance{
+				{
+					ID:             "sap_system_id",
+					AgentID:        "3",
+					Type:           models.SAPSystemTypeDatabase,
+					SID:            "PRD",
+					InstanceNumber: "00",
+				},
+			},
+			Tags: []*models.Tag{
+				{
+					ResourceID:   "3",
+					ResourceType: models.TagHostResourceType,
+					Value:        "tag1",
+				},
+			},
 		},
 	}
 }
@@ -72,25 +90,73 @@ func (suite *PrometheusServiceTestSuite) TearDownTest() {
 }
 
 func (suite *PrometheusServiceTestSuite) TestPrometheusService_GetHttpSDTargets() {
-	targets, err := suite.prometheusService.GetHttpSDTargets()
+	targets, err := suite.prometheusService.GetHttpSDTargets("")
 	suite.NoError(err)
 
 	suite.ElementsMatch(models.PrometheusTargetsList{
 		&models.PrometheusTargets{
 			Targets: []string{"192.168.1.1:9100"},
-			Labels:  map[string]string{"agentID": "1", "hostname": "host1", "exporter_name": "Node Exporter"},
+			Labels: map[string]string{
+				"agentID": "1", "hostname": "host1",
+				"exporter_name": "Node Exporter", "exporter_type": "node_exporter",
+			},
 		},
 		&models.PrometheusTargets{
 			Targets: []string{"192.168.1.2:9100"},
-			Labels:  map[string]string{"agentID": "2", "hostname": "host2", "exporter_name": "Node Exporter"},
+			Labels: map[string]string{
+				"agentID": "2", "hostname": "host2",
+				"exporter_name": "Node Exporter", "exporter_type": "node_exporter",
+				"cluster_id": "cluster_id", "cluster_name": "cluster",
+			},
+		},
+		&models.PrometheusTargets{
+			Targets: []string{"192.168.1.2:9664"},
+			Labels: map[string]string{
+				"agentID": "2", "hostname": "host2",
+				"exporter_name": "HA Cluster Exporter", "exporter_type": "ha_cluster_exporter",
+				"cluster_id": "cluster_id", "cluster_name": "cluster",
+			},
 		},
 		&models.PrometheusTargets{
 			Targets: []string{"192.168.1.3:9100"},
-			Labels:  map[string]string{"agentID": "3", "hostname": "host3", "exporter_name": "Node Exporter"},
+			Labels: map[string]string{
+				"agentID": "3", "hostname": "host3",
+				"exporter_name": "Node Exporter", "exporter_type": "node_exporter",
+				"sid": "PRD", "tags": "tag1",
+			},
+		},
+		&models.PrometheusTargets{
+			Targets: []string{"192.168.1.3:9668"},
+			Labels: map[string]string{
+				"agentID": "3", "hostname": "host3",
+				"exporter_name": "HANADB Exporter", "exporter_type": "hanadb_exporter",
+				"sid": "PRD", "tags": "tag1",
+			},
 		},
 	}, targets)
 }
 
+func (suite *PrometheusServiceTestSuite) TestPrometheusService_GetHttpSDTargetsFiltered() {
+	targets, err := suite.prometheusService.GetHttpSDTargets(HAClusterExporterType)
+	suite.NoError(err)
+
+	suite.ElementsMatch(models.PrometheusTargetsList{
+		&models.PrometheusTargets{
+			Targets: []string{"192.168.1.2:9664"},
+			Labels: map[string]string{
+				"agentID": "2", "hostname": "host2",
+				"exporter_name": "HA Cluster Exporter", "exporter_type": "ha_cluster_exporter",
+				"cluster_id": "cluster_id", "cluster_name": "cluster",
+			},
+		},
+	}, targets)
+}
+
+func (suite *PrometheusServiceTestSuite) TestPrometheusService_GetHttpSDTargetsUnknownType() {
+	_, err := suite.prometheusService.GetHttpSDTargets("some_exporter")
+	suite.EqualError(err, "unknown exporter type some_exporter")
+}
+
 func (suite *PrometheusServiceTestSuite) TestPrometheusService_Query() {
 	cTime := time.Now()
 	expectedResult := prometheusModel.Vector{
@@ -0,0 +1,285 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+const reportTemplateSource = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ .Name }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.4em 0.8em; text-align: left; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: 0.2em; }
+</style>
+</head>
+<body>
+<h1>{{ .Name }}</h1>
+<p>Generated at {{ .Data.GeneratedAt.Format "2006-01-02 15:04:05 MST" }}</p>
+
+<h2>Inventory</h2>
+<table>
+<tr><th>Hosts</th><th>Clusters</th><th>Applications</th><th>Databases</th></tr>
+<tr><td>{{ .Data.HostsCount }}</td><td>{{ .Data.ClustersCount }}</td><td>{{ .Data.ApplicationsCount }}</td><td>{{ .Data.DatabasesCount }}</td></tr>
+</table>
+
+<h2>SAP systems health</h2>
+{{ if .Data.HealthSummary }}
+<table>
+<tr><th>SID</th><th>SAP system</th><th>Clusters</th><th>Database</th><th>Hosts</th></tr>
+{{ range .Data.HealthSummary }}
+<tr><td>{{ .SID }}</td><td>{{ .SAPSystemHealth }}</td><td>{{ .ClustersHealth }}</td><td>{{ .DatabaseHealth }}</td><td>{{ .HostsHealth }}</td></tr>
+{{ end }}
+</table>
+{{ else }}
+<p>No SAP systems discovered.</p>
+{{ end }}
+
+<h2>Failing checks</h2>
+{{ if .Data.FailingChecks }}
+<table>
+<tr><th>Cluster</th><th>Check</th><th>Host</th><th>Description</th><th>Remediation</th></tr>
+{{ range .Data.FailingChecks }}
+<tr><td>{{ .ClusterName }}</td><td>{{ .CheckID }}</td><td>{{ .Host }}</td><td>{{ .Description }}</td><td>{{ .Remediation }}</td></tr>
+{{ end }}
+</table>
+{{ else }}
+<p>No failing checks.</p>
+{{ end }}
+
+<h2>Subscription status</h2>
+{{ if .Data.PremiumData.IsPremium }}
+<p>Premium subscription active, {{ .Data.PremiumData.Sles4SapCount }} SLES for SAP subscriptions discovered.</p>
+{{ else }}
+<p>No premium subscription detected.</p>
+{{ end }}
+</body>
+</html>
+`
+
+var reportTemplate = template.Must(template.New("report").Parse(reportTemplateSource))
+
+//go:generate mockery --name=ReportsService --inpackage --filename=reports_mock.go
+type ReportsService interface {
+	GenerateReport() (*models.Report, error)
+	GetAll() (models.ReportList, error)
+	GetContentByID(id int64) ([]byte, error)
+}
+
+type reportsService struct {
+	db                   *gorm.DB
+	hostsService         HostsService
+	clustersService      ClustersService
+	sapSystemsService    SAPSystemsService
+	healthSummaryService HealthSummaryService
+	checksService        ChecksService
+	subscriptionsService SubscriptionsService
+}
+
+func NewReportsService(
+	db *gorm.DB,
+	hostsService HostsService,
+	clustersService ClustersService,
+	sapSystemsService SAPSystemsService,
+	healthSummaryService HealthSummaryService,
+	checksService ChecksService,
+	subscriptionsService SubscriptionsService,
+) *reportsService {
+	return &reportsService{
+		db:                   db,
+		hostsService:         hostsService,
+		clustersService:      clustersService,
+		sapSystemsService:    sapSystemsService,
+		healthSummaryService: healthSummaryService,
+		checksService:        checksService,
+		subscriptionsService: subscriptionsService,
+	}
+}
+
+// GenerateReport takes a snapshot of the landscape, renders it to a
+// self-contained HTML document and stores it for later download
+func (s *reportsService) GenerateReport() (*models.Report, error) {
+	data, err := s.collectReportData()
+	if err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("Landscape report %s", data.GeneratedAt.Format("2006-01-02 15:04"))
+
+	var content bytes.Buffer
+	err = reportTemplate.Execute(&content, struct {
+		Name string
+		Data *models.ReportData
+	}{name, data})
+	if err != nil {
+		return nil, err
+	}
+
+	report := entities.Report{
+		Name:    name,
+		Content: content.Bytes(),
+	}
+
+	err = s.db.Create(&report).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return report.ToModel(), nil
+}
+
+func (s *reportsService) GetAll() (models.ReportList, error) {
+	var reports []entities.Report
+
+	err := s.db.
+		Select("id", "name", "created_at").
+		Order("created_at DESC, id DESC").
+		Find(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reportList := models.ReportList{}
+	for i := range reports {
+		reportList = append(reportList, reports[i].ToModel())
+	}
+
+	return reportList, nil
+}
+
+func (s *reportsService) GetContentByID(id int64) ([]byte, error) {
+	var report entities.Report
+
+	err := s.db.Where("id = ?", id).First(&report).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return report.Content, nil
+}
+
+func (s *reportsService) collectReportData() (*models.ReportData, error) {
+	hostsCount, err := s.hostsService.GetCount()
+	if err != nil {
+		return nil, err
+	}
+
+	clustersCount, err := s.clustersService.GetCount()
+	if err != nil {
+		return nil, err
+	}
+
+	applicationsCount, err := s.sapSystemsService.GetApplicationsCount()
+	if err != nil {
+		return nil, err
+	}
+
+	databasesCount, err := s.sapSystemsService.GetDatabasesCount()
+	if err != nil {
+		return nil, err
+	}
+
+	healthSummary, err := s.healthSummaryService.GetHealthSummary(false)
+	if err != nil {
+		return nil, err
+	}
+
+	failingChecks, err := s.collectFailingChecks()
+	if err != nil {
+		return nil, err
+	}
+
+	premiumData, err := s.subscriptionsService.GetPremiumData()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ReportData{
+		GeneratedAt:       time.Now(),
+		HostsCount:        hostsCount,
+		ClustersCount:     clustersCount,
+		ApplicationsCount: applicationsCount,
+		DatabasesCount:    databasesCount,
+		HealthSummary:     healthSummary,
+		FailingChecks:     failingChecks,
+		PremiumData:       premiumData,
+	}, nil
+}
+
+func (s *reportsService) collectFailingChecks() ([]*models.FailingCheck, error) {
+	clusters, err := s.clustersService.GetAll(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	catalog, err := s.checksService.GetChecksCatalog()
+	if err != nil {
+		return nil, err
+	}
+
+	catalogByID := make(map[string]*models.Check)
+	for _, check := range catalog {
+		catalogByID[check.ID] = check
+	}
+
+	failingChecks := []*models.FailingCheck{}
+	for _, cluster := range clusters {
+		checksResult, err := s.checksService.GetChecksResultByCluster(cluster.ID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// clusters where the checks never ran have nothing to report
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for checkID, checkByHost := range checksResult.Checks {
+			for host, check := range checkByHost.Hosts {
+				if check.Result != models.CheckCritical {
+					continue
+				}
+
+				failingCheck := &models.FailingCheck{
+					ClusterID:   cluster.ID,
+					ClusterName: cluster.Name,
+					CheckID:     checkID,
+					Host:        host,
+				}
+				if catalogEntry, ok := catalogByID[checkID]; ok {
+					failingCheck.Description = catalogEntry.Description
+					failingCheck.Remediation = catalogEntry.Remediation
+				}
+
+				failingChecks = append(failingChecks, failingCheck)
+			}
+		}
+	}
+
+	sort.Slice(failingChecks, func(i, j int) bool {
+		if failingChecks[i].ClusterName != failingChecks[j].ClusterName {
+			return failingChecks[i].ClusterName < failingChecks[j].ClusterName
+		}
+		if failingChecks[i].CheckID != failingChecks[j].CheckID {
+			return failingChecks[i].CheckID < failingChecks[j].CheckID
+		}
+		return failingChecks[i].Host < failingChecks[j].Host
+	})
+
+	return failingChecks, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type ReportsServiceTestSuite struct {
+	suite.Suite
+	db                   *gorm.DB
+	tx                   *gorm.DB
+	hostsService         *MockHostsService
+	clustersService      *MockClustersService
+	sapSystemsService    *MockSAPSystemsService
+	healthSummaryService *MockHealthSummaryService
+	checksService        *MockChecksService
+	subscriptionsService *MockSubscriptionsService
+	reportsService       *reportsService
+}
+
+func TestReportsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportsServiceTestSuite))
+}
+
+func (suite *ReportsServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(&entities.Report{})
+}
+
+func (suite *ReportsServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(&entities.Report{})
+}
+
+func (suite *ReportsServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.hostsService = new(MockHostsService)
+	suite.clustersService = new(MockClustersService)
+	suite.sapSystemsService = new(MockSAPSystemsService)
+	suite.healthSummaryService = new(MockHealthSummaryService)
+	suite.checksService = new(MockChecksService)
+	suite.subscriptionsService = new(MockSubscriptionsService)
+	suite.reportsService = NewReportsService(suite.tx, suite.hostsService, suite.clustersService,
+		suite.sapSystemsService, suite.healthSummaryService, suite.checksService, suite.subscriptionsService)
+}
+
+func (suite *ReportsServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *ReportsServiceTestSuite) mockLandscape() {
+	suite.hostsService.On("GetCount").Return(4, nil)
+	suite.clustersService.On("GetCount").Return(2, nil)
+	suite.sapSystemsService.On("GetApplicationsCount").Return(1, nil)
+	suite.sapSystemsService.On("GetDatabasesCount").Return(1, nil)
+	suite.healthSummaryService.On("GetHealthSummary", false).Return(models.HealthSummary{
+		{
+			ID:              "sap-system-1",
+			SID:             "PRD",
+			SAPSystemHealth: models.HealthSummaryHealthPassing,
+			ClustersHealth:  models.HealthSummaryHealthCritical,
+			DatabaseHealth:  models.HealthSummaryHealthPassing,
+			HostsHealth:     models.HealthSummaryHealthPassing,
+		},
+	}, nil)
+	suite.clustersService.On("GetAll", (*ClustersFilter)(nil), (*Page)(nil)).Return(models.ClusterList{
+		{
+			ID:   "cluster-1",
+			Name: "hana_cluster",
+		},
+	}, nil)
+	suite.checksService.On("GetChecksCatalog").Return(models.ChecksCatalog{
+		{
+			ID:          "ABCDEF",
+			Description: "SBD watchdog timeout",
+			Remediation: "Set the watchdog timeout to 60 seconds",
+		},
+	}, nil)
+	suite.checksService.On("GetChecksResultByCluster", "cluster-1").Return(&models.ChecksResult{
+		Checks: map[string]*models.ChecksByHost{
+			"ABCDEF": {
+				Hosts: map[string]*models.Check{
+					"host1": {Result: models.CheckCritical},
+					"host2": {Result: models.CheckPassing},
+				},
+			},
+		},
+	}, nil)
+	suite.subscriptionsService.On("GetPremiumData").Return(&models.PremiumData{
+		IsPremium:     true,
+		Sles4SapCount: 4,
+	}, nil)
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GenerateReport() {
+	suite.mockLandscape()
+
+	report, err := suite.reportsService.GenerateReport()
+
+	suite.NoError(err)
+	suite.NotZero(report.ID)
+	suite.Contains(report.Name, "Landscape report")
+
+	content, err := suite.reportsService.GetContentByID(report.ID)
+	suite.NoError(err)
+
+	html := string(content)
+	suite.Contains(html, "PRD")
+	suite.Contains(html, "SBD watchdog timeout")
+	suite.Contains(html, "Set the watchdog timeout to 60 seconds")
+	suite.Contains(html, "host1")
+	suite.NotContains(html, "host2")
+	suite.Contains(html, "Premium subscription active")
+
+	reports, err := suite.reportsService.GetAll()
+	suite.NoError(err)
+	suite.Len(reports, 1)
+	suite.Equal(report.ID, reports[0].ID)
+	suite.Equal(report.Name, reports[0].Name)
+}
+
+func (suite *ReportsServiceTestSuite) TestReportsService_GetContentByIDNotFound() {
+	content, err := suite.reportsService.GetContentByID(9999)
+
+	suite.NoError(err)
+	suite.Nil(content)
+}
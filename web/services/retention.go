@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// A RetentionDays value of 0 disables the pruning for the table
+const DefaultRetentionDays = 0
+
+// prunableTable describes a table the retention pruner is allowed to touch,
+// and which timestamp column decides the age of a row
+type prunableTable struct {
+	model     interface{}
+	ageColumn string
+}
+
+var prunableTables = map[string]prunableTable{
+	"checks_results":  {model: &entities.ChecksResult{}, ageColumn: "created_at"},
+	"host_heartbeats": {model: &entities.HostHeartbeat{}, ageColumn: "updated_at"},
+}
+
+//go:generate mockery --name=RetentionService --inpackage --filename=retention_mock.go
+
+type RetentionService interface {
+	GetPolicies() ([]*models.RetentionPolicy, error)
+	SetPolicy(table string, retentionDays uint) error
+	PruneExpired() error
+}
+
+type retentionService struct {
+	db *gorm.DB
+}
+
+func NewRetentionService(db *gorm.DB) *retentionService {
+	return &retentionService{db: db}
+}
+
+func (s *retentionService) GetPolicies() ([]*models.RetentionPolicy, error) {
+	var storedPolicies []entities.RetentionPolicy
+
+	err := s.db.Find(&storedPolicies).Error
+	if err != nil {
+		return nil, err
+	}
+
+	storedPoliciesMap := make(map[string]*entities.RetentionPolicy)
+	for i, policy := range storedPolicies {
+		storedPoliciesMap[policy.TableName] = &storedPolicies[i]
+	}
+
+	var policies []*models.RetentionPolicy
+	for table := range prunableTables {
+		if stored, ok := storedPoliciesMap[table]; ok {
+			policies = append(policies, stored.ToModel())
+			continue
+		}
+		policies = append(policies, &models.RetentionPolicy{
+			Table:         table,
+			RetentionDays: DefaultRetentionDays,
+		})
+	}
+
+	return policies, nil
+}
+
+func (s *retentionService) SetPolicy(table string, retentionDays uint) error {
+	if _, ok := prunableTables[table]; !ok {
+		return fmt.Errorf("retention is not supported for table %s", table)
+	}
+
+	policy := entities.RetentionPolicy{
+		TableName:     table,
+		RetentionDays: retentionDays,
+	}
+
+	return s.db.Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(&policy).Error
+}
+
+func (s *retentionService) PruneExpired() error {
+	policies, err := s.GetPolicies()
+	if err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if policy.RetentionDays == 0 {
+			continue
+		}
+
+		table := prunableTables[policy.Table]
+		olderThan := time.Now().Add(-time.Duration(policy.RetentionDays) * 24 * time.Hour)
+
+		result := s.db.Delete(table.model, fmt.Sprintf("%s < ?", table.ageColumn), olderThan)
+		if result.Error != nil {
+			return fmt.Errorf("could not prune table %s: %s", policy.Table, result.Error)
+		}
+
+		log.Debugf("Pruned %d rows from table %s", result.RowsAffected, policy.Table)
+	}
+
+	return nil
+}
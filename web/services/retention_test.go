@@ -0,0 +1,125 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+type RetentionServiceTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	tx               *gorm.DB
+	retentionService RetentionService
+}
+
+func TestRetentionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionServiceTestSuite))
+}
+
+func (suite *RetentionServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.RetentionPolicy{}, entities.ChecksResult{}, entities.HostHeartbeat{})
+}
+
+func (suite *RetentionServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.RetentionPolicy{}, entities.ChecksResult{}, entities.HostHeartbeat{})
+}
+
+func (suite *RetentionServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.retentionService = NewRetentionService(suite.tx)
+}
+
+func (suite *RetentionServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *RetentionServiceTestSuite) TestRetentionService_GetPoliciesDefaults() {
+	policies, err := suite.retentionService.GetPolicies()
+	suite.NoError(err)
+
+	suite.Equal(len(prunableTables), len(policies))
+	for _, policy := range policies {
+		suite.EqualValues(DefaultRetentionDays, policy.RetentionDays)
+	}
+}
+
+func (suite *RetentionServiceTestSuite) TestRetentionService_SetPolicy() {
+	err := suite.retentionService.SetPolicy("checks_results", 30)
+	suite.NoError(err)
+
+	policies, err := suite.retentionService.GetPolicies()
+	suite.NoError(err)
+
+	policiesMap := make(map[string]uint)
+	for _, policy := range policies {
+		policiesMap[policy.Table] = policy.RetentionDays
+	}
+	suite.EqualValues(30, policiesMap["checks_results"])
+	suite.EqualValues(DefaultRetentionDays, policiesMap["host_heartbeats"])
+}
+
+func (suite *RetentionServiceTestSuite) TestRetentionService_SetPolicyUnknownTable() {
+	err := suite.retentionService.SetPolicy("hosts", 30)
+	suite.EqualError(err, "retention is not supported for table hosts")
+}
+
+func (suite *RetentionServiceTestSuite) TestRetentionService_PruneExpired() {
+	checksResults := []entities.ChecksResult{
+		{
+			ID:        1,
+			GroupID:   "group_id",
+			Payload:   []byte("{}"),
+			CreatedAt: time.Now().Add(-24 * 15 * time.Hour),
+		},
+		{
+			ID:        2,
+			GroupID:   "group_id",
+			Payload:   []byte("{}"),
+			CreatedAt: time.Now().Add(-24 * 5 * time.Hour),
+		},
+	}
+	err := suite.tx.Create(&checksResults).Error
+	suite.NoError(err)
+
+	err = suite.retentionService.SetPolicy("checks_results", 10)
+	suite.NoError(err)
+
+	err = suite.retentionService.PruneExpired()
+	suite.NoError(err)
+
+	var prunedChecksResults []entities.ChecksResult
+	suite.tx.Find(&prunedChecksResults)
+
+	suite.Equal(1, len(prunedChecksResults))
+	suite.Equal(int64(2), prunedChecksResults[0].ID)
+}
+
+func (suite *RetentionServiceTestSuite) TestRetentionService_PruneExpiredDisabled() {
+	checksResults := []entities.ChecksResult{
+		{
+			ID:        1,
+			GroupID:   "group_id",
+			Payload:   []byte("{}"),
+			CreatedAt: time.Now().Add(-24 * 365 * time.Hour),
+		},
+	}
+	err := suite.tx.Create(&checksResults).Error
+	suite.NoError(err)
+
+	err = suite.retentionService.PruneExpired()
+	suite.NoError(err)
+
+	var storedChecksResults []entities.ChecksResult
+	suite.tx.Find(&storedChecksResults)
+
+	suite.Equal(1, len(storedChecksResults))
+}
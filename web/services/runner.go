@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/internal"
+	"github.com/trento-project/trento/internal/vault"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+const (
+	DefaultRunnerWorkers = 5
+	runnerPollInterval   = 5 * time.Second
+)
+
+//go:generate mockery --name=RunnerService --inpackage --filename=runner_mock.go
+
+// RunnerService embeds the runner orchestration in the server: it picks up
+// the requested check executions, connects to the cluster nodes over SSH with
+// the stored connection settings, runs the selected catalog checks and feeds
+// the results back through the same pipeline used by the external runner.
+type RunnerService interface {
+	Run(ctx context.Context)
+	RunExecution(execution *models.ChecksExecution) error
+}
+
+//go:generate mockery --name=RemoteExecutor --inpackage --filename=remote_executor_mock.go
+
+// RemoteExecutor runs a single command on a cluster node
+type RemoteExecutor interface {
+	Execute(address, user, command string) error
+}
+
+type runnerService struct {
+	db            *gorm.DB
+	checksService ChecksService
+	executor      RemoteExecutor
+	vaultClient   vault.Client
+	workers       int
+}
+
+// NewRunnerService creates the embedded runner. The vault client is optional:
+// when nil, connection settings referencing Vault secrets cannot be resolved
+// and fail the execution.
+func NewRunnerService(db *gorm.DB, checksService ChecksService, executor RemoteExecutor,
+	vaultClient vault.Client, workers int) *runnerService {
+	if workers <= 0 {
+		workers = DefaultRunnerWorkers
+	}
+
+	return &runnerService{
+		db:            db,
+		checksService: checksService,
+		executor:      executor,
+		vaultClient:   vaultClient,
+		workers:       workers,
+	}
+}
+
+// Run polls for requested executions and dispatches them to a pool of
+// workers, so that slow clusters do not delay each other
+func (r *runnerService) Run(ctx context.Context) {
+	log.Infof("Starting embedded checks runner. Workers: %d", r.workers)
+
+	queue := make(chan *models.ChecksExecution)
+
+	var wg sync.WaitGroup
+	for i := 0; i < r.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for execution := range queue {
+				if err := r.RunExecution(execution); err != nil {
+					log.Errorf("Error running checks execution %d: %s", execution.ID, err)
+					if err := r.checksService.FailChecksExecution(execution.ID); err != nil {
+						log.Errorf("Error marking checks execution %d as failed: %s", execution.ID, err)
+					}
+				}
+			}
+		}()
+	}
+
+	tick := func() {
+		executions, err := r.pendingExecutions()
+		if err != nil {
+			log.Errorf("Error fetching the pending checks executions: %s", err)
+			return
+		}
+
+		for _, execution := range executions {
+			if err := r.checksService.StartChecksExecution(execution.ID); err != nil {
+				log.Errorf("Error starting checks execution %d: %s", execution.ID, err)
+				continue
+			}
+			queue <- execution
+		}
+	}
+
+	internal.Repeat("runner.executions", tick, runnerPollInterval, ctx)
+
+	close(queue)
+	wg.Wait()
+}
+
+func (r *runnerService) pendingExecutions() ([]*models.ChecksExecution, error) {
+	var executions []entities.ChecksExecution
+
+	err := r.db.
+		Where("status", models.ExecutionStatusRequested).
+		Order("id").
+		Find(&executions).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	executionModels := []*models.ChecksExecution{}
+	for _, execution := range executions {
+		executionModels = append(executionModels, execution.ToModel())
+	}
+
+	return executionModels, nil
+}
+
+// RunExecution runs the selected catalog checks on every node of the cluster
+// and stores the outcome as a regular checks result
+func (r *runnerService) RunExecution(execution *models.ChecksExecution) error {
+	clusterId := execution.ClusterID
+
+	var hosts []*entities.Host
+	err := r.db.
+		Where("cluster_id", clusterId).
+		Order("name").
+		Find(&hosts).
+		Error
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return fmt.Errorf("no hosts discovered for cluster %s", clusterId)
+	}
+
+	selectedChecks, err := r.checksService.GetSelectedChecksById(clusterId)
+	if err != nil {
+		return err
+	}
+
+	connectionSettings, err := r.checksService.GetConnectionSettingsById(clusterId)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := r.checksService.GetChecksCatalog()
+	if err != nil {
+		return err
+	}
+
+	catalogByID := make(map[string]*models.Check)
+	for _, check := range catalog {
+		catalogByID[check.ID] = check
+	}
+
+	results := &models.ChecksResult{
+		ID:        clusterId,
+		Hosts:     make(map[string]*models.HostState),
+		Checks:    make(map[string]*models.ChecksByHost),
+		Durations: make(map[string]float64),
+	}
+
+	for _, checkId := range selectedChecks.SelectedChecks {
+		results.Checks[checkId] = &models.ChecksByHost{
+			Hosts: make(map[string]*models.Check),
+		}
+	}
+
+	for _, host := range hosts {
+		user := connectionSettings[host.Name].User
+		if user == "" {
+			user, err = getDefaultUserName(host)
+			if err != nil {
+				return err
+			}
+		}
+		if vault.IsReference(user) {
+			user, err = r.resolveVaultReference(user)
+			if err != nil {
+				return err
+			}
+		}
+
+		hostState := &models.HostState{Reachable: true}
+		results.Hosts[host.Name] = hostState
+
+		// the runner requires passwordless sudo on the nodes
+		if err := r.executor.Execute(host.SSHAddress, user, "sudo -n true"); err != nil {
+			hostState.Reachable = false
+			hostState.Msg = err.Error()
+
+			for _, checkId := range selectedChecks.SelectedChecks {
+				results.Checks[checkId].Hosts[host.Name] = &models.Check{
+					Result: models.CheckSkipped,
+				}
+			}
+			continue
+		}
+
+		for _, checkId := range selectedChecks.SelectedChecks {
+			check, ok := catalogByID[checkId]
+			if !ok || check.Implementation == "" {
+				results.Checks[checkId].Hosts[host.Name] = &models.Check{
+					Result: models.CheckSkipped,
+				}
+				continue
+			}
+
+			start := time.Now()
+			checkResult := &models.Check{Result: models.CheckPassing}
+			if err := r.executor.Execute(host.SSHAddress, user, check.Implementation); err != nil {
+				checkResult.Result = models.CheckCritical
+				checkResult.Msg = err.Error()
+			}
+			results.Durations[checkId] += time.Since(start).Seconds()
+
+			results.Checks[checkId].Hosts[host.Name] = checkResult
+		}
+	}
+
+	return r.checksService.CreateChecksResult(results)
+}
+
+// resolveVaultReference reads the connection user from the Vault secret the
+// stored settings point to
+func (r *runnerService) resolveVaultReference(reference string) (string, error) {
+	if r.vaultClient == nil {
+		return "", fmt.Errorf("the connection settings reference a vault secret, but no vault server is configured")
+	}
+
+	path, field, err := vault.ParseReference(reference)
+	if err != nil {
+		return "", err
+	}
+
+	return r.vaultClient.GetSecret(path, field)
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/vault"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+func runnerHostsFixtures() []entities.Host {
+	return []entities.Host{
+		{
+			AgentID:    "1",
+			Name:       "host1",
+			ClusterID:  "group1",
+			SSHAddress: "10.74.1.5",
+		},
+		{
+			AgentID:    "2",
+			Name:       "host2",
+			ClusterID:  "group1",
+			SSHAddress: "10.74.1.10",
+		},
+	}
+}
+
+type RunnerServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	tx            *gorm.DB
+	executor      *MockRemoteExecutor
+	vaultClient   *vault.MockClient
+	checksService ChecksService
+	runnerService *runnerService
+}
+
+func TestRunnerServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RunnerServiceTestSuite))
+}
+
+func (suite *RunnerServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.Host{}, entities.ResourceEvent{},
+	)
+
+	check1payload := `{"id":"check1","name":"name1","group":"group1","implementation":"test -f /etc/check1"}`
+	suite.db.Create(&entities.Check{
+		ID:      "check1",
+		Payload: datatypes.JSON([]byte(check1payload)),
+	})
+	check2payload := `{"id":"check2","name":"name2","group":"group1"}`
+	suite.db.Create(&entities.Check{
+		ID:      "check2",
+		Payload: datatypes.JSON([]byte(check2payload)),
+	})
+
+	suite.db.Create(&models.SelectedChecks{
+		ID:             "group1",
+		SelectedChecks: []string{"check1", "check2"},
+	})
+
+	suite.db.Create(&models.ConnectionSettings{
+		ID:   "group1",
+		Node: "host1",
+		User: "user1",
+	})
+
+	hosts := runnerHostsFixtures()
+	err := suite.db.Create(&hosts).Error
+	suite.NoError(err)
+}
+
+func (suite *RunnerServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.Check{}, entities.ChecksResult{}, models.SelectedChecks{},
+		models.ConnectionSettings{}, entities.HealthState{}, entities.Alert{},
+		entities.ChecksExecution{}, entities.Host{}, entities.ResourceEvent{},
+	)
+}
+
+func (suite *RunnerServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.executor = new(MockRemoteExecutor)
+	suite.vaultClient = new(vault.MockClient)
+
+	premiumDetection := new(MockPremiumDetectionService)
+	premiumDetection.On("IsPremiumActive").Return(false, nil)
+
+	suite.checksService = NewChecksService(suite.tx, premiumDetection, NewAlertsService(suite.tx, nil), NewEventsService(suite.tx, nil), NewSettingsService(suite.tx), NewTrashService(suite.tx))
+	suite.runnerService = NewRunnerService(suite.tx, suite.checksService, suite.executor, suite.vaultClient, 1)
+}
+
+func (suite *RunnerServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *RunnerServiceTestSuite) TestRunnerService_RunExecution() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	suite.executor.On("Execute", "10.74.1.5", "user1", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.5", "user1", "test -f /etc/check1").Return(nil)
+	// host2 has no connection settings, so the default user is probed
+	suite.executor.On("Execute", "10.74.1.10", "root", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.10", "root", "test -f /etc/check1").Return(
+		errors.New("Process exited with status 1"))
+
+	err = suite.runnerService.RunExecution(execution)
+	suite.NoError(err)
+
+	result, err := suite.checksService.GetChecksResultByCluster("group1")
+	suite.NoError(err)
+
+	suite.True(result.Hosts["host1"].Reachable)
+	suite.True(result.Hosts["host2"].Reachable)
+	suite.Equal(models.CheckPassing, result.Checks["check1"].Hosts["host1"].Result)
+	suite.Equal(models.CheckCritical, result.Checks["check1"].Hosts["host2"].Result)
+	suite.Equal("Process exited with status 1", result.Checks["check1"].Hosts["host2"].Msg)
+	// check2 has no implementation, so it cannot be run remotely
+	suite.Equal(models.CheckSkipped, result.Checks["check2"].Hosts["host1"].Result)
+	suite.Equal(models.CheckSkipped, result.Checks["check2"].Hosts["host2"].Result)
+
+	completed, err := suite.checksService.GetChecksExecutionByID(execution.ID)
+	suite.NoError(err)
+	suite.Equal(models.ExecutionStatusCompleted, completed.Status)
+	suite.Contains(completed.Durations, "check1")
+
+	suite.executor.AssertExpectations(suite.T())
+}
+
+func (suite *RunnerServiceTestSuite) TestRunnerService_RunExecutionUnreachableHost() {
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	suite.executor.On("Execute", "10.74.1.5", "user1", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.5", "user1", "test -f /etc/check1").Return(nil)
+	suite.executor.On("Execute", "10.74.1.10", "root", "sudo -n true").Return(
+		errors.New("ssh: handshake failed"))
+
+	err = suite.runnerService.RunExecution(execution)
+	suite.NoError(err)
+
+	result, err := suite.checksService.GetChecksResultByCluster("group1")
+	suite.NoError(err)
+
+	suite.False(result.Hosts["host2"].Reachable)
+	suite.Equal("ssh: handshake failed", result.Hosts["host2"].Msg)
+	suite.Equal(models.CheckSkipped, result.Checks["check1"].Hosts["host2"].Result)
+}
+
+func (suite *RunnerServiceTestSuite) TestRunnerService_RunExecutionVaultReference() {
+	suite.tx.Create(&models.ConnectionSettings{
+		ID:   "group1",
+		Node: "host2",
+		User: "vault:secret/data/trento/host2#user",
+	})
+
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	suite.vaultClient.On("GetSecret", "secret/data/trento/host2", "user").Return("vaultuser", nil)
+
+	suite.executor.On("Execute", "10.74.1.5", "user1", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.5", "user1", "test -f /etc/check1").Return(nil)
+	// host2 settings reference a vault secret, resolved at execution time
+	suite.executor.On("Execute", "10.74.1.10", "vaultuser", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.10", "vaultuser", "test -f /etc/check1").Return(nil)
+
+	err = suite.runnerService.RunExecution(execution)
+	suite.NoError(err)
+
+	suite.executor.AssertExpectations(suite.T())
+	suite.vaultClient.AssertExpectations(suite.T())
+}
+
+func (suite *RunnerServiceTestSuite) TestRunnerService_RunExecutionVaultNotConfigured() {
+	suite.tx.Create(&models.ConnectionSettings{
+		ID:   "group1",
+		Node: "host2",
+		User: "vault:secret/data/trento/host2#user",
+	})
+
+	execution, err := suite.checksService.RequestChecksExecution("group1", "api")
+	suite.NoError(err)
+
+	suite.executor.On("Execute", "10.74.1.5", "user1", "sudo -n true").Return(nil)
+	suite.executor.On("Execute", "10.74.1.5", "user1", "test -f /etc/check1").Return(nil)
+
+	runnerService := NewRunnerService(suite.tx, suite.checksService, suite.executor, nil, 1)
+
+	err = runnerService.RunExecution(execution)
+	suite.EqualError(err, "the connection settings reference a vault secret, but no vault server is configured")
+}
+
+func (suite *RunnerServiceTestSuite) TestRunnerService_RunExecutionUnknownCluster() {
+	err := suite.runnerService.RunExecution(&models.ChecksExecution{
+		ID:        42,
+		ClusterID: "other_cluster",
+	})
+
+	suite.EqualError(err, "no hosts discovered for cluster other_cluster")
+}
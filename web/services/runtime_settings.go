@@ -0,0 +1,75 @@
+package services
+
+import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+//go:generate mockery --name=RuntimeSettingsService --inpackage --filename=runtime_settings_mock.go
+
+// RuntimeSettingsService holds process-local settings that can be changed on
+// a live server without a restart, like the log level.
+// The values are not persisted: a restart goes back to the configured defaults.
+type RuntimeSettingsService interface {
+	GetLogLevel() string
+	SetLogLevel(level string) error
+	GetAll() map[string]string
+	Get(key string) (string, bool)
+	Set(key string, value string)
+}
+
+type runtimeSettingsService struct {
+	mu       sync.RWMutex
+	settings map[string]string
+}
+
+func NewRuntimeSettingsService() *runtimeSettingsService {
+	return &runtimeSettingsService{
+		settings: make(map[string]string),
+	}
+}
+
+func (s *runtimeSettingsService) GetLogLevel() string {
+	return log.GetLevel().String()
+}
+
+func (s *runtimeSettingsService) SetLogLevel(level string) error {
+	parsedLevel, err := log.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	log.SetLevel(parsedLevel)
+	log.Infof("Log level changed to %s", parsedLevel)
+
+	return nil
+}
+
+func (s *runtimeSettingsService) GetAll() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	settings := make(map[string]string)
+	for key, value := range s.settings {
+		settings[key] = value
+	}
+	settings["log-level"] = s.GetLogLevel()
+
+	return settings
+}
+
+func (s *runtimeSettingsService) Get(key string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	value, found := s.settings[key]
+	return value, found
+}
+
+func (s *runtimeSettingsService) Set(key string, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.settings[key] = value
+}
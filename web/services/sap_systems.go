@@ -15,6 +15,7 @@ type SAPSystemsService interface {
 	GetAllApplications(filter *SAPSystemFilter, page *Page) (models.SAPSystemList, error)
 	GetAllDatabases(filter *SAPSystemFilter, page *Page) (models.SAPSystemList, error)
 	GetByID(ID string) (*models.SAPSystem, error)
+	GetTopologyByID(ID string) (*models.SAPSystemTopology, error)
 	GetApplicationsCount() (int, error)
 	GetDatabasesCount() (int, error)
 	GetAllApplicationsSIDs() ([]string, error)
@@ -26,6 +27,12 @@ type SAPSystemsService interface {
 type SAPSystemFilter struct {
 	Tags []string
 	SIDs []string
+	// ScopeTags is set by the authorization layer to enforce the per-account
+	// visibility, see HostsFilter
+	ScopeTags []string
+	// CustomFields matches the systems whose custom fields carry all the
+	// given name/value pairs
+	CustomFields map[string]string
 }
 
 type sapSystemsService struct {
@@ -63,6 +70,96 @@ func (s *sapSystemsService) GetByID(ID string) (*models.SAPSystem, error) {
 	return instances.ToModel()[0], nil
 }
 
+func (s *sapSystemsService) GetTopologyByID(ID string) (*models.SAPSystemTopology, error) {
+	var instances entities.SAPSystemInstances
+
+	err := s.db.
+		Where("id = ?", ID).
+		Preload("Host").
+		Order("sid, instance_number, system_replication, id").
+		Find(&instances).
+		Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(instances) == 0 {
+		return nil, nil
+	}
+
+	topology := &models.SAPSystemTopology{
+		Nodes: []*models.TopologyNode{},
+		Edges: []*models.TopologyEdge{},
+	}
+
+	sapSystem := instances.ToModel()[0]
+	s.addSAPSystemTopology(topology, sapSystem)
+
+	if sapSystem.Type == models.SAPSystemTypeApplication {
+		attachedDatabase, err := s.getAttachedDatabase(sapSystem.DBName, sapSystem.DBHost)
+		if err != nil {
+			return nil, err
+		}
+
+		if attachedDatabase != nil {
+			s.addSAPSystemTopology(topology, attachedDatabase)
+			topology.AddEdge(&models.TopologyEdge{
+				Source: sapSystem.ID,
+				Target: attachedDatabase.ID,
+				Type:   models.TopologyEdgeTypeDependsOn,
+			})
+		}
+	}
+
+	return topology, nil
+}
+
+func (s *sapSystemsService) addSAPSystemTopology(topology *models.SAPSystemTopology, sapSystem *models.SAPSystem) {
+	nodeType := models.TopologyNodeTypeSAPSystem
+	if sapSystem.Type == models.SAPSystemTypeDatabase {
+		nodeType = models.TopologyNodeTypeDatabase
+	}
+
+	topology.AddNode(&models.TopologyNode{
+		ID:   sapSystem.ID,
+		Type: nodeType,
+		Name: sapSystem.SID,
+	})
+
+	for _, instance := range sapSystem.Instances {
+		if instance.HostID == "" {
+			continue
+		}
+
+		topology.AddNode(&models.TopologyNode{
+			ID:   instance.HostID,
+			Type: models.TopologyNodeTypeHost,
+			Name: instance.Hostname,
+		})
+		topology.AddEdge(&models.TopologyEdge{
+			Source: sapSystem.ID,
+			Target: instance.HostID,
+			Type:   models.TopologyEdgeTypeRunsOn,
+		})
+
+		if instance.ClusterID == "" {
+			continue
+		}
+
+		topology.AddNode(&models.TopologyNode{
+			ID:   instance.ClusterID,
+			Type: models.TopologyNodeTypeCluster,
+			Name: instance.ClusterName,
+		})
+		topology.AddEdge(&models.TopologyEdge{
+			Source: instance.HostID,
+			Target: instance.ClusterID,
+			Type:   models.TopologyEdgeTypePartOf,
+		})
+	}
+}
+
 func (s *sapSystemsService) GetApplicationsCount() (int, error) {
 	var count int64
 
@@ -189,6 +286,23 @@ func (s *sapSystemsService) getAllByType(sapSystemType string, tagResourceType s
 				Where("value IN ?", filter.Tags),
 			)
 		}
+
+		if len(filter.ScopeTags) > 0 {
+			db = db.Where("id IN (?)", s.db.Model(&models.Tag{}).
+				Select("resource_id").
+				Where("resource_type = ?", tagResourceType).
+				Where("value IN ?", filter.ScopeTags),
+			)
+		}
+
+		for name, value := range filter.CustomFields {
+			db = db.Where("id IN (?)", s.db.Model(&entities.CustomFieldValue{}).
+				Select("resource_id").
+				Where("resource_type = ?", tagResourceType).
+				Where("name = ?", name).
+				Where("value = ?", value),
+			)
+		}
 	}
 
 	err := db.Find(&instances).Error
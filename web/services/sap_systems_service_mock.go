@@ -214,3 +214,26 @@ func (_m *MockSAPSystemsService) GetDatabasesCount() (int, error) {
 
 	return r0, r1
 }
+
+// GetTopologyByID provides a mock function with given fields: ID
+func (_m *MockSAPSystemsService) GetTopologyByID(ID string) (*models.SAPSystemTopology, error) {
+	ret := _m.Called(ID)
+
+	var r0 *models.SAPSystemTopology
+	if rf, ok := ret.Get(0).(func(string) *models.SAPSystemTopology); ok {
+		r0 = rf(ID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SAPSystemTopology)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(ID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
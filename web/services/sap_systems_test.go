@@ -204,6 +204,21 @@ func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetAllApplication
 	suite.Equal("HA1", applications[0].SID)
 }
 
+func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetAllApplications_ScopeTags() {
+	applications, err := suite.sapSystemsService.GetAllApplications(&SAPSystemFilter{
+		ScopeTags: []string{"tag1"},
+	}, nil)
+	suite.NoError(err)
+	suite.Equal(1, len(applications))
+	suite.Equal("HA1", applications[0].SID)
+
+	applications, err = suite.sapSystemsService.GetAllApplications(&SAPSystemFilter{
+		ScopeTags: []string{"some-other-tag"},
+	}, nil)
+	suite.NoError(err)
+	suite.Empty(applications)
+}
+
 func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetApplicationsCount() {
 	count, err := suite.sapSystemsService.GetApplicationsCount()
 	suite.NoError(err)
@@ -287,6 +302,34 @@ func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetByID_NotFound(
 	suite.Nil(sapSystem)
 }
 
+func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetTopologyByID() {
+	topology, err := suite.sapSystemsService.GetTopologyByID("sap_system_1")
+	suite.NoError(err)
+
+	suite.ElementsMatch([]*models.TopologyNode{
+		{ID: "sap_system_1", Type: models.TopologyNodeTypeSAPSystem, Name: "HA1"},
+		{ID: "1", Type: models.TopologyNodeTypeHost, Name: "apphost"},
+		{ID: "cluster_id_1", Type: models.TopologyNodeTypeCluster, Name: "appcluster"},
+		{ID: "sap_system_2", Type: models.TopologyNodeTypeDatabase, Name: "PRD"},
+		{ID: "2", Type: models.TopologyNodeTypeHost, Name: "dbhost_1"},
+		{ID: "cluster_id_2", Type: models.TopologyNodeTypeCluster, Name: "dbcluster"},
+	}, topology.Nodes)
+
+	suite.ElementsMatch([]*models.TopologyEdge{
+		{Source: "sap_system_1", Target: "1", Type: models.TopologyEdgeTypeRunsOn},
+		{Source: "1", Target: "cluster_id_1", Type: models.TopologyEdgeTypePartOf},
+		{Source: "sap_system_1", Target: "sap_system_2", Type: models.TopologyEdgeTypeDependsOn},
+		{Source: "sap_system_2", Target: "2", Type: models.TopologyEdgeTypeRunsOn},
+		{Source: "2", Target: "cluster_id_2", Type: models.TopologyEdgeTypePartOf},
+	}, topology.Edges)
+}
+
+func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetTopologyByID_NotFound() {
+	topology, err := suite.sapSystemsService.GetTopologyByID("not_found")
+	suite.NoError(err)
+	suite.Nil(topology)
+}
+
 func (suite *SAPSystemsServiceTestSuite) TestSAPSystemsService_GetDatabasesCount() {
 	count, err := suite.sapSystemsService.GetDatabasesCount()
 	suite.NoError(err)
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+//go:generate mockery --name=SearchService --inpackage --filename=search_mock.go
+
+type SearchService interface {
+	Search(query string) (models.SearchResultList, error)
+}
+
+type searchService struct {
+	db *gorm.DB
+}
+
+func NewSearchService(db *gorm.DB) *searchService {
+	return &searchService{db: db}
+}
+
+// Search looks up hosts, clusters and SAP systems by name, SID, IP address
+// or tag. The ILIKE matching takes advantage of the pg_trgm indexes, if available
+func (s *searchService) Search(query string) (models.SearchResultList, error) {
+	results := models.SearchResultList{}
+	pattern := fmt.Sprintf("%%%s%%", query)
+
+	hosts, err := s.searchHosts(pattern)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, hosts...)
+
+	clusters, err := s.searchClusters(pattern)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, clusters...)
+
+	sapSystems, err := s.searchSAPSystems(pattern)
+	if err != nil {
+		return nil, err
+	}
+	results = append(results, sapSystems...)
+
+	return results, nil
+}
+
+func (s *searchService) searchHosts(pattern string) (models.SearchResultList, error) {
+	var hosts []entities.Host
+
+	err := s.db.
+		Model(&entities.Host{}).
+		Where("name ILIKE ?", pattern).
+		Or("agent_id ILIKE ?", pattern).
+		Or("array_to_string(ip_addresses, ',') ILIKE ?", pattern).
+		Or("agent_id IN (?)", s.taggedResources(models.TagHostResourceType, pattern)).
+		Order("name").
+		Find(&hosts).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var results models.SearchResultList
+	for _, host := range hosts {
+		results = append(results, &models.SearchResult{
+			Type: models.SearchResultTypeHost,
+			ID:   host.AgentID,
+			Name: host.Name,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *searchService) searchClusters(pattern string) (models.SearchResultList, error) {
+	var clusters []entities.Cluster
+
+	err := s.db.
+		Model(&entities.Cluster{}).
+		Where("name ILIKE ?", pattern).
+		Or("id ILIKE ?", pattern).
+		Or("sid ILIKE ?", pattern).
+		Or("id IN (?)", s.taggedResources(models.TagClusterResourceType, pattern)).
+		Order("name").
+		Find(&clusters).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var results models.SearchResultList
+	for _, cluster := range clusters {
+		results = append(results, &models.SearchResult{
+			Type: models.SearchResultTypeCluster,
+			ID:   cluster.ID,
+			Name: cluster.Name,
+			SID:  cluster.SID,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *searchService) searchSAPSystems(pattern string) (models.SearchResultList, error) {
+	var instances []entities.SAPSystemInstance
+
+	err := s.db.
+		Model(&entities.SAPSystemInstance{}).
+		Where("sid ILIKE ?", pattern).
+		Or("id ILIKE ?", pattern).
+		Or("id IN (?)", s.taggedResources(models.TagSAPSystemResourceType, pattern)).
+		Or("id IN (?)", s.taggedResources(models.TagDatabaseResourceType, pattern)).
+		Order("sid").
+		Find(&instances).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var results models.SearchResultList
+	seen := make(map[string]bool)
+	for _, instance := range instances {
+		if seen[instance.ID] {
+			continue
+		}
+		seen[instance.ID] = true
+
+		resultType := models.SearchResultTypeSAPSystem
+		if instance.Type == models.SAPSystemTypeDatabase {
+			resultType = models.SearchResultTypeDatabase
+		}
+
+		results = append(results, &models.SearchResult{
+			Type: resultType,
+			ID:   instance.ID,
+			Name: instance.SID,
+			SID:  instance.SID,
+		})
+	}
+
+	return results, nil
+}
+
+func (s *searchService) taggedResources(resourceType string, pattern string) *gorm.DB {
+	return s.db.Model(&models.Tag{}).
+		Select("resource_id").
+		Where("resource_type = ?", resourceType).
+		Where("value ILIKE ?", pattern)
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type SearchServiceTestSuite struct {
+	suite.Suite
+	db            *gorm.DB
+	tx            *gorm.DB
+	searchService SearchService
+}
+
+func TestSearchServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchServiceTestSuite))
+}
+
+func (suite *SearchServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		&entities.Host{}, &entities.Cluster{}, &entities.SAPSystemInstance{}, &models.Tag{})
+}
+
+func (suite *SearchServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		&entities.Host{}, &entities.Cluster{}, &entities.SAPSystemInstance{}, &models.Tag{})
+}
+
+func (suite *SearchServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.searchService = NewSearchService(suite.tx)
+
+	suite.tx.Create(&entities.Host{
+		AgentID:     "1",
+		Name:        "netweaver01",
+		IPAddresses: pq.StringArray{"10.74.1.5"},
+	})
+	suite.tx.Create(&entities.Cluster{
+		ID:   "cluster_id",
+		Name: "hana_cluster",
+		SID:  "PRD",
+	})
+	suite.tx.Create(&entities.SAPSystemInstance{
+		ID:             "sap_system_id",
+		AgentID:        "1",
+		InstanceNumber: "00",
+		Type:           models.SAPSystemTypeApplication,
+		SID:            "HA1",
+	})
+	suite.tx.Create(&models.Tag{
+		ResourceType: models.TagHostResourceType,
+		ResourceID:   "1",
+		Value:        "production",
+	})
+}
+
+func (suite *SearchServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *SearchServiceTestSuite) TestSearchService_SearchByName() {
+	results, err := suite.searchService.Search("netweaver")
+	suite.NoError(err)
+
+	suite.Equal(1, len(results))
+	suite.Equal(models.SearchResultTypeHost, results[0].Type)
+	suite.Equal("netweaver01", results[0].Name)
+}
+
+func (suite *SearchServiceTestSuite) TestSearchService_SearchByIPAddress() {
+	results, err := suite.searchService.Search("10.74.1")
+	suite.NoError(err)
+
+	suite.Equal(1, len(results))
+	suite.Equal(models.SearchResultTypeHost, results[0].Type)
+}
+
+func (suite *SearchServiceTestSuite) TestSearchService_SearchBySID() {
+	results, err := suite.searchService.Search("PRD")
+	suite.NoError(err)
+
+	suite.Equal(1, len(results))
+	suite.Equal(models.SearchResultTypeCluster, results[0].Type)
+	suite.Equal("PRD", results[0].SID)
+
+	results, err = suite.searchService.Search("HA1")
+	suite.NoError(err)
+
+	suite.Equal(1, len(results))
+	suite.Equal(models.SearchResultTypeSAPSystem, results[0].Type)
+}
+
+func (suite *SearchServiceTestSuite) TestSearchService_SearchByTag() {
+	results, err := suite.searchService.Search("production")
+	suite.NoError(err)
+
+	suite.Equal(1, len(results))
+	suite.Equal(models.SearchResultTypeHost, results[0].Type)
+	suite.Equal("netweaver01", results[0].Name)
+}
+
+func (suite *SearchServiceTestSuite) TestSearchService_SearchNoMatches() {
+	results, err := suite.searchService.Search("unknown")
+	suite.NoError(err)
+
+	suite.Equal(0, len(results))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/trento-project/trento/internal/secrets"
 	"github.com/trento-project/trento/web/entities"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -15,16 +16,31 @@ type SettingsService interface {
 	InitializeIdentifier() (uuid.UUID, error)
 	IsEulaAccepted() (bool, error)
 	AcceptEula() error
+	IsTelemetryOptedOut() (bool, error)
+	SetTelemetryOptOut(optOut bool) error
+	IsHealthRollupUnknownAsCritical() (bool, error)
+	SetHealthRollupUnknownAsCritical(unknownAsCritical bool) error
+	GetEncryptionKey() (string, error)
+	GetEnrollmentToken() (string, error)
 }
 
 type settingsService struct {
 	db *gorm.DB
+	// encryptionKey is the externally supplied master secret. When empty,
+	// a generated key stored in the settings table is used instead.
+	encryptionKey string
 }
 
 func NewSettingsService(db *gorm.DB) SettingsService {
 	return &settingsService{db: db}
 }
 
+// NewSettingsServiceWithEncryptionKey builds a settings service using an
+// externally supplied master secret, which keeps the key out of the database
+func NewSettingsServiceWithEncryptionKey(db *gorm.DB, encryptionKey string) SettingsService {
+	return &settingsService{db: db, encryptionKey: encryptionKey}
+}
+
 func (s *settingsService) InitializeIdentifier() (uuid.UUID, error) {
 	var settings entities.Settings
 	err := s.db.First(&settings).Error
@@ -44,6 +60,71 @@ func (s *settingsService) InitializeIdentifier() (uuid.UUID, error) {
 	return installationUUID, nil
 }
 
+// GetEncryptionKey returns the master secret the sensitive values at rest
+// are sealed with. The externally supplied key takes precedence; without
+// one, a random key is generated and stored in the settings table on the
+// first call. The fallback only protects database dumps that exclude the
+// settings table, so supplying the key externally is recommended.
+func (s *settingsService) GetEncryptionKey() (string, error) {
+	if s.encryptionKey != "" {
+		return s.encryptionKey, nil
+	}
+
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if settings.EncryptionKey != "" {
+		return settings.EncryptionKey, nil
+	}
+
+	encryptionKey, err := secrets.NewMasterSecret()
+	if err != nil {
+		return "", err
+	}
+	settings.EncryptionKey = encryptionKey
+
+	err = s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"encryption_key"}),
+	}).Create(&settings).Error
+	if err != nil {
+		return "", err
+	}
+
+	return encryptionKey, nil
+}
+
+// GetEnrollmentToken returns the token new agents have to present to enroll
+// with this installation, generating and storing a random one on the first call
+func (s *settingsService) GetEnrollmentToken() (string, error) {
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", err
+	}
+	if settings.EnrollmentToken != "" {
+		return settings.EnrollmentToken, nil
+	}
+
+	settings.EnrollmentToken = uuid.New().String()
+
+	err = s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"enrollment_token"}),
+	}).Create(&settings).Error
+	if err != nil {
+		return "", err
+	}
+
+	return settings.EnrollmentToken, nil
+}
+
 func (s *settingsService) IsEulaAccepted() (bool, error) {
 	var settings entities.Settings
 	err := s.db.First(&settings).Error
@@ -66,3 +147,49 @@ func (s *settingsService) AcceptEula() error {
 		DoUpdates: clause.AssignmentColumns([]string{"eula_accepted"}),
 	}).Create(&settings).Error
 }
+
+func (s *settingsService) IsTelemetryOptedOut() (bool, error) {
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil {
+		return false, err
+	}
+
+	return settings.TelemetryOptOut, nil
+}
+
+func (s *settingsService) SetTelemetryOptOut(optOut bool) error {
+	var settings entities.Settings
+	s.db.First(&settings)
+	settings.TelemetryOptOut = optOut
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"telemetry_opt_out"}),
+	}).Create(&settings).Error
+}
+
+func (s *settingsService) IsHealthRollupUnknownAsCritical() (bool, error) {
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil {
+		return false, err
+	}
+
+	return settings.HealthRollupUnknownAsCritical, nil
+}
+
+func (s *settingsService) SetHealthRollupUnknownAsCritical(unknownAsCritical bool) error {
+	var settings entities.Settings
+	s.db.First(&settings)
+	settings.HealthRollupUnknownAsCritical = unknownAsCritical
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"health_rollup_unknown_as_critical"}),
+	}).Create(&settings).Error
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// expectedSystemSettings are the kernel and OS settings the SAP notes give
+// recommendations for, evaluated against the values discovered by the agents
+var expectedSystemSettings = []struct {
+	name    string
+	value   string
+	sapNote string
+}{
+	{"sysctl:vm.swappiness", "10", "1984787"},
+	{"sysctl:vm.max_map_count", "2147483647", "900929"},
+	{"sysctl:net.ipv4.tcp_slow_start_after_idle", "0", "2382421"},
+	{"thp", "never", "2684254"},
+	{"service:uuidd.socket", "active", "1984787"},
+	{"service:sysstat.service", "active", "2684254"},
+}
+
+// GetSettingsComplianceByID evaluates the system settings discovered on a
+// host against the values recommended by the SAP notes.
+// It returns nil when the host is not found.
+func (s *hostsService) GetSettingsComplianceByID(id string) (*models.SettingsCompliance, error) {
+	var host entities.Host
+
+	err := s.db.Where("agent_id = ?", id).First(&host).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var discoveredSettings map[string]string
+	if host.SystemSettings != nil {
+		if err := json.Unmarshal(host.SystemSettings, &discoveredSettings); err != nil {
+			discoveredSettings = nil
+		}
+	}
+
+	compliance := &models.SettingsCompliance{
+		HostID:    id,
+		Compliant: true,
+		Settings:  []*models.SettingCompliance{},
+	}
+
+	for _, expected := range expectedSystemSettings {
+		setting := &models.SettingCompliance{
+			Name:          expected.name,
+			ExpectedValue: expected.value,
+			ActualValue:   discoveredSettings[expected.name],
+			SAPNote:       expected.sapNote,
+		}
+		setting.Compliant = setting.ActualValue == setting.ExpectedValue
+		if !setting.Compliant {
+			compliance.Compliant = false
+		}
+
+		compliance.Settings = append(compliance.Settings, setting)
+	}
+
+	return compliance, nil
+}
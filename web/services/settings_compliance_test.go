@@ -0,0 +1,64 @@
+package services
+
+import (
+	"gorm.io/datatypes"
+
+	"github.com/trento-project/trento/web/entities"
+)
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetSettingsComplianceByID() {
+	host := entities.Host{
+		AgentID: "tuned",
+		Name:    "tuned",
+		SystemSettings: datatypes.JSON([]byte(`{
+			"sysctl:vm.swappiness": "10",
+			"sysctl:vm.max_map_count": "2147483647",
+			"sysctl:net.ipv4.tcp_slow_start_after_idle": "0",
+			"thp": "always",
+			"service:uuidd.socket": "active",
+			"service:sysstat.service": "active"
+		}`)),
+	}
+	err := suite.tx.Create(&host).Error
+	suite.NoError(err)
+
+	compliance, err := suite.hostsService.GetSettingsComplianceByID("tuned")
+	suite.NoError(err)
+
+	suite.False(compliance.Compliant)
+	suite.Equal("tuned", compliance.HostID)
+	suite.Len(compliance.Settings, len(expectedSystemSettings))
+
+	settings := make(map[string]int)
+	for i, setting := range compliance.Settings {
+		settings[setting.Name] = i
+	}
+
+	swappiness := compliance.Settings[settings["sysctl:vm.swappiness"]]
+	suite.True(swappiness.Compliant)
+	suite.Equal("10", swappiness.ActualValue)
+	suite.Equal("1984787", swappiness.SAPNote)
+
+	thp := compliance.Settings[settings["thp"]]
+	suite.False(thp.Compliant)
+	suite.Equal("never", thp.ExpectedValue)
+	suite.Equal("always", thp.ActualValue)
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetSettingsComplianceByID_NoSettings() {
+	// fixture host "1" has no discovered settings: everything is non-compliant
+	compliance, err := suite.hostsService.GetSettingsComplianceByID("1")
+	suite.NoError(err)
+
+	suite.False(compliance.Compliant)
+	for _, setting := range compliance.Settings {
+		suite.False(setting.Compliant)
+		suite.Empty(setting.ActualValue)
+	}
+}
+
+func (suite *HostsServiceTestSuite) TestHostsService_GetSettingsComplianceByID_NotFound() {
+	compliance, err := suite.hostsService.GetSettingsComplianceByID("not_found")
+	suite.NoError(err)
+	suite.Nil(compliance)
+}
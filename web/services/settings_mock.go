@@ -26,6 +26,48 @@ func (_m *MockSettingsService) AcceptEula() error {
 	return r0
 }
 
+// GetEncryptionKey provides a mock function with given fields:
+func (_m *MockSettingsService) GetEncryptionKey() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollmentToken provides a mock function with given fields:
+func (_m *MockSettingsService) GetEnrollmentToken() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InitializeIdentifier provides a mock function with given fields:
 func (_m *MockSettingsService) InitializeIdentifier() (uuid.UUID, error) {
 	ret := _m.Called()
@@ -69,3 +111,73 @@ func (_m *MockSettingsService) IsEulaAccepted() (bool, error) {
 
 	return r0, r1
 }
+
+// IsHealthRollupUnknownAsCritical provides a mock function with given fields:
+func (_m *MockSettingsService) IsHealthRollupUnknownAsCritical() (bool, error) {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsTelemetryOptedOut provides a mock function with given fields:
+func (_m *MockSettingsService) IsTelemetryOptedOut() (bool, error) {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHealthRollupUnknownAsCritical provides a mock function with given fields: unknownAsCritical
+func (_m *MockSettingsService) SetHealthRollupUnknownAsCritical(unknownAsCritical bool) error {
+	ret := _m.Called(unknownAsCritical)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool) error); ok {
+		r0 = rf(unknownAsCritical)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetTelemetryOptOut provides a mock function with given fields: optOut
+func (_m *MockSettingsService) SetTelemetryOptOut(optOut bool) error {
+	ret := _m.Called(optOut)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(bool) error); ok {
+		r0 = rf(optOut)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -67,3 +67,73 @@ func (suite *SettingsServiceTestSuite) TestSettingsService_DetectsTrentoAlreadyI
 	suite.NoError(err)
 	suite.EqualValues(dummyInstallationID, installationID.String())
 }
+
+func (suite *SettingsServiceTestSuite) TestSettingsService_GetEncryptionKey() {
+	_, err := suite.settingsService.InitializeIdentifier()
+	suite.NoError(err)
+
+	encryptionKey, err := suite.settingsService.GetEncryptionKey()
+	suite.NoError(err)
+	suite.NotEmpty(encryptionKey)
+
+	// The generated key is persisted and returned on the following calls
+	sameKey, err := suite.settingsService.GetEncryptionKey()
+	suite.NoError(err)
+	suite.Equal(encryptionKey, sameKey)
+
+	var settings entities.Settings
+	suite.tx.First(&settings)
+	suite.Equal(encryptionKey, settings.EncryptionKey)
+}
+
+func (suite *SettingsServiceTestSuite) TestSettingsService_GetEncryptionKeyExternal() {
+	settingsService := NewSettingsServiceWithEncryptionKey(suite.tx, "externally-supplied-secret")
+
+	encryptionKey, err := settingsService.GetEncryptionKey()
+	suite.NoError(err)
+	suite.Equal("externally-supplied-secret", encryptionKey)
+
+	// The externally supplied key never touches the database
+	var settings entities.Settings
+	suite.tx.Find(&settings)
+	suite.Empty(settings.EncryptionKey)
+}
+
+func (suite *SettingsServiceTestSuite) TestSettingsService_GetEnrollmentToken() {
+	_, err := suite.settingsService.InitializeIdentifier()
+	suite.NoError(err)
+
+	token, err := suite.settingsService.GetEnrollmentToken()
+	suite.NoError(err)
+	suite.NotEmpty(token)
+
+	// The generated token is persisted and returned on the following calls
+	sameToken, err := suite.settingsService.GetEnrollmentToken()
+	suite.NoError(err)
+	suite.Equal(token, sameToken)
+
+	var settings entities.Settings
+	suite.tx.First(&settings)
+	suite.Equal(token, settings.EnrollmentToken)
+}
+
+func (suite *SettingsServiceTestSuite) TestSettingsService_TelemetryOptOut() {
+	_, err := suite.settingsService.InitializeIdentifier()
+	suite.NoError(err)
+
+	optedOut, err := suite.settingsService.IsTelemetryOptedOut()
+	suite.NoError(err)
+	suite.False(optedOut)
+
+	suite.NoError(suite.settingsService.SetTelemetryOptOut(true))
+
+	optedOut, err = suite.settingsService.IsTelemetryOptedOut()
+	suite.NoError(err)
+	suite.True(optedOut)
+
+	suite.NoError(suite.settingsService.SetTelemetryOptOut(false))
+
+	optedOut, err = suite.settingsService.IsTelemetryOptedOut()
+	suite.NoError(err)
+	suite.False(optedOut)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockery --name=SetupService --inpackage --filename=setup_mock.go
+
+var ErrSetupStepUnknown = errors.New("unknown setup step")
+var ErrSetupStepOutOfOrder = errors.New("the previous setup steps must be completed first")
+var ErrSetupInvalidStepValues = errors.New("invalid setup step values")
+
+// setupStepNames are the wizard steps, in the order the UI walks through them
+var setupStepNames = []string{
+	models.SetupStepAdmin,
+	models.SetupStepEula,
+	models.SetupStepGrafana,
+	models.SetupStepTelemetry,
+	models.SetupStepCollectorTLS,
+}
+
+// SetupService drives the first-boot setup wizard, tracking which steps were
+// completed and applying the choice each step carries
+type SetupService interface {
+	GetStatus() (*models.SetupStatus, error)
+	CompleteStep(name string, values map[string]string) (*models.SetupStatus, error)
+}
+
+type setupService struct {
+	db              *gorm.DB
+	usersService    UsersService
+	settingsService SettingsService
+}
+
+func NewSetupService(db *gorm.DB, usersService UsersService, settingsService SettingsService) SetupService {
+	return &setupService{
+		db:              db,
+		usersService:    usersService,
+		settingsService: settingsService,
+	}
+}
+
+func (s *setupService) GetStatus() (*models.SetupStatus, error) {
+	var settings entities.Settings
+	err := s.db.First(&settings).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	completed := make(map[string]bool)
+	for _, name := range settings.SetupCompletedSteps {
+		completed[name] = true
+	}
+
+	// the admin and eula steps can also be fulfilled outside of the wizard,
+	// with 'trento ctl create-admin' and the eula interstitial respectively
+	var adminCount int64
+	err = s.db.Model(&entities.User{}).Where("admin = ?", true).Count(&adminCount).Error
+	if err != nil {
+		return nil, err
+	}
+	completed[models.SetupStepAdmin] = completed[models.SetupStepAdmin] || adminCount > 0
+	completed[models.SetupStepEula] = completed[models.SetupStepEula] || settings.EulaAccepted
+
+	status := &models.SetupStatus{
+		Complete: true,
+		Steps:    []*models.SetupStep{},
+	}
+	for _, name := range setupStepNames {
+		status.Steps = append(status.Steps, &models.SetupStep{
+			Name:     name,
+			Complete: completed[name],
+		})
+		if !completed[name] {
+			status.Complete = false
+			if status.NextStep == "" {
+				status.NextStep = name
+			}
+		}
+	}
+
+	return status, nil
+}
+
+func (s *setupService) CompleteStep(name string, values map[string]string) (*models.SetupStatus, error) {
+	status, err := s.GetStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	stepIndex := -1
+	for index, stepName := range setupStepNames {
+		if stepName == name {
+			stepIndex = index
+			break
+		}
+	}
+	if stepIndex < 0 {
+		return nil, ErrSetupStepUnknown
+	}
+
+	for _, step := range status.Steps[:stepIndex] {
+		if !step.Complete {
+			return nil, ErrSetupStepOutOfOrder
+		}
+	}
+
+	switch name {
+	case models.SetupStepAdmin:
+		err = s.createAdmin(values)
+	case models.SetupStepEula:
+		err = s.settingsService.AcceptEula()
+	case models.SetupStepGrafana:
+		err = s.setGrafanaPublicURL(values)
+	case models.SetupStepTelemetry:
+		err = s.setTelemetryChoice(values)
+	case models.SetupStepCollectorTLS:
+		err = s.setCollectorTLSChoice(values)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.markStepCompleted(name); err != nil {
+		return nil, err
+	}
+
+	return s.GetStatus()
+}
+
+func (s *setupService) createAdmin(values map[string]string) error {
+	username := values["username"]
+	password := values["password"]
+	if username == "" || password == "" {
+		return fmt.Errorf("%w: a username and a password are required to create the admin user", ErrSetupInvalidStepValues)
+	}
+
+	_, err := s.usersService.Create(username, password, true)
+	return err
+}
+
+func (s *setupService) setGrafanaPublicURL(values map[string]string) error {
+	publicURL := values["public_url"]
+	if publicURL != "" {
+		if _, err := url.ParseRequestURI(publicURL); err != nil {
+			return fmt.Errorf("%w: %s is not a valid URL", ErrSetupInvalidStepValues, publicURL)
+		}
+	}
+
+	return s.updateSettings("grafana_public_url", func(settings *entities.Settings) {
+		settings.GrafanaPublicURL = publicURL
+	})
+}
+
+func (s *setupService) setTelemetryChoice(values map[string]string) error {
+	optOut, err := strconv.ParseBool(values["opt_out"])
+	if err != nil {
+		return fmt.Errorf("%w: opt_out must be true or false", ErrSetupInvalidStepValues)
+	}
+
+	return s.settingsService.SetTelemetryOptOut(optOut)
+}
+
+func (s *setupService) setCollectorTLSChoice(values map[string]string) error {
+	enabled, err := strconv.ParseBool(values["enabled"])
+	if err != nil {
+		return fmt.Errorf("%w: enabled must be true or false", ErrSetupInvalidStepValues)
+	}
+
+	return s.updateSettings("collector_tls_enabled", func(settings *entities.Settings) {
+		settings.CollectorTLSEnabled = enabled
+	})
+}
+
+func (s *setupService) markStepCompleted(name string) error {
+	return s.updateSettings("setup_completed_steps", func(settings *entities.Settings) {
+		for _, completedName := range settings.SetupCompletedSteps {
+			if completedName == name {
+				return
+			}
+		}
+		settings.SetupCompletedSteps = append(settings.SetupCompletedSteps, name)
+	})
+}
+
+func (s *setupService) updateSettings(column string, update func(settings *entities.Settings)) error {
+	var settings entities.Settings
+	s.db.First(&settings)
+	update(&settings)
+
+	return s.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "installation_id"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{column}),
+	}).Create(&settings).Error
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type SetupServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	setupService SetupService
+}
+
+func TestSetupServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SetupServiceTestSuite))
+}
+
+func (suite *SetupServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.Settings{}, entities.User{})
+}
+
+func (suite *SetupServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.Settings{}, entities.User{})
+}
+
+func (suite *SetupServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.setupService = NewSetupService(suite.tx, NewUsersService(suite.tx), NewSettingsService(suite.tx))
+}
+
+func (suite *SetupServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_GetStatus_FreshInstallation() {
+	status, err := suite.setupService.GetStatus()
+
+	suite.NoError(err)
+	suite.False(status.Complete)
+	suite.Equal(models.SetupStepAdmin, status.NextStep)
+	suite.Len(status.Steps, 5)
+	for _, step := range status.Steps {
+		suite.False(step.Complete)
+	}
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_GetStatus_DetectsOutOfBandCompletion() {
+	// an admin created with 'trento ctl create-admin' and an eula accepted
+	// through the interstitial complete the respective steps
+	_, err := NewUsersService(suite.tx).Create("admin", "S3cr3t!", true)
+	suite.NoError(err)
+	suite.NoError(NewSettingsService(suite.tx).AcceptEula())
+
+	status, err := suite.setupService.GetStatus()
+
+	suite.NoError(err)
+	suite.False(status.Complete)
+	suite.Equal(models.SetupStepGrafana, status.NextStep)
+	suite.True(status.Steps[0].Complete)
+	suite.True(status.Steps[1].Complete)
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_CompleteStep_WalksThroughTheWizard() {
+	_, err := suite.setupService.CompleteStep(models.SetupStepAdmin, map[string]string{
+		"username": "admin",
+		"password": "S3cr3t!",
+	})
+	suite.NoError(err)
+
+	_, err = suite.setupService.CompleteStep(models.SetupStepEula, nil)
+	suite.NoError(err)
+
+	_, err = suite.setupService.CompleteStep(models.SetupStepGrafana, map[string]string{
+		"public_url": "https://grafana.example.com",
+	})
+	suite.NoError(err)
+
+	_, err = suite.setupService.CompleteStep(models.SetupStepTelemetry, map[string]string{
+		"opt_out": "true",
+	})
+	suite.NoError(err)
+
+	status, err := suite.setupService.CompleteStep(models.SetupStepCollectorTLS, map[string]string{
+		"enabled": "true",
+	})
+	suite.NoError(err)
+	suite.True(status.Complete)
+	suite.Empty(status.NextStep)
+
+	var settings entities.Settings
+	suite.tx.First(&settings)
+	suite.True(settings.EulaAccepted)
+	suite.True(settings.TelemetryOptOut)
+	suite.True(settings.CollectorTLSEnabled)
+	suite.Equal("https://grafana.example.com", settings.GrafanaPublicURL)
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_CompleteStep_OutOfOrder() {
+	_, err := suite.setupService.CompleteStep(models.SetupStepGrafana, map[string]string{
+		"public_url": "https://grafana.example.com",
+	})
+
+	suite.ErrorIs(err, ErrSetupStepOutOfOrder)
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_CompleteStep_UnknownStep() {
+	_, err := suite.setupService.CompleteStep("quantum", nil)
+
+	suite.ErrorIs(err, ErrSetupStepUnknown)
+}
+
+func (suite *SetupServiceTestSuite) TestSetupService_CompleteStep_InvalidValues() {
+	_, err := suite.setupService.CompleteStep(models.SetupStepAdmin, map[string]string{
+		"username": "admin",
+	})
+
+	suite.ErrorIs(err, ErrSetupInvalidStepValues)
+}
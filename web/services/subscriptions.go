@@ -1,8 +1,12 @@
 package services
 
 import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 
+	"github.com/trento-project/trento/internal/scc"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 )
@@ -16,14 +20,27 @@ type SubscriptionsService interface {
 	IsTrentoPremium() (bool, error)
 	GetPremiumData() (*models.PremiumData, error)
 	GetHostSubscriptions(host string) ([]*models.SlesSubscription, error)
+	GetExpiringSubscriptions(within time.Duration) ([]*models.ExpiringSubscription, error)
+}
+
+//go:generate mockery --name=SubscriptionValidator --inpackage --filename=subscription_validator_mock.go
+
+// SubscriptionValidator revalidates the discovered subscriptions against a
+// registration service, either the SUSE Customer Center or a local RMT mirror
+type SubscriptionValidator interface {
+	GetSubscriptionInfo(identifier, version, arch string) (*scc.SubscriptionInfo, error)
 }
 
 type subscriptionsService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	validator SubscriptionValidator
 }
 
-func NewSubscriptionsService(db *gorm.DB) *subscriptionsService {
-	return &subscriptionsService{db: db}
+// NewSubscriptionsService creates a new subscriptions service. The validator
+// is optional: when nil, the subscriptions are classified only by the
+// expiration dates reported by the agents discovery
+func NewSubscriptionsService(db *gorm.DB, validator SubscriptionValidator) *subscriptionsService {
+	return &subscriptionsService{db: db, validator: validator}
 }
 
 func (s *subscriptionsService) IsTrentoPremium() (bool, error) {
@@ -68,3 +85,53 @@ func (s *subscriptionsService) GetHostSubscriptions(id string) ([]*models.SlesSu
 
 	return subModels, nil
 }
+
+func (s *subscriptionsService) GetExpiringSubscriptions(within time.Duration) ([]*models.ExpiringSubscription, error) {
+	var subEntities []*entities.SlesSubscription
+	err := s.db.
+		Order("agent_id, id").
+		Find(&subEntities).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var hosts []*entities.Host
+	if err := s.db.Find(&hosts).Error; err != nil {
+		return nil, err
+	}
+
+	hostnames := make(map[string]string)
+	for _, host := range hosts {
+		hostnames[host.AgentID] = host.Name
+	}
+
+	expiringSubscriptions := []*models.ExpiringSubscription{}
+	for _, subEntity := range subEntities {
+		sub := subEntity.ToModel()
+
+		if s.validator != nil {
+			info, err := s.validator.GetSubscriptionInfo(sub.ID, sub.Version, sub.Arch)
+			if err != nil {
+				log.Warnf("Could not validate subscription %s against the registration service: %s", sub.ID, err)
+			} else {
+				sub.SubscriptionStatus = info.Status
+				sub.ExpiresAt = info.ExpiresAt
+			}
+		}
+
+		validity := sub.Validity(within)
+		if validity != models.SubscriptionValidityExpired && validity != models.SubscriptionValidityExpiring {
+			continue
+		}
+
+		expiringSubscriptions = append(expiringSubscriptions, &models.ExpiringSubscription{
+			AgentID:      subEntity.AgentID,
+			Hostname:     hostnames[subEntity.AgentID],
+			Validity:     validity,
+			Subscription: sub,
+		})
+	}
+
+	return expiringSubscriptions, nil
+}
@@ -5,6 +5,8 @@ package services
 import (
 	mock "github.com/stretchr/testify/mock"
 	models "github.com/trento-project/trento/web/models"
+
+	time "time"
 )
 
 // MockSubscriptionsService is an autogenerated mock type for the SubscriptionsService type
@@ -12,6 +14,29 @@ type MockSubscriptionsService struct {
 	mock.Mock
 }
 
+// GetExpiringSubscriptions provides a mock function with given fields: within
+func (_m *MockSubscriptionsService) GetExpiringSubscriptions(within time.Duration) ([]*models.ExpiringSubscription, error) {
+	ret := _m.Called(within)
+
+	var r0 []*models.ExpiringSubscription
+	if rf, ok := ret.Get(0).(func(time.Duration) []*models.ExpiringSubscription); ok {
+		r0 = rf(within)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ExpiringSubscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Duration) error); ok {
+		r1 = rf(within)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHostSubscriptions provides a mock function with given fields: host
 func (_m *MockSubscriptionsService) GetHostSubscriptions(host string) ([]*models.SlesSubscription, error) {
 	ret := _m.Called(host)
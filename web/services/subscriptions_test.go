@@ -1,9 +1,12 @@
 package services
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/internal/scc"
 	"github.com/trento-project/trento/test/helpers"
 	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
@@ -34,7 +37,7 @@ func (suite *SubscriptionServiceTestSuite) TearDownSuite() {
 
 func (suite *SubscriptionServiceTestSuite) SetupTest() {
 	suite.tx = suite.db.Begin()
-	suite.subsService = NewSubscriptionsService(suite.tx)
+	suite.subsService = NewSubscriptionsService(suite.tx, nil)
 }
 
 func (suite *SubscriptionServiceTestSuite) TearDownTest() {
@@ -139,3 +142,37 @@ func (suite *SubscriptionServiceTestSuite) TestSubscriptionService_GetHostSubscr
 	suite.ElementsMatch(expectedSubs, subs)
 	suite.NoError(err)
 }
+
+func (suite *SubscriptionServiceTestSuite) TestSubscriptionService_GetExpiringSubscriptions() {
+	expiring, err := suite.subsService.GetExpiringSubscriptions(30 * 24 * time.Hour)
+
+	suite.NoError(err)
+	suite.Len(expiring, 2)
+	suite.Equal("1", expiring[0].AgentID)
+	suite.Equal("host1", expiring[0].Hostname)
+	suite.Equal(models.SubscriptionValidityExpired, expiring[0].Validity)
+	suite.Equal("SLES_SAP", expiring[0].Subscription.ID)
+	suite.Equal("2", expiring[1].AgentID)
+	suite.Equal("", expiring[1].Hostname)
+	suite.Equal(models.SubscriptionValidityExpired, expiring[1].Validity)
+}
+
+func (suite *SubscriptionServiceTestSuite) TestSubscriptionService_GetExpiringSubscriptionsWithValidator() {
+	renewedExpiresAt := time.Now().Add(365 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05 UTC")
+
+	validator := new(MockSubscriptionValidator)
+	validator.On("GetSubscriptionInfo", "SLES_SAP", "15.2", "x86_64").Return(&scc.SubscriptionInfo{
+		Identifier: "SLES_SAP",
+		Status:     "ACTIVE",
+		ExpiresAt:  renewedExpiresAt,
+	}, nil)
+	validator.On("GetSubscriptionInfo", "sle-module-public-cloud", "15.2", "x86_64").Return(
+		nil, fmt.Errorf("not found"))
+
+	subsService := NewSubscriptionsService(suite.tx, validator)
+	expiring, err := subsService.GetExpiringSubscriptions(30 * 24 * time.Hour)
+
+	suite.NoError(err)
+	suite.Empty(expiring)
+	validator.AssertExpectations(suite.T())
+}
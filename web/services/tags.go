@@ -1,6 +1,8 @@
 package services
 
 import (
+	"fmt"
+
 	"github.com/trento-project/trento/web/models"
 	"gorm.io/gorm"
 )
@@ -14,11 +16,13 @@ type TagsService interface {
 }
 
 type tagsService struct {
-	db *gorm.DB
+	db            *gorm.DB
+	eventsService EventsService
+	trashService  TrashService
 }
 
-func NewTagsService(db *gorm.DB) *tagsService {
-	return &tagsService{db: db}
+func NewTagsService(db *gorm.DB, eventsService EventsService, trashService TrashService) *tagsService {
+	return &tagsService{db: db, eventsService: eventsService, trashService: trashService}
 }
 
 func (r *tagsService) GetAll(resourceTypeFilter ...string) ([]string, error) {
@@ -45,8 +49,16 @@ func (r *tagsService) Create(value string, resourceType string, resourceId strin
 	}
 
 	result := r.db.Create(&tag)
+	if result.Error != nil {
+		return result.Error
+	}
 
-	return result.Error
+	return r.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: resourceType,
+		ResourceID:   resourceId,
+		Type:         models.ResourceEventTypeTag,
+		Message:      fmt.Sprintf("Tag %s added", value),
+	})
 }
 
 func (r *tagsService) Delete(value string, resourceType string, resourceId string) error {
@@ -57,8 +69,26 @@ func (r *tagsService) Delete(value string, resourceType string, resourceId strin
 	}
 
 	result := r.db.Delete(&tag)
+	if result.Error != nil {
+		return result.Error
+	}
 
-	return result.Error
+	// deleting a tag that does not exist is a no-op, not an event
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	// keep the deleted tag restorable for a while
+	if err := r.trashService.Store(TrashResourceTypeTag, value, &tag); err != nil {
+		return err
+	}
+
+	return r.eventsService.StoreEvent(&models.ResourceEvent{
+		ResourceType: resourceType,
+		ResourceID:   resourceId,
+		Type:         models.ResourceEventTypeTag,
+		Message:      fmt.Sprintf("Tag %s removed", value),
+	})
 }
 
 func getTags(db *gorm.DB) ([]string, error) {
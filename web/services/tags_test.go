@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
 	"github.com/trento-project/trento/web/models"
 
 	"gorm.io/gorm"
@@ -24,17 +25,17 @@ func TestTagsServiceTestSuite(t *testing.T) {
 func (suite *TagsServiceTestSuite) SetupSuite() {
 	suite.db = helpers.SetupTestDatabase(suite.T())
 
-	suite.db.AutoMigrate(models.Tag{})
+	suite.db.AutoMigrate(models.Tag{}, entities.ResourceEvent{}, entities.TrashEntry{})
 	loadTagsFixtures(suite.db)
 }
 
 func (suite *TagsServiceTestSuite) TearDownSuite() {
-	suite.db.Migrator().DropTable(models.Tag{})
+	suite.db.Migrator().DropTable(models.Tag{}, entities.ResourceEvent{}, entities.TrashEntry{})
 }
 
 func (suite *TagsServiceTestSuite) SetupTest() {
 	suite.tx = suite.db.Begin()
-	suite.tagsService = NewTagsService(suite.tx)
+	suite.tagsService = NewTagsService(suite.tx, NewEventsService(suite.tx, nil), NewTrashService(suite.tx))
 }
 
 func (suite *TagsServiceTestSuite) TearDownTest() {
@@ -91,6 +92,12 @@ func (suite *TagsServiceTestSuite) TestTagsService_Create() {
 	suite.tx.Where(&expectedTag).Find(&tags)
 
 	suite.ElementsMatch([]models.Tag{expectedTag}, tags)
+
+	var events []entities.ResourceEvent
+	suite.tx.Where("resource_id", "suse").Find(&events)
+	suite.Len(events, 1)
+	suite.Equal(models.ResourceEventTypeTag, events[0].Type)
+	suite.Equal("Tag newtag added", events[0].Message)
 }
 
 func (suite *TagsServiceTestSuite) TestTagsService_Delete() {
@@ -116,4 +123,32 @@ func (suite *TagsServiceTestSuite) TestTagsService_Delete() {
 			ResourceID:   "suse",
 			Value:        "tag3",
 		}}, tags)
+
+	var events []entities.ResourceEvent
+	suite.tx.Where("resource_id", "HA1").Find(&events)
+	suite.Len(events, 1)
+	suite.Equal(models.ResourceEventTypeTag, events[0].Type)
+	suite.Equal("Tag tag1 removed", events[0].Message)
+
+	// removing a tag that is not there leaves no trace
+	suite.tagsService.Delete("does-not-exist", models.TagSAPSystemResourceType, "HA1")
+	suite.tx.Where("resource_id", "HA1").Find(&events)
+	suite.Len(events, 1)
+}
+
+func (suite *TagsServiceTestSuite) TestTagsService_DeleteIsRestorable() {
+	suite.tagsService.Delete("tag1", models.TagSAPSystemResourceType, "HA1")
+
+	var entry entities.TrashEntry
+	suite.NoError(suite.tx.Where("resource_type", TrashResourceTypeTag).First(&entry).Error)
+	suite.Equal("tag1", entry.ResourceID)
+
+	err := NewTrashService(suite.tx).Restore(TrashResourceTypeTag, "tag1")
+	suite.NoError(err)
+
+	var count int64
+	suite.tx.Model(&models.Tag{}).
+		Where("value = ? AND resource_id = ?", "tag1", "HA1").
+		Count(&count)
+	suite.EqualValues(1, count)
 }
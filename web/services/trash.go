@@ -0,0 +1,179 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+// TrashRetention is how long a deleted resource remains restorable before the
+// garbage collector purges its snapshot for good
+const TrashRetention = 30 * 24 * time.Hour
+
+// Trash resource types, next to the host one shared with the tags. They name
+// the :type segment of the restore endpoint.
+const (
+	TrashResourceTypeTag           = "tags"
+	TrashResourceTypeCheckSettings = "check_settings"
+)
+
+// ErrTrashEntryNotFound is returned when there is nothing to restore under
+// the given type and ID
+var ErrTrashEntryNotFound = errors.New("no deleted resource to restore")
+
+// ErrTrashResourceTypeNotSupported is returned for restore requests naming a
+// resource type the trash does not hold
+var ErrTrashResourceTypeNotSupported = errors.New("resource type not supported")
+
+// hostTrashPayload is the snapshot taken when a host is deleted: the host row
+// itself plus the curated tags, which no discovery would ever bring back
+type hostTrashPayload struct {
+	Host entities.Host `json:"host"`
+	Tags []*models.Tag `json:"tags"`
+}
+
+//go:generate mockery --name=TrashService --inpackage --filename=trash_mock.go
+
+// TrashService turns resource deletions into soft-deletes: the removed data
+// is snapshotted into the trash and can be restored within the retention
+// window, after which the garbage collector purges it
+type TrashService interface {
+	List() ([]*models.TrashEntry, error)
+	Store(resourceType string, resourceID string, payload interface{}) error
+	Restore(resourceType string, resourceID string) error
+	PurgeExpired() (int64, error)
+}
+
+type trashService struct {
+	db *gorm.DB
+}
+
+func NewTrashService(db *gorm.DB) *trashService {
+	return &trashService{db: db}
+}
+
+func (s *trashService) List() ([]*models.TrashEntry, error) {
+	var entries []*entities.TrashEntry
+
+	err := s.db.Order("deleted_at DESC").Order("id DESC").Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+
+	entryModels := []*models.TrashEntry{}
+	for _, entry := range entries {
+		entryModels = append(entryModels, entry.ToModel())
+	}
+
+	return entryModels, nil
+}
+
+func (s *trashService) Store(resourceType string, resourceID string, payload interface{}) error {
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.db.Create(&entities.TrashEntry{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Payload:      payloadJSON,
+		DeletedAt:    time.Now(),
+	}).Error
+}
+
+// Restore brings back the most recently deleted resource stored under the
+// given type and ID, and drops its trash entry
+func (s *trashService) Restore(resourceType string, resourceID string) error {
+	var entry entities.TrashEntry
+
+	err := s.db.
+		Where("resource_type = ? AND resource_id = ?", resourceType, resourceID).
+		Order("deleted_at DESC").Order("id DESC").
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrTrashEntryNotFound
+		}
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		switch resourceType {
+		case models.TagHostResourceType:
+			err = restoreHost(tx, entry.Payload)
+		case TrashResourceTypeTag:
+			err = restoreTag(tx, entry.Payload)
+		case TrashResourceTypeCheckSettings:
+			err = restoreCheckSettings(tx, entry.Payload)
+		default:
+			err = ErrTrashResourceTypeNotSupported
+		}
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&entry).Error
+	})
+}
+
+// PurgeExpired removes the trash entries that outlived the retention window,
+// returning how many were dropped
+func (s *trashService) PurgeExpired() (int64, error) {
+	result := s.db.
+		Where("deleted_at < ?", time.Now().Add(-TrashRetention)).
+		Delete(&entities.TrashEntry{})
+
+	return result.RowsAffected, result.Error
+}
+
+// restoreHost recreates the host row and its tags. The discovered data is not
+// touched when the agent re-registered in the meantime.
+func restoreHost(tx *gorm.DB, payload []byte) error {
+	var snapshot hostTrashPayload
+	if err := json.Unmarshal(payload, &snapshot); err != nil {
+		return err
+	}
+
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&snapshot.Host).Error
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range snapshot.Tags {
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(tag).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func restoreTag(tx *gorm.DB, payload []byte) error {
+	var tag models.Tag
+	if err := json.Unmarshal(payload, &tag); err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tag).Error
+}
+
+// restoreCheckSettings puts back the trashed checks selection, overriding the
+// current one: restoring is as deliberate a curation as selecting checks is
+func restoreCheckSettings(tx *gorm.DB, payload []byte) error {
+	var selectedChecks models.SelectedChecks
+	if err := json.Unmarshal(payload, &selectedChecks); err != nil {
+		return err
+	}
+
+	return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&selectedChecks).Error
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+	"gorm.io/gorm"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	trashService *trashService
+}
+
+func TestTrashServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
+
+func (suite *TrashServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(
+		entities.TrashEntry{}, entities.Host{}, models.Tag{}, models.SelectedChecks{},
+	)
+}
+
+func (suite *TrashServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(
+		entities.TrashEntry{}, entities.Host{}, models.Tag{}, models.SelectedChecks{},
+	)
+}
+
+func (suite *TrashServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.trashService = NewTrashService(suite.tx)
+}
+
+func (suite *TrashServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_List() {
+	err := suite.trashService.Store(TrashResourceTypeTag, "env:prod", &models.Tag{
+		Value: "env:prod", ResourceType: models.TagHostResourceType, ResourceID: "agent-1",
+	})
+	suite.NoError(err)
+
+	entries, err := suite.trashService.List()
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal(TrashResourceTypeTag, entries[0].ResourceType)
+	suite.Equal("env:prod", entries[0].ResourceID)
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_RestoreTag() {
+	tag := &models.Tag{Value: "env:prod", ResourceType: models.TagHostResourceType, ResourceID: "agent-1"}
+	suite.NoError(suite.trashService.Store(TrashResourceTypeTag, tag.Value, tag))
+
+	err := suite.trashService.Restore(TrashResourceTypeTag, "env:prod")
+	suite.NoError(err)
+
+	var restored models.Tag
+	suite.NoError(suite.tx.Where("value", "env:prod").First(&restored).Error)
+	suite.Equal("agent-1", restored.ResourceID)
+
+	// a restored entry cannot be restored twice
+	err = suite.trashService.Restore(TrashResourceTypeTag, "env:prod")
+	suite.EqualError(err, ErrTrashEntryNotFound.Error())
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_RestoreHost() {
+	suite.NoError(suite.trashService.Store(models.TagHostResourceType, "agent-1", &hostTrashPayload{
+		Host: entities.Host{AgentID: "agent-1", Name: "host1"},
+		Tags: []*models.Tag{
+			{Value: "env:prod", ResourceType: models.TagHostResourceType, ResourceID: "agent-1"},
+		},
+	}))
+
+	err := suite.trashService.Restore(models.TagHostResourceType, "agent-1")
+	suite.NoError(err)
+
+	var host entities.Host
+	suite.NoError(suite.tx.Where("agent_id", "agent-1").First(&host).Error)
+	suite.Equal("host1", host.Name)
+
+	var count int64
+	suite.tx.Model(&models.Tag{}).Where("resource_id", "agent-1").Count(&count)
+	suite.EqualValues(1, count)
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_RestoreCheckSettings() {
+	suite.NoError(suite.trashService.Store(TrashResourceTypeCheckSettings, "cluster-1", &models.SelectedChecks{
+		ID: "cluster-1", SelectedChecks: pq.StringArray{"ABCDEF"},
+	}))
+
+	// the selection that replaced the trashed one
+	suite.tx.Create(&models.SelectedChecks{ID: "cluster-1", SelectedChecks: pq.StringArray{"123456"}})
+
+	err := suite.trashService.Restore(TrashResourceTypeCheckSettings, "cluster-1")
+	suite.NoError(err)
+
+	var selectedChecks models.SelectedChecks
+	suite.NoError(suite.tx.Where("id", "cluster-1").First(&selectedChecks).Error)
+	suite.Equal(pq.StringArray{"ABCDEF"}, selectedChecks.SelectedChecks)
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_RestoreUnsupportedType() {
+	suite.tx.Create(&entities.TrashEntry{
+		ResourceType: "bogus", ResourceID: "1", Payload: []byte("{}"), DeletedAt: time.Now(),
+	})
+
+	err := suite.trashService.Restore("bogus", "1")
+	suite.EqualError(err, ErrTrashResourceTypeNotSupported.Error())
+}
+
+func (suite *TrashServiceTestSuite) TestTrashService_PurgeExpired() {
+	suite.tx.Create(&entities.TrashEntry{
+		ResourceType: TrashResourceTypeTag, ResourceID: "expired",
+		Payload: []byte("{}"), DeletedAt: time.Now().Add(-TrashRetention - time.Hour),
+	})
+	suite.tx.Create(&entities.TrashEntry{
+		ResourceType: TrashResourceTypeTag, ResourceID: "fresh",
+		Payload: []byte("{}"), DeletedAt: time.Now(),
+	})
+
+	purged, err := suite.trashService.PurgeExpired()
+	suite.NoError(err)
+	suite.EqualValues(1, purged)
+
+	entries, err := suite.trashService.List()
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal("fresh", entries[0].ResourceID)
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/trento-project/trento/web/entities"
+	"github.com/trento-project/trento/web/models"
+)
+
+var (
+	ErrUsernameAlreadyTaken = errors.New("the username is already taken")
+	ErrUserNotFound         = errors.New("the user does not exist")
+	ErrInvalidCredentials   = errors.New("invalid username or password")
+)
+
+//go:generate mockery --name=UsersService --inpackage --filename=users_mock.go
+
+// UsersService manages the local console accounts, for sites without an
+// external identity provider. Passwords are stored as bcrypt hashes and
+// never returned.
+type UsersService interface {
+	GetAll() (models.UserList, error)
+	GetByUsername(username string) (*models.User, error)
+	Create(username, password string, admin bool) (*models.User, error)
+	// UpdatePassword rotates the password, clearing the pending password
+	// change requirement
+	UpdatePassword(username, password string) error
+	// RequirePasswordChange flags the account for a forced password rotation
+	RequirePasswordChange(username string) error
+	// UpdateTagScope replaces the tags the account is restricted to;
+	// an empty list lifts the restriction
+	UpdateTagScope(username string, tags []string) error
+	Authenticate(username, password string) (*models.User, error)
+	Delete(username string) error
+}
+
+type usersService struct {
+	db *gorm.DB
+}
+
+func NewUsersService(db *gorm.DB) *usersService {
+	return &usersService{db: db}
+}
+
+func (s *usersService) GetAll() (models.UserList, error) {
+	var users []entities.User
+
+	err := s.db.Order("username").Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+
+	userList := models.UserList{}
+	for _, user := range users {
+		userList = append(userList, user.ToModel())
+	}
+
+	return userList, nil
+}
+
+func (s *usersService) GetByUsername(username string) (*models.User, error) {
+	user, err := s.getUser(username)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user.ToModel(), nil
+}
+
+func (s *usersService) Create(username, password string, admin bool) (*models.User, error) {
+	existing, err := s.GetByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrUsernameAlreadyTaken
+	}
+
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := entities.User{
+		Username:     username,
+		PasswordHash: passwordHash,
+		Admin:        admin,
+	}
+	if err := s.db.Create(&user).Error; err != nil {
+		return nil, err
+	}
+
+	return user.ToModel(), nil
+}
+
+func (s *usersService) UpdatePassword(username, password string) error {
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	result := s.db.Model(&entities.User{}).
+		Where("username", username).
+		Updates(map[string]interface{}{
+			"password_hash":            passwordHash,
+			"password_change_required": false,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *usersService) RequirePasswordChange(username string) error {
+	result := s.db.Model(&entities.User{}).
+		Where("username", username).
+		Update("password_change_required", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *usersService) UpdateTagScope(username string, tags []string) error {
+	result := s.db.Model(&entities.User{}).
+		Where("username", username).
+		Update("tag_scope", pq.StringArray(tags))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *usersService) Authenticate(username, password string) (*models.User, error) {
+	user, err := s.getUser(username)
+	if errors.Is(err, ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return user.ToModel(), nil
+}
+
+func (s *usersService) Delete(username string) error {
+	result := s.db.Where("username", username).Delete(&entities.User{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func (s *usersService) getUser(username string) (*entities.User, error) {
+	var user entities.User
+
+	err := s.db.Where("username", username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func hashPassword(password string) (string, error) {
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(passwordHash), nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/trento-project/trento/test/helpers"
+	"github.com/trento-project/trento/web/entities"
+	"gorm.io/gorm"
+)
+
+type UsersServiceTestSuite struct {
+	suite.Suite
+	db           *gorm.DB
+	tx           *gorm.DB
+	usersService UsersService
+}
+
+func TestUsersServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UsersServiceTestSuite))
+}
+
+func (suite *UsersServiceTestSuite) SetupSuite() {
+	suite.db = helpers.SetupTestDatabase(suite.T())
+
+	suite.db.AutoMigrate(entities.User{})
+}
+
+func (suite *UsersServiceTestSuite) TearDownSuite() {
+	suite.db.Migrator().DropTable(entities.User{})
+}
+
+func (suite *UsersServiceTestSuite) SetupTest() {
+	suite.tx = suite.db.Begin()
+	suite.usersService = NewUsersService(suite.tx)
+}
+
+func (suite *UsersServiceTestSuite) TearDownTest() {
+	suite.tx.Rollback()
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_Create() {
+	user, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+	suite.Equal("admin", user.Username)
+	suite.True(user.Admin)
+	suite.False(user.PasswordChangeRequired)
+
+	var storedUser entities.User
+	suite.tx.First(&storedUser)
+	suite.NotEqual("s3cret", storedUser.PasswordHash)
+
+	found, err := suite.usersService.GetByUsername("admin")
+	suite.NoError(err)
+	suite.Equal(user, found)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_CreateAlreadyTaken() {
+	_, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+
+	_, err = suite.usersService.Create("admin", "other", false)
+	suite.ErrorIs(err, ErrUsernameAlreadyTaken)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_GetAll() {
+	_, err := suite.usersService.Create("operator", "s3cret", false)
+	suite.NoError(err)
+	_, err = suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+
+	users, err := suite.usersService.GetAll()
+	suite.NoError(err)
+	suite.Len(users, 2)
+	suite.Equal("admin", users[0].Username)
+	suite.Equal("operator", users[1].Username)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_GetByUsernameNotFound() {
+	user, err := suite.usersService.GetByUsername("ghost")
+	suite.NoError(err)
+	suite.Nil(user)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_Authenticate() {
+	_, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+
+	user, err := suite.usersService.Authenticate("admin", "s3cret")
+	suite.NoError(err)
+	suite.Equal("admin", user.Username)
+
+	_, err = suite.usersService.Authenticate("admin", "wrong")
+	suite.ErrorIs(err, ErrInvalidCredentials)
+
+	_, err = suite.usersService.Authenticate("ghost", "s3cret")
+	suite.ErrorIs(err, ErrInvalidCredentials)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_UpdatePassword() {
+	_, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+	suite.NoError(suite.usersService.RequirePasswordChange("admin"))
+
+	suite.NoError(suite.usersService.UpdatePassword("admin", "n3ws3cret"))
+
+	// rotating the password clears the pending password change requirement
+	user, err := suite.usersService.GetByUsername("admin")
+	suite.NoError(err)
+	suite.False(user.PasswordChangeRequired)
+
+	_, err = suite.usersService.Authenticate("admin", "n3ws3cret")
+	suite.NoError(err)
+	_, err = suite.usersService.Authenticate("admin", "s3cret")
+	suite.ErrorIs(err, ErrInvalidCredentials)
+
+	suite.ErrorIs(suite.usersService.UpdatePassword("ghost", "n3ws3cret"), ErrUserNotFound)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_RequirePasswordChange() {
+	_, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+
+	suite.NoError(suite.usersService.RequirePasswordChange("admin"))
+
+	user, err := suite.usersService.GetByUsername("admin")
+	suite.NoError(err)
+	suite.True(user.PasswordChangeRequired)
+
+	suite.ErrorIs(suite.usersService.RequirePasswordChange("ghost"), ErrUserNotFound)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_UpdateTagScope() {
+	_, err := suite.usersService.Create("operator", "s3cret", false)
+	suite.NoError(err)
+
+	suite.NoError(suite.usersService.UpdateTagScope("operator", []string{"env=qa"}))
+
+	user, err := suite.usersService.GetByUsername("operator")
+	suite.NoError(err)
+	suite.Equal([]string{"env=qa"}, user.TagScope)
+
+	suite.NoError(suite.usersService.UpdateTagScope("operator", []string{}))
+
+	user, err = suite.usersService.GetByUsername("operator")
+	suite.NoError(err)
+	suite.Empty(user.TagScope)
+
+	suite.ErrorIs(suite.usersService.UpdateTagScope("ghost", []string{"env=qa"}), ErrUserNotFound)
+}
+
+func (suite *UsersServiceTestSuite) TestUsersService_Delete() {
+	_, err := suite.usersService.Create("admin", "s3cret", true)
+	suite.NoError(err)
+
+	suite.NoError(suite.usersService.Delete("admin"))
+
+	user, err := suite.usersService.GetByUsername("admin")
+	suite.NoError(err)
+	suite.Nil(user)
+
+	suite.ErrorIs(suite.usersService.Delete("admin"), ErrUserNotFound)
+}
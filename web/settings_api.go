@@ -0,0 +1,290 @@
+package web
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/internal/grafana"
+	"github.com/trento-project/trento/web/services"
+	"github.com/trento-project/trento/web/telemetry"
+)
+
+type JSONSettings struct {
+	InstallationID      string `json:"installation_id"`
+	EulaAccepted        bool   `json:"eula_accepted"`
+	PremiumActive       bool   `json:"premium_active"`
+	TelemetryOptOut     bool   `json:"telemetry_opt_out"`
+	TelemetryPublishing bool   `json:"telemetry_publishing"`
+	AirGapped           bool   `json:"air_gapped"`
+	GrafanaPublicURL    string `json:"grafana_public_url"`
+}
+
+type JSONSettingsUpdate struct {
+	EulaAccepted    *bool `json:"eula_accepted"`
+	TelemetryOptOut *bool `json:"telemetry_opt_out"`
+}
+
+type JSONRetentionPolicy struct {
+	Table         string `json:"table" binding:"required"`
+	RetentionDays uint   `json:"retention_days"`
+}
+
+type JSONRetentionPolicies []*JSONRetentionPolicy
+
+// ApiGetSettingsHandler godoc
+// @Summary Get the current server settings
+// @Produce json
+// @Success 200 {object} JSONSettings
+// @Failure 500 {object} map[string]string
+// @Router /settings [get]
+func ApiGetSettingsHandler(settingsService services.SettingsService,
+	premiumDetectionService services.PremiumDetectionService, grafanaPublicURL string, airGapped bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		settings, err := currentSettings(settingsService, premiumDetectionService, grafanaPublicURL, airGapped)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, settings)
+	}
+}
+
+// ApiUpdateSettingsHandler godoc
+// @Summary Update the server settings
+// @Accept json
+// @Produce json
+// @Param Body body JSONSettingsUpdate true "Settings to update"
+// @Success 200 {object} JSONSettings
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /settings [put]
+func ApiUpdateSettingsHandler(settingsService services.SettingsService,
+	premiumDetectionService services.PremiumDetectionService, grafanaPublicURL string, airGapped bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var update JSONSettingsUpdate
+
+		err := c.BindJSON(&update)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if update.TelemetryOptOut != nil {
+			if err := settingsService.SetTelemetryOptOut(*update.TelemetryOptOut); err != nil {
+				_ = c.Error(err)
+				return
+			}
+		}
+
+		if update.EulaAccepted != nil {
+			if *update.EulaAccepted {
+				if err := settingsService.AcceptEula(); err != nil {
+					_ = c.Error(err)
+					return
+				}
+			} else {
+				accepted, err := settingsService.IsEulaAccepted()
+				if err != nil {
+					_ = c.Error(err)
+					return
+				}
+				if accepted {
+					_ = c.Error(BadRequestError("the EULA acceptance cannot be revoked"))
+					return
+				}
+			}
+		}
+
+		settings, err := currentSettings(settingsService, premiumDetectionService, grafanaPublicURL, airGapped)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, settings)
+	}
+}
+
+func currentSettings(settingsService services.SettingsService,
+	premiumDetectionService services.PremiumDetectionService, grafanaPublicURL string, airGapped bool) (*JSONSettings, error) {
+	installationID, err := settingsService.InitializeIdentifier()
+	if err != nil {
+		return nil, err
+	}
+
+	eulaAccepted, err := settingsService.IsEulaAccepted()
+	if err != nil {
+		return nil, err
+	}
+
+	premiumActive, err := premiumDetectionService.IsPremiumActive()
+	if err != nil {
+		return nil, err
+	}
+
+	telemetryOptOut, err := settingsService.IsTelemetryOptedOut()
+	if err != nil {
+		return nil, err
+	}
+
+	telemetryPublishing, err := premiumDetectionService.CanPublishTelemetry()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONSettings{
+		InstallationID:      installationID.String(),
+		EulaAccepted:        eulaAccepted,
+		PremiumActive:       premiumActive,
+		TelemetryOptOut:     telemetryOptOut,
+		TelemetryPublishing: telemetryPublishing,
+		AirGapped:           airGapped,
+		GrafanaPublicURL:    grafanaPublicURL,
+	}, nil
+}
+
+// ApiTelemetryPreviewHandler godoc
+// @Summary Preview the telemetry payloads the server would publish
+// @Produce json
+// @Success 200 {object} map[string]interface{}
+// @Failure 500 {object} map[string]string
+// @Router /settings/telemetry/preview [get]
+func ApiTelemetryPreviewHandler(telemetryEngine *telemetry.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preview, err := telemetryEngine.Preview()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, preview)
+	}
+}
+
+// ApiGetRetentionSettingsHandler godoc
+// @Summary Get the data retention settings
+// @Produce json
+// @Success 200 {object} JSONRetentionPolicies
+// @Failure 500 {object} map[string]string
+// @Router /settings/retention [get]
+func ApiGetRetentionSettingsHandler(s services.RetentionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policies, err := s.GetPolicies()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		var resp JSONRetentionPolicies
+		for _, policy := range policies {
+			resp = append(resp, &JSONRetentionPolicy{
+				Table:         policy.Table,
+				RetentionDays: policy.RetentionDays,
+			})
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+type JSONFeatureFlagUpdate struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// ApiGetFeaturesHandler godoc
+// @Summary Get the feature flags and whether they are enabled
+// @Produce json
+// @Success 200 {object} []models.FeatureFlag
+// @Failure 500 {object} map[string]string
+// @Router /settings/features [get]
+func ApiGetFeaturesHandler(s services.FeatureFlagsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		flags, err := s.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, flags)
+	}
+}
+
+// ApiUpdateFeatureHandler godoc
+// @Summary Enable or disable a feature flag
+// @Accept json
+// @Produce json
+// @Param name path string true "Feature flag name"
+// @Param Body body JSONFeatureFlagUpdate true "Feature flag state"
+// @Success 200 {object} []models.FeatureFlag
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /settings/features/{name} [put]
+func ApiUpdateFeatureHandler(s services.FeatureFlagsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var update JSONFeatureFlagUpdate
+
+		err := c.BindJSON(&update)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		err = s.SetEnabled(c.Param("name"), *update.Enabled)
+		if err != nil {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+
+		flags, err := s.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, flags)
+	}
+}
+
+// ApiGrafanaSettingsHandler godoc
+// @Summary Get the Grafana provisioning status
+// @Produce json
+// @Success 200 {object} grafana.ProvisioningStatus
+// @Router /settings/grafana [get]
+func ApiGrafanaSettingsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, grafana.GetProvisioningStatus())
+	}
+}
+
+// ApiUpdateRetentionSettingsHandler godoc
+// @Summary Update the data retention settings
+// @Accept json
+// @Produce json
+// @Param Body body JSONRetentionPolicies true "Retention policies"
+// @Success 200 {object} JSONRetentionPolicies
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /settings/retention [put]
+func ApiUpdateRetentionSettingsHandler(s services.RetentionService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONRetentionPolicies
+
+		err := c.BindJSON(&r)
+		if err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		for _, policy := range r {
+			err = s.SetPolicy(policy.Table, policy.RetentionDays)
+			if err != nil {
+				_ = c.Error(BadRequestError(err.Error()))
+				return
+			}
+		}
+
+		c.JSON(http.StatusOK, &r)
+	}
+}
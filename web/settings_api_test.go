@@ -0,0 +1,308 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+	"github.com/trento-project/trento/web/telemetry"
+)
+
+func settingsTestPremiumDetectionService() *services.MockPremiumDetectionService {
+	premiumDetection := new(services.MockPremiumDetectionService)
+	premiumDetection.On("RequiresEulaAcceptance").Return(false, nil)
+	premiumDetection.On("IsPremiumActive").Return(true, nil)
+	premiumDetection.On("CanPublishTelemetry").Return(true, nil)
+
+	return premiumDetection
+}
+
+func TestApiGetSettings(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.premiumDetectionService = settingsTestPremiumDetectionService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/settings", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"installation_id": "59fd8017-b7fd-477b-9ebe-b658c558f3e9",
+		"eula_accepted": true,
+		"premium_active": true,
+		"telemetry_opt_out": false,
+		"telemetry_publishing": true,
+		"air_gapped": false,
+		"grafana_public_url": "localhost"
+	}`, resp.Body.String())
+}
+
+func TestApiGetSettingsAirGapped(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.premiumDetectionService = settingsTestPremiumDetectionService()
+
+	config := setupTestConfig()
+	config.AirGapped = true
+
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/settings", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+
+	var settings JSONSettings
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &settings))
+	assert.True(t, settings.AirGapped)
+}
+
+func TestApiUpdateSettingsAcceptEula(t *testing.T) {
+	settingsService := newMockedSettingsService().(*services.MockSettingsService)
+
+	deps := setupTestDependencies()
+	deps.settingsService = settingsService
+	deps.premiumDetectionService = settingsTestPremiumDetectionService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/settings", bytes.NewBuffer([]byte(`{"eula_accepted": true}`)))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	settingsService.AssertCalled(t, "AcceptEula")
+}
+
+func TestApiUpdateSettingsRevokeEula(t *testing.T) {
+	deps := setupTestDependencies()
+	deps.premiumDetectionService = settingsTestPremiumDetectionService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/settings", bytes.NewBuffer([]byte(`{"eula_accepted": false}`)))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiUpdateSettingsTelemetryOptOut(t *testing.T) {
+	settingsService := newMockedSettingsService().(*services.MockSettingsService)
+
+	deps := setupTestDependencies()
+	deps.settingsService = settingsService
+	deps.premiumDetectionService = settingsTestPremiumDetectionService()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/settings", bytes.NewBuffer([]byte(`{"telemetry_opt_out": true}`)))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	settingsService.AssertCalled(t, "SetTelemetryOptOut", true)
+}
+
+func TestApiTelemetryPreview(t *testing.T) {
+	extractor := new(telemetry.MockExtractor)
+	extractor.On("Extract").Return(map[string]string{"sles_version": "15-SP2"}, nil)
+
+	deps := setupTestDependencies()
+	deps.telemetryRegistry = &telemetry.TelemetryRegistry{
+		"host_telemetry": extractor,
+	}
+	deps.telemetryPublisher = new(telemetry.MockPublisher)
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/settings/telemetry/preview", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{"host_telemetry": {"sles_version": "15-SP2"}}`, resp.Body.String())
+	deps.telemetryPublisher.(*telemetry.MockPublisher).AssertNotCalled(t, "Publish")
+}
+
+func TestApiGetRetentionSettingsHandler(t *testing.T) {
+	policies := []*models.RetentionPolicy{
+		{
+			Table:         "checks_results",
+			RetentionDays: 30,
+		},
+		{
+			Table:         "host_heartbeats",
+			RetentionDays: 0,
+		},
+	}
+
+	mockRetentionService := new(services.MockRetentionService)
+	mockRetentionService.On("GetPolicies").Return(policies, nil)
+
+	deps := setupTestDependencies()
+	deps.retentionService = mockRetentionService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/settings/retention", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	expectedBody, _ := json.Marshal([]*JSONRetentionPolicy{
+		{
+			Table:         "checks_results",
+			RetentionDays: 30,
+		},
+		{
+			Table:         "host_heartbeats",
+			RetentionDays: 0,
+		},
+	})
+	assert.Equal(t, 200, resp.Code)
+	assert.Equal(t, expectedBody, resp.Body.Bytes())
+}
+
+func TestApiUpdateRetentionSettingsHandler(t *testing.T) {
+	mockRetentionService := new(services.MockRetentionService)
+	mockRetentionService.On("SetPolicy", "checks_results", uint(15)).Return(nil)
+
+	deps := setupTestDependencies()
+	deps.retentionService = mockRetentionService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal([]*JSONRetentionPolicy{
+		{
+			Table:         "checks_results",
+			RetentionDays: 15,
+		},
+	})
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/settings/retention", bytes.NewBuffer(body))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockRetentionService.AssertExpectations(t)
+}
+
+func TestApiGetFeaturesHandler(t *testing.T) {
+	mockFeatureFlagsService := new(services.MockFeatureFlagsService)
+	mockFeatureFlagsService.On("GetAll").Return([]*models.FeatureFlag{
+		{
+			Name:    models.FeatureAlerting,
+			Enabled: true,
+		},
+		{
+			Name:    models.FeatureEmbeddedRunner,
+			Enabled: false,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.featureFlagsService = mockFeatureFlagsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/settings/features", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[
+		{"name":"alerting","enabled":true},
+		{"name":"embedded-runner","enabled":false}
+	]`, resp.Body.String())
+}
+
+func TestApiUpdateFeatureHandler(t *testing.T) {
+	mockFeatureFlagsService := new(services.MockFeatureFlagsService)
+	mockFeatureFlagsService.On("SetEnabled", "alerting", false).Return(nil)
+	mockFeatureFlagsService.On("GetAll").Return([]*models.FeatureFlag{
+		{
+			Name:    models.FeatureAlerting,
+			Enabled: false,
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.featureFlagsService = mockFeatureFlagsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/settings/features/alerting", bytes.NewBufferString(`{"enabled":false}`))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockFeatureFlagsService.AssertExpectations(t)
+}
+
+func TestApiFeatureFlagMiddleware(t *testing.T) {
+	mockFeatureFlagsService := new(services.MockFeatureFlagsService)
+	mockFeatureFlagsService.On("IsEnabled", models.FeatureAlerting).Return(false)
+
+	deps := setupTestDependencies()
+	deps.featureFlagsService = mockFeatureFlagsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/prometheus/alerts", bytes.NewBufferString(`{}`))
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+	assert.JSONEq(t, `{"error":"the alerting feature is not enabled"}`, resp.Body.String())
+}
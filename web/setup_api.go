@@ -0,0 +1,66 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiSetupStatusHandler godoc
+// @Summary Get the state of the first-boot setup wizard
+// @Produce json
+// @Success 200 {object} models.SetupStatus
+// @Failure 500 {object} map[string]string
+// @Router /setup [get]
+func ApiSetupStatusHandler(s services.SetupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		status, err := s.GetStatus()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, status)
+	}
+}
+
+// ApiSetupCompleteStepHandler godoc
+// @Summary Complete a step of the first-boot setup wizard
+// @Accept json
+// @Produce json
+// @Param step path string true "Setup step name"
+// @Param Body body map[string]string false "Step specific values"
+// @Success 200 {object} models.SetupStatus
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /setup/{step} [post]
+func ApiSetupCompleteStepHandler(s services.SetupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		values := make(map[string]string)
+		if c.Request.ContentLength > 0 {
+			if err := c.BindJSON(&values); err != nil {
+				_ = c.Error(BadRequestError("unable to parse JSON body"))
+				return
+			}
+		}
+
+		status, err := s.CompleteStep(c.Param("step"), values)
+		if errors.Is(err, services.ErrSetupStepUnknown) {
+			_ = c.Error(NotFoundError(err.Error()))
+			return
+		}
+		if errors.Is(err, services.ErrSetupStepOutOfOrder) || errors.Is(err, services.ErrSetupInvalidStepValues) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, status)
+	}
+}
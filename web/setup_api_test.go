@@ -0,0 +1,113 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiSetupStatus(t *testing.T) {
+	mockSetupService := new(services.MockSetupService)
+	mockSetupService.On("GetStatus").Return(&models.SetupStatus{
+		Complete: false,
+		NextStep: models.SetupStepEula,
+		Steps: []*models.SetupStep{
+			{Name: models.SetupStepAdmin, Complete: true},
+			{Name: models.SetupStepEula, Complete: false},
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.setupService = mockSetupService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/setup", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `{
+		"complete": false,
+		"next_step": "eula",
+		"steps": [
+			{"name": "admin", "complete": true},
+			{"name": "eula", "complete": false}
+		]
+	}`, resp.Body.String())
+}
+
+func TestApiSetupCompleteStep(t *testing.T) {
+	mockSetupService := new(services.MockSetupService)
+	mockSetupService.On("CompleteStep", "admin", map[string]string{
+		"username": "admin",
+		"password": "S3cr3t!",
+	}).Return(&models.SetupStatus{
+		Complete: false,
+		NextStep: models.SetupStepEula,
+		Steps:    []*models.SetupStep{},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.setupService = mockSetupService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	body := []byte(`{"username": "admin", "password": "S3cr3t!"}`)
+	req := httptest.NewRequest("POST", "/api/setup/admin", bytes.NewBuffer(body))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	mockSetupService.AssertExpectations(t)
+}
+
+func TestApiSetupCompleteStepOutOfOrder(t *testing.T) {
+	mockSetupService := new(services.MockSetupService)
+	mockSetupService.On("CompleteStep", "grafana", map[string]string{}).
+		Return(nil, services.ErrSetupStepOutOfOrder)
+
+	deps := setupTestDependencies()
+	deps.setupService = mockSetupService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/setup/grafana", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
+
+func TestApiSetupCompleteStepUnknown(t *testing.T) {
+	mockSetupService := new(services.MockSetupService)
+	mockSetupService.On("CompleteStep", "quantum", map[string]string{}).
+		Return(nil, services.ErrSetupStepUnknown)
+
+	deps := setupTestDependencies()
+	deps.setupService = mockSetupService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/setup/quantum", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+// defaultExpiringWithinDays is the window used to flag a subscription as
+// soon-to-expire when no explicit one is requested
+const defaultExpiringWithinDays = 30
+
+type JSONExpiringSubscription struct {
+	AgentID    string `json:"agent_id"`
+	Hostname   string `json:"hostname"`
+	Identifier string `json:"identifier"`
+	Version    string `json:"version"`
+	Arch       string `json:"arch"`
+	ExpiresAt  string `json:"expires_at"`
+	Validity   string `json:"validity"`
+}
+
+type JSONHostSummary struct {
+	ID                   string `json:"id"`
+	Name                 string `json:"name"`
+	Health               string `json:"health"`
+	CloudProvider        string `json:"cloud_provider"`
+	ClusterID            string `json:"cluster_id"`
+	ClusterName          string `json:"cluster_name"`
+	AgentVersion         string `json:"agent_version"`
+	SubscriptionValidity string `json:"subscription_validity"`
+}
+
+// ApiHostsListHandler godoc
+// @Summary List the discovered hosts, flagging the ones with expired or soon-to-expire subscriptions
+// @Produce json
+// @Param ip query []string false "Return only the hosts owning one of the given IP addresses"
+// @Param field query []string false "Return only the hosts whose custom fields match the given name:value pairs"
+// @Success 200 {object} []JSONHostSummary
+// @Failure 500 {object} map[string]string
+// @Router /hosts [get]
+func ApiHostsListHandler(hostsService services.HostsService, subscriptionsService services.SubscriptionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var hostsFilter *services.HostsFilter
+
+		if ipAddresses := c.QueryArray("ip"); len(ipAddresses) > 0 {
+			hostsFilter = &services.HostsFilter{IPAddresses: ipAddresses}
+		}
+
+		if fields := c.QueryArray("field"); len(fields) > 0 {
+			if hostsFilter == nil {
+				hostsFilter = &services.HostsFilter{}
+			}
+			hostsFilter.CustomFields = ParseCustomFieldsQuery(fields)
+		}
+
+		hosts, err := hostsService.GetAll(hostsFilter, nil)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		expiring, err := subscriptionsService.GetExpiringSubscriptions(defaultExpiringWithinDays * 24 * time.Hour)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		flaggedHosts := make(map[string]string)
+		for _, sub := range expiring {
+			// an expired subscription takes precedence over an expiring one
+			if flaggedHosts[sub.AgentID] != models.SubscriptionValidityExpired {
+				flaggedHosts[sub.AgentID] = sub.Validity
+			}
+		}
+
+		hostSummaries := []*JSONHostSummary{}
+		for _, host := range hosts {
+			validity, found := flaggedHosts[host.ID]
+			if !found {
+				validity = models.SubscriptionValidityValid
+			}
+
+			hostSummaries = append(hostSummaries, &JSONHostSummary{
+				ID:                   host.ID,
+				Name:                 host.Name,
+				Health:               host.Health,
+				CloudProvider:        host.CloudProvider,
+				ClusterID:            host.ClusterID,
+				ClusterName:          host.ClusterName,
+				AgentVersion:         host.AgentVersion,
+				SubscriptionValidity: validity,
+			})
+		}
+
+		c.JSON(http.StatusOK, hostSummaries)
+	}
+}
+
+// ApiExpiringSubscriptionsHandler godoc
+// @Summary Report the expired and soon-to-expire SLES subscriptions
+// @Produce json
+// @Param within_days query int false "Days ahead within which a subscription is considered as expiring" default(30)
+// @Success 200 {object} []JSONExpiringSubscription
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions/expiring [get]
+func ApiExpiringSubscriptionsHandler(subscriptionsService services.SubscriptionsService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		withinDays, err := strconv.Atoi(c.DefaultQuery("within_days", strconv.Itoa(defaultExpiringWithinDays)))
+		if err != nil || withinDays < 0 {
+			_ = c.Error(BadRequestError("within_days must be a positive number"))
+			return
+		}
+
+		expiring, err := subscriptionsService.GetExpiringSubscriptions(time.Duration(withinDays) * 24 * time.Hour)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		expiringSubscriptions := []*JSONExpiringSubscription{}
+		for _, sub := range expiring {
+			expiringSubscriptions = append(expiringSubscriptions, &JSONExpiringSubscription{
+				AgentID:    sub.AgentID,
+				Hostname:   sub.Hostname,
+				Identifier: sub.Subscription.ID,
+				Version:    sub.Subscription.Version,
+				Arch:       sub.Subscription.Arch,
+				ExpiresAt:  sub.Subscription.ExpiresAt,
+				Validity:   sub.Validity,
+			})
+		}
+
+		c.JSON(http.StatusOK, expiringSubscriptions)
+	}
+}
@@ -0,0 +1,145 @@
+package web
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiHostsListHandler(t *testing.T) {
+	mockHostsService := new(services.MockHostsService)
+	mockHostsService.On("GetAll", mock.Anything, mock.Anything).Return(models.HostList{
+		{
+			ID:            "agent1",
+			Name:          "host1",
+			Health:        models.HostHealthPassing,
+			CloudProvider: "azure",
+			AgentVersion:  "1.0.0",
+		},
+		{
+			ID:            "agent2",
+			Name:          "host2",
+			Health:        models.HostHealthPassing,
+			CloudProvider: "azure",
+			AgentVersion:  "1.0.0",
+		},
+	}, nil)
+
+	mockSubscriptionsService := new(services.MockSubscriptionsService)
+	mockSubscriptionsService.On("GetExpiringSubscriptions", 30*24*time.Hour).Return(
+		[]*models.ExpiringSubscription{
+			{
+				AgentID:  "agent1",
+				Hostname: "host1",
+				Validity: models.SubscriptionValidityExpired,
+				Subscription: &models.SlesSubscription{
+					ID:        "SLES_SAP",
+					ExpiresAt: "2024-03-20 09:55:32 UTC",
+				},
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.hostsService = mockHostsService
+	deps.subscriptionsService = mockSubscriptionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/hosts", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[
+		{
+			"id": "agent1",
+			"name": "host1",
+			"health": "passing",
+			"cloud_provider": "azure",
+			"cluster_id": "",
+			"cluster_name": "",
+			"agent_version": "1.0.0",
+			"subscription_validity": "expired"
+		},
+		{
+			"id": "agent2",
+			"name": "host2",
+			"health": "passing",
+			"cloud_provider": "azure",
+			"cluster_id": "",
+			"cluster_name": "",
+			"agent_version": "1.0.0",
+			"subscription_validity": "valid"
+		}
+	]`, resp.Body.String())
+}
+
+func TestApiExpiringSubscriptionsHandler(t *testing.T) {
+	mockSubscriptionsService := new(services.MockSubscriptionsService)
+	mockSubscriptionsService.On("GetExpiringSubscriptions", 15*24*time.Hour).Return(
+		[]*models.ExpiringSubscription{
+			{
+				AgentID:  "agent1",
+				Hostname: "host1",
+				Validity: models.SubscriptionValidityExpiring,
+				Subscription: &models.SlesSubscription{
+					ID:        "SLES_SAP",
+					Version:   "15.2",
+					Arch:      "x86_64",
+					ExpiresAt: "2026-09-17 13:41:34 UTC",
+				},
+			},
+		}, nil)
+
+	deps := setupTestDependencies()
+	deps.subscriptionsService = mockSubscriptionsService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/subscriptions/expiring?within_days=15", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[
+		{
+			"agent_id": "agent1",
+			"hostname": "host1",
+			"identifier": "SLES_SAP",
+			"version": "15.2",
+			"arch": "x86_64",
+			"expires_at": "2026-09-17 13:41:34 UTC",
+			"validity": "expiring"
+		}
+	]`, resp.Body.String())
+}
+
+func TestApiExpiringSubscriptionsHandlerInvalidParam(t *testing.T) {
+	deps := setupTestDependencies()
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/subscriptions/expiring?within_days=next-week", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+}
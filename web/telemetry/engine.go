@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -82,6 +83,25 @@ func (e *Engine) Start(ctx context.Context) {
 	)
 }
 
+// Preview extracts and returns the exact telemetry payloads the engine would
+// publish, keyed by telemetry name, without publishing anything
+func (e *Engine) Preview() (map[string]interface{}, error) {
+	preview := make(map[string]interface{})
+
+	for telemetryName, extractor := range *e.telemetryRegistry {
+		if identifiedExtractor, ok := extractor.(InstallationIdAwareExtractor); ok {
+			identifiedExtractor.WithInstallationID(e.installationID)
+		}
+		extractedTelemetry, err := extractor.Extract()
+		if err != nil {
+			return nil, fmt.Errorf("error while extracting telemetry %s: %s", telemetryName, err)
+		}
+		preview[telemetryName] = extractedTelemetry
+	}
+
+	return preview, nil
+}
+
 func NewEngine(
 	installationID uuid.UUID,
 	publisher Publisher,
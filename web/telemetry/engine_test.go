@@ -221,3 +221,44 @@ func (suite *EngineTestSuite) Test_SkippingTelemetry() {
 	suite.mockedPublisher.AssertNumberOfCalls(suite.T(), "Publish", 0)
 	suite.mockedExtractor.AssertNumberOfCalls(suite.T(), "Extract", 0)
 }
+
+// Test_PreviewReturnsExtractedTelemetryWithoutPublishing tests that the preview
+// exposes the payloads the engine would publish, without publishing them.
+func (suite *EngineTestSuite) Test_PreviewReturnsExtractedTelemetryWithoutPublishing() {
+	suite.mockedExtractor.On("Extract").Return(map[string]string{"key": "value"}, nil)
+
+	registry := &TelemetryRegistry{
+		"dummy_1": suite.mockedExtractor,
+	}
+
+	engine := NewEngine(
+		suite.dummyInstallationId,
+		suite.mockedPublisher,
+		registry,
+		suite.mockedPremiumDetection,
+	)
+
+	preview, err := engine.Preview()
+	suite.NoError(err)
+	suite.Equal(map[string]interface{}{"dummy_1": map[string]string{"key": "value"}}, preview)
+	suite.mockedPublisher.AssertNotCalled(suite.T(), "Publish", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test_PreviewFailsOnExtractionErrors tests that the preview surfaces extraction errors.
+func (suite *EngineTestSuite) Test_PreviewFailsOnExtractionErrors() {
+	suite.mockedExtractor.On("Extract").Return(nil, errors.New("extraction error"))
+
+	registry := &TelemetryRegistry{
+		"dummy_1": suite.mockedExtractor,
+	}
+
+	engine := NewEngine(
+		suite.dummyInstallationId,
+		suite.mockedPublisher,
+		registry,
+		suite.mockedPremiumDetection,
+	)
+
+	_, err := engine.Preview()
+	suite.Error(err)
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// FilePublisher writes the extracted telemetry to local JSON files instead of
+// sending it to the telemetry collection service, so that air-gapped
+// installations can still collect usage stats.
+type FilePublisher struct {
+	directory string
+}
+
+func NewFilePublisher(directory string) *FilePublisher {
+	return &FilePublisher{directory: directory}
+}
+
+func (fp *FilePublisher) Publish(telemetryName string, installationID uuid.UUID, extractedTelemetry interface{}) error {
+	if err := os.MkdirAll(fp.directory, 0700); err != nil {
+		return errors.Wrapf(err, "Failed to create the telemetry export directory %s", fp.directory)
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"telemetry_name":  telemetryName,
+		"installation_id": installationID.String(),
+		"exported_at":     time.Now().Format(time.RFC3339),
+		"telemetry":       extractedTelemetry,
+	}, "", " ")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to marshal telemetry %s", telemetryName)
+	}
+
+	filePath := filepath.Join(fp.directory, fmt.Sprintf("%s.json", telemetryName))
+
+	return ioutil.WriteFile(filePath, data, 0644)
+}
+
+// MultiPublisher fans the extracted telemetry out to multiple publishers,
+// e.g. to publish to the collection service and export locally at once.
+type MultiPublisher struct {
+	publishers []Publisher
+}
+
+func NewMultiPublisher(publishers ...Publisher) *MultiPublisher {
+	return &MultiPublisher{publishers: publishers}
+}
+
+func (mp *MultiPublisher) Publish(telemetryName string, installationID uuid.UUID, extractedTelemetry interface{}) error {
+	var lastErr error
+	for _, publisher := range mp.publishers {
+		if err := publisher.Publish(telemetryName, installationID, extractedTelemetry); err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
@@ -0,0 +1,47 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestFilePublisher_ExportsTelemetryAsJSONFile(t *testing.T) {
+	directory := t.TempDir()
+	installationID := uuid.New()
+
+	publisher := NewFilePublisher(directory)
+	err := publisher.Publish("dummy_telemetry", installationID, map[string]string{"key": "value"})
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filepath.Join(directory, "dummy_telemetry.json"))
+	assert.NoError(t, err)
+
+	var exported map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &exported))
+	assert.Equal(t, "dummy_telemetry", exported["telemetry_name"])
+	assert.Equal(t, installationID.String(), exported["installation_id"])
+	assert.Equal(t, map[string]interface{}{"key": "value"}, exported["telemetry"])
+	assert.NotEmpty(t, exported["exported_at"])
+}
+
+func TestMultiPublisher_FansOutToAllPublishers(t *testing.T) {
+	installationID := uuid.New()
+
+	first := new(MockPublisher)
+	first.On("Publish", "dummy_telemetry", installationID, mock.Anything).Return(nil)
+	second := new(MockPublisher)
+	second.On("Publish", "dummy_telemetry", installationID, mock.Anything).Return(nil)
+
+	publisher := NewMultiPublisher(first, second)
+	err := publisher.Publish("dummy_telemetry", installationID, map[string]string{"key": "value"})
+
+	assert.NoError(t, err)
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package telemetry
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,11 +10,13 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/google/uuid"
+	"github.com/trento-project/trento/internal/resilience"
 )
 
 type TelemetryPublisher struct {
 	apiHost    string
 	httpClient *http.Client
+	policy     *resilience.Policy
 }
 
 func (tp *TelemetryPublisher) Publish(telemetryName string, installationID uuid.UUID, extractedTelemetry interface{}) error {
@@ -28,23 +31,44 @@ func (tp *TelemetryPublisher) Publish(telemetryName string, installationID uuid.
 		return errors.Wrapf(err, "Failed to marshal telemetry %s", telemetryName)
 	}
 
-	resp, err := tp.httpClient.Post(endpoint, "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return errors.Wrapf(err, "An error occurred while publishing telemetry %s", telemetryName)
-	}
+	return tp.policy.Execute(context.Background(), func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(requestBody))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
 
-	if resp.StatusCode != http.StatusAccepted {
-		return errors.Errorf("Unexpected response code %d while publishing telemetry %s", resp.StatusCode, telemetryName)
-	}
+		resp, err := tp.httpClient.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "An error occurred while publishing telemetry %s", telemetryName)
+		}
 
-	return nil
+		if resp.StatusCode != http.StatusAccepted {
+			return errors.Errorf("Unexpected response code %d while publishing telemetry %s", resp.StatusCode, telemetryName)
+		}
+
+		return nil
+	})
 }
 
 var telemetryServiceUrl = "https://telemetry.trento.suse.com"
 
-func NewTelemetryPublisher() Publisher {
+func NewTelemetryPublisher(httpClient *http.Client) Publisher {
 	return &TelemetryPublisher{
 		apiHost:    telemetryServiceUrl,
-		httpClient: &http.Client{},
+		httpClient: httpClient,
+		policy:     resilience.NewPolicy("telemetry", resilience.DefaultConfig()),
 	}
 }
+
+// NoopPublisher discards the extracted telemetry. It replaces the outbound
+// publisher when the installation runs air-gapped, where no egress is allowed.
+type NoopPublisher struct{}
+
+func (np *NoopPublisher) Publish(telemetryName string, installationID uuid.UUID, extractedTelemetry interface{}) error {
+	return nil
+}
+
+func NewNoopPublisher() Publisher {
+	return &NoopPublisher{}
+}
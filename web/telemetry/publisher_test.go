@@ -27,7 +27,7 @@ func (suite *PublisherTestSuite) SetupSuite() {
 
 // Test_PublishesExtractedTelemetry tests whether a DummyExtractedTelemetry is correctly published to the telemetry collection service.
 func (suite *PublisherTestSuite) Test_PublishesExtractedTelemetry() {
-	publisher, _ := NewTelemetryPublisher().(*TelemetryPublisher)
+	publisher, _ := NewTelemetryPublisher(&http.Client{}).(*TelemetryPublisher)
 	extractedTelemetry := dummyExtractedTelemetry()
 
 	publisher.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
@@ -47,7 +47,7 @@ func (suite *PublisherTestSuite) Test_PublishesExtractedTelemetry() {
 
 // Test_PublishesExtractedHostTelemetry tests whether extracted HostTelemetries is correctly published to the telemetry collection service.
 func (suite *PublisherTestSuite) Test_PublishesExtractedHostTelemetry() {
-	publisher, _ := NewTelemetryPublisher().(*TelemetryPublisher)
+	publisher, _ := NewTelemetryPublisher(&http.Client{}).(*TelemetryPublisher)
 	extractedHostTelemetry := dummyExtractedHostTelemetry()
 
 	publisher.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
@@ -67,7 +67,7 @@ func (suite *PublisherTestSuite) Test_PublishesExtractedHostTelemetry() {
 
 // Test_PublishingFailsOnMarshalingError tests whether an error is returned when marshaling the telemetry to JSON fails.
 func (suite *PublisherTestSuite) Test_PublishingFailsOnMarshalingError() {
-	publisher, _ := NewTelemetryPublisher().(*TelemetryPublisher)
+	publisher, _ := NewTelemetryPublisher(&http.Client{}).(*TelemetryPublisher)
 	unmarshable := make(chan int)
 
 	err := publisher.Publish("dummy_telemetry", uuid.New(), unmarshable)
@@ -78,7 +78,7 @@ func (suite *PublisherTestSuite) Test_PublishingFailsOnMarshalingError() {
 
 // Test_PublishingFailsOnError tests whether an error is returned when publishing the telemetry fails at net/http level.
 func (suite *PublisherTestSuite) Test_PublishingFailsOnError() {
-	publisher, _ := NewTelemetryPublisher().(*TelemetryPublisher)
+	publisher, _ := NewTelemetryPublisher(&http.Client{}).(*TelemetryPublisher)
 	publisher.httpClient.Transport = helpers.ErroringRoundTripFunc(func() error {
 		return fmt.Errorf("some error")
 	})
@@ -91,7 +91,7 @@ func (suite *PublisherTestSuite) Test_PublishingFailsOnError() {
 
 // Test_PublishingFailsOnUnexpectedResponse tests whether an error is returned when the telemetry collection service responds with an unexpected status code.
 func (suite *PublisherTestSuite) Test_PublishingFailsOnUnexpectedResponse() {
-	publisher, _ := NewTelemetryPublisher().(*TelemetryPublisher)
+	publisher, _ := NewTelemetryPublisher(&http.Client{}).(*TelemetryPublisher)
 
 	publisher.httpClient.Transport = helpers.RoundTripFunc(func(req *http.Request) *http.Response {
 		return &http.Response{
@@ -8,3 +8,10 @@ func NewTelemetryRegistry(db *gorm.DB) *TelemetryRegistry {
 		//"cluster": NewClusterTelemetryExtractor(),
 	}
 }
+
+// Register adds an extractor under the given telemetry name, replacing any
+// extractor already registered with the same name.
+// Extractors must be registered before the engine is started.
+func (r *TelemetryRegistry) Register(telemetryName string, extractor Extractor) {
+	(*r)[telemetryName] = extractor
+}
@@ -0,0 +1,16 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryRegistry_Register(t *testing.T) {
+	extractor := new(MockExtractor)
+
+	registry := &TelemetryRegistry{}
+	registry.Register("dummy_telemetry", extractor)
+
+	assert.Equal(t, extractor, (*registry)["dummy_telemetry"])
+}
@@ -0,0 +1,58 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+// ApiTrashListHandler godoc
+// @Summary List the deleted resources that can still be restored
+// @Produce json
+// @Success 200 {object} []models.TrashEntry
+// @Failure 500 {object} map[string]string
+// @Router /trash [get]
+func ApiTrashListHandler(trashService services.TrashService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		entries, err := trashService.List()
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, entries)
+	}
+}
+
+// ApiTrashRestoreHandler godoc
+// @Summary Restore a deleted resource from the trash
+// @Produce json
+// @Param type path string true "Resource type"
+// @Param id path string true "Resource ID"
+// @Success 204 {object} interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /trash/{type}/{id}/restore [post]
+func ApiTrashRestoreHandler(trashService services.TrashService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := trashService.Restore(c.Param("type"), c.Param("id"))
+		if errors.Is(err, services.ErrTrashEntryNotFound) {
+			_ = c.Error(NotFoundError(err.Error()))
+			return
+		}
+		if errors.Is(err, services.ErrTrashResourceTypeNotSupported) {
+			_ = c.Error(BadRequestError(err.Error()))
+			return
+		}
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
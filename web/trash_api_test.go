@@ -0,0 +1,104 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func TestApiTrashList(t *testing.T) {
+	mockTrashService := new(services.MockTrashService)
+	mockTrashService.On("List").Return([]*models.TrashEntry{
+		{
+			ResourceType: services.TrashResourceTypeTag,
+			ResourceID:   "env:prod",
+			DeletedAt:    time.Date(2022, 4, 1, 10, 30, 0, 0, time.UTC),
+		},
+	}, nil)
+
+	deps := setupTestDependencies()
+	deps.trashService = mockTrashService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/trash", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `[{
+		"resource_type": "tags",
+		"resource_id": "env:prod",
+		"deleted_at": "2022-04-01T10:30:00Z"
+	}]`, resp.Body.String())
+}
+
+func TestApiTrashRestore(t *testing.T) {
+	mockTrashService := new(services.MockTrashService)
+	mockTrashService.On("Restore", "tags", "env:prod").Return(nil)
+
+	deps := setupTestDependencies()
+	deps.trashService = mockTrashService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/trash/tags/env:prod/restore", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	mockTrashService.AssertExpectations(t)
+}
+
+func TestApiTrashRestoreNotFound(t *testing.T) {
+	mockTrashService := new(services.MockTrashService)
+	mockTrashService.On("Restore", "hosts", "not_there").Return(services.ErrTrashEntryNotFound)
+
+	deps := setupTestDependencies()
+	deps.trashService = mockTrashService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/trash/hosts/not_there/restore", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestApiTrashRestoreUnsupportedType(t *testing.T) {
+	mockTrashService := new(services.MockTrashService)
+	mockTrashService.On("Restore", "bogus", "1").Return(services.ErrTrashResourceTypeNotSupported)
+
+	deps := setupTestDependencies()
+	deps.trashService = mockTrashService
+
+	app, err := NewAppWithDeps(setupTestConfig(), deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/trash/bogus/1/restore", nil)
+
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
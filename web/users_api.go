@@ -0,0 +1,195 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/trento-project/trento/web/services"
+)
+
+type JSONCreateUserRequest struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+	Admin    bool   `json:"admin"`
+}
+
+type JSONUpdateUserRequest struct {
+	Password               string `json:"password"`
+	PasswordChangeRequired bool   `json:"password_change_required"`
+	// TagScope replaces the tags the account is restricted to when present;
+	// an empty list lifts the restriction
+	TagScope *[]string `json:"tag_scope"`
+}
+
+// ApiUsersListHandler godoc
+// @Summary List the local user accounts
+// @Produce json
+// @Success 200 {object} models.UserList
+// @Failure 500 {object} map[string]string
+// @Router /users [get]
+func ApiUsersListHandler(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		users, err := usersService.GetAll()
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusOK, users)
+	}
+}
+
+// ApiUserCreateHandler godoc
+// @Summary Create a local user account
+// @Accept json
+// @Produce json
+// @Param Body body JSONCreateUserRequest true "The user to create"
+// @Success 201 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users [post]
+func ApiUserCreateHandler(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var r JSONCreateUserRequest
+
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		user, err := usersService.Create(r.Username, r.Password, r.Admin)
+		if errors.Is(err, services.ErrUsernameAlreadyTaken) {
+			_ = c.Error(BadRequestError(fmt.Sprintf("the username %s is already taken", r.Username)))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, user)
+	}
+}
+
+// ApiUserGetHandler godoc
+// @Summary Get a local user account by username
+// @Produce json
+// @Param username path string true "Username"
+// @Success 200 {object} models.User
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{username} [get]
+func ApiUserGetHandler(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username := c.Param("username")
+
+		user, err := usersService.GetByUsername(username)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if user == nil {
+			_ = c.Error(NotFoundError("could not find user"))
+			return
+		}
+
+		c.JSON(http.StatusOK, user)
+	}
+}
+
+// ApiUserUpdateHandler godoc
+// @Summary Update a local user account, rotating the password and/or forcing a password change
+// @Accept json
+// @Produce json
+// @Param username path string true "Username"
+// @Param Body body JSONUpdateUserRequest true "The updates to apply"
+// @Success 200 {object} models.User
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{username} [put]
+func ApiUserUpdateHandler(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username := c.Param("username")
+
+		var r JSONUpdateUserRequest
+		if err := c.BindJSON(&r); err != nil {
+			_ = c.Error(BadRequestError("unable to parse JSON body"))
+			return
+		}
+
+		if r.Password != "" {
+			if err := apiUserUpdateError(c, usersService.UpdatePassword(username, r.Password)); err != nil {
+				return
+			}
+		}
+
+		if r.PasswordChangeRequired {
+			if err := apiUserUpdateError(c, usersService.RequirePasswordChange(username)); err != nil {
+				return
+			}
+		}
+
+		if r.TagScope != nil {
+			if err := apiUserUpdateError(c, usersService.UpdateTagScope(username, *r.TagScope)); err != nil {
+				return
+			}
+		}
+
+		user, err := usersService.GetByUsername(username)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		if user == nil {
+			_ = c.Error(NotFoundError("could not find user"))
+			return
+		}
+
+		c.JSON(http.StatusOK, user)
+	}
+}
+
+// ApiUserDeleteHandler godoc
+// @Summary Delete a local user account
+// @Produce json
+// @Param username path string true "Username"
+// @Success 204 {object} interface{}
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /users/{username} [delete]
+func ApiUserDeleteHandler(usersService services.UsersService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		username := c.Param("username")
+
+		err := usersService.Delete(username)
+		if errors.Is(err, services.ErrUserNotFound) {
+			_ = c.Error(NotFoundError("could not find user"))
+			return
+		}
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// apiUserUpdateError maps the service errors of an update step to the proper
+// HTTP error, telling the caller whether to stop
+func apiUserUpdateError(c *gin.Context, err error) error {
+	if errors.Is(err, services.ErrUserNotFound) {
+		_ = c.Error(NotFoundError("could not find user"))
+		return err
+	}
+	if err != nil {
+		_ = c.Error(err)
+		return err
+	}
+
+	return nil
+}
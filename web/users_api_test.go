@@ -0,0 +1,198 @@
+package web
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/trento-project/trento/web/models"
+	"github.com/trento-project/trento/web/services"
+)
+
+func usersTestApp(t *testing.T, mockUsersService *services.MockUsersService) *App {
+	deps := setupTestDependencies()
+	deps.usersService = mockUsersService
+
+	config := setupTestConfig()
+	app, err := NewAppWithDeps(config, deps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return app
+}
+
+func TestApiUsersListHandler(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("GetAll").Return(models.UserList{
+		{
+			ID:        1,
+			Username:  "admin",
+			Admin:     true,
+			CreatedAt: time.Date(2022, 2, 1, 10, 30, 0, 0, time.UTC),
+		},
+	}, nil)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/users", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.JSONEq(t, `[{
+		"id": 1,
+		"username": "admin",
+		"admin": true,
+		"password_change_required": false,
+		"tag_scope": null,
+		"created_at": "2022-02-01T10:30:00Z"
+	}]`, resp.Body.String())
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserCreateHandler(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("Create", "operator", "s3cret", false).Return(&models.User{
+		ID:       2,
+		Username: "operator",
+	}, nil)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/users",
+		bytes.NewBufferString(`{"username": "operator", "password": "s3cret"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 201, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"username":"operator"`)
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserCreateHandlerUsernameTaken(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("Create", "operator", "s3cret", false).
+		Return(nil, services.ErrUsernameAlreadyTaken)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/users",
+		bytes.NewBufferString(`{"username": "operator", "password": "s3cret"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+	assert.Contains(t, resp.Body.String(), "already taken")
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserCreateHandlerBadRequest(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/api/users",
+		bytes.NewBufferString(`{"username": "operator"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 400, resp.Code)
+
+	mockUsersService.AssertNotCalled(t, "Create")
+}
+
+func TestApiUserGetHandler(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("GetByUsername", "admin").Return(&models.User{
+		ID:       1,
+		Username: "admin",
+		Admin:    true,
+	}, nil)
+	mockUsersService.On("GetByUsername", "ghost").Return(nil, nil)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/api/users/admin", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"username":"admin"`)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/api/users/ghost", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserUpdateHandler(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("UpdatePassword", "admin", "n3ws3cret").Return(nil)
+	mockUsersService.On("RequirePasswordChange", "admin").Return(nil)
+	mockUsersService.On("UpdateTagScope", "admin", []string{"env=qa"}).Return(nil)
+	mockUsersService.On("GetByUsername", "admin").Return(&models.User{
+		ID:       1,
+		Username: "admin",
+		Admin:    true,
+	}, nil)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/users/admin",
+		bytes.NewBufferString(`{"password": "n3ws3cret", "password_change_required": true, "tag_scope": ["env=qa"]}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 200, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"username":"admin"`)
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserUpdateHandlerNotFound(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("UpdatePassword", "ghost", "n3ws3cret").
+		Return(services.ErrUserNotFound)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/api/users/ghost",
+		bytes.NewBufferString(`{"password": "n3ws3cret"}`))
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockUsersService.AssertExpectations(t)
+}
+
+func TestApiUserDeleteHandler(t *testing.T) {
+	mockUsersService := new(services.MockUsersService)
+	mockUsersService.On("Delete", "admin").Return(nil)
+	mockUsersService.On("Delete", "ghost").Return(services.ErrUserNotFound)
+
+	app := usersTestApp(t, mockUsersService)
+
+	resp := httptest.NewRecorder()
+	req := httptest.NewRequest("DELETE", "/api/users/admin", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 204, resp.Code)
+
+	resp = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/api/users/ghost", nil)
+	app.webEngine.ServeHTTP(resp, req)
+
+	assert.Equal(t, 404, resp.Code)
+
+	mockUsersService.AssertExpectations(t)
+}
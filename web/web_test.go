@@ -4,7 +4,9 @@ import (
 	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
 	"github.com/trento-project/trento/internal/grafana"
+	"github.com/trento-project/trento/web/models"
 	"github.com/trento-project/trento/web/services"
 )
 
@@ -16,6 +18,10 @@ func setupTestDependencies() Dependencies {
 		settingsService:         newMockedSettingsService(),
 		subscriptionsService:    newMockedSubscriptionsService(),
 		premiumDetectionService: newMockedPremiumDetectionService(),
+		alertsService:           newMockedAlertsService(),
+		checksService:           newMockedChecksService(),
+		featureFlagsService:     newMockedFeatureFlagsService(),
+		preferencesService:      newMockedPreferencesService(),
 	}
 }
 
@@ -38,6 +44,9 @@ func newMockedSettingsService() services.SettingsService {
 	settingsService.On("InitializeIdentifier").Return(uuid.MustParse("59fd8017-b7fd-477b-9ebe-b658c558f3e9"), nil)
 	settingsService.On("AcceptEula").Return(nil)
 	settingsService.On("IsEulaAccepted").Return(true, nil)
+	settingsService.On("IsTelemetryOptedOut").Return(false, nil)
+	settingsService.On("SetTelemetryOptOut", mock.Anything).Return(nil)
+	settingsService.On("GetEnrollmentToken").Return("some-enrollment-token", nil)
 
 	return settingsService
 }
@@ -49,6 +58,34 @@ func newMockedSubscriptionsService() services.SubscriptionsService {
 	return subscriptionsService
 }
 
+func newMockedChecksService() services.ChecksService {
+	checksService := new(services.MockChecksService)
+	checksService.On("GetChecksExecutionsByCluster", mock.Anything).Return([]*models.ChecksExecution{}, nil)
+
+	return checksService
+}
+
+func newMockedAlertsService() services.AlertsService {
+	alertsService := new(services.MockAlertsService)
+	alertsService.On("GetFiringByResource", mock.Anything, mock.Anything).Return(nil, nil)
+
+	return alertsService
+}
+
+func newMockedPreferencesService() services.PreferencesService {
+	preferencesService := new(services.MockPreferencesService)
+	preferencesService.On("GetPreferences", mock.Anything).Return(models.NewDefaultPreferences(), nil)
+
+	return preferencesService
+}
+
+func newMockedFeatureFlagsService() services.FeatureFlagsService {
+	featureFlagsService := new(services.MockFeatureFlagsService)
+	featureFlagsService.On("IsEnabled", mock.Anything).Return(true)
+
+	return featureFlagsService
+}
+
 func newMockedPremiumDetectionService() services.PremiumDetectionService {
 	premiumDetection := new(services.MockPremiumDetectionService)
 	premiumDetection.On("RequiresEulaAcceptance").Return(false, nil)